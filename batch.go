@@ -0,0 +1,213 @@
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BatchProvider is implemented by providers with an asynchronous batch API.
+// Batched requests are billed at a discount and complete within the
+// provider's window, typically 24 hours. Like Embedder it is optional; use
+// the Client batch methods to call it.
+type BatchProvider interface {
+	CreateBatch(ctx context.Context, req *BatchRequest) (*Batch, error)
+	GetBatch(ctx context.Context, id string) (*Batch, error)
+	ListBatches(ctx context.Context, limit int) ([]Batch, error)
+	CancelBatch(ctx context.Context, id string) (*Batch, error)
+	BatchResults(ctx context.Context, id string) ([]BatchResult, error)
+}
+
+type BatchRequest struct {
+	Items []BatchItem
+}
+
+// BatchItem is one request in a batch. CustomID identifies its result and
+// must be unique within the batch.
+type BatchItem struct {
+	CustomID string
+	Request  Request
+}
+
+type BatchStatus string
+
+const (
+	BatchStatusValidating BatchStatus = "validating"
+	BatchStatusInProgress BatchStatus = "in_progress"
+	BatchStatusFinalizing BatchStatus = "finalizing"
+	BatchStatusCompleted  BatchStatus = "completed"
+	BatchStatusFailed     BatchStatus = "failed"
+	BatchStatusCancelling BatchStatus = "cancelling"
+	BatchStatusCancelled  BatchStatus = "cancelled"
+	BatchStatusExpired    BatchStatus = "expired"
+)
+
+type BatchCounts struct {
+	Total     int
+	Succeeded int
+	Failed    int
+}
+
+type Batch struct {
+	ID       string
+	Provider string
+	Status   BatchStatus
+	Counts   BatchCounts
+
+	// Message explains a failed batch when the provider reports why.
+	Message string
+
+	// ItemWarnings holds, by custom id, what CreateBatch repaired in each
+	// item before submitting it. Other calls leave it nil.
+	ItemWarnings map[string][]Warning
+
+	CreatedAt time.Time
+	EndedAt   time.Time
+	ExpiresAt time.Time
+}
+
+// Done reports whether the batch has stopped processing. Results are
+// available once a completed, cancelled or expired batch is done.
+func (b *Batch) Done() bool {
+	switch b.Status {
+	case BatchStatusCompleted, BatchStatusFailed, BatchStatusCancelled, BatchStatusExpired:
+		return true
+	}
+	return false
+}
+
+// BatchResult is the outcome of one item. Exactly one of Response and Err is
+// set; Err is a *LiteLLMError.
+type BatchResult struct {
+	CustomID string
+	Response *Response
+	Err      error
+}
+
+// CreateBatch submits req's items as one batch. Each item is prepared like a
+// Chat request: client defaults and message repair apply, repairs are
+// reported in Batch.ItemWarnings, and invalid items fail the whole call.
+func (c *Client) CreateBatch(ctx context.Context, req BatchRequest) (*Batch, error) {
+	batcher, err := c.batchProvider()
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Items) == 0 {
+		return nil, NewValidationError(c.provider.Name(), "batch must contain at least one item")
+	}
+	prepared := BatchRequest{Items: make([]BatchItem, 0, len(req.Items))}
+	seen := make(map[string]bool, len(req.Items))
+	var itemWarnings map[string][]Warning
+	for i, item := range req.Items {
+		if item.CustomID == "" {
+			return nil, NewValidationError(c.provider.Name(), fmt.Sprintf("batch item %d has no custom id", i))
+		}
+		if seen[item.CustomID] {
+			return nil, NewValidationError(c.provider.Name(), fmt.Sprintf("batch custom id %q is not unique", item.CustomID))
+		}
+		seen[item.CustomID] = true
+		itemReq, warnings, err := c.prepareRequest(item.Request)
+		if err != nil {
+			return nil, fmt.Errorf("batch item %q: %w", item.CustomID, err)
+		}
+		if len(warnings) > 0 {
+			if itemWarnings == nil {
+				itemWarnings = make(map[string][]Warning)
+			}
+			stampWarnings(warnings, c.provider.Name())
+			itemWarnings[item.CustomID] = warnings
+		}
+		prepared.Items = append(prepared.Items, BatchItem{CustomID: item.CustomID, Request: *itemReq})
+	}
+	batch, err := batcher.CreateBatch(ctx, &prepared)
+	if batch, err = c.finalizeBatch(batch, err); err != nil {
+		return nil, err
+	}
+	batch.ItemWarnings = itemWarnings
+	return batch, nil
+}
+
+func (c *Client) GetBatch(ctx context.Context, id string) (*Batch, error) {
+	batcher, err := c.batchProvider()
+	if err != nil {
+		return nil, err
+	}
+	batch, err := batcher.GetBatch(ctx, id)
+	return c.finalizeBatch(batch, err)
+}
+
+// ListBatches returns up to limit of the most recent batches. A limit of zero
+// uses the provider's default page size.
+func (c *Client) ListBatches(ctx context.Context, limit int) ([]Batch, error) {
+	batcher, err := c.batchProvider()
+	if err != nil {
+		return nil, err
+	}
+	if limit < 0 {
+		return nil, NewValidationError(c.provider.Name(), "batch list limit cannot be negative")
+	}
+	batches, err := batcher.ListBatches(ctx, limit)
+	if err != nil {
+		return nil, WrapError(err, c.provider.Name())
+	}
+	for i := range batches {
+		if batches[i].Provider == "" {
+			batches[i].Provider = c.provider.Name()
+		}
+	}
+	return batches, nil
+}
+
+func (c *Client) CancelBatch(ctx context.Context, id string) (*Batch, error) {
+	batcher, err := c.batchProvider()
+	if err != nil {
+		return nil, err
+	}
+	batch, err := batcher.CancelBatch(ctx, id)
+	return c.finalizeBatch(batch, err)
+}
+
+// BatchResults fetches the per-item results of a finished batch.
+func (c *Client) BatchResults(ctx context.Context, id string) ([]BatchResult, error) {
+	batcher, err := c.batchProvider()
+	if err != nil {
+		return nil, err
+	}
+	results, err := batcher.BatchResults(ctx, id)
+	if err != nil {
+		return nil, WrapError(err, c.provider.Name())
+	}
+	for i := range results {
+		if results[i].Err != nil {
+			results[i].Err = WrapError(results[i].Err, c.provider.Name())
+		}
+		if resp := results[i].Response; resp != nil {
+			finalizeResponse(resp, c.provider.Name(), resp.Model)
+		}
+	}
+	return results, nil
+}
+
+func (c *Client) batchProvider() (BatchProvider, error) {
+	if c == nil || c.provider == nil {
+		return nil, NewError(ErrorTypeValidation, "client has no provider")
+	}
+	batcher, ok := c.provider.(BatchProvider)
+	if !ok {
+		return nil, NewProviderError(c.provider.Name(), ErrorTypeValidation, fmt.Sprintf("%s provider does not support batches", c.provider.Name()))
+	}
+	return batcher, nil
+}
+
+func (c *Client) finalizeBatch(batch *Batch, err error) (*Batch, error) {
+	if err != nil {
+		return nil, WrapError(err, c.provider.Name())
+	}
+	if batch == nil {
+		return nil, NewProviderError(c.provider.Name(), ErrorTypeInternal, "provider returned nil batch without error")
+	}
+	if batch.Provider == "" {
+		batch.Provider = c.provider.Name()
+	}
+	return batch, nil
+}
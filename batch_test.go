@@ -0,0 +1,110 @@
+package litellm
+
+import (
+	"context"
+	"testing"
+)
+
+type batchTestProvider struct {
+	*testProvider
+	created *BatchRequest
+	results []BatchResult
+}
+
+func (p *batchTestProvider) CreateBatch(ctx context.Context, req *BatchRequest) (*Batch, error) {
+	p.created = req
+	return &Batch{ID: "batch_1", Status: BatchStatusValidating}, nil
+}
+
+func (p *batchTestProvider) GetBatch(ctx context.Context, id string) (*Batch, error) {
+	return &Batch{ID: id, Status: BatchStatusCompleted}, nil
+}
+
+func (p *batchTestProvider) ListBatches(ctx context.Context, limit int) ([]Batch, error) {
+	return []Batch{{ID: "batch_1"}}, nil
+}
+
+func (p *batchTestProvider) CancelBatch(ctx context.Context, id string) (*Batch, error) {
+	return nil, NewHTTPError("", 404, "not found")
+}
+
+func (p *batchTestProvider) BatchResults(ctx context.Context, id string) ([]BatchResult, error) {
+	return p.results, nil
+}
+
+func TestClientCreateBatchPreparesItems(t *testing.T) {
+	provider := &batchTestProvider{testProvider: &testProvider{name: "test"}}
+	client, _ := New(provider, WithDefaults(RequestDefaults{MaxTokens: IntPtr(128)}))
+	batch, err := client.CreateBatch(context.Background(), BatchRequest{Items: []BatchItem{
+		{CustomID: "a", Request: Request{Model: "m", Messages: []Message{UserText("hi")}}},
+		{CustomID: "b", Request: Request{Model: "m", Messages: []Message{UserText("bye")}}},
+	}})
+	if err != nil {
+		t.Fatalf("CreateBatch returned error: %v", err)
+	}
+	if batch.Provider != "test" || batch.Done() {
+		t.Fatalf("batch = %+v", batch)
+	}
+	if len(provider.created.Items) != 2 || *provider.created.Items[1].Request.MaxTokens != 128 {
+		t.Fatalf("created = %+v", provider.created)
+	}
+
+	for _, items := range [][]BatchItem{
+		nil,
+		{{Request: Request{Model: "m", Messages: []Message{UserText("hi")}}}},
+		{{CustomID: "a", Request: Request{Model: "m", Messages: []Message{UserText("hi")}}}, {CustomID: "a", Request: Request{Model: "m", Messages: []Message{UserText("hi")}}}},
+		{{CustomID: "a", Request: Request{Messages: []Message{UserText("hi")}}}},
+	} {
+		if _, err := client.CreateBatch(context.Background(), BatchRequest{Items: items}); !IsValidationError(err) {
+			t.Fatalf("CreateBatch(%+v) error = %v, want validation error", items, err)
+		}
+	}
+}
+
+func TestClientCreateBatchReportsItemRepairs(t *testing.T) {
+	provider := &batchTestProvider{testProvider: &testProvider{name: "test"}}
+	client, _ := New(provider, WithMessageRepair(RepairAll))
+	batch, err := client.CreateBatch(context.Background(), BatchRequest{Items: []BatchItem{
+		{CustomID: "clean", Request: Request{Model: "m", Messages: []Message{UserText("hi")}}},
+		{CustomID: "repaired", Request: Request{Model: "m", Messages: []Message{
+			UserText("weather?"),
+			Assistant(ToolUseBlock{ID: "call_1", Name: "weather", Arguments: []byte(`{}`)}),
+			UserText("never mind"),
+		}}},
+	}})
+	if err != nil {
+		t.Fatalf("CreateBatch returned error: %v", err)
+	}
+	warnings := batch.ItemWarnings["repaired"]
+	if len(batch.ItemWarnings) != 1 || len(warnings) != 1 || warnings[0].Code != "message.synthetic_tool_result_inserted" || warnings[0].Provider != "test" {
+		t.Fatalf("item warnings = %+v", batch.ItemWarnings)
+	}
+}
+
+func TestClientBatchResultsStampsProvider(t *testing.T) {
+	provider := &batchTestProvider{testProvider: &testProvider{name: "test"}, results: []BatchResult{
+		{CustomID: "a", Response: &Response{Model: "m", Blocks: []Block{Text("ok")}}},
+		{CustomID: "b", Err: NewHTTPError("", 400, "bad")},
+	}}
+	client, _ := New(provider)
+	results, err := client.BatchResults(context.Background(), "batch_1")
+	if err != nil {
+		t.Fatalf("BatchResults returned error: %v", err)
+	}
+	if results[0].Response.Provider != "test" {
+		t.Fatalf("response = %+v", results[0].Response)
+	}
+	if !IsValidationError(results[1].Err) || results[1].Err.(*LiteLLMError).Provider != "test" {
+		t.Fatalf("err = %#v", results[1].Err)
+	}
+	if _, err := client.CancelBatch(context.Background(), "missing"); err == nil || err.(*LiteLLMError).Provider != "test" {
+		t.Fatalf("CancelBatch error = %v", err)
+	}
+}
+
+func TestClientBatchRequiresBatchProvider(t *testing.T) {
+	client, _ := New(&testProvider{name: "test"})
+	if _, err := client.ListBatches(context.Background(), 0); !IsValidationError(err) {
+		t.Fatalf("ListBatches error = %v", err)
+	}
+}
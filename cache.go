@@ -0,0 +1,350 @@
+package litellm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ProviderOptionCacheBypass, set to true in Request.ProviderOptions, skips the
+// client response cache for one call. The client removes it before the request
+// reaches the provider.
+const ProviderOptionCacheBypass = "litellm_cache_bypass"
+
+// CacheStore persists encoded responses for WithResponseCache. A ttl of zero
+// means the entry does not expire. Get reports a miss with ok == false.
+type CacheStore interface {
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// WithResponseCache caches successful Chat and Stream responses in store,
+// keyed by a hash of the prepared request and provider name. Hits are
+// reported by a "cache.hit" warning and CallMeta.CacheHit; streams replay the
+// cached response as synthetic events.
+func WithResponseCache(store CacheStore, ttl time.Duration) ClientOption {
+	return func(c *Client) error {
+		if store == nil {
+			return fmt.Errorf("cache store cannot be nil")
+		}
+		if ttl < 0 {
+			return fmt.Errorf("cache ttl cannot be negative")
+		}
+		c.cache = store
+		c.cacheTTL = ttl
+		return nil
+	}
+}
+
+func takeCacheBypass(req *Request) bool {
+	value, ok := req.ProviderOptions[ProviderOptionCacheBypass]
+	if !ok {
+		return false
+	}
+	delete(req.ProviderOptions, ProviderOptionCacheBypass)
+	bypass, _ := value.(bool)
+	return bypass
+}
+
+// cacheKey returns "" when the call must not use the cache.
+func (c *Client) cacheKey(req *Request) string {
+	if c.cache == nil || req.cacheBypass {
+		return ""
+	}
+	messages := make([]cachedMessage, 0, len(req.Messages))
+	for _, msg := range req.Messages {
+		blocks, err := encodeCachedBlocks(msg.Blocks)
+		if err != nil {
+			return ""
+		}
+		messages = append(messages, cachedMessage{Role: msg.Role, Blocks: blocks})
+	}
+	data, err := json.Marshal(struct {
+		Provider        string
+		Model           string
+		Messages        []cachedMessage
+		MaxTokens       *int
+		Temperature     *float64
+		TopP            *float64
+		Stop            []string
+		Tools           []Tool
+		ToolChoiceType  string
+		ToolChoice      ToolChoice
+		ResponseFormat  *ResponseFormat
+		Thinking        *Thinking
+		Cache           *CachePolicy
+		WebSearch       *WebSearch
+		ProviderOptions ProviderOptions
+	}{
+		Provider:        c.provider.Name(),
+		Model:           req.Model,
+		Messages:        messages,
+		MaxTokens:       req.MaxTokens,
+		Temperature:     req.Temperature,
+		TopP:            req.TopP,
+		Stop:            req.Stop,
+		Tools:           req.Tools,
+		ToolChoiceType:  fmt.Sprintf("%T", req.ToolChoice),
+		ToolChoice:      req.ToolChoice,
+		ResponseFormat:  req.ResponseFormat,
+		Thinking:        req.Thinking,
+		Cache:           req.Cache,
+		WebSearch:       req.WebSearch,
+		ProviderOptions: req.ProviderOptions,
+	})
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// cachedResponse looks key up, turning store and decode failures into
+// warnings so the call falls through to the provider.
+func (c *Client) cachedResponse(ctx context.Context, key string) (*Response, *Warning) {
+	if key == "" {
+		return nil, nil
+	}
+	data, ok, err := c.cache.Get(ctx, key)
+	if err == nil && ok {
+		var resp *Response
+		if resp, err = decodeCachedResponse(data); err == nil {
+			return resp, nil
+		}
+	}
+	if err != nil {
+		return nil, &Warning{Code: "cache.lookup_failed", Provider: c.ProviderName(), Message: err.Error()}
+	}
+	return nil, nil
+}
+
+func (c *Client) storeResponse(ctx context.Context, key string, resp *Response) *Warning {
+	data, err := encodeCachedResponse(resp)
+	if err == nil {
+		err = c.cache.Set(ctx, key, data, c.cacheTTL)
+	}
+	if err != nil {
+		return &Warning{Code: "cache.store_failed", Provider: c.ProviderName(), Message: err.Error()}
+	}
+	return nil
+}
+
+func cacheHitWarning(provider string) Warning {
+	return Warning{Code: "cache.hit", Provider: provider, Message: "response served from cache"}
+}
+
+type cachedMessage struct {
+	Role   Role
+	Blocks []cachedBlock
+}
+
+type cachedBlock struct {
+	Type    string
+	Block   json.RawMessage
+	Content []cachedBlock `json:",omitempty"`
+}
+
+// cachedResponseWire omits Warnings and Raw: warnings belong to the call that
+// produced them, and raw capture is not part of the cache key.
+type cachedResponseWire struct {
+	Blocks          []cachedBlock
+	Usage           Usage
+	Refusal         string
+	Model           string
+	Provider        string
+	FinishReason    FinishReason
+	FinishReasonRaw string
+	Grounding       *Grounding         `json:",omitempty"`
+	Safety          []SafetyAssessment `json:",omitempty"`
+}
+
+func encodeCachedResponse(resp *Response) ([]byte, error) {
+	blocks, err := encodeCachedBlocks(resp.Blocks)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(cachedResponseWire{
+		Blocks:          blocks,
+		Usage:           resp.Usage,
+		Refusal:         resp.Refusal,
+		Model:           resp.Model,
+		Provider:        resp.Provider,
+		FinishReason:    resp.FinishReason,
+		FinishReasonRaw: resp.FinishReasonRaw,
+		Grounding:       resp.Grounding,
+		Safety:          resp.Safety,
+	})
+}
+
+func decodeCachedResponse(data []byte) (*Response, error) {
+	var wire cachedResponseWire
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return nil, fmt.Errorf("decode cached response: %w", err)
+	}
+	blocks, err := decodeCachedBlocks(wire.Blocks)
+	if err != nil {
+		return nil, err
+	}
+	return &Response{
+		Blocks:          blocks,
+		Usage:           wire.Usage,
+		Refusal:         wire.Refusal,
+		Model:           wire.Model,
+		Provider:        wire.Provider,
+		FinishReason:    wire.FinishReason,
+		FinishReasonRaw: wire.FinishReasonRaw,
+		Grounding:       wire.Grounding,
+		Safety:          wire.Safety,
+	}, nil
+}
+
+func encodeCachedBlocks(blocks []Block) ([]cachedBlock, error) {
+	out := make([]cachedBlock, 0, len(blocks))
+	for _, block := range blocks {
+		var entry cachedBlock
+		var payload any = block
+		switch b := block.(type) {
+		case TextBlock:
+			entry.Type = "text"
+		case ImageBlock:
+			entry.Type = "image"
+		case DocumentBlock:
+			entry.Type = "document"
+		case AudioBlock:
+			entry.Type = "audio"
+		case AudioOutputBlock:
+			entry.Type = "audio_output"
+		case ReasoningBlock:
+			entry.Type = "reasoning"
+		case ToolUseBlock:
+			entry.Type = "tool_use"
+		case ToolReferenceBlock:
+			entry.Type = "tool_reference"
+		case ServerToolUseBlock:
+			entry.Type = "server_tool_use"
+		case ServerToolResultBlock:
+			entry.Type = "server_tool_result"
+		case GuardContentBlock:
+			entry.Type = "guard_content"
+		case ToolResultBlock:
+			entry.Type = "tool_result"
+			content, err := encodeCachedBlocks(b.Content)
+			if err != nil {
+				return nil, err
+			}
+			entry.Content = content
+			b.Content = nil
+			payload = b
+		default:
+			return nil, fmt.Errorf("cache: unsupported block %T", block)
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("cache: encode %s block: %w", entry.Type, err)
+		}
+		entry.Block = data
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+func decodeCachedBlocks(entries []cachedBlock) ([]Block, error) {
+	out := make([]Block, 0, len(entries))
+	for _, entry := range entries {
+		block, err := decodeCachedBlock(entry)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, block)
+	}
+	return out, nil
+}
+
+func decodeCachedBlock(entry cachedBlock) (Block, error) {
+	switch entry.Type {
+	case "text":
+		return decodeCachedBlockAs[TextBlock](entry)
+	case "image":
+		return decodeCachedBlockAs[ImageBlock](entry)
+	case "document":
+		return decodeCachedBlockAs[DocumentBlock](entry)
+	case "audio":
+		return decodeCachedBlockAs[AudioBlock](entry)
+	case "audio_output":
+		return decodeCachedBlockAs[AudioOutputBlock](entry)
+	case "reasoning":
+		return decodeCachedBlockAs[ReasoningBlock](entry)
+	case "tool_use":
+		return decodeCachedBlockAs[ToolUseBlock](entry)
+	case "tool_reference":
+		return decodeCachedBlockAs[ToolReferenceBlock](entry)
+	case "server_tool_use":
+		return decodeCachedBlockAs[ServerToolUseBlock](entry)
+	case "server_tool_result":
+		return decodeCachedBlockAs[ServerToolResultBlock](entry)
+	case "guard_content":
+		return decodeCachedBlockAs[GuardContentBlock](entry)
+	case "tool_result":
+		block, err := decodeCachedBlockAs[ToolResultBlock](entry)
+		if err != nil {
+			return nil, err
+		}
+		if block.Content, err = decodeCachedBlocks(entry.Content); err != nil {
+			return nil, err
+		}
+		return block, nil
+	default:
+		return nil, fmt.Errorf("cache: unsupported block type %q", entry.Type)
+	}
+}
+
+func decodeCachedBlockAs[T Block](entry cachedBlock) (T, error) {
+	var block T
+	if err := json.Unmarshal(entry.Block, &block); err != nil {
+		return block, fmt.Errorf("cache: decode %s block: %w", entry.Type, err)
+	}
+	return block, nil
+}
+
+// cachingStream collects a live stream and stores the result once it
+// completes. Store failures are dropped: the events have already been
+// delivered.
+type cachingStream struct {
+	ctx       context.Context
+	client    *Client
+	key       string
+	inner     Stream
+	collector *EventCollector
+	finished  bool
+}
+
+func (s *cachingStream) Next() (Event, error) {
+	event, err := s.inner.Next()
+	if err != nil || s.finished {
+		s.finished = true
+		return event, err
+	}
+	if _, ok := event.(WarningEvent); ok {
+		return event, nil
+	}
+	done, applyErr := s.collector.Apply(event)
+	if applyErr != nil {
+		s.finished = true
+		return event, nil
+	}
+	if done {
+		s.finished = true
+		resp := s.collector.Response()
+		if validateResponse(resp, resp.Provider, resp.Model) == nil {
+			s.client.storeResponse(s.ctx, s.key, resp)
+		}
+	}
+	return event, nil
+}
+
+func (s *cachingStream) Close() error {
+	return s.inner.Close()
+}
@@ -0,0 +1,177 @@
+package litellm
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// LRUCacheStore is an in-memory CacheStore that evicts the least recently
+// used entry once it holds capacity entries.
+type LRUCacheStore struct {
+	capacity int
+	now      func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewLRUCacheStore(capacity int) (*LRUCacheStore, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("lru cache capacity must be positive")
+	}
+	return &LRUCacheStore{
+		capacity: capacity,
+		now:      time.Now,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}, nil
+}
+
+func (s *LRUCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := elem.Value.(*lruEntry)
+	if expired(entry.expiresAt, s.now()) {
+		s.order.Remove(elem)
+		delete(s.entries, key)
+		return nil, false, nil
+	}
+	s.order.MoveToFront(elem)
+	return cloneBytes(entry.value), true, nil
+}
+
+func (s *LRUCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := &lruEntry{key: key, value: cloneBytes(value), expiresAt: expiryFor(s.now(), ttl)}
+	if elem, ok := s.entries[key]; ok {
+		elem.Value = entry
+		s.order.MoveToFront(elem)
+		return nil
+	}
+	s.entries[key] = s.order.PushFront(entry)
+	for s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+func (s *LRUCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+// FileCacheStore is a CacheStore that keeps one JSON file per key in a
+// directory, so cached responses survive across processes such as CI runs.
+type FileCacheStore struct {
+	dir string
+	now func() time.Time
+}
+
+type fileCacheEntry struct {
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+	Value     []byte    `json:"value"`
+}
+
+func NewFileCacheStore(dir string) (*FileCacheStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("file cache directory cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create file cache directory: %w", err)
+	}
+	return &FileCacheStore{dir: dir, now: time.Now}, nil
+}
+
+func (s *FileCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, false, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("read cache entry: %w", err)
+	}
+	var entry fileCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false, fmt.Errorf("decode cache entry: %w", err)
+	}
+	if expired(entry.ExpiresAt, s.now()) {
+		_ = os.Remove(path)
+		return nil, false, nil
+	}
+	return entry.Value, true, nil
+}
+
+func (s *FileCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(fileCacheEntry{ExpiresAt: expiryFor(s.now(), ttl), Value: value})
+	if err != nil {
+		return fmt.Errorf("encode cache entry: %w", err)
+	}
+	// Write then rename so concurrent readers never see a partial entry.
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+	return nil
+}
+
+func (s *FileCacheStore) path(key string) (string, error) {
+	if key == "" || filepath.Base(key) != key || key[0] == '.' {
+		return "", fmt.Errorf("invalid cache key %q", key)
+	}
+	return filepath.Join(s.dir, key+".json"), nil
+}
+
+func expiryFor(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
+
+func expired(expiresAt, now time.Time) bool {
+	return !expiresAt.IsZero() && !now.Before(expiresAt)
+}
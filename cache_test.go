@@ -0,0 +1,223 @@
+package litellm
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestClientChatServesRepeatedRequestsFromCache(t *testing.T) {
+	calls := 0
+	provider := &testProvider{name: "openai", chatFunc: func(_ context.Context, req *Request) (*Response, error) {
+		calls++
+		return &Response{
+			Blocks: []Block{
+				TextBlock{Text: "answer"},
+				ToolUseBlock{ID: "call_1", Name: "lookup", Arguments: json.RawMessage(`{"q":"x"}`)},
+			},
+			Usage:        Usage{InputTokens: 5, OutputTokens: 2},
+			FinishReason: FinishReasonToolCall,
+		}, nil
+	}}
+	store, err := NewLRUCacheStore(8)
+	if err != nil {
+		t.Fatalf("NewLRUCacheStore returned error: %v", err)
+	}
+	var metas []CallMeta
+	client, err := New(provider, WithResponseCache(store, time.Minute), WithHook(HookFuncs{
+		AfterResponseFunc: func(_ context.Context, meta CallMeta, _ *Response, _ error) { metas = append(metas, meta) },
+	}))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	req := Request{Model: "gpt", Messages: []Message{UserText("hi")}}
+	first, err := client.Chat(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	second, err := client.Chat(context.Background(), req)
+	if err != nil {
+		t.Fatalf("cached Chat returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("provider calls = %d, want 1", calls)
+	}
+	if second.Text() != first.Text() || len(second.ToolCalls()) != 1 || string(second.ToolCalls()[0].Arguments) != `{"q":"x"}` {
+		t.Fatalf("cached response = %+v", second)
+	}
+	if second.Usage.InputTokens != 5 || second.Provider != "openai" || second.Model != "gpt" {
+		t.Fatalf("cached response metadata = %+v", second)
+	}
+	if len(second.Warnings) != 1 || second.Warnings[0].Code != "cache.hit" || len(first.Warnings) != 0 {
+		t.Fatalf("warnings first/second = %+v/%+v", first.Warnings, second.Warnings)
+	}
+	if len(metas) != 2 || metas[0].CacheHit || !metas[1].CacheHit {
+		t.Fatalf("metas = %+v", metas)
+	}
+
+	req.Messages = []Message{UserText("different")}
+	if _, err := client.Chat(context.Background(), req); err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("different prompt should miss, calls = %d", calls)
+	}
+}
+
+func TestClientChatCacheBypassOption(t *testing.T) {
+	calls := 0
+	provider := &testProvider{name: "openai", chatFunc: func(_ context.Context, req *Request) (*Response, error) {
+		calls++
+		if _, ok := req.ProviderOptions[ProviderOptionCacheBypass]; ok {
+			t.Fatal("bypass option should not reach the provider")
+		}
+		return &Response{Blocks: []Block{TextBlock{Text: "ok"}}}, nil
+	}}
+	store, err := NewLRUCacheStore(8)
+	if err != nil {
+		t.Fatalf("NewLRUCacheStore returned error: %v", err)
+	}
+	client, err := New(provider, WithResponseCache(store, 0))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	req := Request{Model: "gpt", Messages: []Message{UserText("hi")}, ProviderOptions: ProviderOptions{ProviderOptionCacheBypass: true}}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Chat(context.Background(), req); err != nil {
+			t.Fatalf("Chat returned error: %v", err)
+		}
+	}
+	if calls != 2 || store.Len() != 0 {
+		t.Fatalf("calls = %d, cached entries = %d", calls, store.Len())
+	}
+}
+
+func TestClientStreamReplaysCachedResponse(t *testing.T) {
+	streams := 0
+	provider := &testProvider{name: "anthropic", streamFunc: func(_ context.Context, req *Request) (Stream, error) {
+		streams++
+		return &testStream{events: []Event{
+			ReasoningDelta{Text: "think", Signature: "sig"},
+			ContentDelta{Text: "hello"},
+			UsageEvent{Usage: Usage{InputTokens: 3, OutputTokens: 1}},
+			DoneEvent{FinishReason: FinishReasonStop, Provider: "anthropic", Model: req.Model},
+		}}, nil
+	}}
+	store, err := NewLRUCacheStore(8)
+	if err != nil {
+		t.Fatalf("NewLRUCacheStore returned error: %v", err)
+	}
+	client, err := New(provider, WithResponseCache(store, 0))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	req := Request{Model: "claude", Messages: []Message{UserText("hi")}}
+	var responses []*Response
+	for i := 0; i < 2; i++ {
+		stream, err := client.Stream(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Stream returned error: %v", err)
+		}
+		resp, err := Collect(stream)
+		stream.Close()
+		if err != nil {
+			t.Fatalf("Collect returned error: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+	if streams != 1 {
+		t.Fatalf("provider streams = %d, want 1", streams)
+	}
+	replayed := responses[1]
+	if replayed.Text() != "hello" || replayed.Reasoning() != "think" || replayed.Usage.OutputTokens != 1 || replayed.FinishReason != FinishReasonStop {
+		t.Fatalf("replayed = %+v", replayed)
+	}
+	if len(replayed.Warnings) != 1 || replayed.Warnings[0].Code != "cache.hit" {
+		t.Fatalf("warnings = %+v", replayed.Warnings)
+	}
+}
+
+func TestReplayStreamKeepsAnnotationOffsets(t *testing.T) {
+	location := &CitationLocation{Index: 0, Unit: CitationUnitPage, Start: 2, End: 3}
+	resp := &Response{
+		Blocks: []Block{
+			TextBlock{Text: "According to the report, "},
+			TextBlock{Text: "revenue grew.", Annotations: []Annotation{{Type: AnnotationDocumentCitation, End: 13, Text: "Revenue grew 8%.", Location: location}}},
+		},
+		Provider:  "anthropic",
+		Model:     "claude",
+		Grounding: &Grounding{SearchQueries: []string{"report revenue"}},
+	}
+	data, err := encodeCachedResponse(resp)
+	if err != nil {
+		t.Fatalf("encodeCachedResponse returned error: %v", err)
+	}
+	cached, err := decodeCachedResponse(data)
+	if err != nil {
+		t.Fatalf("decodeCachedResponse returned error: %v", err)
+	}
+	replayed, err := Collect(newReplayStream(cached))
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	block, ok := replayed.Blocks[0].(TextBlock)
+	if len(replayed.Blocks) != 1 || !ok || len(block.Annotations) != 1 {
+		t.Fatalf("blocks = %#v", replayed.Blocks)
+	}
+	annotation := block.Annotations[0]
+	if block.Text[annotation.Start:annotation.End] != "revenue grew." || annotation.Location == nil || *annotation.Location != *location {
+		t.Fatalf("annotation = %+v", annotation)
+	}
+	if replayed.Grounding == nil || replayed.Grounding.SearchQueries[0] != "report revenue" {
+		t.Fatalf("grounding = %+v", replayed.Grounding)
+	}
+}
+
+func TestLRUCacheStoreEvictsAndExpires(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	store, err := NewLRUCacheStore(2)
+	if err != nil {
+		t.Fatalf("NewLRUCacheStore returned error: %v", err)
+	}
+	store.now = func() time.Time { return now }
+	_ = store.Set(ctx, "a", []byte("1"), 0)
+	_ = store.Set(ctx, "b", []byte("2"), time.Second)
+	if _, ok, _ := store.Get(ctx, "a"); !ok {
+		t.Fatal("a should be cached")
+	}
+	_ = store.Set(ctx, "c", []byte("3"), 0)
+	if _, ok, _ := store.Get(ctx, "b"); ok {
+		t.Fatal("b should be evicted as least recently used")
+	}
+	_ = store.Set(ctx, "d", []byte("4"), time.Second)
+	now = now.Add(time.Second)
+	if _, ok, _ := store.Get(ctx, "d"); ok {
+		t.Fatal("d should be expired")
+	}
+}
+
+func TestFileCacheStoreRoundTripAndExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	store, err := NewFileCacheStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCacheStore returned error: %v", err)
+	}
+	store.now = func() time.Time { return now }
+	if err := store.Set(ctx, "key", []byte("value"), time.Minute); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	value, ok, err := store.Get(ctx, "key")
+	if err != nil || !ok || string(value) != "value" {
+		t.Fatalf("Get = %q, %v, %v", value, ok, err)
+	}
+	now = now.Add(time.Minute)
+	if _, ok, err := store.Get(ctx, "key"); ok || err != nil {
+		t.Fatalf("expected expired miss, got %v, %v", ok, err)
+	}
+	if err := store.Set(ctx, "../escape", []byte("x"), 0); err == nil {
+		t.Fatal("expected invalid key error")
+	}
+}
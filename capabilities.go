@@ -55,6 +55,7 @@ type ToolCapabilities struct {
 	RequiresAdjacency   bool
 	RoundTripSignatures Support
 	HostedProviderTools Support
+	WebSearch           Support
 }
 
 type StructuredCapabilities struct {
@@ -69,6 +70,14 @@ type MediaCapabilities struct {
 	ImageBytes  Support
 	FileURI     Support
 	ImageDetail Support
+
+	DocumentURL       Support
+	DocumentBytes     Support
+	DocumentFileURI   Support
+	DocumentCitations Support
+
+	AudioInput  Support
+	AudioOutput Support
 }
 
 type CacheCapabilities struct {
@@ -14,6 +14,10 @@ type Client struct {
 	repair             MessageRepairPolicy
 	captureRawResponse bool
 	streamIdleTimeout  time.Duration
+	cache              CacheStore
+	cacheTTL           time.Duration
+	strictValidation   bool
+	contextWindow      *ContextWindow
 }
 
 type RequestDefaults struct {
@@ -72,6 +76,16 @@ func WithStreamIdleTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithStrictValidation fails calls whose tool arguments or structured output
+// violate the request's schemas with an ErrorTypeValidation error. By default
+// violations are reported as "schema.*" warnings.
+func WithStrictValidation(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.strictValidation = enabled
+		return nil
+	}
+}
+
 func (c *Client) ProviderName() string {
 	if c == nil || c.provider == nil {
 		return ""
@@ -91,20 +105,58 @@ func (c *Client) Chat(ctx context.Context, req Request) (*Response, error) {
 	if err != nil {
 		return nil, err
 	}
+	fitWarnings, err := c.fitContext(ctx, prepared)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, fitWarnings...)
 	stampWarnings(warnings, c.ProviderName())
 	meta := c.newCallMeta("chat", prepared.Model, false)
 	c.notifyBeforeRequest(ctx, meta, prepared)
 	start := meta.StartedAt
-	resp, err := c.provider.Chat(ctx, prepared)
-	if err != nil {
-		err = WrapError(err, c.provider.Name())
+	key := c.cacheKey(prepared)
+	resp, warning := c.cachedResponse(ctx, key)
+	if warning != nil {
+		warnings = append(warnings, *warning)
 	}
-	if err == nil {
-		err = validateResponse(resp, c.provider.Name(), prepared.Model)
+	if resp != nil {
+		meta.CacheHit = true
+		warnings = append(warnings, cacheHitWarning(c.ProviderName()))
+	} else {
+		resp, err = c.provider.Chat(ctx, prepared)
+		if err != nil {
+			err = WrapError(err, c.provider.Name())
+		}
+		if retryWarnings, retry := c.refitAfterOverflow(ctx, prepared, err); retry {
+			stampWarnings(retryWarnings, c.ProviderName())
+			warnings = append(warnings, retryWarnings...)
+			resp, err = c.provider.Chat(ctx, prepared)
+			if err != nil {
+				err = WrapError(err, c.provider.Name())
+			}
+		}
+		if err == nil {
+			err = validateResponse(resp, c.provider.Name(), prepared.Model)
+		}
 	}
 	if resp != nil {
-		resp.Warnings = append(warnings, resp.Warnings...)
 		finalizeResponse(resp, c.provider.Name(), prepared.Model)
+		if err == nil {
+			if schemaWarnings := checkResponseSchemas(prepared, resp, c.provider.Name()); len(schemaWarnings) > 0 {
+				if c.strictValidation {
+					err = schemaViolationError(c.provider.Name(), schemaWarnings)
+				} else {
+					warnings = append(warnings, schemaWarnings...)
+				}
+			}
+		}
+		if err == nil && key != "" && !meta.CacheHit {
+			if warning := c.storeResponse(ctx, key, resp); warning != nil {
+				warnings = append(warnings, *warning)
+			}
+		}
+		resp.Warnings = append(warnings, resp.Warnings...)
+		meta.ServedProvider, meta.ServedModel = resp.Provider, resp.Model
 	}
 	meta.Duration = time.Since(start)
 	c.notifyAfterResponse(ctx, meta, resp, err)
@@ -116,6 +168,11 @@ func (c *Client) Stream(ctx context.Context, req Request) (Stream, error) {
 	if err != nil {
 		return nil, err
 	}
+	fitWarnings, err := c.fitContext(ctx, prepared)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, fitWarnings...)
 	streamCtx := ctx
 	var cancel context.CancelFunc
 	if c.streamIdleTimeout > 0 {
@@ -125,11 +182,35 @@ func (c *Client) Stream(ctx context.Context, req Request) (Stream, error) {
 	meta := c.newCallMeta("stream", prepared.Model, true)
 	c.notifyBeforeRequest(streamCtx, meta, prepared)
 	start := meta.StartedAt
-	stream, err := c.provider.Stream(streamCtx, prepared)
+	key := c.cacheKey(prepared)
+	cached, warning := c.cachedResponse(streamCtx, key)
+	if warning != nil {
+		warnings = append(warnings, *warning)
+	}
+	var stream Stream
+	if cached != nil {
+		meta.CacheHit = true
+		warnings = append(warnings, cacheHitWarning(c.ProviderName()))
+		stream = newReplayStream(cached)
+	} else {
+		stream, err = c.provider.Stream(streamCtx, prepared)
+		if err != nil {
+			if retryWarnings, retry := c.refitAfterOverflow(streamCtx, prepared, WrapError(err, c.provider.Name())); retry {
+				stampWarnings(retryWarnings, c.ProviderName())
+				warnings = append(warnings, retryWarnings...)
+				stream, err = c.provider.Stream(streamCtx, prepared)
+			}
+		}
+	}
 	if err != nil {
 		err = WrapError(err, c.provider.Name())
 	} else if stream == nil {
 		err = NewProviderError(c.provider.Name(), ErrorTypeInternal, "provider returned nil stream without error")
+	} else {
+		meta.ServedProvider, meta.ServedModel = c.provider.Name(), prepared.Model
+		if provider, model := streamServedBy(stream); provider != "" {
+			meta.ServedProvider, meta.ServedModel = provider, model
+		}
 	}
 	meta.Duration = time.Since(start)
 	c.notifyAfterResponse(streamCtx, meta, nil, err)
@@ -141,6 +222,10 @@ func (c *Client) Stream(ctx context.Context, req Request) (Stream, error) {
 	}
 	stream = wrapProviderStreamErrors(c.provider.Name(), stream)
 	stream = newStreamIdleWatchdog(stream, cancel, c.streamIdleTimeout, c.provider.Name())
+	stream = newSchemaCheckingStream(stream, prepared, c.provider.Name(), c.strictValidation)
+	if key != "" && !meta.CacheHit {
+		stream = &cachingStream{ctx: streamCtx, client: c, key: key, inner: stream, collector: NewEventCollector()}
+	}
 	stream = prependWarningEvents(stream, warnings)
 	return newHookedStream(streamCtx, meta, c.hooks, stream), nil
 }
@@ -200,6 +285,7 @@ func (c *Client) prepareRequest(req Request) (*Request, []Warning, error) {
 		applyDefaults(prepared, *c.defaults)
 	}
 	prepared.captureRawResponse = c.captureRawResponse
+	prepared.cacheBypass = takeCacheBypass(prepared)
 	warnings, err := repairRequest(prepared, c.repair)
 	if err != nil {
 		return nil, nil, err
@@ -53,6 +53,17 @@ func validateRequest(req *Request) error {
 		if len(tool.Parameters) > 0 && !json.Valid(tool.Parameters) {
 			return NewError(ErrorTypeValidation, fmt.Sprintf("tool %q parameters must be valid JSON", tool.Name))
 		}
+		if tool.Server != nil {
+			if tool.Server.Type == "" || !utf8.ValidString(tool.Server.Type) {
+				return NewError(ErrorTypeValidation, fmt.Sprintf("server tool %q requires a valid type", tool.Name))
+			}
+			if len(tool.Parameters) > 0 {
+				return NewError(ErrorTypeValidation, fmt.Sprintf("server tool %q cannot have parameters", tool.Name))
+			}
+			if err := validateAnyUTF8(tool.Server.Config, fmt.Sprintf("server tool %q config", tool.Name)); err != nil {
+				return err
+			}
+		}
 	}
 	if req.ResponseFormat != nil && req.ResponseFormat.Type == ResponseFormatJSONSchema {
 		if req.ResponseFormat.JSONSchema == nil {
@@ -74,6 +85,9 @@ func validateRequest(req *Request) error {
 	if err := validateThinking(req.Thinking); err != nil {
 		return err
 	}
+	if err := validateWebSearch(req.WebSearch); err != nil {
+		return err
+	}
 	if err := validateAnyUTF8(req.ToolChoice, "tool choice"); err != nil {
 		return err
 	}
@@ -108,6 +122,30 @@ func validateMessages(messages []Message) error {
 				if err := validateCacheControl(i, b.Cache); err != nil {
 					return err
 				}
+			case DocumentBlock:
+				if msg.Role == RoleAssistant {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: document block cannot use assistant role", i))
+				}
+				if err := validateDocument(i, b); err != nil {
+					return err
+				}
+			case AudioBlock:
+				if msg.Role == RoleAssistant {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: audio block cannot use assistant role; use AudioOutputBlock", i))
+				}
+				if err := validateAudio(i, b); err != nil {
+					return err
+				}
+			case AudioOutputBlock:
+				if msg.Role != RoleAssistant {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: audio output block requires assistant role", i))
+				}
+				if b.ID == "" && len(b.Data) == 0 {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: audio output block requires id or data", i))
+				}
+				if !utf8.ValidString(b.ID) || !utf8.ValidString(b.Format) || !utf8.ValidString(b.Transcript) {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: audio output block fields must be valid UTF-8", i))
+				}
 			case ReasoningBlock:
 				if msg.Role != RoleAssistant {
 					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: reasoning block requires assistant role", i))
@@ -156,6 +194,38 @@ func validateMessages(messages []Message) error {
 				}
 				seenToolUses[b.ID] = true
 				openToolUses[b.ID] = true
+			case ServerToolUseBlock:
+				if msg.Role != RoleAssistant {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: server tool use block requires assistant role", i))
+				}
+				if b.ID == "" || b.Name == "" {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: server tool use requires id and name", i))
+				}
+				if !utf8.ValidString(b.ID) || !utf8.ValidString(b.Name) {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: server tool use fields must be valid UTF-8", i))
+				}
+				if len(b.Arguments) > 0 && !json.Valid(b.Arguments) {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: server tool use %q arguments must be valid JSON", i, b.ID))
+				}
+				if err := validateCacheControl(i, b.Cache); err != nil {
+					return err
+				}
+			case ServerToolResultBlock:
+				if msg.Role != RoleAssistant {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: server tool result block requires assistant role", i))
+				}
+				if b.ToolUseID == "" || b.Type == "" {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: server tool result requires tool use id and type", i))
+				}
+				if !utf8.ValidString(b.ToolUseID) || !utf8.ValidString(b.Type) {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: server tool result fields must be valid UTF-8", i))
+				}
+				if len(b.Content) > 0 && !json.Valid(b.Content) {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: server tool result %q content must be valid JSON", i, b.ToolUseID))
+				}
+				if err := validateCacheControl(i, b.Cache); err != nil {
+					return err
+				}
 			case ToolResultBlock:
 				if msg.Role != RoleTool {
 					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: tool result block requires tool role", i))
@@ -179,6 +249,13 @@ func validateMessages(messages []Message) error {
 					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: tool result references unknown tool use %q", i, b.ToolUseID))
 				}
 				delete(openToolUses, b.ToolUseID)
+			case GuardContentBlock:
+				if msg.Role != RoleUser && msg.Role != RoleSystem {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: guard content block requires user or system role", i))
+				}
+				if !utf8.ValidString(b.Text) {
+					return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: guard content text must be valid UTF-8", i))
+				}
 			default:
 				return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: unsupported block %T", i, block))
 			}
@@ -244,6 +321,14 @@ func validateToolResultContent(messageIndex int, blocks []Block) error {
 			if err := validateCacheControl(messageIndex, b.Cache); err != nil {
 				return err
 			}
+		case DocumentBlock:
+			if err := validateDocument(messageIndex, b); err != nil {
+				return err
+			}
+		case AudioBlock:
+			if err := validateAudio(messageIndex, b); err != nil {
+				return err
+			}
 		case ToolReferenceBlock:
 			if b.ToolName == "" {
 				return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: tool reference missing tool name", messageIndex))
@@ -277,6 +362,51 @@ func validateImageUTF8(messageIndex int, block ImageBlock) error {
 	return nil
 }
 
+func validateDocument(messageIndex int, block DocumentBlock) error {
+	sources := 0
+	if len(block.Data) > 0 {
+		sources++
+	}
+	if block.URL != "" {
+		sources++
+	}
+	if block.FileURI != "" {
+		sources++
+	}
+	if sources != 1 {
+		return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: document block requires exactly one of data, URL or file URI", messageIndex))
+	}
+	if len(block.Data) > 0 && block.MIME == "" {
+		return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: document data requires MIME", messageIndex))
+	}
+	if !utf8.ValidString(block.URL) {
+		return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: document URL must be valid UTF-8", messageIndex))
+	}
+	if !utf8.ValidString(block.MIME) {
+		return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: document MIME must be valid UTF-8", messageIndex))
+	}
+	if !utf8.ValidString(block.FileURI) {
+		return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: document file URI must be valid UTF-8", messageIndex))
+	}
+	if !utf8.ValidString(block.Title) {
+		return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: document title must be valid UTF-8", messageIndex))
+	}
+	return validateCacheControl(messageIndex, block.Cache)
+}
+
+func validateAudio(messageIndex int, block AudioBlock) error {
+	if len(block.Data) == 0 {
+		return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: audio block requires data", messageIndex))
+	}
+	if block.Format == "" {
+		return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: audio block requires format", messageIndex))
+	}
+	if !utf8.ValidString(block.Format) {
+		return NewError(ErrorTypeValidation, fmt.Sprintf("messages[%d]: audio format must be valid UTF-8", messageIndex))
+	}
+	return validateCacheControl(messageIndex, block.Cache)
+}
+
 func validateProviderOptionsUTF8(options ProviderOptions) error {
 	for key, value := range options {
 		if !utf8.ValidString(key) {
@@ -293,6 +423,32 @@ func validateThinking(thinking *Thinking) error {
 	return thinking.Validate()
 }
 
+func validateWebSearch(search *WebSearch) error {
+	if search == nil {
+		return nil
+	}
+	if search.MaxUses < 0 {
+		return NewError(ErrorTypeValidation, "web search max uses cannot be negative")
+	}
+	if len(search.AllowedDomains) > 0 && len(search.BlockedDomains) > 0 {
+		return NewError(ErrorTypeValidation, "web search allowed and blocked domains are mutually exclusive")
+	}
+	if err := validateAnyUTF8(search.AllowedDomains, "web search allowed domains"); err != nil {
+		return err
+	}
+	if err := validateAnyUTF8(search.BlockedDomains, "web search blocked domains"); err != nil {
+		return err
+	}
+	if loc := search.UserLocation; loc != nil {
+		for _, v := range []string{loc.City, loc.Region, loc.Country, loc.Timezone} {
+			if !utf8.ValidString(v) {
+				return NewError(ErrorTypeValidation, "web search user location must be valid UTF-8")
+			}
+		}
+	}
+	return nil
+}
+
 func validateAnyUTF8(value any, path string) error {
 	switch v := value.(type) {
 	case string:
@@ -405,6 +561,7 @@ func cloneRequest(req Request) *Request {
 	out.ResponseFormat = cloneResponseFormat(req.ResponseFormat)
 	out.Thinking = cloneThinking(req.Thinking)
 	out.Cache = cloneCachePolicy(req.Cache)
+	out.WebSearch = cloneWebSearch(req.WebSearch)
 	if req.ProviderOptions != nil {
 		out.ProviderOptions = make(ProviderOptions, len(req.ProviderOptions))
 		for k, v := range req.ProviderOptions {
@@ -436,13 +593,33 @@ func cloneBlocks(blocks []Block) []Block {
 	return out
 }
 
+func cloneAnnotation(annotation Annotation) Annotation {
+	annotation.Extra = cloneBytes(annotation.Extra)
+	if annotation.Location != nil {
+		location := *annotation.Location
+		annotation.Location = &location
+	}
+	return annotation
+}
+
+func cloneGrounding(grounding Grounding) Grounding {
+	grounding.SearchQueries = append([]string(nil), grounding.SearchQueries...)
+	return grounding
+}
+
+func cloneSafety(safety SafetyAssessment) SafetyAssessment {
+	safety.Categories = append([]SafetyCategory(nil), safety.Categories...)
+	safety.Raw = cloneBytes(safety.Raw)
+	return safety
+}
+
 func cloneBlock(block Block) Block {
 	switch b := block.(type) {
 	case TextBlock:
 		b.Logprobs = cloneBytes(b.Logprobs)
 		b.Annotations = append([]Annotation(nil), b.Annotations...)
 		for i := range b.Annotations {
-			b.Annotations[i].Extra = cloneBytes(b.Annotations[i].Extra)
+			b.Annotations[i] = cloneAnnotation(b.Annotations[i])
 		}
 		b.Cache = cloneCacheControl(b.Cache)
 		return b
@@ -450,6 +627,17 @@ func cloneBlock(block Block) Block {
 		b.Data = cloneBytes(b.Data)
 		b.Cache = cloneCacheControl(b.Cache)
 		return b
+	case DocumentBlock:
+		b.Data = cloneBytes(b.Data)
+		b.Cache = cloneCacheControl(b.Cache)
+		return b
+	case AudioBlock:
+		b.Data = cloneBytes(b.Data)
+		b.Cache = cloneCacheControl(b.Cache)
+		return b
+	case AudioOutputBlock:
+		b.Data = cloneBytes(b.Data)
+		return b
 	case ReasoningBlock:
 		b.Redacted = cloneBytes(b.Redacted)
 		b.Extra = cloneBytes(b.Extra)
@@ -468,6 +656,17 @@ func cloneBlock(block Block) Block {
 		b.Extra = cloneBytes(b.Extra)
 		b.Cache = cloneCacheControl(b.Cache)
 		return b
+	case ServerToolUseBlock:
+		b.Arguments = cloneBytes(b.Arguments)
+		b.Cache = cloneCacheControl(b.Cache)
+		return b
+	case ServerToolResultBlock:
+		b.Content = cloneBytes(b.Content)
+		b.Cache = cloneCacheControl(b.Cache)
+		return b
+	case GuardContentBlock:
+		b.Qualifiers = append([]string(nil), b.Qualifiers...)
+		return b
 	default:
 		return block
 	}
@@ -554,6 +753,13 @@ func cloneTools(tools []Tool) []Tool {
 	for i, tool := range tools {
 		out[i] = tool
 		out[i].Parameters = Schema(cloneBytes(tool.Parameters))
+		if tool.Server != nil {
+			server := *tool.Server
+			if server.Config != nil {
+				server.Config = cloneAny(server.Config).(map[string]any)
+			}
+			out[i].Server = &server
+		}
 	}
 	return out
 }
@@ -596,6 +802,20 @@ func cloneThinking(thinking *Thinking) *Thinking {
 	return &out
 }
 
+func cloneWebSearch(search *WebSearch) *WebSearch {
+	if search == nil {
+		return nil
+	}
+	out := *search
+	out.AllowedDomains = append([]string(nil), search.AllowedDomains...)
+	out.BlockedDomains = append([]string(nil), search.BlockedDomains...)
+	if search.UserLocation != nil {
+		loc := *search.UserLocation
+		out.UserLocation = &loc
+	}
+	return &out
+}
+
 func cloneCachePolicy(cache *CachePolicy) *CachePolicy {
 	if cache == nil {
 		return nil
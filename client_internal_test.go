@@ -304,6 +304,30 @@ func TestValidateRejectsInvalidCachePolicy(t *testing.T) {
 	}
 }
 
+func TestValidateRejectsInvalidWebSearch(t *testing.T) {
+	client, err := New(&testProvider{name: "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	_, err = client.Chat(context.Background(), Request{
+		Model:     "model",
+		Messages:  []Message{UserText("hi")},
+		WebSearch: &WebSearch{MaxUses: -1},
+	})
+	if err == nil || !IsValidationError(err) || !strings.Contains(err.Error(), "max uses") {
+		t.Fatalf("expected max uses validation error, got %v", err)
+	}
+
+	_, err = client.Chat(context.Background(), Request{
+		Model:     "model",
+		Messages:  []Message{UserText("hi")},
+		WebSearch: &WebSearch{AllowedDomains: []string{"a.test"}, BlockedDomains: []string{"b.test"}},
+	})
+	if err == nil || !IsValidationError(err) || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Fatalf("expected domain validation error, got %v", err)
+	}
+}
+
 func TestValidateRejectsInvalidBlockCacheControl(t *testing.T) {
 	client, err := New(&testProvider{name: "test"})
 	if err != nil {
@@ -762,6 +786,51 @@ func TestValidateAllowsToolReferenceInsideToolResult(t *testing.T) {
 	}
 }
 
+func TestValidateRejectsInvalidDocumentBlocks(t *testing.T) {
+	client, err := New(&testProvider{name: "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		msg  Message
+		want string
+	}{
+		{"no source", User(DocumentBlock{MIME: "application/pdf"}), "exactly one of data, URL or file URI"},
+		{"two sources", User(DocumentBlock{URL: "https://example.test/a.pdf", FileURI: "file-1"}), "exactly one of data, URL or file URI"},
+		{"data without mime", User(DocumentBlock{Data: []byte("x")}), "document data requires MIME"},
+		{"assistant", Assistant(DocumentURL("https://example.test/a.pdf")), "document"},
+	} {
+		_, err := client.Chat(context.Background(), Request{Model: "m", Messages: []Message{tc.msg}})
+		if err == nil || !IsValidationError(err) || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected validation error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
+func TestValidateRejectsInvalidAudioBlocks(t *testing.T) {
+	client, err := New(&testProvider{name: "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	for _, tc := range []struct {
+		name string
+		msg  Message
+		want string
+	}{
+		{"no data", User(AudioBlock{Format: "wav"}), "audio block requires data"},
+		{"no format", User(AudioBlock{Data: []byte("x")}), "audio block requires format"},
+		{"assistant input", Assistant(Audio([]byte("x"), "wav")), "use AudioOutputBlock"},
+		{"user output", User(AudioOutputBlock{ID: "a1"}), "audio output block requires assistant role"},
+		{"empty output", Assistant(AudioOutputBlock{Format: "wav"}), "requires id or data"},
+	} {
+		_, err := client.Chat(context.Background(), Request{Model: "m", Messages: []Message{tc.msg}})
+		if err == nil || !IsValidationError(err) || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected validation error containing %q, got %v", tc.name, tc.want, err)
+		}
+	}
+}
+
 func TestMessageRepairIsExplicitAndWarns(t *testing.T) {
 	provider := &testProvider{name: "test"}
 	var hookWarnings []Warning
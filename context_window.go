@@ -0,0 +1,327 @@
+package litellm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ContextWindow keeps Request.Messages under a model's input token limit.
+// Strategies run in order until the request fits; system messages are always
+// kept and a tool use is never separated from its result.
+type ContextWindow struct {
+	// MaxInputTokens is the input budget for every model. ModelLimit, when it
+	// returns a positive value, overrides it for one model.
+	MaxInputTokens int
+	ModelLimit     func(model string) int
+
+	// Strategies default to ElideToolResults(DefaultElideThreshold) followed
+	// by DropOldestTurns().
+	Strategies []ContextStrategy
+
+	// Counter estimates a request's input tokens. It defaults to
+	// EstimateTokens.
+	Counter func(*Request) int
+
+	// RetryOnOverflow retries a call once, trimmed to three quarters of its
+	// previous size, when the provider rejects it with
+	// ErrorTypeContextOverflow.
+	RetryOnOverflow bool
+}
+
+// DefaultElideThreshold is the tool result size, in tokens, above which the
+// default strategies elide a result.
+const DefaultElideThreshold = 1000
+
+// ContextBudget is what a ContextStrategy trims towards.
+type ContextBudget struct {
+	MaxInputTokens int
+	Count          func(*Request) int
+}
+
+// Fits reports whether req is within the budget.
+func (b ContextBudget) Fits(req *Request) bool {
+	return b.Count(req) <= b.MaxInputTokens
+}
+
+// ContextStrategy shrinks req.Messages towards budget and returns a warning
+// for each change it made. A strategy that cannot reach the budget shrinks
+// what it can and leaves the rest to the next one. An error fails the call.
+type ContextStrategy interface {
+	Shrink(ctx context.Context, req *Request, budget ContextBudget) ([]Warning, error)
+}
+
+// ContextStrategyFunc adapts a function to ContextStrategy.
+type ContextStrategyFunc func(ctx context.Context, req *Request, budget ContextBudget) ([]Warning, error)
+
+func (f ContextStrategyFunc) Shrink(ctx context.Context, req *Request, budget ContextBudget) ([]Warning, error) {
+	return f(ctx, req, budget)
+}
+
+// WithContextWindow trims requests that exceed window's budget before they are
+// sent. Trims are reported as "context.*" warnings.
+func WithContextWindow(window ContextWindow) ClientOption {
+	return func(c *Client) error {
+		if window.MaxInputTokens < 0 {
+			return fmt.Errorf("context window max input tokens cannot be negative")
+		}
+		if window.MaxInputTokens == 0 && window.ModelLimit == nil {
+			return fmt.Errorf("context window needs MaxInputTokens or ModelLimit")
+		}
+		for _, strategy := range window.Strategies {
+			if strategy == nil {
+				return fmt.Errorf("context strategy cannot be nil")
+			}
+		}
+		if len(window.Strategies) == 0 {
+			window.Strategies = []ContextStrategy{ElideToolResults(DefaultElideThreshold), DropOldestTurns()}
+		}
+		if window.Counter == nil {
+			window.Counter = func(req *Request) int { return EstimateTokens(req).InputTokens }
+		}
+		c.contextWindow = &window
+		return nil
+	}
+}
+
+func (w *ContextWindow) limit(model string) int {
+	if w.ModelLimit != nil {
+		if limit := w.ModelLimit(model); limit > 0 {
+			return limit
+		}
+	}
+	return w.MaxInputTokens
+}
+
+// fitContext trims req to the model's budget.
+func (c *Client) fitContext(ctx context.Context, req *Request) ([]Warning, error) {
+	if c.contextWindow == nil {
+		return nil, nil
+	}
+	limit := c.contextWindow.limit(req.Model)
+	if limit <= 0 {
+		return nil, nil
+	}
+	return c.shrinkContext(ctx, req, limit)
+}
+
+// refitAfterOverflow trims req below its current size after the provider
+// rejected it as too long. It reports false when the call should not be
+// retried.
+func (c *Client) refitAfterOverflow(ctx context.Context, req *Request, err error) ([]Warning, bool) {
+	if c.contextWindow == nil || !c.contextWindow.RetryOnOverflow || !IsContextOverflowError(err) {
+		return nil, false
+	}
+	before := c.contextWindow.Counter(req)
+	budget := before
+	if limit := c.contextWindow.limit(req.Model); limit > 0 && limit < budget {
+		budget = limit
+	}
+	budget = budget * 3 / 4
+	warnings, shrinkErr := c.shrinkContext(ctx, req, budget)
+	if shrinkErr != nil || c.contextWindow.Counter(req) >= before {
+		return nil, false
+	}
+	warnings = append([]Warning{{
+		Code:    "context.overflow_retry",
+		Message: fmt.Sprintf("provider rejected the request as too long; retrying trimmed to %d input tokens", budget),
+	}}, warnings...)
+	return warnings, true
+}
+
+func (c *Client) shrinkContext(ctx context.Context, req *Request, limit int) ([]Warning, error) {
+	budget := ContextBudget{MaxInputTokens: limit, Count: c.contextWindow.Counter}
+	if budget.Fits(req) {
+		return nil, nil
+	}
+	var warnings []Warning
+	for _, strategy := range c.contextWindow.Strategies {
+		stepWarnings, err := strategy.Shrink(ctx, req, budget)
+		warnings = append(warnings, stepWarnings...)
+		if err != nil {
+			return warnings, WrapError(err, c.ProviderName())
+		}
+		if budget.Fits(req) {
+			return warnings, nil
+		}
+	}
+	return append(warnings, Warning{
+		Code:    "context.over_budget",
+		Message: fmt.Sprintf("request is still about %d input tokens after trimming, over the %d token budget", budget.Count(req), limit),
+	}), nil
+}
+
+// ElideToolResults replaces tool results larger than threshold tokens with a
+// short placeholder, oldest first, until the request fits. The results keep
+// their tool use IDs and error flags.
+func ElideToolResults(threshold int) ContextStrategy {
+	return ContextStrategyFunc(func(ctx context.Context, req *Request, budget ContextBudget) ([]Warning, error) {
+		var warnings []Warning
+		for i := range req.Messages {
+			for j, block := range req.Messages[i].Blocks {
+				if budget.Fits(req) {
+					return warnings, nil
+				}
+				result, ok := block.(ToolResultBlock)
+				if !ok {
+					continue
+				}
+				size := budget.Count(&Request{Model: req.Model, Messages: []Message{{Role: RoleTool, Blocks: result.Content}}})
+				if size <= threshold {
+					continue
+				}
+				result.Content = []Block{Text(fmt.Sprintf("[tool result elided to fit the context window: about %d tokens]", size))}
+				req.Messages[i].Blocks[j] = result
+				warnings = append(warnings, Warning{
+					Code:    "context.tool_result_elided",
+					Message: fmt.Sprintf("elided tool result %q of about %d tokens", result.ToolUseID, size),
+				})
+			}
+		}
+		return warnings, nil
+	})
+}
+
+// DropOldestTurns removes whole turns, oldest first, until the request fits.
+// A turn runs from a user message to the next one, so tool uses and their
+// results go together. The latest turn and all system messages are kept.
+func DropOldestTurns() ContextStrategy {
+	return ContextStrategyFunc(func(ctx context.Context, req *Request, budget ContextBudget) ([]Warning, error) {
+		turns := conversationTurns(req.Messages)
+		original := req.Messages
+		dropped, messages := 0, 0
+		for dropped < len(turns)-1 && !budget.Fits(req) {
+			messages += len(turns[dropped])
+			dropped++
+			req.Messages = removeMessages(original, turns[:dropped])
+		}
+		if dropped == 0 {
+			return nil, nil
+		}
+		return []Warning{{
+			Code:    "context.turns_dropped",
+			Message: fmt.Sprintf("dropped %d oldest turns (%d messages) to fit %d input tokens", dropped, messages, budget.MaxInputTokens),
+		}}, nil
+	})
+}
+
+// SummarizeOldTurns replaces all but the latest keep turns with a system
+// message summarizing them, written by model through client. A failed
+// summary is reported as a warning and leaves the messages unchanged.
+func SummarizeOldTurns(client *Client, model string, keep int) ContextStrategy {
+	if keep < 1 {
+		keep = 1
+	}
+	return ContextStrategyFunc(func(ctx context.Context, req *Request, budget ContextBudget) ([]Warning, error) {
+		if client == nil {
+			return nil, fmt.Errorf("summarize old turns: client cannot be nil")
+		}
+		turns := conversationTurns(req.Messages)
+		if len(turns) <= keep {
+			return nil, nil
+		}
+		old := turns[:len(turns)-keep]
+		var transcript strings.Builder
+		count := 0
+		for _, turn := range old {
+			for _, i := range turn {
+				writeTranscriptMessage(&transcript, req.Messages[i])
+				count++
+			}
+		}
+		resp, err := client.Chat(ctx, Request{
+			Model: model,
+			Messages: []Message{
+				System("Summarize the conversation below so it can replace the original messages. Keep facts, decisions, tool results and open questions; be concise."),
+				UserText(transcript.String()),
+			},
+		})
+		if err != nil {
+			return []Warning{{
+				Code:    "context.summary_failed",
+				Message: fmt.Sprintf("could not summarize %d earlier turns: %v", len(old), err),
+			}}, nil
+		}
+		summary := System("Summary of the earlier conversation:\n" + resp.Text())
+		messages := removeMessages(req.Messages, old)
+		insert := 0
+		for insert < len(messages) && messages[insert].Role == RoleSystem {
+			insert++
+		}
+		req.Messages = append(messages[:insert:insert], append([]Message{summary}, messages[insert:]...)...)
+		return []Warning{{
+			Code:    "context.turns_summarized",
+			Message: fmt.Sprintf("summarized %d earlier turns (%d messages) with %s", len(old), count, model),
+		}}, nil
+	})
+}
+
+// conversationTurns groups the indexes of the non-system messages into turns.
+// A turn starts at a user message that carries no tool results.
+func conversationTurns(messages []Message) [][]int {
+	var turns [][]int
+	for i, msg := range messages {
+		if msg.Role == RoleSystem {
+			continue
+		}
+		if len(turns) == 0 || (msg.Role == RoleUser && !hasToolResult(msg)) {
+			turns = append(turns, nil)
+		}
+		turns[len(turns)-1] = append(turns[len(turns)-1], i)
+	}
+	return turns
+}
+
+func hasToolResult(msg Message) bool {
+	for _, block := range msg.Blocks {
+		if _, ok := block.(ToolResultBlock); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func removeMessages(messages []Message, turns [][]int) []Message {
+	drop := make(map[int]bool)
+	for _, turn := range turns {
+		for _, i := range turn {
+			drop[i] = true
+		}
+	}
+	out := make([]Message, 0, len(messages)-len(drop))
+	for i, msg := range messages {
+		if !drop[i] {
+			out = append(out, msg)
+		}
+	}
+	return out
+}
+
+func writeTranscriptMessage(b *strings.Builder, msg Message) {
+	for _, block := range msg.Blocks {
+		switch v := block.(type) {
+		case TextBlock:
+			fmt.Fprintf(b, "%s: %s\n", msg.Role, v.Text)
+		case GuardContentBlock:
+			fmt.Fprintf(b, "%s: %s\n", msg.Role, v.Text)
+		case ToolUseBlock:
+			fmt.Fprintf(b, "%s called %s(%s)\n", msg.Role, v.Name, compactJSON(v.Arguments))
+		case ToolResultBlock:
+			for _, inner := range v.Content {
+				if text, ok := inner.(TextBlock); ok {
+					fmt.Fprintf(b, "tool result: %s\n", text.Text)
+				}
+			}
+		}
+	}
+}
+
+func compactJSON(raw json.RawMessage) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, raw); err != nil {
+		return string(raw)
+	}
+	return b.String()
+}
@@ -0,0 +1,158 @@
+package litellm
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// countMessages makes every non-system message cost one token.
+func countMessages(req *Request) int {
+	n := 0
+	for _, msg := range req.Messages {
+		if msg.Role != RoleSystem {
+			n++
+		}
+	}
+	return n
+}
+
+func agentTranscript() []Message {
+	return []Message{
+		System("You are a helpful agent."),
+		UserText("first question"),
+		Assistant(ToolUseBlock{ID: "call_1", Name: "search", Arguments: json.RawMessage(`{"q":"a"}`)}),
+		ToolResultText("call_1", strings.Repeat("result ", 2000)),
+		AssistantText("first answer"),
+		UserText("second question"),
+		AssistantText("second answer"),
+		UserText("third question"),
+	}
+}
+
+func hasWarning(warnings []Warning, code string) bool {
+	for _, w := range warnings {
+		if w.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+func TestContextWindowDropsOldestTurns(t *testing.T) {
+	provider := &testProvider{name: "test"}
+	client, err := New(provider, WithContextWindow(ContextWindow{
+		MaxInputTokens: 3,
+		Strategies:     []ContextStrategy{DropOldestTurns()},
+		Counter:        countMessages,
+	}))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := client.Chat(context.Background(), Request{Model: "m", Messages: agentTranscript()})
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	sent := provider.lastReq.Messages
+	if len(sent) != 4 || sent[0].Role != RoleSystem || sent[1].Blocks[0].(TextBlock).Text != "second question" {
+		t.Fatalf("sent messages = %+v", sent)
+	}
+	if !hasWarning(resp.Warnings, "context.turns_dropped") || resp.Warnings[0].Provider != "test" {
+		t.Fatalf("warnings = %+v", resp.Warnings)
+	}
+}
+
+func TestContextWindowKeepsToolUseWithResult(t *testing.T) {
+	req := &Request{Model: "m", Messages: agentTranscript()[:5]}
+	req.Messages = append(req.Messages, User(ToolResultBlock{ToolUseID: "call_1", Content: []Block{Text("late result")}}))
+	turns := conversationTurns(req.Messages)
+	if len(turns) != 1 || len(turns[0]) != 5 {
+		t.Fatalf("turns = %v", turns)
+	}
+	warnings, err := DropOldestTurns().Shrink(context.Background(), req, ContextBudget{MaxInputTokens: 1, Count: countMessages})
+	if err != nil || len(warnings) != 0 || len(req.Messages) != 6 {
+		t.Fatalf("latest turn was split: warnings=%+v messages=%d err=%v", warnings, len(req.Messages), err)
+	}
+}
+
+func TestContextWindowElidesLargeToolResults(t *testing.T) {
+	provider := &testProvider{name: "test"}
+	client, err := New(provider, WithContextWindow(ContextWindow{MaxInputTokens: 500}))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := client.Chat(context.Background(), Request{Model: "m", Messages: agentTranscript()})
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	result := provider.lastReq.Messages[3].Blocks[0].(ToolResultBlock)
+	if result.ToolUseID != "call_1" || !strings.Contains(result.Content[0].(TextBlock).Text, "elided") {
+		t.Fatalf("tool result = %+v", result)
+	}
+	if len(provider.lastReq.Messages) != len(agentTranscript()) || !hasWarning(resp.Warnings, "context.tool_result_elided") {
+		t.Fatalf("messages=%d warnings=%+v", len(provider.lastReq.Messages), resp.Warnings)
+	}
+}
+
+func TestContextWindowSummarizesOldTurns(t *testing.T) {
+	summarizer, _ := New(&testProvider{name: "summarizer", chatFunc: func(_ context.Context, req *Request) (*Response, error) {
+		if !strings.Contains(req.Messages[1].Blocks[0].(TextBlock).Text, "assistant called search") {
+			t.Errorf("transcript = %q", req.Messages[1].Blocks[0].(TextBlock).Text)
+		}
+		return &Response{Blocks: []Block{Text("they asked two questions")}}, nil
+	}})
+	provider := &testProvider{name: "test"}
+	client, _ := New(provider, WithContextWindow(ContextWindow{
+		MaxInputTokens: 2,
+		Strategies:     []ContextStrategy{SummarizeOldTurns(summarizer, "small", 1)},
+		Counter:        countMessages,
+	}))
+	resp, err := client.Chat(context.Background(), Request{Model: "m", Messages: agentTranscript()})
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	sent := provider.lastReq.Messages
+	if len(sent) != 3 || sent[1].Role != RoleSystem || !strings.Contains(sent[1].Blocks[0].(TextBlock).Text, "two questions") {
+		t.Fatalf("sent messages = %+v", sent)
+	}
+	if !hasWarning(resp.Warnings, "context.turns_summarized") {
+		t.Fatalf("warnings = %+v", resp.Warnings)
+	}
+}
+
+func TestContextWindowRetriesOnOverflow(t *testing.T) {
+	var sizes []int
+	provider := &testProvider{name: "test", chatFunc: func(_ context.Context, req *Request) (*Response, error) {
+		sizes = append(sizes, len(req.Messages))
+		if len(sizes) == 1 {
+			return nil, NewProviderError("test", ErrorTypeContextOverflow, "prompt is too long")
+		}
+		return &Response{Blocks: []Block{Text("ok")}}, nil
+	}}
+	client, _ := New(provider, WithContextWindow(ContextWindow{
+		MaxInputTokens:  100,
+		Strategies:      []ContextStrategy{DropOldestTurns()},
+		Counter:         countMessages,
+		RetryOnOverflow: true,
+	}))
+	resp, err := client.Chat(context.Background(), Request{Model: "m", Messages: agentTranscript()})
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	if len(sizes) != 2 || sizes[1] >= sizes[0] {
+		t.Fatalf("request sizes = %v", sizes)
+	}
+	if !hasWarning(resp.Warnings, "context.overflow_retry") || !hasWarning(resp.Warnings, "context.turns_dropped") {
+		t.Fatalf("warnings = %+v", resp.Warnings)
+	}
+}
+
+func TestWithContextWindowValidates(t *testing.T) {
+	if _, err := New(&testProvider{name: "test"}, WithContextWindow(ContextWindow{})); err == nil {
+		t.Fatal("expected error without a limit")
+	}
+	if _, err := New(&testProvider{name: "test"}, WithContextWindow(ContextWindow{MaxInputTokens: 10, Strategies: []ContextStrategy{nil}})); err == nil {
+		t.Fatal("expected error for nil strategy")
+	}
+}
@@ -74,8 +74,11 @@ concurrently.
 
 # Design
 
-The SDK is intentionally not a gateway, router, agent runtime, account system,
-or request scheduler. It binds one Client to one Provider and exposes explicit
-configuration and local validation.
+The SDK is intentionally not a gateway, agent runtime, account system, or
+request scheduler. It binds one Client to one Provider and exposes explicit
+configuration and local validation. Router and Pool are themselves Providers
+that chain fallback targets or balance interchangeable members, so failover
+stays inside that model. Runner drives a bounded tool-call loop on top of a
+Client; it keeps no state between runs.
 */
 package litellm
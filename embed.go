@@ -0,0 +1,142 @@
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// Embedder is implemented by providers that expose a text embeddings endpoint.
+// Like ModelLister it is optional; use Client.Embed to call it.
+type Embedder interface {
+	Embed(context.Context, *EmbedRequest) (*EmbedResponse, error)
+}
+
+type EmbedRequest struct {
+	Model string
+	Input []string
+
+	// Dimensions requests a reduced output size on models that support it.
+	Dimensions *int
+
+	// TaskType hints the intended use of the vectors. Gemini maps it to
+	// taskType and Bedrock Cohere models to input_type; other providers ignore
+	// it.
+	TaskType string
+
+	ProviderOptions ProviderOptions
+}
+
+type EmbedResponse struct {
+	// Embeddings holds one vector per input, in input order.
+	Embeddings [][]float64
+	Usage      Usage
+	Model      string
+	Provider   string
+}
+
+const (
+	EmbedTaskRetrievalQuery    = "retrieval_query"
+	EmbedTaskRetrievalDocument = "retrieval_document"
+	EmbedTaskSimilarity        = "similarity"
+	EmbedTaskClassification    = "classification"
+	EmbedTaskClustering        = "clustering"
+)
+
+// Embed returns one vector per req.Input using the bound provider's embeddings
+// endpoint. Hooks observe the call with CallMeta.Operation "embed": the hook
+// Request carries only Model and ProviderOptions, and the hook Response carries
+// Model, Provider and Usage.
+func (c *Client) Embed(ctx context.Context, req EmbedRequest) (*EmbedResponse, error) {
+	if c == nil || c.provider == nil {
+		return nil, NewError(ErrorTypeValidation, "client has no provider")
+	}
+	embedder, ok := c.provider.(Embedder)
+	if !ok {
+		return nil, NewProviderError(c.provider.Name(), ErrorTypeValidation, fmt.Sprintf("%s provider does not support embeddings", c.provider.Name()))
+	}
+	prepared := cloneEmbedRequest(req)
+	if err := validateEmbedRequest(prepared); err != nil {
+		return nil, err
+	}
+	meta := c.newCallMeta("embed", prepared.Model, false)
+	c.notifyBeforeRequest(ctx, meta, &Request{Model: prepared.Model, ProviderOptions: prepared.ProviderOptions})
+	start := meta.StartedAt
+	resp, err := embedder.Embed(ctx, prepared)
+	if err != nil {
+		err = WrapError(err, c.provider.Name())
+	}
+	if err == nil {
+		err = validateEmbedResponse(resp, prepared, c.provider.Name())
+	}
+	var hookResp *Response
+	if resp != nil {
+		if resp.Provider == "" {
+			resp.Provider = c.provider.Name()
+		}
+		if resp.Model == "" {
+			resp.Model = prepared.Model
+		}
+		resp.Usage.StampModel(resp.Provider, resp.Model)
+		hookResp = &Response{Model: resp.Model, Provider: resp.Provider, Usage: resp.Usage}
+	}
+	meta.Duration = time.Since(start)
+	c.notifyAfterResponse(ctx, meta, hookResp, err)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func validateEmbedRequest(req *EmbedRequest) error {
+	if req.Model == "" {
+		return NewError(ErrorTypeValidation, "model cannot be empty")
+	}
+	if !utf8.ValidString(req.Model) {
+		return NewError(ErrorTypeValidation, "model must be valid UTF-8")
+	}
+	if len(req.Input) == 0 {
+		return NewError(ErrorTypeValidation, "embed input cannot be empty")
+	}
+	for i, input := range req.Input {
+		if input == "" {
+			return NewError(ErrorTypeValidation, fmt.Sprintf("input[%d] cannot be empty", i))
+		}
+		if !utf8.ValidString(input) {
+			return NewError(ErrorTypeValidation, fmt.Sprintf("input[%d] must be valid UTF-8", i))
+		}
+	}
+	if req.Dimensions != nil && *req.Dimensions <= 0 {
+		return NewError(ErrorTypeValidation, "dimensions must be positive")
+	}
+	if !utf8.ValidString(req.TaskType) {
+		return NewError(ErrorTypeValidation, "task type must be valid UTF-8")
+	}
+	return validateProviderOptionsUTF8(req.ProviderOptions)
+}
+
+func validateEmbedResponse(resp *EmbedResponse, req *EmbedRequest, provider string) error {
+	if resp == nil {
+		return NewProviderError(provider, ErrorTypeInternal, "provider returned nil embed response without error")
+	}
+	if len(resp.Embeddings) != len(req.Input) {
+		return NewProviderError(provider, ErrorTypeProvider, fmt.Sprintf("%s: embeddings count %d does not match input count %d", provider, len(resp.Embeddings), len(req.Input)))
+	}
+	return nil
+}
+
+func cloneEmbedRequest(req EmbedRequest) *EmbedRequest {
+	out := req
+	out.Input = append([]string(nil), req.Input...)
+	if req.Dimensions != nil {
+		out.Dimensions = IntPtr(*req.Dimensions)
+	}
+	if req.ProviderOptions != nil {
+		out.ProviderOptions = make(ProviderOptions, len(req.ProviderOptions))
+		for k, v := range req.ProviderOptions {
+			out.ProviderOptions[k] = cloneAny(v)
+		}
+	}
+	return &out
+}
@@ -0,0 +1,93 @@
+package litellm
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type testEmbedProvider struct {
+	*testProvider
+	embedFunc func(context.Context, *EmbedRequest) (*EmbedResponse, error)
+}
+
+func (p *testEmbedProvider) Embed(ctx context.Context, req *EmbedRequest) (*EmbedResponse, error) {
+	return p.embedFunc(ctx, req)
+}
+
+func TestClientEmbedRunsHooksAndStampsUsage(t *testing.T) {
+	var before, after CallMeta
+	var afterResp *Response
+	client, err := New(&testEmbedProvider{
+		testProvider: &testProvider{name: "embedder"},
+		embedFunc: func(_ context.Context, req *EmbedRequest) (*EmbedResponse, error) {
+			return &EmbedResponse{
+				Embeddings: [][]float64{{1, 0}, {0, 1}},
+				Usage:      Usage{InputTokens: 3, TotalTokens: 3},
+			}, nil
+		},
+	}, WithHook(HookFuncs{
+		BeforeRequestFunc: func(_ context.Context, meta CallMeta, req *Request) {
+			before = meta
+			if req.Model != "embed-model" {
+				t.Fatalf("hook request model = %q", req.Model)
+			}
+		},
+		AfterResponseFunc: func(_ context.Context, meta CallMeta, resp *Response, err error) {
+			after = meta
+			afterResp = resp
+		},
+	}))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := client.Embed(context.Background(), EmbedRequest{Model: "embed-model", Input: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("Embed returned error: %v", err)
+	}
+	if before.Operation != "embed" || after.Operation != "embed" || after.Streaming {
+		t.Fatalf("meta before/after = %+v/%+v", before, after)
+	}
+	if resp.Provider != "embedder" || resp.Model != "embed-model" || resp.Usage.Provider != "embedder" || resp.Usage.Model != "embed-model" {
+		t.Fatalf("resp = %+v", resp)
+	}
+	if afterResp == nil || afterResp.Usage.InputTokens != 3 {
+		t.Fatalf("hook response = %+v", afterResp)
+	}
+}
+
+func TestClientEmbedRejectsProviderWithoutEmbedder(t *testing.T) {
+	client, err := New(&testProvider{name: "chat-only"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	_, err = client.Embed(context.Background(), EmbedRequest{Model: "m", Input: []string{"a"}})
+	if !IsValidationError(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestClientEmbedValidatesInputAndCount(t *testing.T) {
+	boom := errors.New("boom")
+	client, err := New(&testEmbedProvider{
+		testProvider: &testProvider{name: "embedder"},
+		embedFunc: func(_ context.Context, req *EmbedRequest) (*EmbedResponse, error) {
+			if req.Input[0] == "fail" {
+				return nil, boom
+			}
+			return &EmbedResponse{Embeddings: [][]float64{{1}}}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if _, err := client.Embed(context.Background(), EmbedRequest{Model: "m"}); !IsValidationError(err) {
+		t.Fatalf("expected empty input validation error, got %v", err)
+	}
+	if _, err := client.Embed(context.Background(), EmbedRequest{Model: "m", Input: []string{"a", "b"}}); !IsProviderError(err) {
+		t.Fatalf("expected count mismatch provider error, got %v", err)
+	}
+	if _, err := client.Embed(context.Background(), EmbedRequest{Model: "m", Input: []string{"fail"}}); !errors.Is(err, boom) {
+		t.Fatalf("expected wrapped provider error, got %v", err)
+	}
+}
@@ -232,6 +232,7 @@ func IsModelError(err error) bool           { return isErrorType(err, ErrorTypeM
 func IsContextOverflowError(err error) bool { return isErrorType(err, ErrorTypeContextOverflow) }
 func IsOverloadedError(err error) bool      { return isErrorType(err, ErrorTypeOverloaded) }
 func IsContentFilterError(err error) bool   { return isErrorType(err, ErrorTypeContentFilter) }
+func IsQuotaError(err error) bool           { return isErrorType(err, ErrorTypeQuota) }
 
 func IsRetryableError(err error) bool {
 	var e *LiteLLMError
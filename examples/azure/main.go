@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/voocel/litellm"
+	"github.com/voocel/litellm/examples/internal/exampleutil"
+	"github.com/voocel/litellm/provider/azure"
+)
+
+func main() {
+	mode := "stream"
+	if len(os.Args) > 1 {
+		mode = os.Args[1]
+	}
+
+	client, err := azure.NewClient(azure.Config{
+		Endpoint: os.Getenv("AZURE_OPENAI_ENDPOINT"),
+		APIKey:   os.Getenv("AZURE_OPENAI_API_KEY"),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	switch mode {
+	case "chat":
+		runChat(ctx, client)
+	case "stream":
+		runStream(ctx, client)
+	default:
+		log.Fatalf("unknown mode %q; use one of: chat | stream", mode)
+	}
+}
+
+func model() string {
+	if m := os.Getenv("AZURE_OPENAI_DEPLOYMENT"); m != "" {
+		return m
+	}
+	return "gpt-4.1"
+}
+
+func runChat(ctx context.Context, client *litellm.Client) {
+	resp, err := client.Chat(ctx, litellm.Request{
+		Model: model(),
+		Messages: []litellm.Message{
+			litellm.UserText("Explain multimodal prompts in one sentence."),
+		},
+		MaxTokens: litellm.IntPtr(256),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(resp.Text())
+}
+
+func runStream(ctx context.Context, client *litellm.Client) {
+	printer := exampleutil.StreamPrinter{}
+	resp, err := client.StreamWith(ctx, litellm.Request{
+		Model: model(),
+		Messages: []litellm.Message{
+			litellm.UserText("Explain multimodal prompts in one sentence."),
+		},
+		MaxTokens: litellm.IntPtr(256),
+	}, printer.Handler())
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println()
+	fmt.Println()
+	exampleutil.PrintUsage(resp.Usage)
+}
@@ -22,12 +22,8 @@ func main() {
 		region = "us-east-1"
 	}
 	client, err := bedrock.NewClient(bedrock.Config{
-		Region: region,
-		Credentials: bedrock.StaticCredentials(
-			os.Getenv("AWS_ACCESS_KEY_ID"),
-			os.Getenv("AWS_SECRET_ACCESS_KEY"),
-			os.Getenv("AWS_SESSION_TOKEN"),
-		),
+		Region:      region,
+		Credentials: bedrock.DefaultCredentials(),
 	})
 	if err != nil {
 		log.Fatal(err)
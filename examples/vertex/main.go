@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/voocel/litellm"
+	"github.com/voocel/litellm/examples/internal/exampleutil"
+	"github.com/voocel/litellm/provider/vertex"
+)
+
+func main() {
+	mode := "stream"
+	if len(os.Args) > 1 {
+		mode = os.Args[1]
+	}
+
+	keyJSON, err := os.ReadFile(os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	tokens, err := vertex.ServiceAccount(keyJSON)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client, err := vertex.NewClient(vertex.Config{
+		Project:     os.Getenv("VERTEX_PROJECT"),
+		Location:    os.Getenv("VERTEX_LOCATION"),
+		TokenSource: tokens,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	switch mode {
+	case "chat":
+		runChat(ctx, client)
+	case "stream":
+		runStream(ctx, client)
+	default:
+		log.Fatalf("unknown mode %q; use one of: chat | stream", mode)
+	}
+}
+
+func model() string {
+	if m := os.Getenv("VERTEX_MODEL"); m != "" {
+		return m
+	}
+	return "gemini-2.5-flash"
+}
+
+func runChat(ctx context.Context, client *litellm.Client) {
+	resp, err := client.Chat(ctx, litellm.Request{
+		Model: model(),
+		Messages: []litellm.Message{
+			litellm.UserText("Explain multimodal prompts in one sentence."),
+		},
+		MaxTokens: litellm.IntPtr(256),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(resp.Text())
+}
+
+func runStream(ctx context.Context, client *litellm.Client) {
+	printer := exampleutil.StreamPrinter{}
+	resp, err := client.StreamWith(ctx, litellm.Request{
+		Model: model(),
+		Messages: []litellm.Message{
+			litellm.UserText("Explain multimodal prompts in one sentence."),
+		},
+		MaxTokens: litellm.IntPtr(256),
+	}, printer.Handler())
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println()
+	fmt.Println()
+	exampleutil.PrintUsage(resp.Usage)
+}
@@ -0,0 +1,170 @@
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FileStore is implemented by providers with a file upload API. An uploaded
+// file is referenced from ImageBlock or DocumentBlock by File.URI, so large
+// inputs are sent once and reused across requests. Like Embedder it is
+// optional; use the Client file methods to call it.
+type FileStore interface {
+	UploadFile(ctx context.Context, req *FileUpload) (*File, error)
+	GetFile(ctx context.Context, id string) (*File, error)
+	ListFiles(ctx context.Context, limit int) ([]File, error)
+	DeleteFile(ctx context.Context, id string) error
+}
+
+// FileUpload is a file to upload. Name is the file name or display name.
+// Purpose is the OpenAI file purpose and defaults to "user_data"; other
+// providers ignore it.
+type FileUpload struct {
+	Name    string
+	MIME    string
+	Data    []byte
+	Purpose string
+}
+
+type FileState string
+
+const (
+	FileStateProcessing FileState = "processing"
+	FileStateActive     FileState = "active"
+	FileStateFailed     FileState = "failed"
+)
+
+// File is an uploaded file. URI is the reference to put in
+// ImageBlock.FileURI or DocumentBlock.FileURI; for OpenAI and Anthropic it is
+// the file ID, for Gemini the file's URI.
+type File struct {
+	ID       string
+	Provider string
+	URI      string
+	Name     string
+	MIME     string
+	Size     int64
+	State    FileState
+
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Image returns an image block referencing f.
+func (f *File) Image() ImageBlock {
+	return ImageBlock{FileURI: f.URI, MIME: f.MIME}
+}
+
+// Document returns a document block referencing f.
+func (f *File) Document() DocumentBlock {
+	return DocumentBlock{FileURI: f.URI, MIME: f.MIME}
+}
+
+// FileOwner reports which provider issued a file reference, judged by its
+// shape, or "" when the shape is not recognised. Providers use it to reject
+// references uploaded to another provider.
+func FileOwner(ref string) string {
+	switch {
+	case strings.HasPrefix(ref, "file-"):
+		return "openai"
+	case strings.HasPrefix(ref, "file_"):
+		return "anthropic"
+	case strings.HasPrefix(ref, "files/"), strings.HasPrefix(ref, "https://generativelanguage.googleapis.com/"):
+		return "gemini"
+	case strings.HasPrefix(ref, "s3://"):
+		return "bedrock"
+	}
+	return ""
+}
+
+// UploadFile uploads req.Data with the bound provider's file API. Gemini
+// returns once the file is active and ready to reference.
+func (c *Client) UploadFile(ctx context.Context, req FileUpload) (*File, error) {
+	store, err := c.fileStore()
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Data) == 0 {
+		return nil, NewValidationError(c.provider.Name(), "file upload requires data")
+	}
+	if !utf8.ValidString(req.Name) || !utf8.ValidString(req.MIME) || !utf8.ValidString(req.Purpose) {
+		return nil, NewValidationError(c.provider.Name(), "file upload name, MIME and purpose must be valid UTF-8")
+	}
+	file, err := store.UploadFile(ctx, &req)
+	return c.finalizeFile(file, err)
+}
+
+func (c *Client) GetFile(ctx context.Context, id string) (*File, error) {
+	store, err := c.fileStore()
+	if err != nil {
+		return nil, err
+	}
+	if id == "" {
+		return nil, NewValidationError(c.provider.Name(), "file id cannot be empty")
+	}
+	file, err := store.GetFile(ctx, id)
+	return c.finalizeFile(file, err)
+}
+
+// ListFiles returns up to limit files. A limit of zero uses the provider's
+// default page size.
+func (c *Client) ListFiles(ctx context.Context, limit int) ([]File, error) {
+	store, err := c.fileStore()
+	if err != nil {
+		return nil, err
+	}
+	if limit < 0 {
+		return nil, NewValidationError(c.provider.Name(), "file list limit cannot be negative")
+	}
+	files, err := store.ListFiles(ctx, limit)
+	if err != nil {
+		return nil, WrapError(err, c.provider.Name())
+	}
+	for i := range files {
+		if files[i].Provider == "" {
+			files[i].Provider = c.provider.Name()
+		}
+	}
+	return files, nil
+}
+
+func (c *Client) DeleteFile(ctx context.Context, id string) error {
+	store, err := c.fileStore()
+	if err != nil {
+		return err
+	}
+	if id == "" {
+		return NewValidationError(c.provider.Name(), "file id cannot be empty")
+	}
+	if err := store.DeleteFile(ctx, id); err != nil {
+		return WrapError(err, c.provider.Name())
+	}
+	return nil
+}
+
+func (c *Client) fileStore() (FileStore, error) {
+	if c == nil || c.provider == nil {
+		return nil, NewError(ErrorTypeValidation, "client has no provider")
+	}
+	store, ok := c.provider.(FileStore)
+	if !ok {
+		return nil, NewProviderError(c.provider.Name(), ErrorTypeValidation, fmt.Sprintf("%s provider does not support file uploads", c.provider.Name()))
+	}
+	return store, nil
+}
+
+func (c *Client) finalizeFile(file *File, err error) (*File, error) {
+	if err != nil {
+		return nil, WrapError(err, c.provider.Name())
+	}
+	if file == nil {
+		return nil, NewProviderError(c.provider.Name(), ErrorTypeInternal, "provider returned nil file without error")
+	}
+	if file.Provider == "" {
+		file.Provider = c.provider.Name()
+	}
+	return file, nil
+}
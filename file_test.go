@@ -0,0 +1,73 @@
+package litellm
+
+import (
+	"context"
+	"testing"
+)
+
+type fileTestProvider struct {
+	*testProvider
+	uploaded *FileUpload
+}
+
+func (p *fileTestProvider) UploadFile(ctx context.Context, req *FileUpload) (*File, error) {
+	p.uploaded = req
+	return &File{ID: "file-1", URI: "file-1", MIME: req.MIME, State: FileStateActive}, nil
+}
+
+func (p *fileTestProvider) GetFile(ctx context.Context, id string) (*File, error) {
+	return nil, NewHTTPError("", 404, "not found")
+}
+
+func (p *fileTestProvider) ListFiles(ctx context.Context, limit int) ([]File, error) {
+	return []File{{ID: "file-1"}}, nil
+}
+
+func (p *fileTestProvider) DeleteFile(ctx context.Context, id string) error {
+	return nil
+}
+
+func TestClientFileStore(t *testing.T) {
+	provider := &fileTestProvider{testProvider: &testProvider{name: "test"}}
+	client, _ := New(provider)
+	file, err := client.UploadFile(context.Background(), FileUpload{Name: "a.pdf", MIME: "application/pdf", Data: []byte("%PDF")})
+	if err != nil {
+		t.Fatalf("UploadFile returned error: %v", err)
+	}
+	if file.Provider != "test" || provider.uploaded.Name != "a.pdf" {
+		t.Fatalf("file = %+v, uploaded = %+v", file, provider.uploaded)
+	}
+	if doc := file.Document(); doc.FileURI != "file-1" || doc.MIME != "application/pdf" {
+		t.Fatalf("document = %+v", doc)
+	}
+	if _, err := client.UploadFile(context.Background(), FileUpload{Name: "empty"}); !IsValidationError(err) {
+		t.Fatalf("UploadFile error = %v, want validation error", err)
+	}
+	files, err := client.ListFiles(context.Background(), 0)
+	if err != nil || len(files) != 1 || files[0].Provider != "test" {
+		t.Fatalf("ListFiles = %+v, %v", files, err)
+	}
+	if _, err := client.GetFile(context.Background(), "missing"); err == nil || err.(*LiteLLMError).Provider != "test" {
+		t.Fatalf("GetFile error = %v", err)
+	}
+
+	plain, _ := New(&testProvider{name: "plain"})
+	if err := plain.DeleteFile(context.Background(), "file-1"); !IsValidationError(err) {
+		t.Fatalf("DeleteFile error = %v, want validation error", err)
+	}
+}
+
+func TestFileOwner(t *testing.T) {
+	for ref, want := range map[string]string{
+		"file-abc":    "openai",
+		"file_011abc": "anthropic",
+		"files/abc":   "gemini",
+		"https://generativelanguage.googleapis.com/v1beta/files/abc": "gemini",
+		"s3://bucket/key": "bedrock",
+		"gs://bucket/key": "",
+	} {
+		if got := FileOwner(ref); got != want {
+			t.Fatalf("FileOwner(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
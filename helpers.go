@@ -30,6 +30,18 @@ func ImageURL(url string) ImageBlock {
 	return ImageBlock{URL: url}
 }
 
+func DocumentURL(url string) DocumentBlock {
+	return DocumentBlock{URL: url}
+}
+
+func DocumentData(data []byte, mime string) DocumentBlock {
+	return DocumentBlock{Data: data, MIME: mime}
+}
+
+func Audio(data []byte, format string) AudioBlock {
+	return AudioBlock{Data: data, Format: format}
+}
+
 func User(blocks ...Block) Message {
 	return Message{Role: RoleUser, Blocks: blocks}
 }
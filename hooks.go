@@ -5,14 +5,22 @@ import (
 	"time"
 )
 
+// CallMeta describes one client call. Provider and Model are the client's
+// provider and requested model; ServedProvider and ServedModel name the
+// backend that actually answered, which differs when the provider is a
+// Router. They are set once the provider has responded. CacheHit reports a
+// response served by WithResponseCache without calling the provider.
 type CallMeta struct {
-	CallID    string
-	Provider  string
-	Operation string
-	Model     string
-	Streaming bool
-	StartedAt time.Time
-	Duration  time.Duration
+	CallID         string
+	Provider       string
+	Operation      string
+	Model          string
+	ServedProvider string
+	ServedModel    string
+	CacheHit       bool
+	Streaming      bool
+	StartedAt      time.Time
+	Duration       time.Duration
 }
 
 type Hook interface {
@@ -0,0 +1,180 @@
+package litellm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ImageGenerator is implemented by providers with a dedicated image generation
+// endpoint. Like Embedder it is optional; use Client.GenerateImage to call it.
+// Chat models that return images inline surface them as ImageBlocks in
+// Response.Blocks instead.
+type ImageGenerator interface {
+	GenerateImage(context.Context, *ImageRequest) (*ImageResponse, error)
+}
+
+type ImageRequest struct {
+	Model  string
+	Prompt string
+
+	// Size is WIDTHxHEIGHT, such as "1024x1024". AspectRatio is W:H, such as
+	// "16:9", for providers that size images by ratio. Providers reject the
+	// form they cannot express.
+	Size        string
+	AspectRatio string
+
+	// N is the number of images to generate; zero means one.
+	N int
+
+	// Images are source images to edit or vary, and Mask marks the region to
+	// repaint. Both need Data.
+	Images []ImageBlock
+	Mask   *ImageBlock
+
+	ProviderOptions ProviderOptions
+}
+
+type ImageResponse struct {
+	// Images holds the generated images with Data and MIME set, or URL when
+	// the provider only returns links.
+	Images   []ImageBlock
+	Usage    Usage
+	Model    string
+	Provider string
+}
+
+// ParseImageSize splits a WIDTHxHEIGHT size such as "1024x1536".
+func ParseImageSize(size string) (width, height int, err error) {
+	w, h, ok := strings.Cut(strings.ToLower(strings.TrimSpace(size)), "x")
+	if ok {
+		width, err = strconv.Atoi(w)
+		if err == nil {
+			height, err = strconv.Atoi(h)
+		}
+	}
+	if !ok || err != nil || width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("image size %q must be WIDTHxHEIGHT", size)
+	}
+	return width, height, nil
+}
+
+// GenerateImage creates images from req.Prompt, editing req.Images when set,
+// using the bound provider's image endpoint. Hooks observe the call with
+// CallMeta.Operation "image": the hook Request carries only Model and
+// ProviderOptions, and the hook Response carries Model, Provider and Usage.
+func (c *Client) GenerateImage(ctx context.Context, req ImageRequest) (*ImageResponse, error) {
+	if c == nil || c.provider == nil {
+		return nil, NewError(ErrorTypeValidation, "client has no provider")
+	}
+	generator, ok := c.provider.(ImageGenerator)
+	if !ok {
+		return nil, NewProviderError(c.provider.Name(), ErrorTypeValidation, fmt.Sprintf("%s provider does not support image generation", c.provider.Name()))
+	}
+	prepared := cloneImageRequest(req)
+	if err := validateImageRequest(prepared); err != nil {
+		return nil, err
+	}
+	meta := c.newCallMeta("image", prepared.Model, false)
+	c.notifyBeforeRequest(ctx, meta, &Request{Model: prepared.Model, ProviderOptions: prepared.ProviderOptions})
+	start := meta.StartedAt
+	resp, err := generator.GenerateImage(ctx, prepared)
+	if err != nil {
+		err = WrapError(err, c.provider.Name())
+	}
+	if err == nil && resp == nil {
+		err = NewProviderError(c.provider.Name(), ErrorTypeInternal, "provider returned nil image response without error")
+	}
+	if err == nil && len(resp.Images) == 0 {
+		err = NewProviderError(c.provider.Name(), ErrorTypeProvider, fmt.Sprintf("%s: image response contains no images", c.provider.Name()))
+	}
+	var hookResp *Response
+	if resp != nil {
+		if resp.Provider == "" {
+			resp.Provider = c.provider.Name()
+		}
+		if resp.Model == "" {
+			resp.Model = prepared.Model
+		}
+		resp.Usage.StampModel(resp.Provider, resp.Model)
+		hookResp = &Response{Model: resp.Model, Provider: resp.Provider, Usage: resp.Usage}
+	}
+	meta.Duration = time.Since(start)
+	c.notifyAfterResponse(ctx, meta, hookResp, err)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func validateImageRequest(req *ImageRequest) error {
+	if req.Model == "" {
+		return NewError(ErrorTypeValidation, "model cannot be empty")
+	}
+	if !utf8.ValidString(req.Model) {
+		return NewError(ErrorTypeValidation, "model must be valid UTF-8")
+	}
+	if strings.TrimSpace(req.Prompt) == "" {
+		return NewError(ErrorTypeValidation, "image prompt cannot be empty")
+	}
+	if !utf8.ValidString(req.Prompt) {
+		return NewError(ErrorTypeValidation, "image prompt must be valid UTF-8")
+	}
+	if req.Size != "" {
+		if _, _, err := ParseImageSize(req.Size); err != nil {
+			return NewError(ErrorTypeValidation, err.Error())
+		}
+	}
+	if req.AspectRatio != "" {
+		w, h, ok := strings.Cut(req.AspectRatio, ":")
+		if _, err := strconv.Atoi(w); !ok || err != nil {
+			return NewError(ErrorTypeValidation, fmt.Sprintf("image aspect ratio %q must be W:H", req.AspectRatio))
+		}
+		if _, err := strconv.Atoi(h); err != nil {
+			return NewError(ErrorTypeValidation, fmt.Sprintf("image aspect ratio %q must be W:H", req.AspectRatio))
+		}
+	}
+	if req.N < 0 {
+		return NewError(ErrorTypeValidation, "image count cannot be negative")
+	}
+	for i, image := range req.Images {
+		if len(image.Data) == 0 {
+			return NewError(ErrorTypeValidation, fmt.Sprintf("images[%d] requires data", i))
+		}
+	}
+	if req.Mask != nil {
+		if len(req.Images) == 0 {
+			return NewError(ErrorTypeValidation, "image mask requires a source image")
+		}
+		if len(req.Mask.Data) == 0 {
+			return NewError(ErrorTypeValidation, "image mask requires data")
+		}
+	}
+	return validateProviderOptionsUTF8(req.ProviderOptions)
+}
+
+func cloneImageRequest(req ImageRequest) *ImageRequest {
+	out := req
+	if req.Images != nil {
+		out.Images = make([]ImageBlock, len(req.Images))
+		for i, image := range req.Images {
+			image.Data = cloneBytes(image.Data)
+			out.Images[i] = image
+		}
+	}
+	if req.Mask != nil {
+		mask := *req.Mask
+		mask.Data = cloneBytes(mask.Data)
+		out.Mask = &mask
+	}
+	if req.ProviderOptions != nil {
+		out.ProviderOptions = make(ProviderOptions, len(req.ProviderOptions))
+		for k, v := range req.ProviderOptions {
+			out.ProviderOptions[k] = cloneAny(v)
+		}
+	}
+	return &out
+}
@@ -0,0 +1,84 @@
+package litellm
+
+import (
+	"context"
+	"testing"
+)
+
+type testImageProvider struct {
+	*testProvider
+	lastImageReq *ImageRequest
+}
+
+func (p *testImageProvider) GenerateImage(ctx context.Context, req *ImageRequest) (*ImageResponse, error) {
+	p.lastImageReq = req
+	return &ImageResponse{
+		Images: []ImageBlock{{Data: []byte("png"), MIME: "image/png"}},
+		Usage:  Usage{InputTokens: 4, OutputTokens: 10, TotalTokens: 14},
+	}, nil
+}
+
+func TestClientGenerateImageRunsHooksAndStampsUsage(t *testing.T) {
+	var after CallMeta
+	provider := &testImageProvider{testProvider: &testProvider{name: "painter"}}
+	client, err := New(provider, WithHook(HookFuncs{
+		AfterResponseFunc: func(_ context.Context, meta CallMeta, resp *Response, err error) {
+			after = meta
+		},
+	}))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	source := []byte("src")
+	resp, err := client.GenerateImage(context.Background(), ImageRequest{
+		Model:  "image-model",
+		Prompt: "a red fox",
+		Size:   "1024x1536",
+		Images: []ImageBlock{{Data: source, MIME: "image/png"}},
+	})
+	if err != nil {
+		t.Fatalf("GenerateImage returned error: %v", err)
+	}
+	if after.Operation != "image" {
+		t.Fatalf("meta = %+v", after)
+	}
+	if resp.Provider != "painter" || resp.Model != "image-model" || resp.Usage.Provider != "painter" || len(resp.Images) != 1 {
+		t.Fatalf("resp = %+v", resp)
+	}
+	source[0] = 'x'
+	if string(provider.lastImageReq.Images[0].Data) != "src" {
+		t.Fatalf("request images were not cloned")
+	}
+}
+
+func TestClientGenerateImageValidatesRequest(t *testing.T) {
+	client, _ := New(&testImageProvider{testProvider: &testProvider{name: "painter"}})
+	for _, req := range []ImageRequest{
+		{Model: "m"},
+		{Model: "m", Prompt: "p", Size: "large"},
+		{Model: "m", Prompt: "p", AspectRatio: "wide"},
+		{Model: "m", Prompt: "p", N: -1},
+		{Model: "m", Prompt: "p", Images: []ImageBlock{ImageURL("https://example.com/a.png")}},
+		{Model: "m", Prompt: "p", Mask: &ImageBlock{Data: []byte("m")}},
+	} {
+		if _, err := client.GenerateImage(context.Background(), req); !IsValidationError(err) {
+			t.Fatalf("GenerateImage(%+v) error = %v, want validation error", req, err)
+		}
+	}
+
+	chatOnly, _ := New(&testProvider{name: "chat-only"})
+	if _, err := chatOnly.GenerateImage(context.Background(), ImageRequest{Model: "m", Prompt: "p"}); !IsValidationError(err) {
+		t.Fatalf("expected unsupported provider validation error, got %v", err)
+	}
+}
+
+func TestParseImageSize(t *testing.T) {
+	if w, h, err := ParseImageSize("1792x1024"); err != nil || w != 1792 || h != 1024 {
+		t.Fatalf("ParseImageSize = %d, %d, %v", w, h, err)
+	}
+	for _, size := range []string{"", "1024", "0x10", "axb"} {
+		if _, _, err := ParseImageSize(size); err == nil {
+			t.Fatalf("ParseImageSize(%q) returned nil error", size)
+		}
+	}
+}
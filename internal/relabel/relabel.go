@@ -0,0 +1,95 @@
+// Package relabel reports results from a wrapped adapter under the name of
+// the provider that wraps it. The wrapped adapter stamps its own name on
+// responses, usage, warnings, errors and stream events; only values carrying
+// that name are rewritten.
+package relabel
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/voocel/litellm"
+)
+
+// Response rewrites resp's provider, usage and warnings from one name to
+// another. A nil resp is ignored.
+func Response(resp *litellm.Response, from, to string) {
+	if resp == nil {
+		return
+	}
+	if resp.Provider == from {
+		resp.Provider = to
+	}
+	if resp.Usage.Provider == from {
+		resp.Usage.Provider = to
+	}
+	for i := range resp.Warnings {
+		if resp.Warnings[i].Provider == from {
+			resp.Warnings[i].Provider = to
+		}
+	}
+}
+
+// Error rewrites the provider of the *litellm.LiteLLMError in err's chain
+// and returns err.
+func Error(err error, from, to string) error {
+	var e *litellm.LiteLLMError
+	if errors.As(err, &e) && e.Provider == from {
+		e.Provider = to
+	}
+	return err
+}
+
+// Stream relabels the events and errors of inner.
+func Stream(inner litellm.Stream, from, to string) litellm.Stream {
+	return &stream{inner: inner, from: from, to: to}
+}
+
+type stream struct {
+	inner    litellm.Stream
+	from, to string
+}
+
+func (s *stream) Next() (litellm.Event, error) {
+	event, err := s.inner.Next()
+	if err != nil {
+		return event, Error(err, s.from, s.to)
+	}
+	switch e := event.(type) {
+	case litellm.DoneEvent:
+		if e.Provider == s.from {
+			e.Provider = s.to
+		}
+		return e, nil
+	case litellm.UsageEvent:
+		if e.Usage.Provider == s.from {
+			e.Usage.Provider = s.to
+		}
+		return e, nil
+	case litellm.WarningEvent:
+		if e.Warning.Provider == s.from {
+			e.Warning.Provider = s.to
+		}
+		return e, nil
+	case litellm.ErrorEvent:
+		e.Err = Error(e.Err, s.from, s.to)
+		return e, nil
+	}
+	return event, nil
+}
+
+func (s *stream) Close() error {
+	return s.inner.Close()
+}
+
+// ClientTransport adapts a provider's HTTPClient to the http.RoundTripper
+// its wrapped adapter takes as Transport.
+type ClientTransport struct {
+	Client interface {
+		Do(*http.Request) (*http.Response, error)
+	}
+}
+
+func (t ClientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.Client.Do(req)
+}
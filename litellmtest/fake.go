@@ -0,0 +1,340 @@
+package litellmtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/voocel/litellm"
+)
+
+// RequestMatcher selects the scripted Step that answers a request.
+type RequestMatcher func(*litellm.Request) bool
+
+// MatchModel matches requests for model.
+func MatchModel(model string) RequestMatcher {
+	return func(req *litellm.Request) bool {
+		return req.Model == model
+	}
+}
+
+// MatchLastMessage matches when the text of the final message contains substr.
+func MatchLastMessage(substr string) RequestMatcher {
+	return func(req *litellm.Request) bool {
+		if len(req.Messages) == 0 {
+			return false
+		}
+		return strings.Contains(messageText(req.Messages[len(req.Messages)-1]), substr)
+	}
+}
+
+// MatchToolResult matches when the final message carries a result for
+// toolUseID.
+func MatchToolResult(toolUseID string) RequestMatcher {
+	return func(req *litellm.Request) bool {
+		if len(req.Messages) == 0 {
+			return false
+		}
+		for _, block := range req.Messages[len(req.Messages)-1].Blocks {
+			if result, ok := block.(litellm.ToolResultBlock); ok && result.ToolUseID == toolUseID {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Step scripts one call. Steps are consumed in order: each call takes the first
+// unused step whose matchers all accept the request. Repeat keeps a step
+// available after it is used.
+//
+// Err fails the call outright. Otherwise Chat returns Response, or the
+// collected Events when Response is nil, and Stream emits Events, or events
+// synthesized from Response. StreamErr is returned after the events to
+// simulate a mid-stream failure. Latency delays the call; Stall pauses the
+// stream before event StallAt, which exercises stream idle timeouts.
+type Step struct {
+	Match  []RequestMatcher
+	Repeat bool
+
+	Response  *litellm.Response
+	Events    []litellm.Event
+	Err       error
+	StreamErr error
+
+	Latency time.Duration
+	Stall   time.Duration
+	StallAt int
+}
+
+// FakeProvider is a scriptable litellm.Provider for application tests. It
+// records every request it receives.
+type FakeProvider struct {
+	name string
+
+	mu           sync.Mutex
+	steps        []Step
+	used         []bool
+	requests     []*litellm.Request
+	models       []litellm.ModelInfo
+	capabilities func(model string) litellm.Capabilities
+}
+
+func NewFakeProvider(name string, steps ...Step) *FakeProvider {
+	f := &FakeProvider{name: name}
+	f.Push(steps...)
+	return f
+}
+
+// Push appends steps to the script.
+func (f *FakeProvider) Push(steps ...Step) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.steps = append(f.steps, steps...)
+	f.used = append(f.used, make([]bool, len(steps))...)
+}
+
+// SetModels sets the ListModels result.
+func (f *FakeProvider) SetModels(models ...litellm.ModelInfo) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.models = append([]litellm.ModelInfo(nil), models...)
+}
+
+// SetCapabilities sets the Capabilities result.
+func (f *FakeProvider) SetCapabilities(fn func(model string) litellm.Capabilities) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.capabilities = fn
+}
+
+// Requests returns every request received, in call order.
+func (f *FakeProvider) Requests() []*litellm.Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*litellm.Request(nil), f.requests...)
+}
+
+// LastRequest returns the most recent request, or nil.
+func (f *FakeProvider) LastRequest() *litellm.Request {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.requests) == 0 {
+		return nil
+	}
+	return f.requests[len(f.requests)-1]
+}
+
+// Remaining reports how many non-repeating steps have not been used.
+func (f *FakeProvider) Remaining() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for i, step := range f.steps {
+		if !step.Repeat && !f.used[i] {
+			n++
+		}
+	}
+	return n
+}
+
+func (f *FakeProvider) Name() string {
+	return f.name
+}
+
+func (f *FakeProvider) Capabilities(model string) litellm.Capabilities {
+	f.mu.Lock()
+	fn := f.capabilities
+	f.mu.Unlock()
+	if fn == nil {
+		return litellm.Capabilities{Provider: f.name, Model: model}
+	}
+	return fn(model)
+}
+
+func (f *FakeProvider) ListModels(context.Context) ([]litellm.ModelInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]litellm.ModelInfo(nil), f.models...), nil
+}
+
+func (f *FakeProvider) Chat(ctx context.Context, req *litellm.Request) (*litellm.Response, error) {
+	step, err := f.next(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := sleep(ctx, step.Latency); err != nil {
+		return nil, err
+	}
+	if step.Err != nil {
+		return nil, step.Err
+	}
+	if step.Response != nil {
+		resp := *step.Response
+		resp.Blocks = append([]litellm.Block(nil), step.Response.Blocks...)
+		resp.Warnings = append([]litellm.Warning(nil), step.Response.Warnings...)
+		return &resp, nil
+	}
+	if step.StreamErr != nil {
+		return litellm.Collect(&fakeStream{ctx: ctx, events: step.Events, err: step.StreamErr})
+	}
+	if len(step.Events) > 0 {
+		return litellm.Collect(&fakeStream{ctx: ctx, events: f.withDone(step.Events, req)})
+	}
+	return nil, fmt.Errorf("litellmtest: step for %s has no response, events or error", req.Model)
+}
+
+func (f *FakeProvider) Stream(ctx context.Context, req *litellm.Request) (litellm.Stream, error) {
+	step, err := f.next(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := sleep(ctx, step.Latency); err != nil {
+		return nil, err
+	}
+	if step.Err != nil {
+		return nil, step.Err
+	}
+	events := step.Events
+	if len(events) == 0 && step.Response != nil {
+		events = responseEvents(step.Response)
+	}
+	if len(events) == 0 && step.StreamErr == nil {
+		return nil, fmt.Errorf("litellmtest: step for %s has no response, events or error", req.Model)
+	}
+	if step.StreamErr == nil {
+		events = f.withDone(events, req)
+	}
+	return &fakeStream{ctx: ctx, events: events, err: step.StreamErr, stall: step.Stall, stallAt: step.StallAt}, nil
+}
+
+func (f *FakeProvider) next(req *litellm.Request) (Step, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	for i, step := range f.steps {
+		if f.used[i] && !step.Repeat {
+			continue
+		}
+		if !matches(step.Match, req) {
+			continue
+		}
+		f.used[i] = true
+		return step, nil
+	}
+	return Step{}, fmt.Errorf("litellmtest: no scripted step matches request %d for model %q", len(f.requests), req.Model)
+}
+
+func matches(matchers []RequestMatcher, req *litellm.Request) bool {
+	for _, match := range matchers {
+		if !match(req) {
+			return false
+		}
+	}
+	return true
+}
+
+// withDone appends a DoneEvent when the script omits one and fills in the
+// provider and model a real provider would report.
+func (f *FakeProvider) withDone(events []litellm.Event, req *litellm.Request) []litellm.Event {
+	out := append([]litellm.Event(nil), events...)
+	for i, event := range out {
+		if done, ok := event.(litellm.DoneEvent); ok {
+			if done.Provider == "" {
+				done.Provider = f.name
+			}
+			if done.Model == "" {
+				done.Model = req.Model
+			}
+			out[i] = done
+			return out
+		}
+	}
+	return append(out, litellm.DoneEvent{FinishReason: litellm.FinishReasonStop, Provider: f.name, Model: req.Model})
+}
+
+// responseEvents streams resp the way litellm.ReplayStream does, with a
+// stop finish reason when the script leaves it empty.
+func responseEvents(resp *litellm.Response) []litellm.Event {
+	var events []litellm.Event
+	stream := litellm.ReplayStream(resp)
+	for {
+		event, err := stream.Next()
+		if err != nil {
+			break
+		}
+		if done, ok := event.(litellm.DoneEvent); ok && done.FinishReason == "" {
+			done.FinishReason = litellm.FinishReasonStop
+			event = done
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+type fakeStream struct {
+	ctx     context.Context
+	events  []litellm.Event
+	err     error
+	stall   time.Duration
+	stallAt int
+	index   int
+	closed  bool
+}
+
+func (s *fakeStream) Next() (litellm.Event, error) {
+	if s.closed {
+		return nil, io.EOF
+	}
+	if s.stall > 0 && s.index == s.stallAt {
+		stall := s.stall
+		s.stall = 0
+		if err := sleep(s.ctx, stall); err != nil {
+			return nil, err
+		}
+	}
+	if s.index >= len(s.events) {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	event := s.events[s.index]
+	s.index++
+	return event, nil
+}
+
+func (s *fakeStream) Close() error {
+	s.closed = true
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func messageText(msg litellm.Message) string {
+	var b strings.Builder
+	for _, block := range msg.Blocks {
+		switch v := block.(type) {
+		case litellm.TextBlock:
+			b.WriteString(v.Text)
+		case litellm.ToolResultBlock:
+			b.WriteString(messageText(litellm.Message{Blocks: v.Content}))
+		}
+	}
+	return b.String()
+}
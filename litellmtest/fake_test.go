@@ -0,0 +1,151 @@
+package litellmtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/voocel/litellm"
+)
+
+func TestFakeProviderFollowsScriptWithMatchers(t *testing.T) {
+	fake := NewFakeProvider("fake",
+		Step{
+			Match: []RequestMatcher{MatchToolResult("call_1")},
+			Response: &litellm.Response{
+				Blocks: []litellm.Block{litellm.TextBlock{Text: "it is sunny"}},
+			},
+		},
+		Step{
+			Match: []RequestMatcher{MatchModel("m"), MatchLastMessage("weather")},
+			Response: &litellm.Response{
+				Blocks:       []litellm.Block{litellm.ToolUseBlock{ID: "call_1", Name: "weather", Arguments: json.RawMessage(`{}`)}},
+				FinishReason: litellm.FinishReasonToolCall,
+			},
+		},
+	)
+	client, err := litellm.New(fake)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	messages := []litellm.Message{litellm.UserText("what is the weather?")}
+	resp, err := client.Chat(context.Background(), litellm.Request{Model: "m", Messages: messages})
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	if len(resp.ToolCalls()) != 1 {
+		t.Fatalf("first response = %+v", resp)
+	}
+	messages = append(messages, litellm.Assistant(resp.Blocks...), litellm.ToolResultText("call_1", "sunny"))
+	resp, err = client.Chat(context.Background(), litellm.Request{Model: "m", Messages: messages})
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	if resp.Text() != "it is sunny" || resp.Provider != "fake" {
+		t.Fatalf("second response = %+v", resp)
+	}
+	if len(fake.Requests()) != 2 || fake.Remaining() != 0 || len(fake.LastRequest().Messages) != 3 {
+		t.Fatalf("requests = %d, remaining = %d", len(fake.Requests()), fake.Remaining())
+	}
+	if _, err := client.Chat(context.Background(), litellm.Request{Model: "m", Messages: messages}); err == nil {
+		t.Fatal("expected error once the script is exhausted")
+	}
+}
+
+func TestFakeProviderStreamsResponseAndMidStreamErrors(t *testing.T) {
+	boom := litellm.NewHTTPError("fake", 503, "unavailable")
+	fake := NewFakeProvider("fake",
+		Step{Response: &litellm.Response{Blocks: []litellm.Block{litellm.TextBlock{Text: "hello"}}}},
+		Step{Events: []litellm.Event{litellm.ContentDelta{Text: "par"}}, StreamErr: boom},
+	)
+	client, err := litellm.New(fake)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	req := litellm.Request{Model: "m", Messages: []litellm.Message{litellm.UserText("hi")}}
+	resp, err := client.StreamText(context.Background(), req, func(string) error { return nil })
+	if err != nil || resp.Text() != "hello" {
+		t.Fatalf("StreamText = %+v, %v", resp, err)
+	}
+	var got string
+	_, err = client.StreamText(context.Background(), req, func(text string) error {
+		got += text
+		return nil
+	})
+	if got != "par" || !errors.Is(err, boom) {
+		t.Fatalf("partial text = %q, err = %v", got, err)
+	}
+}
+
+func TestFakeProviderStallTriggersIdleTimeout(t *testing.T) {
+	fake := NewFakeProvider("fake", Step{
+		Events:  []litellm.Event{litellm.ContentDelta{Text: "a"}, litellm.ContentDelta{Text: "b"}},
+		Stall:   time.Second,
+		StallAt: 1,
+	})
+	client, err := litellm.New(fake, litellm.WithStreamIdleTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	stream, err := client.Stream(context.Background(), litellm.Request{Model: "m", Messages: []litellm.Message{litellm.UserText("hi")}})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	defer stream.Close()
+	if _, err := litellm.Collect(stream); !litellm.IsStreamIdleError(err) {
+		t.Fatalf("expected idle timeout, got %v", err)
+	}
+}
+
+func TestFakeProviderModelsAndCapabilities(t *testing.T) {
+	fake := NewFakeProvider("fake")
+	fake.SetModels(litellm.ModelInfo{ID: "m1"})
+	fake.SetCapabilities(func(model string) litellm.Capabilities {
+		return litellm.Capabilities{Provider: "fake", Model: model, Tools: litellm.ToolCapabilities{Calls: litellm.SupportYes}}
+	})
+	client, err := litellm.New(fake)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	models, err := client.ListModels(context.Background())
+	if err != nil || len(models) != 1 || models[0].ID != "m1" {
+		t.Fatalf("ListModels = %+v, %v", models, err)
+	}
+	if caps := client.Capabilities("m1"); caps.Tools.Calls != litellm.SupportYes {
+		t.Fatalf("capabilities = %+v", caps)
+	}
+}
+
+func TestFakeProviderStreamsEveryResponseBlock(t *testing.T) {
+	scripted := &litellm.Response{
+		Blocks: []litellm.Block{
+			litellm.ReasoningBlock{Text: "thinking", Signature: "sig", Extra: json.RawMessage(`{"id":"rs_1"}`)},
+			litellm.ServerToolUseBlock{ID: "srv_1", Name: "web_search", Arguments: json.RawMessage(`{"query":"go"}`)},
+			litellm.ServerToolResultBlock{ToolUseID: "srv_1", Type: "web_search_tool_result", Content: json.RawMessage(`[]`)},
+			litellm.ReasoningBlock{Redacted: []byte("opaque")},
+			litellm.AudioOutputBlock{ID: "audio_1", Data: []byte("pcm"), Format: "wav", Transcript: "hi"},
+			litellm.ToolUseBlock{ID: "call_1", Name: "lookup", Arguments: json.RawMessage(`{"q":"x"}`), Signature: "tool-sig"},
+		},
+		FinishReason: litellm.FinishReasonToolCall,
+	}
+	fake := NewFakeProvider("fake", Step{Response: scripted, Repeat: true})
+	req := &litellm.Request{Model: "m", Messages: []litellm.Message{litellm.UserText("hi")}}
+	chat, err := fake.Chat(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	stream, err := fake.Stream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	streamed, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	if !reflect.DeepEqual(streamed.Blocks, chat.Blocks) || streamed.FinishReason != chat.FinishReason {
+		t.Fatalf("streamed = %#v\nchat = %#v", streamed.Blocks, chat.Blocks)
+	}
+}
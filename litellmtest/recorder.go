@@ -0,0 +1,376 @@
+// Package litellmtest provides helpers for testing code built on litellm
+// without network access.
+//
+// Recorder is an http.RoundTripper that records real provider exchanges into
+// cassette files and replays them later. Plug it into any provider config
+// through the Transport field. FakeProvider is a scriptable Provider for
+// testing application code above the provider layer.
+package litellmtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+type Mode int
+
+const (
+	// ModeReplay serves responses from the cassette and fails on requests it
+	// does not contain.
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests to the real transport and writes every
+	// exchange to the cassette, replacing its previous contents.
+	ModeRecord
+)
+
+// RecordEnv selects ModeRecord in NewTestRecorder when set to a non-empty
+// value.
+const RecordEnv = "LITELLMTEST_RECORD"
+
+const redacted = "REDACTED"
+
+var defaultRedactedHeaders = []string{
+	"Authorization",
+	"X-Api-Key",
+	"Api-Key",
+	"X-Goog-Api-Key",
+	"X-Amz-Security-Token",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+var defaultRedactedQuery = []string{"key", "api-key", "X-Amz-Signature", "X-Amz-Credential", "X-Amz-Security-Token"}
+
+// Cassette is the on-disk form of recorded exchanges.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body"`
+}
+
+// Body is stored as text when it is valid UTF-8 (JSON, SSE) and as base64
+// otherwise (such as Bedrock's binary event stream).
+type Body []byte
+
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(map[string]string{"text": string(b)})
+	}
+	return json.Marshal(map[string][]byte{"base64": b})
+}
+
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var wire struct {
+		Text   *string `json:"text"`
+		Base64 []byte  `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	if wire.Text != nil {
+		*b = Body(*wire.Text)
+	} else {
+		*b = Body(wire.Base64)
+	}
+	return nil
+}
+
+// Matcher reports whether a recorded request answers an incoming one.
+type Matcher func(req *http.Request, body []byte, recorded RecordedRequest) bool
+
+// DefaultMatcher matches on method, redacted URL and body.
+func DefaultMatcher(req *http.Request, body []byte, recorded RecordedRequest) bool {
+	return req.Method == recorded.Method &&
+		redactURL(req.URL, defaultRedactedQuery) == recorded.URL &&
+		bytes.Equal(body, recorded.Body)
+}
+
+type Recorder struct {
+	path     string
+	mode     Mode
+	base     http.RoundTripper
+	matcher  Matcher
+	headers  []string
+	query    []string
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+type RecorderOption func(*Recorder) error
+
+// NewRecorder opens path in the given mode. Replay requires the cassette to
+// exist; record starts from an empty cassette and writes path after each
+// completed exchange.
+func NewRecorder(path string, mode Mode, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:    path,
+		mode:    mode,
+		base:    http.DefaultTransport,
+		matcher: DefaultMatcher,
+		headers: append([]string(nil), defaultRedactedHeaders...),
+		query:   append([]string(nil), defaultRedactedQuery...),
+	}
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, fmt.Errorf("apply recorder option: %w", err)
+		}
+	}
+	switch mode {
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("litellmtest: read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("litellmtest: decode cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	case ModeRecord:
+	default:
+		return nil, fmt.Errorf("litellmtest: unsupported mode %d", mode)
+	}
+	return r, nil
+}
+
+// NewTestRecorder records when RecordEnv is set and replays otherwise,
+// failing the test if the recorder cannot be opened.
+func NewTestRecorder(t testing.TB, path string, opts ...RecorderOption) *Recorder {
+	t.Helper()
+	mode := ModeReplay
+	if os.Getenv(RecordEnv) != "" {
+		mode = ModeRecord
+	}
+	r, err := NewRecorder(path, mode, opts...)
+	if err != nil {
+		t.Fatalf("open cassette: %v", err)
+	}
+	return r
+}
+
+// WithRecordTransport sets the transport used to reach the real provider in
+// record mode. The default is http.DefaultTransport.
+func WithRecordTransport(base http.RoundTripper) RecorderOption {
+	return func(r *Recorder) error {
+		if base == nil {
+			return fmt.Errorf("record transport cannot be nil")
+		}
+		r.base = base
+		return nil
+	}
+}
+
+func WithMatcher(matcher Matcher) RecorderOption {
+	return func(r *Recorder) error {
+		if matcher == nil {
+			return fmt.Errorf("matcher cannot be nil")
+		}
+		r.matcher = matcher
+		return nil
+	}
+}
+
+// WithRedactedHeaders adds headers whose values are replaced before they are
+// written to the cassette.
+func WithRedactedHeaders(headers ...string) RecorderOption {
+	return func(r *Recorder) error {
+		r.headers = append(r.headers, headers...)
+		return nil
+	}
+}
+
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Cassette returns a copy of the recorded or loaded interactions.
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || !r.matcher(req, body, interaction.Request) {
+			continue
+		}
+		r.used[i] = true
+		recorded := interaction.Response
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+			StatusCode:    recorded.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(recorded.Body)),
+			ContentLength: int64(len(recorded.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("litellmtest: no recorded interaction for %s %s in %s", req.Method, redactURL(req.URL, r.query), r.path)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    redactURL(req.URL, r.query),
+			Header: redactHeader(req.Header, r.headers),
+			Body:   body,
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header, r.headers),
+		},
+	}
+	resp.Body = &recordingBody{inner: resp.Body, recorder: r, interaction: interaction}
+	return resp, nil
+}
+
+func (r *Recorder) append(interaction Interaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("litellmtest: encode cassette: %w", err)
+	}
+	if dir := filepath.Dir(r.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("litellmtest: create cassette directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("litellmtest: write cassette: %w", err)
+	}
+	return nil
+}
+
+// recordingBody copies the response body as the caller reads it, so streamed
+// SSE and event-stream bodies are recorded without being buffered up front.
+// The exchange is written when the body reaches EOF or is closed.
+type recordingBody struct {
+	inner       io.ReadCloser
+	recorder    *Recorder
+	interaction Interaction
+	buf         bytes.Buffer
+	done        bool
+	err         error
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.inner.Read(p)
+	b.buf.Write(p[:n])
+	if err == io.EOF {
+		b.finish()
+		if b.err != nil {
+			return n, b.err
+		}
+	}
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	b.finish()
+	closeErr := b.inner.Close()
+	if b.err != nil {
+		return b.err
+	}
+	return closeErr
+}
+
+func (b *recordingBody) finish() {
+	if b.done {
+		return
+	}
+	b.done = true
+	b.interaction.Response.Body = Body(bytes.Clone(b.buf.Bytes()))
+	b.err = b.recorder.append(b.interaction)
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("litellmtest: read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func redactHeader(header http.Header, names []string) http.Header {
+	out := header.Clone()
+	for _, name := range names {
+		if _, ok := out[http.CanonicalHeaderKey(name)]; ok {
+			out.Set(name, redacted)
+		}
+	}
+	return out
+}
+
+func redactURL(u *url.URL, params []string) string {
+	copied := *u
+	query := copied.Query()
+	changed := false
+	for key := range query {
+		for _, param := range params {
+			if strings.EqualFold(key, param) {
+				query.Set(key, redacted)
+				changed = true
+			}
+		}
+	}
+	if changed {
+		copied.RawQuery = query.Encode()
+	}
+	return copied.String()
+}
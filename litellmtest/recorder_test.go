@@ -0,0 +1,132 @@
+package litellmtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/voocel/litellm"
+	"github.com/voocel/litellm/internal/testgolden"
+	"github.com/voocel/litellm/provider/anthropic"
+	"github.com/voocel/litellm/provider/bedrock"
+)
+
+func TestRecorderRecordsAndReplaysSSE(t *testing.T) {
+	sse := testgolden.ReadFixture(t, "../testdata/anthropic/messages_stream.sse")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write(sse)
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "anthropic.json")
+
+	recorder, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	recorded := streamAnthropic(t, server.URL, recorder)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read cassette: %v", err)
+	}
+	if strings.Contains(string(data), "secret-key") {
+		t.Fatalf("cassette leaks API key:\n%s", data)
+	}
+	cassette := recorder.Cassette()
+	if len(cassette.Interactions) != 1 || cassette.Interactions[0].Request.Header.Get("X-Api-Key") != redacted {
+		t.Fatalf("cassette = %+v", cassette)
+	}
+
+	replayer, err := NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	server.Close()
+	replayed := streamAnthropic(t, server.URL, replayer)
+	if replayed.Text() != recorded.Text() || replayed.Text() == "" || replayed.Reasoning() != recorded.Reasoning() {
+		t.Fatalf("replayed = %+v, recorded = %+v", replayed, recorded)
+	}
+	if _, err := replayer.RoundTrip(httptest.NewRequest(http.MethodPost, server.URL+"/v1/messages", strings.NewReader("{}"))); err == nil {
+		t.Fatal("expected error for unrecorded request")
+	}
+}
+
+func TestRecorderRecordsBinaryEventStream(t *testing.T) {
+	eventStream := testgolden.ReadFixture(t, "../testdata/bedrock/eventstream.bin")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.amazon.eventstream")
+		w.Write(eventStream)
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "bedrock.json")
+
+	recorder, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	streamBedrock(t, server.URL, recorder)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read cassette: %v", err)
+	}
+	if strings.Contains(string(data), "AKIDSECRET") || !strings.Contains(string(data), `"base64"`) {
+		t.Fatalf("cassette should redact SigV4 and base64 the body:\n%s", data)
+	}
+
+	replayer, err := NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder returned error: %v", err)
+	}
+	resp := streamBedrock(t, server.URL, replayer)
+	if resp.Text() != "hel" || len(resp.ToolCalls()) != 1 {
+		t.Fatalf("replayed = %+v", resp)
+	}
+}
+
+func streamAnthropic(t *testing.T, baseURL string, transport http.RoundTripper) *litellm.Response {
+	t.Helper()
+	provider, err := anthropic.New(anthropic.Config{APIKey: "secret-key", BaseURL: baseURL, Transport: transport})
+	if err != nil {
+		t.Fatalf("anthropic.New returned error: %v", err)
+	}
+	return collect(t, provider, "claude-sonnet")
+}
+
+func streamBedrock(t *testing.T, baseURL string, transport http.RoundTripper) *litellm.Response {
+	t.Helper()
+	provider, err := bedrock.New(bedrock.Config{
+		BaseURL:     baseURL,
+		Credentials: bedrock.StaticCredentials("AKIDSECRET", "SECRET", ""),
+		Transport:   transport,
+	})
+	if err != nil {
+		t.Fatalf("bedrock.New returned error: %v", err)
+	}
+	return collect(t, provider, "anthropic.claude-3-5-sonnet-20240620-v1:0")
+}
+
+func collect(t *testing.T, provider litellm.Provider, model string) *litellm.Response {
+	t.Helper()
+	client, err := litellm.New(provider)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	stream, err := client.Stream(context.Background(), litellm.Request{
+		Model:     model,
+		MaxTokens: litellm.IntPtr(256),
+		Messages:  []litellm.Message{litellm.UserText("hi")},
+	})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	defer stream.Close()
+	resp, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	return resp
+}
@@ -88,8 +88,19 @@ func genAIParts(blocks []litellm.Block) []any {
 		switch b := block.(type) {
 		case litellm.TextBlock:
 			parts = append(parts, genAITextPart{Type: "text", Content: b.Text})
+		case litellm.GuardContentBlock:
+			parts = append(parts, genAITextPart{Type: "text", Content: b.Text})
 		case litellm.ImageBlock:
 			parts = append(parts, genAIImagePart(b))
+		case litellm.AudioBlock:
+			parts = append(parts, genAIAudioPart(b.Format, b.Data))
+		case litellm.AudioOutputBlock:
+			if len(b.Data) > 0 {
+				parts = append(parts, genAIAudioPart(b.Format, b.Data))
+			}
+			if b.Transcript != "" {
+				parts = append(parts, genAITextPart{Type: "text", Content: b.Transcript})
+			}
 		case litellm.ReasoningBlock:
 			if b.Text != "" {
 				parts = append(parts, genAIReasoningPart{Type: "reasoning", Content: b.Text})
@@ -110,6 +121,19 @@ func genAIParts(blocks []litellm.Block) []any {
 			})
 		case litellm.ToolReferenceBlock:
 			parts = append(parts, genAIToolReferencePart{Type: "tool_reference", Name: b.ToolName})
+		case litellm.ServerToolUseBlock:
+			parts = append(parts, genAIToolCallPart{
+				Type:      "server_tool_call",
+				ID:        b.ID,
+				Name:      b.Name,
+				Arguments: rawJSONValue(b.Arguments),
+			})
+		case litellm.ServerToolResultBlock:
+			parts = append(parts, genAIToolCallResponsePart{
+				Type:     "server_tool_call_response",
+				ID:       b.ToolUseID,
+				Response: rawJSONValue(b.Content),
+			})
 		}
 	}
 	return parts
@@ -136,6 +160,18 @@ func genAIImagePart(image litellm.ImageBlock) any {
 	}
 }
 
+func genAIAudioPart(format string, data []byte) genAIBlobPart {
+	part := genAIBlobPart{
+		Type:     "blob",
+		Modality: "audio",
+		Content:  base64.StdEncoding.EncodeToString(data),
+	}
+	if format != "" {
+		part.MIMEType = "audio/" + format
+	}
+	return part
+}
+
 func toolResponse(blocks []litellm.Block) any {
 	parts := genAIParts(blocks)
 	if len(parts) == 1 {
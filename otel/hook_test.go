@@ -323,9 +323,11 @@ func TestSemanticConventionMessageEncoding(t *testing.T) {
 func TestSemanticProviderNames(t *testing.T) {
 	tests := map[string]string{
 		"bedrock":    "aws.bedrock",
+		"azure":      "azure.ai.openai",
 		"gemini":     "gcp.gemini",
 		"grok":       "x_ai",
 		"openrouter": "openrouter",
+		"vertex":     "gcp.vertex_ai",
 	}
 	for provider, want := range tests {
 		if got := semanticProvider(provider); got != want {
@@ -44,10 +44,14 @@ func semanticOperation(meta litellm.CallMeta) string {
 
 func semanticProvider(provider string) string {
 	switch provider {
+	case "azure":
+		return "azure.ai.openai"
 	case "bedrock":
 		return "aws.bedrock"
 	case "gemini":
 		return "gcp.gemini"
+	case "vertex":
+		return "gcp.vertex_ai"
 	case "grok":
 		return "x_ai"
 	default:
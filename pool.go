@@ -0,0 +1,381 @@
+package litellm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+type PoolStrategy string
+
+const (
+	PoolRoundRobin    PoolStrategy = "round_robin"
+	PoolWeighted      PoolStrategy = "weighted"
+	PoolLeastInFlight PoolStrategy = "least_in_flight"
+)
+
+const defaultPoolCooldown = 30 * time.Second
+
+// PoolMember is one interchangeable backend in a Pool, typically the same
+// provider package configured with a different API key or region. Weight is
+// used by PoolWeighted and defaults to 1.
+type PoolMember struct {
+	Name     string
+	Provider Provider
+	Weight   int
+}
+
+// PoolMemberState is a snapshot of a member's health returned by Pool.Members.
+type PoolMemberState struct {
+	Name         string
+	Weight       int
+	InFlight     int
+	Requests     int
+	Failures     int
+	Healthy      bool
+	EjectedUntil time.Time
+	LastError    error
+}
+
+// Pool is a Provider that spreads calls across interchangeable members.
+// Members failing with auth, quota, rate-limit or overloaded errors are
+// ejected until their RetryAfter (or the pool cooldown) elapses, and the call
+// moves on to another healthy member.
+type Pool struct {
+	name     string
+	strategy PoolStrategy
+	cooldown time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	members []*poolMember
+	next    int
+}
+
+type poolMember struct {
+	PoolMember
+	current      int
+	inFlight     int
+	requests     int
+	failures     int
+	ejectedUntil time.Time
+	lastErr      error
+}
+
+type PoolOption func(*Pool) error
+
+func NewPool(members []PoolMember, opts ...PoolOption) (*Pool, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("pool requires at least one member")
+	}
+	for i, member := range members {
+		if member.Provider == nil {
+			return nil, fmt.Errorf("pool member %d provider cannot be nil", i)
+		}
+		if member.Weight < 0 {
+			return nil, fmt.Errorf("pool member %d weight cannot be negative", i)
+		}
+	}
+	pool := &Pool{
+		name:     members[0].Provider.Name(),
+		strategy: PoolRoundRobin,
+		cooldown: defaultPoolCooldown,
+		now:      time.Now,
+	}
+	for i, member := range members {
+		if member.Weight == 0 {
+			member.Weight = 1
+		}
+		if member.Name == "" {
+			member.Name = fmt.Sprintf("%s-%d", member.Provider.Name(), i)
+		}
+		pool.members = append(pool.members, &poolMember{PoolMember: member})
+	}
+	for _, opt := range opts {
+		if err := opt(pool); err != nil {
+			return nil, fmt.Errorf("apply pool option: %w", err)
+		}
+	}
+	return pool, nil
+}
+
+// WithPoolName overrides the pool name, which defaults to the first member's
+// provider name so errors and capabilities keep reporting the real provider.
+func WithPoolName(name string) PoolOption {
+	return func(p *Pool) error {
+		if name == "" {
+			return fmt.Errorf("pool name cannot be empty")
+		}
+		p.name = name
+		return nil
+	}
+}
+
+func WithPoolStrategy(strategy PoolStrategy) PoolOption {
+	return func(p *Pool) error {
+		switch strategy {
+		case PoolRoundRobin, PoolWeighted, PoolLeastInFlight:
+			p.strategy = strategy
+			return nil
+		default:
+			return fmt.Errorf("unsupported pool strategy %q", strategy)
+		}
+	}
+}
+
+// WithPoolCooldown sets how long a failing member is ejected when its error
+// carries no RetryAfter. The default is 30s.
+func WithPoolCooldown(d time.Duration) PoolOption {
+	return func(p *Pool) error {
+		if d <= 0 {
+			return fmt.Errorf("pool cooldown must be positive")
+		}
+		p.cooldown = d
+		return nil
+	}
+}
+
+func (p *Pool) Name() string {
+	return p.name
+}
+
+func (p *Pool) Capabilities(model string) Capabilities {
+	return GetCapabilities(p.members[0].Provider, model)
+}
+
+// Members returns the current state of every member in configuration order.
+func (p *Pool) Members() []PoolMemberState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	out := make([]PoolMemberState, 0, len(p.members))
+	for _, m := range p.members {
+		state := PoolMemberState{
+			Name:      m.Name,
+			Weight:    m.Weight,
+			InFlight:  m.inFlight,
+			Requests:  m.requests,
+			Failures:  m.failures,
+			Healthy:   !now.Before(m.ejectedUntil),
+			LastError: m.lastErr,
+		}
+		if !state.Healthy {
+			state.EjectedUntil = m.ejectedUntil
+		}
+		out = append(out, state)
+	}
+	return out
+}
+
+func (p *Pool) Chat(ctx context.Context, req *Request) (*Response, error) {
+	tried := make(map[*poolMember]bool, len(p.members))
+	for {
+		member, err := p.acquire(tried)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := member.Provider.Chat(ctx, req)
+		if err == nil && resp == nil {
+			err = NewProviderError(member.Provider.Name(), ErrorTypeInternal, "provider returned nil response without error")
+		}
+		if err != nil {
+			err = WrapError(err, member.Provider.Name())
+		}
+		ejected := p.release(member, err)
+		if err == nil {
+			return resp, nil
+		}
+		if !ejected || ctx.Err() != nil || !p.hasCandidate(tried) {
+			return nil, err
+		}
+	}
+}
+
+func (p *Pool) Stream(ctx context.Context, req *Request) (Stream, error) {
+	tried := make(map[*poolMember]bool, len(p.members))
+	for {
+		member, err := p.acquire(tried)
+		if err != nil {
+			return nil, err
+		}
+		stream, err := member.Provider.Stream(ctx, req)
+		if err == nil && stream == nil {
+			err = NewProviderError(member.Provider.Name(), ErrorTypeInternal, "provider returned nil stream without error")
+		}
+		if err == nil {
+			return &pooledStream{pool: p, member: member, inner: stream}, nil
+		}
+		err = WrapError(err, member.Provider.Name())
+		ejected := p.release(member, err)
+		if !ejected || ctx.Err() != nil || !p.hasCandidate(tried) {
+			return nil, err
+		}
+	}
+}
+
+// acquire picks a healthy member not yet tried for this call and counts it in
+// flight.
+func (p *Pool) acquire(tried map[*poolMember]bool) (*poolMember, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	member := p.pick(now, tried)
+	if member == nil {
+		return nil, p.unavailableError(now)
+	}
+	tried[member] = true
+	member.inFlight++
+	member.requests++
+	return member, nil
+}
+
+func (p *Pool) pick(now time.Time, tried map[*poolMember]bool) *poolMember {
+	n := len(p.members)
+	var picked *poolMember
+	switch p.strategy {
+	case PoolWeighted:
+		// Smooth weighted round-robin: spreads picks evenly in proportion to
+		// weight instead of sending bursts to the heaviest member.
+		total := 0
+		for _, m := range p.members {
+			if !p.available(m, now, tried) {
+				continue
+			}
+			m.current += m.Weight
+			total += m.Weight
+			if picked == nil || m.current > picked.current {
+				picked = m
+			}
+		}
+		if picked != nil {
+			picked.current -= total
+		}
+		return picked
+	case PoolLeastInFlight:
+		for i := 0; i < n; i++ {
+			m := p.members[(p.next+i)%n]
+			if p.available(m, now, tried) && (picked == nil || m.inFlight < picked.inFlight) {
+				picked = m
+			}
+		}
+	default:
+		for i := 0; i < n; i++ {
+			m := p.members[(p.next+i)%n]
+			if p.available(m, now, tried) {
+				picked = m
+				break
+			}
+		}
+	}
+	if picked != nil {
+		for i, m := range p.members {
+			if m == picked {
+				p.next = (i + 1) % n
+			}
+		}
+	}
+	return picked
+}
+
+func (p *Pool) available(m *poolMember, now time.Time, tried map[*poolMember]bool) bool {
+	return !tried[m] && !now.Before(m.ejectedUntil)
+}
+
+func (p *Pool) hasCandidate(tried map[*poolMember]bool) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	for _, m := range p.members {
+		if p.available(m, now, tried) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Pool) unavailableError(now time.Time) error {
+	var soonest time.Time
+	var last error
+	for _, m := range p.members {
+		if m.ejectedUntil.After(now) && (soonest.IsZero() || m.ejectedUntil.Before(soonest)) {
+			soonest = m.ejectedUntil
+			last = m.lastErr
+		}
+	}
+	err := NewProviderError(p.name, ErrorTypeOverloaded, "all pool members are unavailable")
+	if !soonest.IsZero() {
+		err.RetryAfter = int((soonest.Sub(now) + time.Second - 1) / time.Second)
+	}
+	err.Cause = last
+	return err
+}
+
+// release ends an in-flight call and records its outcome, reporting whether
+// the member was ejected.
+func (p *Pool) release(member *poolMember, err error) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	member.inFlight--
+	if err == nil {
+		member.lastErr = nil
+		return false
+	}
+	if !shouldEject(err) {
+		return false
+	}
+	member.failures++
+	member.lastErr = err
+	cooldown := p.cooldown
+	if retryAfter := GetRetryAfter(err); retryAfter > 0 {
+		cooldown = time.Duration(retryAfter) * time.Second
+	}
+	member.ejectedUntil = p.now().Add(cooldown)
+	return true
+}
+
+func shouldEject(err error) bool {
+	return IsAuthError(err) || IsQuotaError(err) || IsRateLimitError(err) || IsOverloadedError(err)
+}
+
+// pooledStream keeps its member in flight until the stream ends and feeds
+// mid-stream failures back into the member's health.
+type pooledStream struct {
+	pool   *Pool
+	member *poolMember
+	inner  Stream
+	once   sync.Once
+}
+
+func (s *pooledStream) Next() (Event, error) {
+	event, err := s.inner.Next()
+	if errEvent, ok := event.(ErrorEvent); ok && err == nil {
+		s.finish(errEvent.Err)
+	} else if err != nil {
+		if errors.Is(err, io.EOF) {
+			s.finish(nil)
+		} else {
+			s.finish(err)
+		}
+	} else if _, ok := event.(DoneEvent); ok {
+		s.finish(nil)
+	}
+	return event, err
+}
+
+func (s *pooledStream) ServedBy() (string, string) {
+	return streamServedBy(s.inner)
+}
+
+func (s *pooledStream) Close() error {
+	s.finish(nil)
+	return s.inner.Close()
+}
+
+func (s *pooledStream) finish(err error) {
+	s.once.Do(func() {
+		s.pool.release(s.member, err)
+	})
+}
@@ -0,0 +1,153 @@
+package litellm
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPoolRoundRobinSpreadsCalls(t *testing.T) {
+	a := &testProvider{name: "openai"}
+	b := &testProvider{name: "openai"}
+	pool, err := NewPool([]PoolMember{{Name: "a", Provider: a}, {Name: "b", Provider: b}})
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	for i := 0; i < 4; i++ {
+		if _, err := pool.Chat(context.Background(), &Request{Model: "m"}); err != nil {
+			t.Fatalf("Chat returned error: %v", err)
+		}
+	}
+	states := pool.Members()
+	if states[0].Requests != 2 || states[1].Requests != 2 {
+		t.Fatalf("states = %+v", states)
+	}
+	if pool.Name() != "openai" {
+		t.Fatalf("name = %q", pool.Name())
+	}
+}
+
+func TestPoolWeightedFollowsWeights(t *testing.T) {
+	pool, err := NewPool([]PoolMember{
+		{Name: "heavy", Provider: &testProvider{name: "p"}, Weight: 3},
+		{Name: "light", Provider: &testProvider{name: "p"}},
+	}, WithPoolStrategy(PoolWeighted))
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	for i := 0; i < 8; i++ {
+		if _, err := pool.Chat(context.Background(), &Request{Model: "m"}); err != nil {
+			t.Fatalf("Chat returned error: %v", err)
+		}
+	}
+	states := pool.Members()
+	if states[0].Requests != 6 || states[1].Requests != 2 {
+		t.Fatalf("states = %+v", states)
+	}
+}
+
+func TestPoolLeastInFlightAvoidsBusyMember(t *testing.T) {
+	busy := &testProvider{name: "p", streamFunc: func(context.Context, *Request) (Stream, error) {
+		return &testStream{events: []Event{ContentDelta{Text: "x"}}}, nil
+	}}
+	idle := &testProvider{name: "p"}
+	pool, err := NewPool([]PoolMember{{Name: "busy", Provider: busy}, {Name: "idle", Provider: idle}}, WithPoolStrategy(PoolLeastInFlight))
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	stream, err := pool.Stream(context.Background(), &Request{Model: "m"})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := pool.Chat(context.Background(), &Request{Model: "m"}); err != nil {
+			t.Fatalf("Chat returned error: %v", err)
+		}
+	}
+	states := pool.Members()
+	if states[0].InFlight != 1 || states[0].Requests != 1 || states[1].Requests != 2 {
+		t.Fatalf("states = %+v", states)
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if states := pool.Members(); states[0].InFlight != 0 {
+		t.Fatalf("in flight after close = %d", states[0].InFlight)
+	}
+}
+
+func TestPoolEjectsFailingMemberUsingRetryAfter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limited := &testProvider{name: "p", chatFunc: func(context.Context, *Request) (*Response, error) {
+		return nil, NewRateLimitError("p", "slow down", 10)
+	}}
+	healthy := &testProvider{name: "p"}
+	pool, err := NewPool([]PoolMember{{Name: "limited", Provider: limited}, {Name: "healthy", Provider: healthy}})
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	pool.now = func() time.Time { return now }
+
+	if _, err := pool.Chat(context.Background(), &Request{Model: "m"}); err != nil {
+		t.Fatalf("Chat should fail over to the healthy member: %v", err)
+	}
+	states := pool.Members()
+	if states[0].Healthy || !states[0].EjectedUntil.Equal(now.Add(10*time.Second)) || !IsRateLimitError(states[0].LastError) {
+		t.Fatalf("limited state = %+v", states[0])
+	}
+	limited.lastReq = nil
+	if _, err := pool.Chat(context.Background(), &Request{Model: "m"}); err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	if limited.lastReq != nil {
+		t.Fatal("ejected member should be skipped")
+	}
+
+	now = now.Add(11 * time.Second)
+	if states := pool.Members(); !states[0].Healthy {
+		t.Fatalf("member should recover after cooldown: %+v", states[0])
+	}
+}
+
+func TestPoolReportsRetryAfterWhenAllMembersEjected(t *testing.T) {
+	now := time.Unix(1000, 0)
+	auth := &testProvider{name: "p", chatFunc: func(context.Context, *Request) (*Response, error) {
+		return nil, NewHTTPError("p", 401, "bad key")
+	}}
+	pool, err := NewPool([]PoolMember{{Provider: auth}}, WithPoolCooldown(time.Minute))
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	pool.now = func() time.Time { return now }
+	if _, err := pool.Chat(context.Background(), &Request{Model: "m"}); !IsAuthError(err) {
+		t.Fatalf("expected auth error, got %v", err)
+	}
+	_, err = pool.Chat(context.Background(), &Request{Model: "m"})
+	if !IsOverloadedError(err) || GetRetryAfter(err) != 60 {
+		t.Fatalf("expected overloaded error with retry after, got %v (%d)", err, GetRetryAfter(err))
+	}
+}
+
+func TestPoolDoesNotEjectOnValidationError(t *testing.T) {
+	bad := &testProvider{name: "p", chatFunc: func(context.Context, *Request) (*Response, error) {
+		return nil, NewProviderError("p", ErrorTypeValidation, "bad request")
+	}}
+	other := &testProvider{name: "p"}
+	pool, err := NewPool([]PoolMember{{Provider: bad}, {Provider: other}})
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	if _, err := pool.Chat(context.Background(), &Request{Model: "m"}); !IsValidationError(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if other.lastReq != nil || !pool.Members()[0].Healthy {
+		t.Fatalf("validation errors should not fail over or eject: %+v", pool.Members())
+	}
+}
+
+func TestNewPoolRejectsNilFirstProvider(t *testing.T) {
+	_, err := NewPool([]PoolMember{{}, {Provider: &testProvider{name: "p"}}})
+	if err == nil || err.Error() != "pool member 0 provider cannot be nil" {
+		t.Fatalf("expected nil provider error, got %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package anthropic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/voocel/litellm"
+)
+
+type batchRequestItem struct {
+	CustomID string            `json:"custom_id"`
+	Params   *anthropicRequest `json:"params"`
+}
+
+type messageBatch struct {
+	ID                string     `json:"id"`
+	ProcessingStatus  string     `json:"processing_status"`
+	CreatedAt         time.Time  `json:"created_at"`
+	EndedAt           *time.Time `json:"ended_at"`
+	ExpiresAt         time.Time  `json:"expires_at"`
+	CancelInitiatedAt *time.Time `json:"cancel_initiated_at"`
+	ResultsURL        string     `json:"results_url"`
+	RequestCounts     struct {
+		Processing int `json:"processing"`
+		Succeeded  int `json:"succeeded"`
+		Errored    int `json:"errored"`
+		Canceled   int `json:"canceled"`
+		Expired    int `json:"expired"`
+	} `json:"request_counts"`
+}
+
+type messageBatchList struct {
+	Data []messageBatch `json:"data"`
+}
+
+type batchResultLine struct {
+	CustomID string `json:"custom_id"`
+	Result   struct {
+		Type    string             `json:"type"`
+		Message *anthropicResponse `json:"message"`
+		Error   json.RawMessage    `json:"error"`
+	} `json:"result"`
+}
+
+// batchErrorStatus maps Anthropic error types to the HTTP status the
+// synchronous API would have returned, so per-item errors classify alike.
+var batchErrorStatus = map[string]int{
+	"invalid_request_error": http.StatusBadRequest,
+	"authentication_error":  http.StatusUnauthorized,
+	"billing_error":         http.StatusPaymentRequired,
+	"permission_error":      http.StatusForbidden,
+	"not_found_error":       http.StatusNotFound,
+	"request_too_large":     http.StatusRequestEntityTooLarge,
+	"rate_limit_error":      http.StatusTooManyRequests,
+	"api_error":             http.StatusInternalServerError,
+	"overloaded_error":      529,
+}
+
+// CreateBatch calls /v1/messages/batches.
+func (p *Provider) CreateBatch(ctx context.Context, req *litellm.BatchRequest) (*litellm.Batch, error) {
+	items := make([]batchRequestItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		wire, _, err := p.buildRequest(&item.Request, false)
+		if err != nil {
+			return nil, litellm.WrapValidationError(p.Name(), fmt.Errorf("batch item %q: %w", item.CustomID, err))
+		}
+		items = append(items, batchRequestItem{CustomID: item.CustomID, Params: wire})
+	}
+	var batch messageBatch
+	if err := p.batchCall(ctx, http.MethodPost, "/v1/messages/batches", map[string]any{"requests": items}, &batch); err != nil {
+		return nil, err
+	}
+	return p.convertBatch(batch), nil
+}
+
+func (p *Provider) GetBatch(ctx context.Context, id string) (*litellm.Batch, error) {
+	batch, err := p.getBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return p.convertBatch(*batch), nil
+}
+
+func (p *Provider) ListBatches(ctx context.Context, limit int) ([]litellm.Batch, error) {
+	path := "/v1/messages/batches"
+	if limit > 0 {
+		path += "?limit=" + strconv.Itoa(limit)
+	}
+	var list messageBatchList
+	if err := p.batchCall(ctx, http.MethodGet, path, nil, &list); err != nil {
+		return nil, err
+	}
+	out := make([]litellm.Batch, 0, len(list.Data))
+	for _, batch := range list.Data {
+		out = append(out, *p.convertBatch(batch))
+	}
+	return out, nil
+}
+
+func (p *Provider) CancelBatch(ctx context.Context, id string) (*litellm.Batch, error) {
+	var batch messageBatch
+	if err := p.batchCall(ctx, http.MethodPost, "/v1/messages/batches/"+url.PathEscape(id)+"/cancel", nil, &batch); err != nil {
+		return nil, err
+	}
+	return p.convertBatch(batch), nil
+}
+
+// BatchResults downloads the batch's results_url. Canceled and expired items
+// are returned as errors.
+func (p *Provider) BatchResults(ctx context.Context, id string) ([]litellm.BatchResult, error) {
+	batch, err := p.getBatch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if batch.ResultsURL == "" {
+		return nil, litellm.NewValidationError(p.Name(), fmt.Sprintf("anthropic: batch %s has no results (status %s)", id, batch.ProcessingStatus))
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, batch.ResultsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("anthropic: create batch results request: %w", err)
+	}
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "batch results request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	var results []litellm.BatchResult
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var line batchResultLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "anthropic: decode batch result", err)
+		}
+		results = append(results, p.convertBatchResult(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "read batch results failed", err)
+	}
+	return results, nil
+}
+
+func (p *Provider) convertBatchResult(line batchResultLine) litellm.BatchResult {
+	result := litellm.BatchResult{CustomID: line.CustomID}
+	switch line.Result.Type {
+	case "succeeded":
+		resp, err := convertResponse(line.Result.Message, "")
+		if err != nil {
+			result.Err = litellm.WrapError(err, p.Name())
+			break
+		}
+		result.Response = resp
+	case "errored":
+		var payload struct {
+			Error struct {
+				Type string `json:"type"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(line.Result.Error, &payload)
+		status, ok := batchErrorStatus[payload.Error.Type]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+		result.Err = litellm.NewHTTPError(p.Name(), status, string(line.Result.Error))
+	case "canceled":
+		result.Err = litellm.NewProviderError(p.Name(), litellm.ErrorTypeProvider, "anthropic: batch request was canceled before processing")
+	case "expired":
+		result.Err = litellm.NewProviderError(p.Name(), litellm.ErrorTypeTimeout, "anthropic: batch request expired before processing")
+	default:
+		result.Err = litellm.NewProviderError(p.Name(), litellm.ErrorTypeProvider, fmt.Sprintf("anthropic: unknown batch result type %q", line.Result.Type))
+	}
+	return result
+}
+
+func (p *Provider) convertBatch(batch messageBatch) *litellm.Batch {
+	counts := batch.RequestCounts
+	out := &litellm.Batch{
+		ID:       batch.ID,
+		Provider: p.Name(),
+		Counts: litellm.BatchCounts{
+			Total:     counts.Processing + counts.Succeeded + counts.Errored + counts.Canceled + counts.Expired,
+			Succeeded: counts.Succeeded,
+			Failed:    counts.Errored + counts.Canceled + counts.Expired,
+		},
+		CreatedAt: batch.CreatedAt,
+		ExpiresAt: batch.ExpiresAt,
+	}
+	if batch.EndedAt != nil {
+		out.EndedAt = *batch.EndedAt
+	}
+	switch batch.ProcessingStatus {
+	case "in_progress":
+		out.Status = litellm.BatchStatusInProgress
+	case "canceling":
+		out.Status = litellm.BatchStatusCancelling
+	case "ended":
+		switch {
+		case batch.CancelInitiatedAt != nil:
+			out.Status = litellm.BatchStatusCancelled
+		case counts.Expired > 0 && counts.Succeeded+counts.Errored == 0:
+			out.Status = litellm.BatchStatusExpired
+		default:
+			out.Status = litellm.BatchStatusCompleted
+		}
+	default:
+		out.Status = litellm.BatchStatus(batch.ProcessingStatus)
+	}
+	return out
+}
+
+func (p *Provider) getBatch(ctx context.Context, id string) (*messageBatch, error) {
+	var batch messageBatch
+	if err := p.batchCall(ctx, http.MethodGet, "/v1/messages/batches/"+url.PathEscape(id), nil, &batch); err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func (p *Provider) batchCall(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("anthropic: marshal batch request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(p.cfg.BaseURL, "/")+path, body)
+	if err != nil {
+		return fmt.Errorf("anthropic: create batch request: %w", err)
+	}
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return litellm.WrapValidationError(p.Name(), err)
+	}
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return litellm.NewNetworkError(p.Name(), "batch request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "anthropic: decode batch response", err)
+	}
+	return nil
+}
@@ -22,6 +22,8 @@ func (p *Provider) Capabilities(model string) litellm.Capabilities {
 			Choice:              litellm.SupportPartial,
 			MultimodalResults:   litellm.SupportYes,
 			RoundTripSignatures: litellm.SupportYes,
+			HostedProviderTools: litellm.SupportYes,
+			WebSearch:           litellm.SupportYes,
 		},
 		Structured: litellm.StructuredCapabilities{
 			JSONObject: litellm.SupportNo,
@@ -29,9 +31,13 @@ func (p *Provider) Capabilities(model string) litellm.Capabilities {
 			Strict:     litellm.SupportYes,
 		},
 		Media: litellm.MediaCapabilities{
-			ImageURL:   litellm.SupportYes,
-			ImageBytes: litellm.SupportYes,
-			FileURI:    litellm.SupportNo,
+			ImageURL:          litellm.SupportYes,
+			ImageBytes:        litellm.SupportYes,
+			FileURI:           litellm.SupportYes,
+			DocumentURL:       litellm.SupportYes,
+			DocumentBytes:     litellm.SupportPartial,
+			DocumentFileURI:   litellm.SupportYes,
+			DocumentCitations: litellm.SupportYes,
 		},
 		Cache: litellm.CacheCapabilities{
 			Block:      litellm.SupportYes,
@@ -0,0 +1,91 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/voocel/litellm"
+)
+
+// anthropicCitation is one entry of a text block's citations array or a
+// citations_delta. Anthropic splits cited text into its own text blocks, so a
+// citation covers its whole block.
+type anthropicCitation struct {
+	Type              string `json:"type"`
+	CitedText         string `json:"cited_text"`
+	DocumentIndex     int    `json:"document_index"`
+	DocumentTitle     string `json:"document_title"`
+	StartCharIndex    int    `json:"start_char_index"`
+	EndCharIndex      int    `json:"end_char_index"`
+	StartPageNumber   int    `json:"start_page_number"`
+	EndPageNumber     int    `json:"end_page_number"`
+	StartBlockIndex   int    `json:"start_block_index"`
+	EndBlockIndex     int    `json:"end_block_index"`
+	SearchResultIndex int    `json:"search_result_index"`
+	Source            string `json:"source"`
+	Title             string `json:"title"`
+	URL               string `json:"url"`
+}
+
+func convertCitations(raw json.RawMessage, text string) ([]litellm.Annotation, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var entries []json.RawMessage
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, fmt.Errorf("anthropic: decode citations: %w", err)
+	}
+	out := make([]litellm.Annotation, 0, len(entries))
+	for _, entry := range entries {
+		annotation, err := convertCitation(entry, len(text))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, annotation)
+	}
+	return out, nil
+}
+
+// convertCitation maps a citation onto the first end bytes of its text block.
+// Document and search result locations keep Anthropic's units: characters,
+// 1-based pages and content blocks, each with an exclusive end.
+func convertCitation(raw json.RawMessage, end int) (litellm.Annotation, error) {
+	var citation anthropicCitation
+	if err := json.Unmarshal(raw, &citation); err != nil {
+		return litellm.Annotation{}, fmt.Errorf("anthropic: decode citation: %w", err)
+	}
+	annotation := litellm.Annotation{
+		Type:  citation.Type,
+		End:   end,
+		Text:  citation.CitedText,
+		Title: citation.DocumentTitle,
+		Extra: append(json.RawMessage(nil), raw...),
+	}
+	document := func(unit litellm.CitationUnit, start, end int) {
+		annotation.Type = litellm.AnnotationDocumentCitation
+		annotation.Location = &litellm.CitationLocation{Index: citation.DocumentIndex, Unit: unit, Start: start, End: end}
+	}
+	switch citation.Type {
+	case "char_location":
+		document(litellm.CitationUnitChar, citation.StartCharIndex, citation.EndCharIndex)
+	case "page_location":
+		document(litellm.CitationUnitPage, citation.StartPageNumber, citation.EndPageNumber)
+	case "content_block_location":
+		document(litellm.CitationUnitBlock, citation.StartBlockIndex, citation.EndBlockIndex)
+	case "web_search_result_location":
+		annotation.Type = litellm.AnnotationURLCitation
+		annotation.URL = citation.URL
+		annotation.Title = citation.Title
+	case "search_result_location":
+		annotation.Type = litellm.AnnotationSearchResultCitation
+		annotation.URL = citation.Source
+		annotation.Title = citation.Title
+		annotation.Location = &litellm.CitationLocation{
+			Index: citation.SearchResultIndex,
+			Unit:  litellm.CitationUnitBlock,
+			Start: citation.StartBlockIndex,
+			End:   citation.EndBlockIndex,
+		}
+	}
+	return annotation, nil
+}
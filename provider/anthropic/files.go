@@ -0,0 +1,185 @@
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/voocel/litellm"
+)
+
+// filesBeta is the beta header the Files API and file references in
+// messages need.
+const filesBeta = "files-api-2025-04-14"
+
+type fileMetadata struct {
+	ID        string    `json:"id"`
+	Filename  string    `json:"filename"`
+	MIMEType  string    `json:"mime_type"`
+	SizeBytes int64     `json:"size_bytes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type fileList struct {
+	Data []fileMetadata `json:"data"`
+}
+
+// UploadFile calls /v1/files.
+func (p *Provider) UploadFile(ctx context.Context, req *litellm.FileUpload) (*litellm.File, error) {
+	name := req.Name
+	if name == "" {
+		name = "file"
+	}
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, name))
+	if req.MIME != "" {
+		header.Set("Content-Type", req.MIME)
+	} else {
+		header.Set("Content-Type", "application/octet-stream")
+	}
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("anthropic: write file upload: %w", err)
+	}
+	if _, err := part.Write(req.Data); err != nil {
+		return nil, fmt.Errorf("anthropic: write file upload: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("anthropic: write file upload: %w", err)
+	}
+	httpReq, err := p.newFileRequest(ctx, http.MethodPost, "/v1/files", &body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", form.FormDataContentType())
+	var file fileMetadata
+	if err := p.doFileRequest(httpReq, &file); err != nil {
+		return nil, err
+	}
+	return p.convertFile(file), nil
+}
+
+func (p *Provider) GetFile(ctx context.Context, id string) (*litellm.File, error) {
+	httpReq, err := p.newFileRequest(ctx, http.MethodGet, "/v1/files/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	var file fileMetadata
+	if err := p.doFileRequest(httpReq, &file); err != nil {
+		return nil, err
+	}
+	return p.convertFile(file), nil
+}
+
+func (p *Provider) ListFiles(ctx context.Context, limit int) ([]litellm.File, error) {
+	path := "/v1/files"
+	if limit > 0 {
+		path += "?limit=" + strconv.Itoa(limit)
+	}
+	httpReq, err := p.newFileRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var list fileList
+	if err := p.doFileRequest(httpReq, &list); err != nil {
+		return nil, err
+	}
+	out := make([]litellm.File, 0, len(list.Data))
+	for _, file := range list.Data {
+		out = append(out, *p.convertFile(file))
+	}
+	return out, nil
+}
+
+func (p *Provider) DeleteFile(ctx context.Context, id string) error {
+	httpReq, err := p.newFileRequest(ctx, http.MethodDelete, "/v1/files/"+url.PathEscape(id), nil)
+	if err != nil {
+		return err
+	}
+	var deleted struct {
+		ID string `json:"id"`
+	}
+	return p.doFileRequest(httpReq, &deleted)
+}
+
+func (p *Provider) convertFile(file fileMetadata) *litellm.File {
+	return &litellm.File{
+		ID:        file.ID,
+		Provider:  p.Name(),
+		URI:       file.ID,
+		Name:      file.Filename,
+		MIME:      file.MIMEType,
+		Size:      file.SizeBytes,
+		State:     litellm.FileStateActive,
+		CreatedAt: file.CreatedAt,
+	}
+}
+
+// fileSource references an uploaded file, rejecting files issued by another
+// provider.
+func fileSource(uri string) (*anthropicImageSource, error) {
+	if owner := litellm.FileOwner(uri); owner != "" && owner != "anthropic" {
+		return nil, fmt.Errorf("anthropic: file %q belongs to %s", uri, owner)
+	}
+	return &anthropicImageSource{Type: "file", FileID: uri}, nil
+}
+
+// setFileBeta adds the Files API beta header when a message references an
+// uploaded file.
+func setFileBeta(httpReq *http.Request, messages []litellm.Message) {
+	for _, msg := range messages {
+		for _, block := range msg.Blocks {
+			switch b := block.(type) {
+			case litellm.ImageBlock:
+				if b.FileURI != "" {
+					addBeta(httpReq, filesBeta)
+					return
+				}
+			case litellm.DocumentBlock:
+				if b.FileURI != "" {
+					addBeta(httpReq, filesBeta)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (p *Provider) newFileRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(p.cfg.BaseURL, "/")+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("anthropic: create file request: %w", err)
+	}
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	addBeta(httpReq, filesBeta)
+	return httpReq, nil
+}
+
+func (p *Provider) doFileRequest(httpReq *http.Request, out any) error {
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return litellm.NewNetworkError(p.Name(), "file request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "anthropic: decode file response", err)
+	}
+	return nil
+}
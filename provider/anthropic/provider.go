@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/voocel/litellm"
 	"github.com/voocel/litellm/retry"
@@ -93,6 +94,8 @@ func (p *Provider) Chat(ctx context.Context, req *litellm.Request) (*litellm.Res
 	if err := p.setHeaders(ctx, httpReq); err != nil {
 		return nil, litellm.WrapValidationError(p.Name(), err)
 	}
+	setToolBetas(httpReq, req.Tools)
+	setFileBeta(httpReq, req.Messages)
 	resp, err := p.cfg.HTTPClient.Do(httpReq)
 	if err != nil {
 		return nil, litellm.NewNetworkError(p.Name(), "request failed", err)
@@ -135,6 +138,8 @@ func (p *Provider) Stream(ctx context.Context, req *litellm.Request) (litellm.St
 	if err := p.setHeaders(ctx, httpReq); err != nil {
 		return nil, litellm.WrapValidationError(p.Name(), err)
 	}
+	setToolBetas(httpReq, req.Tools)
+	setFileBeta(httpReq, req.Messages)
 	httpReq.Header.Set("Accept", "text/event-stream")
 	resp, err := p.cfg.HTTPClient.Do(httpReq)
 	if err != nil {
@@ -214,7 +219,35 @@ func imageSource(block litellm.ImageBlock) (*anthropicImageSource, error) {
 			MediaType: block.MIME,
 			Data:      base64.StdEncoding.EncodeToString(block.Data),
 		}, nil
+	case block.FileURI != "":
+		return fileSource(block.FileURI)
 	default:
-		return nil, fmt.Errorf("anthropic: image requires URL or data")
+		return nil, fmt.Errorf("anthropic: image requires URL, data or file URI")
+	}
+}
+
+func documentSource(block litellm.DocumentBlock) (*anthropicImageSource, error) {
+	switch {
+	case block.URL != "":
+		return &anthropicImageSource{Type: "url", URL: block.URL}, nil
+	case len(block.Data) > 0:
+		if block.MIME == "text/plain" {
+			if !utf8.Valid(block.Data) {
+				return nil, fmt.Errorf("anthropic: text document data must be valid UTF-8")
+			}
+			return &anthropicImageSource{Type: "text", MediaType: block.MIME, Data: string(block.Data)}, nil
+		}
+		if block.MIME != "application/pdf" {
+			return nil, fmt.Errorf("anthropic: unsupported document MIME %q; use application/pdf or text/plain", block.MIME)
+		}
+		return &anthropicImageSource{
+			Type:      "base64",
+			MediaType: block.MIME,
+			Data:      base64.StdEncoding.EncodeToString(block.Data),
+		}, nil
+	case block.FileURI != "":
+		return fileSource(block.FileURI)
+	default:
+		return nil, fmt.Errorf("anthropic: document requires URL or data")
 	}
 }
@@ -43,6 +43,8 @@ type anthropicContent struct {
 	Content      any                    `json:"content,omitempty"`
 	ToolName     string                 `json:"tool_name,omitempty"`
 	IsError      bool                   `json:"is_error,omitempty"`
+	Title        string                 `json:"title,omitempty"`
+	Citations    json.RawMessage        `json:"citations,omitempty"`
 	CacheControl *anthropicCacheControl `json:"cache_control,omitempty"`
 }
 
@@ -51,6 +53,7 @@ type anthropicImageSource struct {
 	MediaType string `json:"media_type,omitempty"`
 	Data      string `json:"data,omitempty"`
 	URL       string `json:"url,omitempty"`
+	FileID    string `json:"file_id,omitempty"`
 }
 
 type anthropicCacheControl struct {
@@ -63,6 +66,17 @@ type anthropicTool struct {
 	Description string         `json:"description"`
 	InputSchema map[string]any `json:"input_schema"`
 	Strict      *bool          `json:"strict,omitempty"`
+	// Raw is the wire form of a server tool, sent in place of the fields
+	// above.
+	Raw map[string]any `json:"-"`
+}
+
+func (t anthropicTool) MarshalJSON() ([]byte, error) {
+	if t.Raw != nil {
+		return json.Marshal(t.Raw)
+	}
+	type plain anthropicTool
+	return json.Marshal(plain(t))
 }
 
 type anthropicThinking struct {
@@ -179,6 +193,18 @@ func (p *Provider) buildRequest(req *litellm.Request, stream bool) (*anthropicRe
 			out.Tools = append(out.Tools, converted)
 		}
 	}
+	if req.WebSearch != nil {
+		for _, tool := range req.Tools {
+			if tool.Server != nil && tool.Server.Type == WebSearchToolType {
+				return nil, nil, fmt.Errorf("anthropic: WebSearch cannot be combined with a web_search server tool")
+			}
+		}
+		converted, err := convertTool(webSearchTool(req.WebSearch))
+		if err != nil {
+			return nil, nil, err
+		}
+		out.Tools = append(out.Tools, converted)
+	}
 	system, messages, err := convertMessages(req.Messages)
 	if err != nil {
 		return nil, nil, err
@@ -431,6 +457,9 @@ func convertTool(tool litellm.Tool) (anthropicTool, error) {
 	if tool.Name == "" {
 		return anthropicTool{}, fmt.Errorf("anthropic: tool name is required")
 	}
+	if tool.Server != nil {
+		return convertServerTool(tool)
+	}
 	var schema map[string]any
 	if len(tool.Parameters) == 0 {
 		schema = map[string]any{"type": "object"}
@@ -565,6 +594,20 @@ func convertBlocks(blocks []litellm.Block) ([]anthropicContent, error) {
 				return nil, err
 			}
 			out = append(out, anthropicContent{Type: "image", Source: source, CacheControl: cache})
+		case litellm.DocumentBlock:
+			source, err := documentSource(b)
+			if err != nil {
+				return nil, err
+			}
+			cache, err := cacheControl(b.Cache)
+			if err != nil {
+				return nil, err
+			}
+			content := anthropicContent{Type: "document", Source: source, Title: b.Title, CacheControl: cache}
+			if b.Citations {
+				content.Citations = json.RawMessage(`{"enabled":true}`)
+			}
+			out = append(out, content)
 		case litellm.ReasoningBlock:
 			cache, err := cacheControl(b.Cache)
 			if err != nil {
@@ -606,6 +649,27 @@ func convertBlocks(blocks []litellm.Block) ([]anthropicContent, error) {
 				return nil, err
 			}
 			out = append(out, anthropicContent{Type: "tool_reference", ToolName: b.ToolName, CacheControl: cache})
+		case litellm.ServerToolUseBlock:
+			input := map[string]any{}
+			if len(b.Arguments) > 0 {
+				if err := json.Unmarshal(b.Arguments, &input); err != nil || input == nil {
+					return nil, fmt.Errorf("anthropic: server tool use %q arguments must be object", b.ID)
+				}
+			}
+			cache, err := cacheControl(b.Cache)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, anthropicContent{Type: "server_tool_use", ID: b.ID, Name: b.Name, Input: &input, CacheControl: cache})
+		case litellm.ServerToolResultBlock:
+			if !isServerToolResult(b.Type) {
+				return nil, fmt.Errorf("anthropic: unsupported server tool result type %q", b.Type)
+			}
+			cache, err := cacheControl(b.Cache)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, anthropicContent{Type: b.Type, ToolUseID: b.ToolUseID, Content: json.RawMessage(b.Content), CacheControl: cache})
 		default:
 			return nil, fmt.Errorf("anthropic: unsupported block %T", block)
 		}
@@ -133,6 +133,87 @@ func TestBuildRequestUsesRedactedThinkingData(t *testing.T) {
 	}
 }
 
+func TestBuildRequestEncodesDocumentBlocks(t *testing.T) {
+	provider, err := New(Config{APIKey: "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	maxTokens := 1024
+	wire, _, err := provider.buildRequest(&litellm.Request{
+		Model:     "claude",
+		MaxTokens: &maxTokens,
+		Messages: []litellm.Message{
+			litellm.User(
+				litellm.DocumentBlock{Data: []byte("%PDF"), MIME: "application/pdf", Title: "Report", Citations: true, Cache: &litellm.CacheControl{}},
+				litellm.DocumentData([]byte("plain notes"), "text/plain"),
+				litellm.DocumentURL("https://example.test/a.pdf"),
+				litellm.Text("summarize"),
+			),
+		},
+	}, false)
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	data, err := json.Marshal(wire)
+	if err != nil {
+		t.Fatalf("marshal wire: %v", err)
+	}
+	jsonText := string(data)
+	for _, want := range []string{
+		`{"type":"document","source":{"type":"base64","media_type":"application/pdf","data":"JVBERg=="},"title":"Report","citations":{"enabled":true},"cache_control":{"type":"ephemeral"}}`,
+		`{"type":"document","source":{"type":"text","media_type":"text/plain","data":"plain notes"}}`,
+		`{"type":"document","source":{"type":"url","url":"https://example.test/a.pdf"}}`,
+	} {
+		if !strings.Contains(jsonText, want) {
+			t.Fatalf("request missing %s:\n%s", want, jsonText)
+		}
+	}
+
+	_, _, err = provider.buildRequest(&litellm.Request{
+		Model:     "claude",
+		MaxTokens: &maxTokens,
+		Messages:  []litellm.Message{litellm.User(litellm.DocumentData([]byte("x"), "application/msword"))},
+	}, false)
+	if err == nil || !strings.Contains(err.Error(), "unsupported document MIME") {
+		t.Fatalf("expected MIME error, got %v", err)
+	}
+}
+
+func TestBuildRequestMapsWebSearch(t *testing.T) {
+	provider, err := New(Config{APIKey: "test"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	maxTokens := 1024
+	req := &litellm.Request{
+		Model:     "claude",
+		MaxTokens: &maxTokens,
+		Messages:  []litellm.Message{litellm.UserText("news?")},
+		WebSearch: &litellm.WebSearch{
+			MaxUses:        3,
+			AllowedDomains: []string{"go.dev"},
+			UserLocation:   &litellm.UserLocation{City: "Berlin", Country: "DE"},
+		},
+	}
+	wire, _, err := provider.buildRequest(req, false)
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	data, err := json.Marshal(wire.Tools)
+	if err != nil {
+		t.Fatalf("marshal tools: %v", err)
+	}
+	want := `[{"allowed_domains":["go.dev"],"max_uses":3,"name":"web_search","type":"web_search_20250305","user_location":{"city":"Berlin","country":"DE","type":"approximate"}}]`
+	if string(data) != want {
+		t.Fatalf("tools = %s, want %s", data, want)
+	}
+
+	req.Tools = []litellm.Tool{WebSearchTool(WebSearchOptions{})}
+	if _, _, err := provider.buildRequest(req, false); err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Fatalf("expected duplicate web search error, got %v", err)
+	}
+}
+
 func TestBuildRequestRejectsInvalidBlockCache(t *testing.T) {
 	provider, err := New(Config{APIKey: "test"})
 	if err != nil {
@@ -584,7 +665,7 @@ func TestConvertResponseDropsUnsupportedContentTypeWithWarning(t *testing.T) {
 	resp, err := convertResponse(&anthropicResponse{
 		Model: "claude",
 		Content: []anthropicContent{
-			{Type: "server_tool_use"},
+			{Type: "mcp_tool_use"},
 			{Type: "text", Text: "ok"},
 		},
 	}, "fallback")
@@ -702,6 +783,153 @@ func TestStreamMergesUsageFromMessageDelta(t *testing.T) {
 	}
 }
 
+func TestChatRoundTripsServerTools(t *testing.T) {
+	maxTokens := 1024
+	provider, err := New(Config{
+		APIKey: "test-key",
+		Beta:   "beta-name",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("anthropic-beta"); got != "beta-name,web-fetch-2025-09-10" {
+				t.Fatalf("anthropic-beta = %q", got)
+			}
+			var body map[string]any
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			tools := body["tools"].([]any)
+			fetch := tools[1].(map[string]any)
+			if tools[0].(map[string]any)["type"] != WebSearchToolType || fetch["type"] != WebFetchToolType || fetch["name"] != "web_fetch" || fetch["max_uses"] != 2.0 || fetch["input_schema"] != nil {
+				t.Fatalf("tools = %#v", tools)
+			}
+			content := body["messages"].([]any)[1].(map[string]any)["content"].([]any)
+			use, result := content[0].(map[string]any), content[1].(map[string]any)
+			if use["type"] != "server_tool_use" || use["input"].(map[string]any)["query"] != "go" {
+				t.Fatalf("server tool use = %#v", use)
+			}
+			if result["type"] != "web_search_tool_result" || result["tool_use_id"] != "srvtoolu_1" || len(result["content"].([]any)) != 1 {
+				t.Fatalf("server tool result = %#v", result)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`{"model":"claude","stop_reason":"pause_turn","usage":{"input_tokens":1,"output_tokens":1},"content":[` +
+					`{"type":"server_tool_use","id":"srvtoolu_2","name":"web_fetch","input":{"url":"https://go.dev"}},` +
+					`{"type":"web_fetch_tool_result","tool_use_id":"srvtoolu_2","content":{"type":"web_fetch_result","url":"https://go.dev"}}]}`)),
+			}, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.Chat(context.Background(), &litellm.Request{
+		Model:     "claude",
+		MaxTokens: &maxTokens,
+		Tools:     []litellm.Tool{WebSearchTool(WebSearchOptions{}), WebFetchTool(WebFetchOptions{MaxUses: 2})},
+		Messages: []litellm.Message{
+			litellm.UserText("search"),
+			litellm.Assistant(
+				litellm.ServerToolUseBlock{ID: "srvtoolu_1", Name: "web_search", Arguments: json.RawMessage(`{"query":"go"}`)},
+				litellm.ServerToolResultBlock{ToolUseID: "srvtoolu_1", Type: "web_search_tool_result", Content: json.RawMessage(`[{"type":"web_search_result","url":"https://go.dev"}]`)},
+			),
+			litellm.UserText("fetch it"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	if resp.FinishReason != litellm.FinishReasonPause || len(resp.Blocks) != 2 {
+		t.Fatalf("resp = %+v", resp)
+	}
+	call, ok := resp.Blocks[0].(litellm.ServerToolUseBlock)
+	if !ok || call.ID != "srvtoolu_2" || string(call.Arguments) != `{"url":"https://go.dev"}` {
+		t.Fatalf("call = %#v", resp.Blocks[0])
+	}
+	result, ok := resp.Blocks[1].(litellm.ServerToolResultBlock)
+	if !ok || result.Type != "web_fetch_tool_result" || result.ToolUseID != "srvtoolu_2" || !strings.Contains(string(result.Content), "web_fetch_result") {
+		t.Fatalf("result = %#v", resp.Blocks[1])
+	}
+}
+
+func TestStreamCollectsServerToolBlocks(t *testing.T) {
+	stream := newStream(streamResponse(strings.Join([]string{
+		`data: {"type":"content_block_start","index":0,"content_block":{"type":"server_tool_use","id":"srvtoolu_1","name":"code_execution","input":{}}}`,
+		`data: {"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":"{\"command\":"}}`,
+		`data: {"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":"\"ls\"}"}}`,
+		`data: {"type":"content_block_stop","index":0}`,
+		`data: {"type":"content_block_start","index":1,"content_block":{"type":"bash_code_execution_tool_result","tool_use_id":"srvtoolu_1","content":{"type":"bash_code_execution_result","stdout":"a.txt","return_code":0}}}`,
+		`data: {"type":"content_block_stop","index":1}`,
+		`data: {"type":"content_block_delta","index":2,"delta":{"type":"text_delta","text":"done"}}`,
+		`data: {"type":"message_stop"}`,
+	}, "\n")), &litellm.Request{Model: "claude"}, nil)
+	resp, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	if len(resp.Blocks) != 3 || resp.Text() != "done" {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	call, ok := resp.Blocks[0].(litellm.ServerToolUseBlock)
+	if !ok || call.Name != "code_execution" || string(call.Arguments) != `{"command":"ls"}` {
+		t.Fatalf("call = %#v", resp.Blocks[0])
+	}
+	result, ok := resp.Blocks[1].(litellm.ServerToolResultBlock)
+	if !ok || result.Type != "bash_code_execution_tool_result" || !strings.Contains(string(result.Content), "a.txt") {
+		t.Fatalf("result = %#v", resp.Blocks[1])
+	}
+}
+
+func TestConvertResponseMapsCitations(t *testing.T) {
+	var wire anthropicResponse
+	if err := json.Unmarshal([]byte(`{"model":"claude","content":[
+		{"type":"text","text":"The report says "},
+		{"type":"text","text":"revenue grew 8%","citations":[{"type":"page_location","cited_text":"Revenue grew 8%.","document_index":0,"document_title":"Report","start_page_number":2,"end_page_number":3}]},
+		{"type":"text","text":" and ","citations":[{"type":"web_search_result_location","cited_text":"Shares rose.","url":"https://example.com/news","title":"News","encrypted_index":"abc"}]}
+	]}`), &wire); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	resp, err := convertResponse(&wire, "fallback")
+	if err != nil {
+		t.Fatalf("convertResponse returned error: %v", err)
+	}
+	if len(resp.Blocks) != 3 || resp.Text() != "The report says revenue grew 8% and " {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	page := resp.Blocks[1].(litellm.TextBlock).Annotations
+	want := litellm.CitationLocation{Index: 0, Unit: litellm.CitationUnitPage, Start: 2, End: 3}
+	if len(page) != 1 || page[0].Type != litellm.AnnotationDocumentCitation || page[0].End != len("revenue grew 8%") || page[0].Text != "Revenue grew 8%." || page[0].Title != "Report" || page[0].Location == nil || *page[0].Location != want {
+		t.Fatalf("page citation = %+v", page)
+	}
+	web := resp.Blocks[2].(litellm.TextBlock).Annotations
+	if len(web) != 1 || web[0].Type != litellm.AnnotationURLCitation || web[0].URL != "https://example.com/news" || web[0].Title != "News" || !strings.Contains(string(web[0].Extra), "encrypted_index") {
+		t.Fatalf("web citation = %+v", web)
+	}
+}
+
+func TestStreamCollectsCitationsDelta(t *testing.T) {
+	stream := newStream(streamResponse(strings.Join([]string{
+		`data: {"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		`data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Per the docs, "}}`,
+		`data: {"type":"content_block_stop","index":0}`,
+		`data: {"type":"content_block_start","index":1,"content_block":{"type":"text","text":"","citations":[]}}`,
+		`data: {"type":"content_block_delta","index":1,"delta":{"type":"citations_delta","citation":{"type":"char_location","cited_text":"The sky is blue.","document_index":1,"start_char_index":0,"end_char_index":16}}}`,
+		`data: {"type":"content_block_delta","index":1,"delta":{"type":"text_delta","text":"the sky is blue"}}`,
+		`data: {"type":"content_block_stop","index":1}`,
+		`data: {"type":"message_stop"}`,
+	}, "\n")), &litellm.Request{Model: "claude"}, nil)
+	resp, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	block, ok := resp.Blocks[0].(litellm.TextBlock)
+	if len(resp.Blocks) != 1 || !ok || len(block.Annotations) != 1 {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	annotation := block.Annotations[0]
+	want := litellm.CitationLocation{Index: 1, Unit: litellm.CitationUnitChar, Start: 0, End: 16}
+	if block.Text[annotation.Start:annotation.End] != "the sky is blue" || annotation.Location == nil || *annotation.Location != want {
+		t.Fatalf("annotation = %+v", annotation)
+	}
+}
+
 func TestStreamRejectsEOFBeforeMessageStop(t *testing.T) {
 	stream := newStream(streamResponse(strings.Join([]string{
 		`event: content_block_delta`,
@@ -1093,3 +1321,166 @@ func mustTool(t *testing.T, name, description string, schema any) litellm.Tool {
 	}
 	return tool
 }
+
+func TestCountTokensPostsMessagesWithoutMaxTokens(t *testing.T) {
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/v1/messages/count_tokens" {
+				t.Fatalf("path = %s", req.URL.Path)
+			}
+			body, _ := io.ReadAll(req.Body)
+			var wire map[string]any
+			if err := json.Unmarshal(body, &wire); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if _, ok := wire["max_tokens"]; ok || wire["system"] == nil || wire["tools"] == nil {
+				t.Fatalf("body = %s", body)
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(`{"input_tokens":42}`))}, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	count, err := provider.CountTokens(context.Background(), &litellm.Request{
+		Model:    "claude-sonnet-4-5",
+		Messages: []litellm.Message{litellm.System("be brief"), litellm.UserText("hi")},
+		Tools:    []litellm.Tool{{Name: "lookup", Parameters: litellm.Schema(`{"type":"object"}`)}},
+	})
+	if err != nil {
+		t.Fatalf("CountTokens returned error: %v", err)
+	}
+	if count.InputTokens != 42 || count.Provider != "anthropic" {
+		t.Fatalf("count = %+v", count)
+	}
+}
+
+func TestMessageBatchesCreateAndResults(t *testing.T) {
+	respond := func(body string) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(body))}
+	}
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("x-api-key") != "test-key" {
+				t.Fatalf("missing api key on %s", req.URL.Path)
+			}
+			switch req.Method + " " + req.URL.Path {
+			case "POST /v1/messages/batches":
+				body, _ := io.ReadAll(req.Body)
+				var wire struct {
+					Requests []struct {
+						CustomID string         `json:"custom_id"`
+						Params   map[string]any `json:"params"`
+					} `json:"requests"`
+				}
+				if err := json.Unmarshal(body, &wire); err != nil || len(wire.Requests) != 1 || wire.Requests[0].CustomID != "a" || wire.Requests[0].Params["max_tokens"] != float64(64) {
+					t.Fatalf("create body = %s (%v)", body, err)
+				}
+				return respond(`{"id":"msgbatch_1","processing_status":"in_progress","created_at":"2026-01-02T03:04:05Z","expires_at":"2026-01-03T03:04:05Z","request_counts":{"processing":1}}`), nil
+			case "GET /v1/messages/batches/msgbatch_1":
+				return respond(`{"id":"msgbatch_1","processing_status":"ended","ended_at":"2026-01-02T04:00:00Z","results_url":"https://example.test/v1/messages/batches/msgbatch_1/results","request_counts":{"succeeded":1,"errored":1,"expired":1}}`), nil
+			case "GET /v1/messages/batches/msgbatch_1/results":
+				return respond(strings.Join([]string{
+					`{"custom_id":"a","result":{"type":"succeeded","message":{"model":"claude-sonnet-4-5","content":[{"type":"text","text":"hello"}],"stop_reason":"end_turn","usage":{"input_tokens":5,"output_tokens":1}}}}`,
+					`{"custom_id":"b","result":{"type":"errored","error":{"type":"error","error":{"type":"invalid_request_error","message":"max_tokens too large"}}}}`,
+					`{"custom_id":"c","result":{"type":"expired"}}`,
+				}, "\n")), nil
+			}
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+			return nil, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	batch, err := provider.CreateBatch(context.Background(), &litellm.BatchRequest{Items: []litellm.BatchItem{
+		{CustomID: "a", Request: litellm.Request{Model: "claude-sonnet-4-5", MaxTokens: litellm.IntPtr(64), Messages: []litellm.Message{litellm.UserText("hi")}}},
+	}})
+	if err != nil {
+		t.Fatalf("CreateBatch returned error: %v", err)
+	}
+	if batch.Status != litellm.BatchStatusInProgress || batch.Counts.Total != 1 || batch.CreatedAt.IsZero() || batch.Done() {
+		t.Fatalf("batch = %+v", batch)
+	}
+	got, err := provider.GetBatch(context.Background(), "msgbatch_1")
+	if err != nil || got.Status != litellm.BatchStatusCompleted || got.Counts.Failed != 2 || !got.Done() {
+		t.Fatalf("GetBatch = %+v, %v", got, err)
+	}
+	results, err := provider.BatchResults(context.Background(), "msgbatch_1")
+	if err != nil {
+		t.Fatalf("BatchResults returned error: %v", err)
+	}
+	if len(results) != 3 || results[0].Response.Text() != "hello" || results[0].Response.Model != "claude-sonnet-4-5" {
+		t.Fatalf("results = %+v", results)
+	}
+	if !litellm.IsValidationError(results[1].Err) || !strings.Contains(results[1].Err.Error(), "max_tokens too large") {
+		t.Fatalf("errored result = %v", results[1].Err)
+	}
+	if results[2].Err == nil || results[2].Response != nil {
+		t.Fatalf("expired result = %+v", results[2])
+	}
+}
+
+func TestFilesUploadAndReference(t *testing.T) {
+	respond := func(body string) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(strings.NewReader(body))}
+	}
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if !strings.Contains(req.Header.Get("anthropic-beta"), filesBeta) {
+				t.Fatalf("missing files beta on %s %s", req.Method, req.URL.Path)
+			}
+			switch req.Method + " " + req.URL.Path {
+			case "POST /v1/files":
+				if err := req.ParseMultipartForm(1 << 20); err != nil {
+					t.Fatalf("ParseMultipartForm: %v", err)
+				}
+				_, header, err := req.FormFile("file")
+				if err != nil || header.Filename != "chart.png" {
+					t.Fatalf("FormFile = %+v, %v", header, err)
+				}
+				return respond(`{"id":"file_011abc","type":"file","filename":"chart.png","mime_type":"image/png","size_bytes":3,"created_at":"2026-01-02T03:04:05Z"}`), nil
+			case "POST /v1/messages":
+				body, _ := io.ReadAll(req.Body)
+				if !strings.Contains(string(body), `"source":{"type":"file","file_id":"file_011abc"}`) {
+					t.Fatalf("messages body = %s", body)
+				}
+				return respond(`{"model":"claude","content":[{"type":"text","text":"a chart"}],"stop_reason":"end_turn","usage":{"input_tokens":5,"output_tokens":2}}`), nil
+			}
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+			return nil, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	file, err := provider.UploadFile(context.Background(), &litellm.FileUpload{Name: "chart.png", MIME: "image/png", Data: []byte("png")})
+	if err != nil {
+		t.Fatalf("UploadFile returned error: %v", err)
+	}
+	if file.URI != "file_011abc" || file.MIME != "image/png" || file.Size != 3 || file.CreatedAt.IsZero() {
+		t.Fatalf("file = %+v", file)
+	}
+	if _, err := provider.Chat(context.Background(), &litellm.Request{
+		Model:     "claude",
+		MaxTokens: litellm.IntPtr(64),
+		Messages:  []litellm.Message{litellm.User(file.Image(), litellm.Text("describe"))},
+	}); err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+
+	_, _, err = provider.buildRequest(&litellm.Request{
+		Model:     "claude",
+		MaxTokens: litellm.IntPtr(64),
+		Messages:  []litellm.Message{litellm.User(litellm.DocumentBlock{FileURI: "file-openai"})},
+	}, false)
+	if err == nil || !strings.Contains(err.Error(), "belongs to openai") {
+		t.Fatalf("expected foreign file error, got %v", err)
+	}
+}
@@ -45,7 +45,11 @@ func convertResponse(resp *anthropicResponse, fallbackModel string) (*litellm.Re
 	for _, content := range resp.Content {
 		switch content.Type {
 		case "text":
-			out.Blocks = append(out.Blocks, litellm.TextBlock{Text: content.Text})
+			annotations, err := convertCitations(content.Citations, content.Text)
+			if err != nil {
+				return nil, err
+			}
+			out.Blocks = append(out.Blocks, litellm.TextBlock{Text: content.Text, Annotations: annotations})
 		case "thinking":
 			out.Blocks = append(out.Blocks, litellm.ReasoningBlock{Text: content.Thinking, Signature: content.Signature})
 		case "redacted_thinking":
@@ -60,8 +64,22 @@ func convertResponse(resp *anthropicResponse, fallbackModel string) (*litellm.Re
 				Name:      content.Name,
 				Arguments: args,
 			})
+		case "server_tool_use":
+			args, err := json.Marshal(content.Input)
+			if err != nil {
+				return nil, fmt.Errorf("anthropic: marshal server tool use %q arguments: %w", content.Name, err)
+			}
+			out.Blocks = append(out.Blocks, litellm.ServerToolUseBlock{ID: content.ID, Name: content.Name, Arguments: args})
 		default:
-			// Beta features (server tools, compaction, fallback blocks, ...)
+			if isServerToolResult(content.Type) {
+				block, err := serverToolResultBlock(content)
+				if err != nil {
+					return nil, err
+				}
+				out.Blocks = append(out.Blocks, block)
+				continue
+			}
+			// Beta features (compaction, MCP, fallback blocks, ...)
 			// can add block types this provider does not model; keep the
 			// response usable and surface the drop instead of failing.
 			out.Warnings = append(out.Warnings, warning("anthropic.unsupported_content_block",
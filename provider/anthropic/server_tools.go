@@ -0,0 +1,177 @@
+package anthropic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/voocel/litellm"
+)
+
+// Server tool versions. Anthropic runs these tools itself and returns their
+// calls and results as ServerToolUseBlock and ServerToolResultBlock.
+const (
+	WebSearchToolType     = "web_search_20250305"
+	WebFetchToolType      = "web_fetch_20250910"
+	CodeExecutionToolType = "code_execution_20250825"
+)
+
+// serverToolBetas lists the beta header each server tool version needs; it is
+// added to requests that use the tool.
+var serverToolBetas = map[string]string{
+	WebFetchToolType:      "web-fetch-2025-09-10",
+	CodeExecutionToolType: "code-execution-2025-08-25",
+}
+
+// WebSearchOptions configures WebSearchTool. Zero values are omitted;
+// AllowedDomains and BlockedDomains cannot both be set.
+type WebSearchOptions struct {
+	MaxUses        int
+	AllowedDomains []string
+	BlockedDomains []string
+	UserLocation   *UserLocation
+}
+
+// UserLocation localizes web search results.
+type UserLocation struct {
+	City     string
+	Region   string
+	Country  string
+	Timezone string
+}
+
+// WebFetchOptions configures WebFetchTool. MaxContentTokens caps the fetched
+// content added to the context.
+type WebFetchOptions struct {
+	MaxUses          int
+	AllowedDomains   []string
+	BlockedDomains   []string
+	Citations        bool
+	MaxContentTokens int
+}
+
+// WebSearchTool returns Anthropic's hosted web search tool.
+func WebSearchTool(opts WebSearchOptions) litellm.Tool {
+	config := domainConfig(opts.MaxUses, opts.AllowedDomains, opts.BlockedDomains)
+	if loc := opts.UserLocation; loc != nil {
+		location := map[string]any{"type": "approximate"}
+		for key, value := range map[string]string{"city": loc.City, "region": loc.Region, "country": loc.Country, "timezone": loc.Timezone} {
+			if value != "" {
+				location[key] = value
+			}
+		}
+		config["user_location"] = location
+	}
+	return serverTool("web_search", WebSearchToolType, config)
+}
+
+// webSearchTool maps the portable Request.WebSearch onto WebSearchTool.
+func webSearchTool(search *litellm.WebSearch) litellm.Tool {
+	opts := WebSearchOptions{
+		MaxUses:        search.MaxUses,
+		AllowedDomains: search.AllowedDomains,
+		BlockedDomains: search.BlockedDomains,
+	}
+	if loc := search.UserLocation; !loc.IsZero() {
+		opts.UserLocation = &UserLocation{City: loc.City, Region: loc.Region, Country: loc.Country, Timezone: loc.Timezone}
+	}
+	return WebSearchTool(opts)
+}
+
+// WebFetchTool returns Anthropic's hosted web fetch tool, which retrieves the
+// full content of URLs that appear in the conversation.
+func WebFetchTool(opts WebFetchOptions) litellm.Tool {
+	config := domainConfig(opts.MaxUses, opts.AllowedDomains, opts.BlockedDomains)
+	if opts.Citations {
+		config["citations"] = map[string]any{"enabled": true}
+	}
+	if opts.MaxContentTokens > 0 {
+		config["max_content_tokens"] = opts.MaxContentTokens
+	}
+	return serverTool("web_fetch", WebFetchToolType, config)
+}
+
+// CodeExecutionTool returns Anthropic's hosted code execution tool, which runs
+// bash commands and edits files in a sandboxed container.
+func CodeExecutionTool() litellm.Tool {
+	return serverTool("code_execution", CodeExecutionToolType, nil)
+}
+
+func serverTool(name, typ string, config map[string]any) litellm.Tool {
+	if len(config) == 0 {
+		config = nil
+	}
+	return litellm.Tool{Name: name, Server: &litellm.ServerTool{Type: typ, Config: config}}
+}
+
+func domainConfig(maxUses int, allowed, blocked []string) map[string]any {
+	config := map[string]any{}
+	if maxUses > 0 {
+		config["max_uses"] = maxUses
+	}
+	if len(allowed) > 0 {
+		config["allowed_domains"] = append([]string(nil), allowed...)
+	}
+	if len(blocked) > 0 {
+		config["blocked_domains"] = append([]string(nil), blocked...)
+	}
+	return config
+}
+
+func convertServerTool(tool litellm.Tool) (anthropicTool, error) {
+	raw := make(map[string]any, len(tool.Server.Config)+2)
+	for key, value := range tool.Server.Config {
+		raw[key] = value
+	}
+	if _, ok := raw["type"]; ok {
+		return anthropicTool{}, fmt.Errorf("anthropic: server tool %q config cannot set type", tool.Name)
+	}
+	raw["type"] = tool.Server.Type
+	raw["name"] = tool.Name
+	return anthropicTool{Raw: raw}, nil
+}
+
+// setToolBetas adds the beta headers the request's server tools need to any
+// configured in Config.Beta.
+func setToolBetas(httpReq *http.Request, tools []litellm.Tool) {
+	for _, tool := range tools {
+		if tool.Server == nil {
+			continue
+		}
+		if beta, ok := serverToolBetas[tool.Server.Type]; ok {
+			addBeta(httpReq, beta)
+		}
+	}
+}
+
+// addBeta appends beta to the request's anthropic-beta header unless it is
+// already listed.
+func addBeta(httpReq *http.Request, beta string) {
+	var betas []string
+	if current := httpReq.Header.Get("anthropic-beta"); current != "" {
+		for _, item := range strings.Split(current, ",") {
+			betas = append(betas, strings.TrimSpace(item))
+		}
+	}
+	if slices.Contains(betas, beta) {
+		return
+	}
+	httpReq.Header.Set("anthropic-beta", strings.Join(append(betas, beta), ","))
+}
+
+// isServerToolResult reports whether a response block type is the result of a
+// server tool, such as "web_search_tool_result" or
+// "bash_code_execution_tool_result".
+func isServerToolResult(typ string) bool {
+	return typ != "tool_result" && strings.HasSuffix(typ, "_tool_result")
+}
+
+func serverToolResultBlock(content anthropicContent) (litellm.ServerToolResultBlock, error) {
+	data, err := json.Marshal(content.Content)
+	if err != nil {
+		return litellm.ServerToolResultBlock{}, fmt.Errorf("anthropic: marshal %s content: %w", content.Type, err)
+	}
+	return litellm.ServerToolResultBlock{ToolUseID: content.ToolUseID, Type: content.Type, Content: data}, nil
+}
@@ -22,6 +22,18 @@ type stream struct {
 	finish           litellm.FinishReason
 	toolIDs          map[int]string
 	toolNames        map[int]string
+	// serverTools buffers server tool calls by index; they are emitted whole
+	// at content_block_stop.
+	serverTools map[int]*serverToolCall
+	// textLens and citations track text blocks by index; citations apply to
+	// the whole block, so they are emitted at content_block_stop.
+	textLens  map[int]int
+	citations map[int][]json.RawMessage
+}
+
+type serverToolCall struct {
+	id, name string
+	args     strings.Builder
 }
 
 type streamChunk struct {
@@ -32,13 +44,15 @@ type streamChunk struct {
 	Message      *streamMessage  `json:"message,omitempty"`
 	Error        *streamError    `json:"error,omitempty"`
 	ContentBlock *struct {
-		Type      string         `json:"type"`
-		ID        string         `json:"id,omitempty"`
-		Name      string         `json:"name,omitempty"`
-		Input     map[string]any `json:"input,omitempty"`
-		Thinking  string         `json:"thinking,omitempty"`
-		Signature string         `json:"signature,omitempty"`
-		Data      string         `json:"data,omitempty"`
+		Type      string          `json:"type"`
+		ID        string          `json:"id,omitempty"`
+		Name      string          `json:"name,omitempty"`
+		Input     map[string]any  `json:"input,omitempty"`
+		Thinking  string          `json:"thinking,omitempty"`
+		Signature string          `json:"signature,omitempty"`
+		Data      string          `json:"data,omitempty"`
+		ToolUseID string          `json:"tool_use_id,omitempty"`
+		Content   json.RawMessage `json:"content,omitempty"`
 	} `json:"content_block,omitempty"`
 }
 
@@ -54,12 +68,13 @@ type streamError struct {
 }
 
 type streamDelta struct {
-	Type        string `json:"type"`
-	Text        string `json:"text,omitempty"`
-	Thinking    string `json:"thinking,omitempty"`
-	Signature   string `json:"signature,omitempty"`
-	PartialJSON string `json:"partial_json,omitempty"`
-	StopReason  string `json:"stop_reason,omitempty"`
+	Type        string          `json:"type"`
+	Text        string          `json:"text,omitempty"`
+	Thinking    string          `json:"thinking,omitempty"`
+	Signature   string          `json:"signature,omitempty"`
+	PartialJSON string          `json:"partial_json,omitempty"`
+	StopReason  string          `json:"stop_reason,omitempty"`
+	Citation    json.RawMessage `json:"citation,omitempty"`
 }
 
 func newStream(resp *http.Response, req *litellm.Request, warnings []litellm.Warning) *stream {
@@ -73,6 +88,9 @@ func newStream(resp *http.Response, req *litellm.Request, warnings []litellm.War
 		model:            req.Model,
 		toolIDs:          make(map[int]string),
 		toolNames:        make(map[int]string),
+		serverTools:      make(map[int]*serverToolCall),
+		textLens:         make(map[int]int),
+		citations:        make(map[int][]json.RawMessage),
 	}
 	for _, w := range warnings {
 		s.pending = append(s.pending, litellm.WarningEvent{Warning: w})
@@ -191,9 +209,19 @@ func (s *stream) events(chunk streamChunk, raw json.RawMessage) ([]litellm.Event
 				Redacted: []byte(chunk.ContentBlock.Data),
 				Index:    litellm.IntPtr(chunk.Index),
 			}}, nil
+		case "server_tool_use":
+			s.serverTools[chunk.Index] = &serverToolCall{id: chunk.ContentBlock.ID, name: chunk.ContentBlock.Name}
+			return nil, nil
 		case "text":
 			return nil, nil
 		default:
+			if isServerToolResult(chunk.ContentBlock.Type) {
+				return []litellm.Event{litellm.ServerToolResultEvent{Block: litellm.ServerToolResultBlock{
+					ToolUseID: chunk.ContentBlock.ToolUseID,
+					Type:      chunk.ContentBlock.Type,
+					Content:   chunk.ContentBlock.Content,
+				}}}, nil
+			}
 			return []litellm.Event{litellm.ProviderEvent{Name: chunk.Type + "." + chunk.ContentBlock.Type, Raw: raw}}, nil
 		}
 	case "content_block_delta":
@@ -202,6 +230,7 @@ func (s *stream) events(chunk streamChunk, raw json.RawMessage) ([]litellm.Event
 		}
 		switch chunk.Delta.Type {
 		case "text_delta":
+			s.textLens[chunk.Index] += len(chunk.Delta.Text)
 			return []litellm.Event{litellm.ContentDelta{Text: chunk.Delta.Text, ContentIndex: litellm.IntPtr(chunk.Index)}}, nil
 		case "thinking_delta":
 			if !s.includeReasoning {
@@ -213,7 +242,14 @@ func (s *stream) events(chunk streamChunk, raw json.RawMessage) ([]litellm.Event
 				return nil, nil
 			}
 			return []litellm.Event{litellm.ReasoningDelta{Signature: chunk.Delta.Signature, Index: litellm.IntPtr(chunk.Index)}}, nil
+		case "citations_delta":
+			s.citations[chunk.Index] = append(s.citations[chunk.Index], chunk.Delta.Citation)
+			return nil, nil
 		case "input_json_delta":
+			if call := s.serverTools[chunk.Index]; call != nil {
+				call.args.WriteString(chunk.Delta.PartialJSON)
+				return nil, nil
+			}
 			return []litellm.Event{litellm.ToolUseDelta{
 				ID:             s.toolIDs[chunk.Index],
 				Index:          litellm.IntPtr(chunk.Index),
@@ -223,6 +259,30 @@ func (s *stream) events(chunk streamChunk, raw json.RawMessage) ([]litellm.Event
 			return []litellm.Event{litellm.ProviderEvent{Name: chunk.Type + "." + chunk.Delta.Type, Raw: raw}}, nil
 		}
 	case "content_block_stop":
+		if citations, ok := s.citations[chunk.Index]; ok {
+			delete(s.citations, chunk.Index)
+			events := make([]litellm.Event, 0, len(citations))
+			for _, raw := range citations {
+				annotation, err := convertCitation(raw, s.textLens[chunk.Index])
+				if err != nil {
+					return nil, litellm.NewProviderErrorWithCause("anthropic", litellm.ErrorTypeProvider, "anthropic: parse citations delta", err)
+				}
+				events = append(events, litellm.AnnotationDelta{Annotation: annotation, ContentIndex: litellm.IntPtr(chunk.Index)})
+			}
+			return events, nil
+		}
+		if call := s.serverTools[chunk.Index]; call != nil {
+			delete(s.serverTools, chunk.Index)
+			args := call.args.String()
+			if args == "" {
+				args = "{}"
+			}
+			return []litellm.Event{litellm.ServerToolUseEvent{Block: litellm.ServerToolUseBlock{
+				ID:        call.id,
+				Name:      call.name,
+				Arguments: json.RawMessage(args),
+			}}}, nil
+		}
 		if id := s.toolIDs[chunk.Index]; id != "" {
 			return []litellm.Event{litellm.ToolUseDone{ID: id, Index: litellm.IntPtr(chunk.Index)}}, nil
 		}
@@ -0,0 +1,77 @@
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/voocel/litellm"
+)
+
+// countMaxTokens stands in for an unset MaxTokens so buildRequest's checks
+// pass; the count endpoint does not take max_tokens.
+const countMaxTokens = 64000
+
+type countTokensRequest struct {
+	Model      string             `json:"model"`
+	System     any                `json:"system,omitempty"`
+	Messages   []anthropicMessage `json:"messages"`
+	Tools      []anthropicTool    `json:"tools,omitempty"`
+	ToolChoice any                `json:"tool_choice,omitempty"`
+	Thinking   *anthropicThinking `json:"thinking,omitempty"`
+}
+
+type countTokensResponse struct {
+	InputTokens int `json:"input_tokens"`
+}
+
+// CountTokens calls /v1/messages/count_tokens.
+func (p *Provider) CountTokens(ctx context.Context, req *litellm.Request) (*litellm.TokenCount, error) {
+	if req.MaxTokens == nil {
+		copied := *req
+		copied.MaxTokens = litellm.IntPtr(countMaxTokens)
+		req = &copied
+	}
+	wire, _, err := p.buildRequest(req, false)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	body, err := json.Marshal(countTokensRequest{
+		Model:      wire.Model,
+		System:     wire.System,
+		Messages:   wire.Messages,
+		Tools:      wire.Tools,
+		ToolChoice: wire.ToolChoice,
+		Thinking:   wire.Thinking,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("anthropic: marshal count tokens request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(p.cfg.BaseURL, "/")+"/v1/messages/count_tokens", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	setToolBetas(httpReq, req.Tools)
+	setFileBeta(httpReq, req.Messages)
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "count tokens request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	var parsed countTokensResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "anthropic: decode count tokens response", err)
+	}
+	return &litellm.TokenCount{InputTokens: parsed.InputTokens, Provider: p.Name(), Model: req.Model}, nil
+}
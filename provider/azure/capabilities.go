@@ -0,0 +1,19 @@
+package azure
+
+import "github.com/voocel/litellm"
+
+// Capabilities follows the openai adapter for the model behind a
+// deployment. Azure OpenAI implements Structured Outputs; files uploaded
+// through the OpenAI file API are not visible to it.
+func (p *Provider) Capabilities(model string) litellm.Capabilities {
+	caps := p.openai.Capabilities(model)
+	caps.Provider = p.Name()
+	caps.Structured = litellm.StructuredCapabilities{
+		JSONObject: litellm.SupportYes,
+		JSONSchema: litellm.SupportYes,
+		Strict:     litellm.SupportYes,
+	}
+	caps.Media.FileURI = litellm.SupportNo
+	caps.Media.DocumentFileURI = litellm.SupportNo
+	return caps
+}
@@ -0,0 +1,153 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/voocel/litellm"
+	"github.com/voocel/litellm/internal/relabel"
+	"github.com/voocel/litellm/provider/openai"
+	"github.com/voocel/litellm/retry"
+)
+
+const defaultAPIVersion = "2024-10-21"
+
+type Config struct {
+	// Endpoint is the resource endpoint, such as
+	// https://my-resource.openai.azure.com.
+	Endpoint string
+	// APIVersion is the api-version sent with chat completions.
+	APIVersion string
+	// API selects chat completions or the Responses API, as in
+	// openai.Config.
+	API string
+	// Authenticate with either APIKey (sent as the api-key header) or
+	// TokenFunc, which returns a Microsoft Entra ID access token for the
+	// https://cognitiveservices.azure.com/.default scope.
+	APIKey    string
+	TokenFunc func(context.Context) (string, error)
+	// Deployments maps model names to deployment names. Models without an
+	// entry are used as the deployment name.
+	Deployments       map[string]string
+	HTTPClient        HTTPClient
+	Transport         http.RoundTripper
+	Retry             *retry.Policy
+	StreamIdleTimeout time.Duration
+	Headers           map[string]string
+}
+
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Provider calls Azure OpenAI deployments with the openai adapter's
+// conversions.
+type Provider struct {
+	cfg    Config
+	openai *openai.Provider
+}
+
+func New(cfg Config) (*Provider, error) {
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("azure: endpoint is required")
+	}
+	if cfg.APIKey == "" && cfg.TokenFunc == nil {
+		return nil, fmt.Errorf("azure: api key or token func is required")
+	}
+	if cfg.APIKey != "" && cfg.TokenFunc != nil {
+		return nil, fmt.Errorf("azure: APIKey and TokenFunc are mutually exclusive")
+	}
+	if cfg.HTTPClient != nil && cfg.Transport != nil {
+		return nil, fmt.Errorf("azure: HTTPClient and Transport are mutually exclusive")
+	}
+	if cfg.HTTPClient != nil && cfg.Retry != nil {
+		return nil, fmt.Errorf("azure: Retry cannot be used with a custom HTTPClient; use Transport or configure retry on the client")
+	}
+	if cfg.APIVersion == "" {
+		cfg.APIVersion = defaultAPIVersion
+	}
+	base := cfg.Transport
+	if base == nil {
+		if cfg.HTTPClient != nil {
+			base = relabel.ClientTransport{Client: cfg.HTTPClient}
+		} else {
+			base = http.DefaultTransport
+		}
+	}
+	inner, err := openai.New(openai.Config{
+		API:               cfg.API,
+		APIKey:            cfg.APIKey,
+		APIKeyFunc:        cfg.TokenFunc,
+		BaseURL:           strings.TrimRight(cfg.Endpoint, "/") + "/openai/v1",
+		Transport:         &transport{apiKey: cfg.APIKey != "", apiVersion: cfg.APIVersion, base: base},
+		Retry:             cfg.Retry,
+		StreamIdleTimeout: cfg.StreamIdleTimeout,
+		Headers:           cfg.Headers,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("azure: %w", err)
+	}
+	return &Provider{cfg: cfg, openai: inner}, nil
+}
+
+func Factory(cfg Config) (litellm.Provider, error) {
+	return New(cfg)
+}
+
+func (p *Provider) Name() string {
+	return "azure"
+}
+
+func (p *Provider) Chat(ctx context.Context, req *litellm.Request) (*litellm.Response, error) {
+	routed := *req
+	routed.Model = p.deployment(req.Model)
+	resp, err := p.openai.Chat(ctx, &routed)
+	if err != nil {
+		return nil, relabel.Error(err, p.openai.Name(), p.Name())
+	}
+	relabel.Response(resp, p.openai.Name(), p.Name())
+	return resp, nil
+}
+
+func (p *Provider) Stream(ctx context.Context, req *litellm.Request) (litellm.Stream, error) {
+	routed := *req
+	routed.Model = p.deployment(req.Model)
+	inner, err := p.openai.Stream(ctx, &routed)
+	if err != nil {
+		return nil, relabel.Error(err, p.openai.Name(), p.Name())
+	}
+	return relabel.Stream(inner, p.openai.Name(), p.Name()), nil
+}
+
+// Responses calls the Responses API under /openai/v1 with req.Model mapped
+// to its deployment.
+func (p *Provider) Responses(ctx context.Context, req *openai.ResponsesRequest) (*litellm.Response, error) {
+	routed := *req
+	routed.Model = p.deployment(req.Model)
+	resp, err := p.openai.Responses(ctx, &routed)
+	if err != nil {
+		return nil, relabel.Error(err, p.openai.Name(), p.Name())
+	}
+	relabel.Response(resp, p.openai.Name(), p.Name())
+	return resp, nil
+}
+
+func (p *Provider) ResponsesStream(ctx context.Context, req *openai.ResponsesRequest) (litellm.Stream, error) {
+	routed := *req
+	routed.Model = p.deployment(req.Model)
+	inner, err := p.openai.ResponsesStream(ctx, &routed)
+	if err != nil {
+		return nil, relabel.Error(err, p.openai.Name(), p.Name())
+	}
+	return relabel.Stream(inner, p.openai.Name(), p.Name()), nil
+}
+
+func (p *Provider) deployment(model string) string {
+	if deployment := p.cfg.Deployments[model]; deployment != "" {
+		return deployment
+	}
+	return model
+}
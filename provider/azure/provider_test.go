@@ -0,0 +1,173 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/voocel/litellm"
+	"github.com/voocel/litellm/provider/openai"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestChatRoutesModelToDeployment(t *testing.T) {
+	provider, err := New(Config{
+		Endpoint:    "https://res.openai.azure.com/",
+		APIKey:      "secret",
+		Deployments: map[string]string{"gpt-4.1": "prod gpt"},
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Host != "res.openai.azure.com" {
+				t.Fatalf("host = %q", req.URL.Host)
+			}
+			if want := "/openai/deployments/prod%20gpt/chat/completions"; req.URL.EscapedPath() != want {
+				t.Fatalf("path = %q, want %q", req.URL.EscapedPath(), want)
+			}
+			if got := req.URL.Query().Get("api-version"); got != defaultAPIVersion {
+				t.Fatalf("api-version = %q", got)
+			}
+			if req.Header.Get("api-key") != "secret" || req.Header.Get("Authorization") != "" {
+				t.Fatalf("headers = %v", req.Header)
+			}
+			return jsonResponse(http.StatusOK, `{"id":"c1","model":"gpt-4.1","choices":[{"index":0,"message":{"role":"assistant","content":"hi"},"finish_reason":"stop"}],"usage":{"prompt_tokens":3,"completion_tokens":1,"total_tokens":4}}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.Chat(context.Background(), &litellm.Request{
+		Model:    "gpt-4.1",
+		Messages: []litellm.Message{litellm.UserText("hello")},
+	})
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	if resp.Text() != "hi" || resp.Provider != "azure" || resp.Usage.Provider != "azure" {
+		t.Fatalf("response = %+v", resp)
+	}
+}
+
+func TestResponsesUsesV1PathWithEntraToken(t *testing.T) {
+	provider, err := New(Config{
+		Endpoint: "https://res.openai.azure.com",
+		TokenFunc: func(context.Context) (string, error) {
+			return "entra-token", nil
+		},
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/openai/v1/responses" || req.URL.RawQuery != "" {
+				t.Fatalf("url = %q", req.URL)
+			}
+			if req.Header.Get("Authorization") != "Bearer entra-token" || req.Header.Get("api-key") != "" {
+				t.Fatalf("headers = %v", req.Header)
+			}
+			var body map[string]any
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body["model"] != "my-deployment" {
+				t.Fatalf("model = %v", body["model"])
+			}
+			return jsonResponse(http.StatusOK, `{"id":"resp_1","object":"response","status":"completed","model":"gpt-4.1","output":[{"type":"message","id":"msg_1","role":"assistant","content":[{"type":"output_text","text":"hi"}]}],"usage":{"input_tokens":3,"output_tokens":1,"total_tokens":4}}`), nil
+		}),
+		Deployments: map[string]string{"gpt-4.1": "my-deployment"},
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.Responses(context.Background(), &openai.ResponsesRequest{
+		Model:    "gpt-4.1",
+		Messages: []litellm.Message{litellm.UserText("hello")},
+	})
+	if err != nil {
+		t.Fatalf("Responses returned error: %v", err)
+	}
+	if resp.Text() != "hi" || resp.Provider != "azure" {
+		t.Fatalf("response = %+v", resp)
+	}
+}
+
+func TestChatReportsContentFilterResults(t *testing.T) {
+	provider, err := New(Config{
+		Endpoint: "https://res.openai.azure.com",
+		APIKey:   "secret",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusOK, `{"id":"c1","model":"gpt-4.1","prompt_filter_results":[{"prompt_index":0,"content_filter_results":{"hate":{"filtered":false,"severity":"safe"},"self_harm":{"filtered":false,"severity":"low"}}}],"choices":[{"index":0,"message":{"role":"assistant","content":""},"finish_reason":"content_filter","content_filter_results":{"violence":{"filtered":true,"severity":"medium"},"protected_material_text":{"filtered":false,"detected":true}}}]}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.Chat(context.Background(), &litellm.Request{
+		Model:    "gpt-4.1",
+		Messages: []litellm.Message{litellm.UserText("hello")},
+	})
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	if resp.FinishReason != litellm.FinishReasonSafety || len(resp.Safety) != 2 {
+		t.Fatalf("finish/safety = %q/%+v", resp.FinishReason, resp.Safety)
+	}
+	output := resp.Safety[1]
+	if output.Target != litellm.SafetyTargetOutput || !output.Blocked || len(output.Categories) != 2 {
+		t.Fatalf("output assessment = %+v", output)
+	}
+	if c := output.Categories[0]; c.Name != "protected_material_text" || !c.Detected || c.Filtered {
+		t.Fatalf("category = %+v", c)
+	}
+}
+
+func TestChatClassifiesFilteredPrompt(t *testing.T) {
+	provider, err := New(Config{
+		Endpoint: "https://res.openai.azure.com",
+		APIKey:   "secret",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return jsonResponse(http.StatusBadRequest, `{"error":{"code":"content_filter","message":"The response was filtered due to the prompt triggering Azure OpenAI's content management policy.","innererror":{"code":"ResponsibleAIPolicyViolation"}}}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	_, err = provider.Chat(context.Background(), &litellm.Request{
+		Model:    "gpt-4.1",
+		Messages: []litellm.Message{litellm.UserText("hello")},
+	})
+	var e *litellm.LiteLLMError
+	if !litellm.IsContentFilterError(err) || !errors.As(err, &e) || e.Provider != "azure" {
+		t.Fatalf("Chat error = %v, want azure content filter error", err)
+	}
+}
+
+func TestNewValidatesConfig(t *testing.T) {
+	for _, cfg := range []Config{
+		{APIKey: "k"},
+		{Endpoint: "https://res.openai.azure.com"},
+		{Endpoint: "https://res.openai.azure.com", APIKey: "k", TokenFunc: func(context.Context) (string, error) { return "", nil }},
+	} {
+		if _, err := New(cfg); err == nil {
+			t.Fatalf("New(%+v) returned nil error", cfg)
+		}
+	}
+	provider, err := New(Config{Endpoint: "https://res.openai.azure.com", APIKey: "k"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if caps := provider.Capabilities("gpt-4.1"); caps.Provider != "azure" || caps.Structured.JSONSchema != litellm.SupportYes {
+		t.Fatalf("capabilities = %+v", caps)
+	}
+}
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
@@ -0,0 +1,67 @@
+package azure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// transport maps requests built by the openai adapter onto Azure OpenAI.
+// Chat completions go to the deployment-scoped path with api-version; the
+// Responses API is served as-is under /openai/v1. With an API key, the
+// bearer credential the adapter sends moves to the api-key header.
+type transport struct {
+	apiKey     bool
+	apiVersion string
+	base       http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if out.URL.Path == "/openai/v1/chat/completions" {
+		if err := t.rewriteChat(out); err != nil {
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, err
+		}
+	}
+	if t.apiKey {
+		key, _ := strings.CutPrefix(out.Header.Get("Authorization"), "Bearer ")
+		out.Header.Del("Authorization")
+		out.Header.Set("api-key", key)
+	}
+	return t.base.RoundTrip(out)
+}
+
+func (t *transport) rewriteChat(req *http.Request) error {
+	if req.Body == nil {
+		return fmt.Errorf("azure: request has no body")
+	}
+	defer req.Body.Close()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return fmt.Errorf("azure: read request body: %w", err)
+	}
+	var wire struct {
+		Model string `json:"model"`
+	}
+	if err := json.Unmarshal(body, &wire); err != nil || wire.Model == "" {
+		return fmt.Errorf("azure: chat request has no deployment")
+	}
+	req.URL.Path = "/openai/deployments/" + wire.Model + "/chat/completions"
+	req.URL.RawPath = "/openai/deployments/" + url.PathEscape(wire.Model) + "/chat/completions"
+	query := req.URL.Query()
+	query.Set("api-version", t.apiVersion)
+	req.URL.RawQuery = query.Encode()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.ContentLength = int64(len(body))
+	return nil
+}
@@ -0,0 +1,381 @@
+package bedrock
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/voocel/litellm"
+)
+
+// Batch inference takes records keyed by an 11 character alphanumeric
+// recordId, so custom IDs are kept in a manifest next to the input file.
+const (
+	batchInputFile    = "input.jsonl"
+	batchManifestFile = "custom_ids.json"
+)
+
+var batchJobSeq atomic.Uint64
+
+type batchRecord struct {
+	RecordID   string   `json:"recordId"`
+	ModelInput *request `json:"modelInput"`
+}
+
+type createJobRequest struct {
+	JobName             string          `json:"jobName"`
+	RoleARN             string          `json:"roleArn"`
+	ModelID             string          `json:"modelId"`
+	ModelInvocationType string          `json:"modelInvocationType"`
+	InputDataConfig     jobInputConfig  `json:"inputDataConfig"`
+	OutputDataConfig    jobOutputConfig `json:"outputDataConfig"`
+}
+
+type jobInputConfig struct {
+	S3InputDataConfig struct {
+		S3URI         string `json:"s3Uri"`
+		S3InputFormat string `json:"s3InputFormat,omitempty"`
+	} `json:"s3InputDataConfig"`
+}
+
+type jobOutputConfig struct {
+	S3OutputDataConfig struct {
+		S3URI string `json:"s3Uri"`
+	} `json:"s3OutputDataConfig"`
+}
+
+type invocationJob struct {
+	JobARN            string          `json:"jobArn"`
+	ModelID           string          `json:"modelId"`
+	Status            string          `json:"status"`
+	Message           string          `json:"message"`
+	SubmitTime        time.Time       `json:"submitTime"`
+	EndTime           *time.Time      `json:"endTime"`
+	JobExpirationTime *time.Time      `json:"jobExpirationTime"`
+	InputDataConfig   jobInputConfig  `json:"inputDataConfig"`
+	OutputDataConfig  jobOutputConfig `json:"outputDataConfig"`
+}
+
+type invocationJobList struct {
+	InvocationJobSummaries []invocationJob `json:"invocationJobSummaries"`
+}
+
+type batchOutputRecord struct {
+	RecordID    string    `json:"recordId"`
+	ModelOutput *response `json:"modelOutput"`
+	Error       *struct {
+		ErrorCode    any    `json:"errorCode"`
+		ErrorMessage string `json:"errorMessage"`
+	} `json:"error"`
+}
+
+// CreateBatch writes the items to Config.BatchS3URI and starts a batch
+// inference job with CreateModelInvocationJob. Records use the Converse
+// request format, and every item must target the same model. Batches are
+// not available with APIMessages.
+func (p *Provider) CreateBatch(ctx context.Context, req *litellm.BatchRequest) (*litellm.Batch, error) {
+	if p.messages != nil {
+		return nil, litellm.NewValidationError(p.Name(), "bedrock: batch inference uses the Converse format and is not available with the Messages API")
+	}
+	if p.cfg.BatchRoleARN == "" || p.cfg.BatchS3URI == "" {
+		return nil, litellm.NewValidationError(p.Name(), "bedrock: BatchRoleARN and BatchS3URI are required for batch inference")
+	}
+	if len(req.Items) == 0 {
+		return nil, litellm.NewValidationError(p.Name(), "bedrock: batch must contain at least one item")
+	}
+	model := req.Items[0].Request.Model
+	var input bytes.Buffer
+	encoder := json.NewEncoder(&input)
+	customIDs := make(map[string]string, len(req.Items))
+	for i, item := range req.Items {
+		if item.Request.Model != model {
+			return nil, litellm.NewValidationError(p.Name(), fmt.Sprintf("bedrock: batch items must share one model; %q uses %q, not %q", item.CustomID, item.Request.Model, model))
+		}
+		wire, err := p.buildRequest(&item.Request)
+		if err != nil {
+			return nil, litellm.WrapValidationError(p.Name(), fmt.Errorf("batch item %q: %w", item.CustomID, err))
+		}
+		wire.dropStreamSettings()
+		recordID := fmt.Sprintf("REC%08d", i)
+		customIDs[recordID] = item.CustomID
+		if err := encoder.Encode(batchRecord{RecordID: recordID, ModelInput: wire}); err != nil {
+			return nil, fmt.Errorf("bedrock: marshal batch item %q: %w", item.CustomID, err)
+		}
+	}
+	manifest, err := json.Marshal(customIDs)
+	if err != nil {
+		return nil, fmt.Errorf("bedrock: marshal batch manifest: %w", err)
+	}
+	jobName := fmt.Sprintf("litellm-%s-%d", time.Now().UTC().Format("20060102T150405"), batchJobSeq.Add(1))
+	prefix := strings.TrimRight(p.cfg.BatchS3URI, "/") + "/" + jobName
+	if err := p.putS3Object(ctx, prefix+"/"+batchManifestFile, manifest); err != nil {
+		return nil, err
+	}
+	if err := p.putS3Object(ctx, prefix+"/"+batchInputFile, input.Bytes()); err != nil {
+		return nil, err
+	}
+	create := createJobRequest{JobName: jobName, RoleARN: p.cfg.BatchRoleARN, ModelID: model, ModelInvocationType: "Converse"}
+	create.InputDataConfig.S3InputDataConfig.S3URI = prefix + "/" + batchInputFile
+	create.InputDataConfig.S3InputDataConfig.S3InputFormat = "JSONL"
+	create.OutputDataConfig.S3OutputDataConfig.S3URI = prefix + "/output/"
+	var created struct {
+		JobARN string `json:"jobArn"`
+	}
+	if err := p.controlPlaneCall(ctx, http.MethodPost, "/model-invocation-job", "/model-invocation-job", "", create, &created); err != nil {
+		return nil, err
+	}
+	return &litellm.Batch{
+		ID:        created.JobARN,
+		Provider:  p.Name(),
+		Status:    litellm.BatchStatusValidating,
+		Counts:    litellm.BatchCounts{Total: len(req.Items)},
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+func (p *Provider) GetBatch(ctx context.Context, id string) (*litellm.Batch, error) {
+	job, err := p.getInvocationJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return p.convertBatch(*job), nil
+}
+
+func (p *Provider) ListBatches(ctx context.Context, limit int) ([]litellm.Batch, error) {
+	query := "sortBy=CreationTime&sortOrder=Descending"
+	if limit > 0 {
+		query = "maxResults=" + strconv.Itoa(limit) + "&" + query
+	}
+	var list invocationJobList
+	if err := p.controlPlaneCall(ctx, http.MethodGet, "/model-invocation-jobs", "/model-invocation-jobs", query, nil, &list); err != nil {
+		return nil, err
+	}
+	out := make([]litellm.Batch, 0, len(list.InvocationJobSummaries))
+	for _, job := range list.InvocationJobSummaries {
+		out = append(out, *p.convertBatch(job))
+	}
+	return out, nil
+}
+
+// CancelBatch stops the job and returns its status afterwards.
+func (p *Provider) CancelBatch(ctx context.Context, id string) (*litellm.Batch, error) {
+	resource, rawResource := jobResource(id)
+	if err := p.controlPlaneCall(ctx, http.MethodPost, resource+"/stop", rawResource+"/stop", "", struct{}{}, nil); err != nil {
+		return nil, err
+	}
+	return p.GetBatch(ctx, id)
+}
+
+// BatchResults reads the job's output file from S3.
+func (p *Provider) BatchResults(ctx context.Context, id string) ([]litellm.BatchResult, error) {
+	job, err := p.getInvocationJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	inputURI := job.InputDataConfig.S3InputDataConfig.S3URI
+	outputURI := job.OutputDataConfig.S3OutputDataConfig.S3URI
+	if inputURI == "" || outputURI == "" {
+		return nil, litellm.NewProviderError(p.Name(), litellm.ErrorTypeProvider, fmt.Sprintf("bedrock: batch %s has no S3 locations", id))
+	}
+	// path.Dir would collapse the "s3://" scheme, so split by hand.
+	slash := strings.LastIndexByte(inputURI, '/')
+	manifestData, err := p.getS3Object(ctx, inputURI[:slash+1]+batchManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	var customIDs map[string]string
+	if err := json.Unmarshal(manifestData, &customIDs); err != nil {
+		return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "bedrock: decode batch manifest", err)
+	}
+	jobID := job.JobARN[strings.LastIndexByte(job.JobARN, '/')+1:]
+	data, err := p.getS3Object(ctx, strings.TrimRight(outputURI, "/")+"/"+jobID+"/"+inputURI[slash+1:]+".out")
+	if err != nil {
+		return nil, err
+	}
+	var results []litellm.BatchResult
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record batchOutputRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "bedrock: decode batch result", err)
+		}
+		customID, ok := customIDs[record.RecordID]
+		if !ok {
+			customID = record.RecordID
+		}
+		results = append(results, p.convertBatchResult(customID, job.ModelID, record))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "read batch results failed", err)
+	}
+	return results, nil
+}
+
+func (p *Provider) convertBatchResult(customID, model string, record batchOutputRecord) litellm.BatchResult {
+	result := litellm.BatchResult{CustomID: customID}
+	switch {
+	case record.Error != nil:
+		status := http.StatusInternalServerError
+		switch code := record.Error.ErrorCode.(type) {
+		case float64:
+			status = int(code)
+		case string:
+			if n, err := strconv.Atoi(code); err == nil {
+				status = n
+			}
+		}
+		result.Err = litellm.NewHTTPError(p.Name(), status, record.Error.ErrorMessage)
+	case record.ModelOutput == nil:
+		result.Err = litellm.NewProviderError(p.Name(), litellm.ErrorTypeProvider, "bedrock: batch record has neither output nor error")
+	default:
+		resp, err := convertResponse(record.ModelOutput, model)
+		if err != nil {
+			result.Err = litellm.WrapError(err, p.Name())
+			break
+		}
+		result.Response = resp
+	}
+	return result
+}
+
+func (p *Provider) convertBatch(job invocationJob) *litellm.Batch {
+	out := &litellm.Batch{
+		ID:        job.JobARN,
+		Provider:  p.Name(),
+		Message:   job.Message,
+		CreatedAt: job.SubmitTime,
+	}
+	if job.EndTime != nil {
+		out.EndedAt = *job.EndTime
+	}
+	if job.JobExpirationTime != nil {
+		out.ExpiresAt = *job.JobExpirationTime
+	}
+	switch job.Status {
+	case "Submitted", "Validating", "Scheduled":
+		out.Status = litellm.BatchStatusValidating
+	case "InProgress":
+		out.Status = litellm.BatchStatusInProgress
+	case "Completed", "PartiallyCompleted":
+		out.Status = litellm.BatchStatusCompleted
+	case "Failed":
+		out.Status = litellm.BatchStatusFailed
+	case "Stopping":
+		out.Status = litellm.BatchStatusCancelling
+	case "Stopped":
+		out.Status = litellm.BatchStatusCancelled
+	case "Expired":
+		out.Status = litellm.BatchStatusExpired
+	default:
+		out.Status = litellm.BatchStatus(strings.ToLower(job.Status))
+	}
+	return out
+}
+
+func (p *Provider) getInvocationJob(ctx context.Context, id string) (*invocationJob, error) {
+	var job invocationJob
+	resource, rawResource := jobResource(id)
+	if err := p.controlPlaneCall(ctx, http.MethodGet, resource, rawResource, "", nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// jobResource returns the path of a job, which is usually identified by its
+// ARN, with the identifier escaped as a single segment.
+func jobResource(id string) (string, string) {
+	return "/model-invocation-job/" + id, "/model-invocation-job/" + awsEscapePath(id, true)
+}
+
+// controlPlaneCall sends a JSON request to the Bedrock control plane.
+// rawResource is resource with its job ARN segment escaped, as from
+// jobResource.
+func (p *Provider) controlPlaneCall(ctx context.Context, method, resource, rawResource, query string, in, out any) error {
+	endpoint, err := url.Parse(strings.TrimRight(p.cfg.ControlPlaneBaseURL, "/"))
+	if err != nil {
+		return fmt.Errorf("bedrock: create control plane endpoint: %w", err)
+	}
+	endpoint.RawPath = strings.TrimRight(endpoint.EscapedPath(), "/") + rawResource
+	endpoint.Path = strings.TrimRight(endpoint.Path, "/") + resource
+	endpoint.RawQuery = query
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("bedrock: marshal batch request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+	if err != nil {
+		return fmt.Errorf("bedrock: create batch request: %w", err)
+	}
+	httpReq.URL.RawPath = endpoint.RawPath
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return litellm.NewNetworkError(p.Name(), "batch request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "bedrock: decode batch response", err)
+	}
+	return nil
+}
+
+func (p *Provider) putS3Object(ctx context.Context, uri string, data []byte) error {
+	_, err := p.s3Call(ctx, http.MethodPut, uri, data)
+	return err
+}
+
+func (p *Provider) getS3Object(ctx context.Context, uri string) ([]byte, error) {
+	return p.s3Call(ctx, http.MethodGet, uri, nil)
+}
+
+func (p *Provider) s3Call(ctx context.Context, method, uri string, payload []byte) ([]byte, error) {
+	bucket, key, ok := strings.Cut(strings.TrimPrefix(uri, "s3://"), "/")
+	if !strings.HasPrefix(uri, "s3://") || !ok || bucket == "" || key == "" {
+		return nil, litellm.NewValidationError(p.Name(), fmt.Sprintf("bedrock: invalid S3 URI %q", uri))
+	}
+	endpoint := &url.URL{Scheme: "https", Host: bucket + ".s3." + p.cfg.Region + ".amazonaws.com", Path: "/" + key}
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("bedrock: create S3 request: %w", err)
+	}
+	resp, err := p.s3.Do(httpReq)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "S3 request failed", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "read S3 response failed", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	return data, nil
+}
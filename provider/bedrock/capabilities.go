@@ -7,6 +7,9 @@ import (
 )
 
 func (p *Provider) Capabilities(model string) litellm.Capabilities {
+	if p.messages != nil {
+		return p.messagesCapabilities(model)
+	}
 	claude := strings.Contains(strings.ToLower(model), "claude")
 	thinking := litellm.ThinkingCapabilities{
 		Supported: litellm.SupportNo,
@@ -36,6 +39,8 @@ func (p *Provider) Capabilities(model string) litellm.Capabilities {
 			Choice:              litellm.SupportYes,
 			MultimodalResults:   litellm.SupportYes,
 			RoundTripSignatures: litellm.SupportYes,
+			HostedProviderTools: litellm.SupportPartial,
+			WebSearch:           litellm.SupportNo,
 		},
 		Structured: litellm.StructuredCapabilities{
 			JSONObject: litellm.SupportUnknown,
@@ -43,9 +48,13 @@ func (p *Provider) Capabilities(model string) litellm.Capabilities {
 			Strict:     litellm.SupportNo,
 		},
 		Media: litellm.MediaCapabilities{
-			ImageURL:   litellm.SupportNo,
-			ImageBytes: litellm.SupportYes,
-			FileURI:    litellm.SupportNo,
+			ImageURL:          litellm.SupportNo,
+			ImageBytes:        litellm.SupportYes,
+			FileURI:           litellm.SupportNo,
+			DocumentURL:       litellm.SupportNo,
+			DocumentBytes:     litellm.SupportYes,
+			DocumentFileURI:   litellm.SupportPartial,
+			DocumentCitations: litellm.SupportPartial,
 		},
 		Cache: litellm.CacheCapabilities{
 			Block:         litellm.SupportYes,
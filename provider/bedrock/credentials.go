@@ -0,0 +1,507 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/voocel/litellm"
+)
+
+const (
+	credentialsRefreshWindow = 5 * time.Minute
+	// credentialsMissTTL is how long a chain that found no credentials is
+	// remembered, so every request does not wait on the metadata timeouts.
+	credentialsMissTTL = 30 * time.Second
+
+	defaultIMDSEndpoint      = "http://169.254.169.254"
+	defaultContainerEndpoint = "http://169.254.170.2"
+	imdsTimeout              = 2 * time.Second
+)
+
+// errNoCredentials reports that a source is not configured; the chain moves
+// on to the next one.
+var errNoCredentials = errors.New("bedrock: no credentials")
+
+var credentialsHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// DefaultCredentials resolves credentials the way the AWS SDKs do, trying
+// in order: environment variables, the shared config and credentials files
+// (AWS_PROFILE or "default"), a web identity token file (EKS IRSA), ECS
+// container credentials and EC2 instance metadata (IMDSv2). Credentials
+// are cached until shortly before they expire.
+func DefaultCredentials() CredentialsProvider {
+	return newCachedCredentials(credentialsChain{
+		envCredentials{},
+		profileCredentials{},
+		webIdentityCredentials{},
+		containerCredentials{},
+		imdsCredentials{},
+	})
+}
+
+// ProfileCredentials resolves a named profile from the shared config and
+// credentials files, including credential_process and role_arn with
+// source_profile or credential_source.
+func ProfileCredentials(profile string) CredentialsProvider {
+	return newCachedCredentials(profileCredentials{name: profile})
+}
+
+type credentialsChain []CredentialsProvider
+
+func (c credentialsChain) Credentials(ctx context.Context) (Credentials, error) {
+	for _, source := range c {
+		credentials, err := source.Credentials(ctx)
+		if errors.Is(err, errNoCredentials) {
+			continue
+		}
+		return credentials, err
+	}
+	err := litellm.NewAuthError("bedrock", "no AWS credentials found in the environment, shared config, web identity, container or instance metadata")
+	err.Cause = errNoCredentials
+	return Credentials{}, err
+}
+
+// cachedCredentials refreshes credentials a few minutes before they expire.
+// One caller refreshes at a time, outside the lock; the others keep using
+// the cached credentials while they are valid, or wait for the refresh.
+type cachedCredentials struct {
+	source      CredentialsProvider
+	mu          sync.Mutex
+	credentials Credentials
+	valid       bool
+	refresh     *credentialsFlight
+	miss        error
+	missUntil   time.Time
+	now         func() time.Time
+}
+
+type credentialsFlight struct {
+	done        chan struct{}
+	credentials Credentials
+	err         error
+}
+
+func newCachedCredentials(source CredentialsProvider) *cachedCredentials {
+	return &cachedCredentials{source: source, now: time.Now}
+}
+
+func (c *cachedCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	c.mu.Lock()
+	now := c.now()
+	usable := c.usable(now)
+	if usable && (c.credentials.Expires.IsZero() || now.Add(credentialsRefreshWindow).Before(c.credentials.Expires)) {
+		defer c.mu.Unlock()
+		return c.credentials, nil
+	}
+	if usable && c.refresh != nil {
+		defer c.mu.Unlock()
+		return c.credentials, nil
+	}
+	if !usable && c.miss != nil && now.Before(c.missUntil) {
+		defer c.mu.Unlock()
+		return Credentials{}, c.miss
+	}
+	if flight := c.refresh; flight != nil {
+		c.mu.Unlock()
+		select {
+		case <-flight.done:
+			return flight.credentials, flight.err
+		case <-ctx.Done():
+			return Credentials{}, ctx.Err()
+		}
+	}
+	flight := &credentialsFlight{done: make(chan struct{})}
+	c.refresh = flight
+	c.mu.Unlock()
+
+	credentials, err := c.source.Credentials(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refresh = nil
+	switch {
+	case err == nil:
+		c.credentials, c.valid, c.miss = credentials, true, nil
+	case c.usable(c.now()):
+		// Keep serving credentials that have not expired yet; the next
+		// call past the refresh window tries again.
+		credentials, err = c.credentials, nil
+	case errors.Is(err, errNoCredentials):
+		c.miss, c.missUntil = err, c.now().Add(credentialsMissTTL)
+	}
+	if err != nil {
+		credentials = Credentials{}
+	}
+	flight.credentials, flight.err = credentials, err
+	close(flight.done)
+	return credentials, err
+}
+
+func (c *cachedCredentials) usable(now time.Time) bool {
+	return c.valid && (c.credentials.Expires.IsZero() || now.Before(c.credentials.Expires))
+}
+
+type envCredentials struct{}
+
+func (envCredentials) Credentials(context.Context) (Credentials, error) {
+	id := firstEnv("AWS_ACCESS_KEY_ID", "AWS_ACCESS_KEY")
+	secret := firstEnv("AWS_SECRET_ACCESS_KEY", "AWS_SECRET_KEY")
+	if id == "" && secret == "" {
+		return Credentials{}, errNoCredentials
+	}
+	if id == "" || secret == "" {
+		return Credentials{}, fmt.Errorf("bedrock: AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must both be set")
+	}
+	return Credentials{AccessKeyID: id, SecretAccessKey: secret, SessionToken: os.Getenv("AWS_SESSION_TOKEN")}, nil
+}
+
+// webIdentityCredentials exchanges the token in AWS_WEB_IDENTITY_TOKEN_FILE
+// for the role in AWS_ROLE_ARN, as set up by EKS IAM roles for service
+// accounts.
+type webIdentityCredentials struct{}
+
+func (webIdentityCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	tokenFile, roleARN := os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE"), os.Getenv("AWS_ROLE_ARN")
+	if tokenFile == "" || roleARN == "" {
+		return Credentials{}, errNoCredentials
+	}
+	return assumeRoleWithWebIdentity(ctx, envRegion(), roleARN, os.Getenv("AWS_ROLE_SESSION_NAME"), tokenFile)
+}
+
+// containerCredentials reads the ECS (or EKS Pod Identity) credentials
+// endpoint named by AWS_CONTAINER_CREDENTIALS_RELATIVE_URI or
+// AWS_CONTAINER_CREDENTIALS_FULL_URI.
+type containerCredentials struct{}
+
+func (containerCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	var endpoint string
+	if relative := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); relative != "" {
+		endpoint = defaultContainerEndpoint + relative
+	} else if full := os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI"); full != "" {
+		if err := checkContainerEndpoint(full); err != nil {
+			return Credentials{}, err
+		}
+		endpoint = full
+	} else {
+		return Credentials{}, errNoCredentials
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("bedrock: create container credentials request: %w", err)
+	}
+	token := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN")
+	if file := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE"); file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("bedrock: read container authorization token: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	if token != "" {
+		httpReq.Header.Set("Authorization", token)
+	}
+	var out metadataCredentials
+	if err := getJSON(httpReq, "container credentials", &out); err != nil {
+		return Credentials{}, err
+	}
+	return out.credentials()
+}
+
+// checkContainerEndpoint allows plain HTTP only to loopback and the ECS and
+// EKS link-local endpoints, as the AWS SDKs do.
+func checkContainerEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("bedrock: invalid AWS_CONTAINER_CREDENTIALS_FULL_URI: %w", err)
+	}
+	if u.Scheme == "https" {
+		return nil
+	}
+	host := u.Hostname()
+	switch host {
+	case "localhost", "169.254.170.2", "169.254.170.23", "fd00:ec2::23":
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return nil
+	}
+	return fmt.Errorf("bedrock: AWS_CONTAINER_CREDENTIALS_FULL_URI host %q must be loopback or use https", host)
+}
+
+// imdsCredentials reads the instance role from the EC2 instance metadata
+// service with an IMDSv2 session token.
+type imdsCredentials struct{}
+
+func (imdsCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	if strings.EqualFold(os.Getenv("AWS_EC2_METADATA_DISABLED"), "true") {
+		return Credentials{}, errNoCredentials
+	}
+	endpoint := strings.TrimRight(os.Getenv("AWS_EC2_METADATA_SERVICE_ENDPOINT"), "/")
+	if endpoint == "" {
+		endpoint = defaultIMDSEndpoint
+	}
+	ctx, cancel := context.WithTimeout(ctx, imdsTimeout)
+	defer cancel()
+	tokenReq, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint+"/latest/api/token", nil)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("bedrock: create metadata token request: %w", err)
+	}
+	tokenReq.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "21600")
+	tokenResp, err := credentialsHTTPClient.Do(tokenReq)
+	if err != nil {
+		// Not running on EC2, or the metadata service is unreachable.
+		return Credentials{}, errNoCredentials
+	}
+	token, err := readMetadata(tokenResp, "metadata token")
+	if err != nil {
+		return Credentials{}, err
+	}
+	get := func(path string) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("bedrock: create metadata request: %w", err)
+		}
+		req.Header.Set("X-aws-ec2-metadata-token", token)
+		return req, nil
+	}
+	rolesReq, err := get("/latest/meta-data/iam/security-credentials/")
+	if err != nil {
+		return Credentials{}, err
+	}
+	rolesResp, err := credentialsHTTPClient.Do(rolesReq)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("bedrock: metadata request failed: %w", err)
+	}
+	if rolesResp.StatusCode == http.StatusNotFound {
+		rolesResp.Body.Close()
+		return Credentials{}, errNoCredentials
+	}
+	roles, err := readMetadata(rolesResp, "instance roles")
+	if err != nil {
+		return Credentials{}, err
+	}
+	role, _, _ := strings.Cut(strings.TrimSpace(roles), "\n")
+	if role == "" {
+		return Credentials{}, errNoCredentials
+	}
+	credsReq, err := get("/latest/meta-data/iam/security-credentials/" + url.PathEscape(role))
+	if err != nil {
+		return Credentials{}, err
+	}
+	var out metadataCredentials
+	if err := getJSON(credsReq, "instance credentials", &out); err != nil {
+		return Credentials{}, err
+	}
+	if out.Code != "" && out.Code != "Success" {
+		return Credentials{}, litellm.NewAuthError("bedrock", fmt.Sprintf("instance credentials unavailable: %s", out.Code))
+	}
+	return out.credentials()
+}
+
+// metadataCredentials is the document served by the container and
+// instance metadata endpoints.
+type metadataCredentials struct {
+	Code            string `json:"Code"`
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	Token           string `json:"Token"`
+	Expiration      string `json:"Expiration"`
+}
+
+func (m metadataCredentials) credentials() (Credentials, error) {
+	if m.AccessKeyID == "" || m.SecretAccessKey == "" {
+		return Credentials{}, fmt.Errorf("bedrock: credentials response has no access key")
+	}
+	credentials := Credentials{AccessKeyID: m.AccessKeyID, SecretAccessKey: m.SecretAccessKey, SessionToken: m.Token}
+	if m.Expiration != "" {
+		expires, err := time.Parse(time.RFC3339, m.Expiration)
+		if err != nil {
+			return Credentials{}, fmt.Errorf("bedrock: parse credentials expiration: %w", err)
+		}
+		credentials.Expires = expires
+	}
+	return credentials, nil
+}
+
+func readMetadata(resp *http.Response, what string) (string, error) {
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("bedrock: read %s: %w", what, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", litellm.NewHTTPError("bedrock", resp.StatusCode, string(data))
+	}
+	return string(data), nil
+}
+
+func getJSON(req *http.Request, what string, out any) error {
+	resp, err := credentialsHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("bedrock: %s request failed: %w", what, err)
+	}
+	data, err := readMetadata(resp, what)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(data), out); err != nil {
+		return fmt.Errorf("bedrock: decode %s: %w", what, err)
+	}
+	return nil
+}
+
+// stsResponse holds the credentials returned by AssumeRole and
+// AssumeRoleWithWebIdentity.
+type stsResponse struct {
+	AssumeRole  stsCredentials `xml:"AssumeRoleResult>Credentials"`
+	WebIdentity stsCredentials `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+}
+
+type stsCredentials struct {
+	AccessKeyID     string    `xml:"AccessKeyId"`
+	SecretAccessKey string    `xml:"SecretAccessKey"`
+	SessionToken    string    `xml:"SessionToken"`
+	Expiration      time.Time `xml:"Expiration"`
+}
+
+type assumeRoleInput struct {
+	roleARN     string
+	sessionName string
+	externalID  string
+	duration    int
+}
+
+// assumeRole calls STS AssumeRole, signed with source.
+func assumeRole(ctx context.Context, region string, source Credentials, in assumeRoleInput) (Credentials, error) {
+	form := url.Values{
+		"Action":          {"AssumeRole"},
+		"Version":         {"2011-06-15"},
+		"RoleArn":         {in.roleARN},
+		"RoleSessionName": {sessionName(in.sessionName)},
+	}
+	if in.externalID != "" {
+		form.Set("ExternalId", in.externalID)
+	}
+	if in.duration > 0 {
+		form.Set("DurationSeconds", strconv.Itoa(in.duration))
+	}
+	httpReq, err := newSTSRequest(ctx, region, form)
+	if err != nil {
+		return Credentials{}, err
+	}
+	source.Region = region
+	if err := signRequestAs(httpReq, []byte(form.Encode()), source, "sts"); err != nil {
+		return Credentials{}, fmt.Errorf("bedrock: sign AssumeRole request: %w", err)
+	}
+	out, err := callSTS(httpReq)
+	if err != nil {
+		return Credentials{}, err
+	}
+	return out.AssumeRole.credentials()
+}
+
+// assumeRoleWithWebIdentity calls STS AssumeRoleWithWebIdentity, which is
+// authorized by the token itself rather than a signature.
+func assumeRoleWithWebIdentity(ctx context.Context, region, roleARN, session, tokenFile string) (Credentials, error) {
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("bedrock: read web identity token: %w", err)
+	}
+	form := url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"Version":          {"2011-06-15"},
+		"RoleArn":          {roleARN},
+		"RoleSessionName":  {sessionName(session)},
+		"WebIdentityToken": {strings.TrimSpace(string(token))},
+	}
+	httpReq, err := newSTSRequest(ctx, region, form)
+	if err != nil {
+		return Credentials{}, err
+	}
+	out, err := callSTS(httpReq)
+	if err != nil {
+		return Credentials{}, err
+	}
+	return out.WebIdentity.credentials()
+}
+
+func newSTSRequest(ctx context.Context, region string, form url.Values) (*http.Request, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, stsEndpoint(region), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("bedrock: create STS request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return httpReq, nil
+}
+
+func callSTS(httpReq *http.Request) (*stsResponse, error) {
+	resp, err := credentialsHTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, litellm.NewNetworkError("bedrock", "STS request failed", err)
+	}
+	data, err := readMetadata(resp, "STS response")
+	if err != nil {
+		return nil, err
+	}
+	var out stsResponse
+	if err := xml.Unmarshal([]byte(data), &out); err != nil {
+		return nil, fmt.Errorf("bedrock: decode STS response: %w", err)
+	}
+	return &out, nil
+}
+
+func (c stsCredentials) credentials() (Credentials, error) {
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		return Credentials{}, fmt.Errorf("bedrock: STS response has no credentials")
+	}
+	return Credentials{
+		AccessKeyID:     c.AccessKeyID,
+		SecretAccessKey: c.SecretAccessKey,
+		SessionToken:    c.SessionToken,
+		Expires:         c.Expiration,
+	}, nil
+}
+
+// stsEndpoint honors AWS_ENDPOINT_URL_STS and AWS_ENDPOINT_URL before the
+// regional endpoint.
+func stsEndpoint(region string) string {
+	if endpoint := firstEnv("AWS_ENDPOINT_URL_STS", "AWS_ENDPOINT_URL"); endpoint != "" {
+		return strings.TrimRight(endpoint, "/") + "/"
+	}
+	return fmt.Sprintf("https://sts.%s.amazonaws.com/", region)
+}
+
+func sessionName(name string) string {
+	if name != "" {
+		return name
+	}
+	return fmt.Sprintf("litellm-%d", time.Now().UnixNano())
+}
+
+func envRegion() string {
+	if region := firstEnv("AWS_REGION", "AWS_DEFAULT_REGION"); region != "" {
+		return region
+	}
+	return defaultRegion
+}
+
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,331 @@
+package bedrock
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/voocel/litellm"
+)
+
+// isolateAWSEnv clears every variable the credential chain reads so tests
+// only see the sources they configure.
+func isolateAWSEnv(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range []string{
+		"AWS_ACCESS_KEY_ID", "AWS_ACCESS_KEY", "AWS_SECRET_ACCESS_KEY", "AWS_SECRET_KEY", "AWS_SESSION_TOKEN",
+		"AWS_PROFILE", "AWS_REGION", "AWS_DEFAULT_REGION", "AWS_ENDPOINT_URL", "AWS_ENDPOINT_URL_STS",
+		"AWS_WEB_IDENTITY_TOKEN_FILE", "AWS_ROLE_ARN", "AWS_ROLE_SESSION_NAME",
+		"AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "AWS_CONTAINER_CREDENTIALS_FULL_URI",
+		"AWS_CONTAINER_AUTHORIZATION_TOKEN", "AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE",
+		"AWS_EC2_METADATA_SERVICE_ENDPOINT",
+	} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	return dir
+}
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func stsServer(t *testing.T, check func(r *http.Request)) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		check(r)
+		action := r.PostForm.Get("Action")
+		fmt.Fprintf(w, `<%sResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><%sResult><Credentials><AccessKeyId>ASIAROLE</AccessKeyId><SecretAccessKey>role-secret</SecretAccessKey><SessionToken>role-token</SessionToken><Expiration>2030-01-02T03:04:05Z</Expiration></Credentials></%sResult></%sResponse>`, action, action, action, action)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDefaultCredentialsPrefersEnvironment(t *testing.T) {
+	dir := isolateAWSEnv(t)
+	writeFile(t, filepath.Join(dir, "credentials"), "[default]\naws_access_key_id = PROFILEKEY\naws_secret_access_key = profile-secret\n")
+	t.Setenv("AWS_ACCESS_KEY_ID", "ENVKEY")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "env-secret")
+	t.Setenv("AWS_SESSION_TOKEN", "env-token")
+	credentials, err := DefaultCredentials().Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("Credentials returned error: %v", err)
+	}
+	if credentials.AccessKeyID != "ENVKEY" || credentials.SessionToken != "env-token" {
+		t.Fatalf("credentials = %+v", credentials)
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	credentials, err = DefaultCredentials().Credentials(context.Background())
+	if err != nil || credentials.AccessKeyID != "PROFILEKEY" {
+		t.Fatalf("credentials = %+v, %v; want default profile", credentials, err)
+	}
+}
+
+func TestDefaultCredentialsReportsMissingCredentials(t *testing.T) {
+	isolateAWSEnv(t)
+	_, err := DefaultCredentials().Credentials(context.Background())
+	if !litellm.IsAuthError(err) {
+		t.Fatalf("Credentials error = %v, want auth error", err)
+	}
+	t.Setenv("AWS_PROFILE", "missing")
+	_, err = DefaultCredentials().Credentials(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `profile "missing" not found`) {
+		t.Fatalf("Credentials error = %v, want missing profile", err)
+	}
+}
+
+func TestProfileAssumesRoleWithSourceProfile(t *testing.T) {
+	dir := isolateAWSEnv(t)
+	server := stsServer(t, func(r *http.Request) {
+		if r.PostForm.Get("Action") != "AssumeRole" || r.PostForm.Get("RoleArn") != "arn:aws:iam::123456789012:role/dev" || r.PostForm.Get("ExternalId") != "ext" {
+			t.Fatalf("form = %v", r.PostForm)
+		}
+		auth := r.Header.Get("Authorization")
+		if !strings.Contains(auth, "Credential=BASEKEY/") || !strings.Contains(auth, "/eu-west-1/sts/aws4_request") {
+			t.Fatalf("Authorization = %q", auth)
+		}
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+			t.Fatalf("Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+	})
+	t.Setenv("AWS_ENDPOINT_URL_STS", server.URL)
+	writeFile(t, filepath.Join(dir, "config"), strings.Join([]string{
+		"[profile dev]",
+		"role_arn = arn:aws:iam::123456789012:role/dev",
+		"source_profile = base",
+		"external_id = ext",
+		"region = eu-west-1",
+		"s3 =",
+		"  max_concurrent_requests = 10",
+		"",
+	}, "\n"))
+	writeFile(t, filepath.Join(dir, "credentials"), "[base]\naws_access_key_id = BASEKEY\naws_secret_access_key = base-secret\n")
+	credentials, err := ProfileCredentials("dev").Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("Credentials returned error: %v", err)
+	}
+	if credentials.AccessKeyID != "ASIAROLE" || credentials.SessionToken != "role-token" || !credentials.Expires.Equal(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatalf("credentials = %+v", credentials)
+	}
+}
+
+func TestProfileRunsCredentialProcess(t *testing.T) {
+	dir := isolateAWSEnv(t)
+	output := filepath.Join(dir, "process.json")
+	writeFile(t, output, `{"Version":1,"AccessKeyId":"PROCKEY","SecretAccessKey":"proc-secret","SessionToken":"proc-token","Expiration":"2030-01-02T03:04:05Z"}`)
+	writeFile(t, filepath.Join(dir, "config"), "[default]\ncredential_process = cat "+output+"\n")
+	credentials, err := DefaultCredentials().Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("Credentials returned error: %v", err)
+	}
+	if credentials.AccessKeyID != "PROCKEY" || credentials.SessionToken != "proc-token" || credentials.Expires.IsZero() {
+		t.Fatalf("credentials = %+v", credentials)
+	}
+}
+
+func TestWebIdentityCredentials(t *testing.T) {
+	dir := isolateAWSEnv(t)
+	server := stsServer(t, func(r *http.Request) {
+		if r.PostForm.Get("Action") != "AssumeRoleWithWebIdentity" || r.PostForm.Get("WebIdentityToken") != "jwt-token" || r.PostForm.Get("RoleSessionName") != "pod" {
+			t.Fatalf("form = %v", r.PostForm)
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Fatalf("Authorization = %q, want unsigned", r.Header.Get("Authorization"))
+		}
+	})
+	tokenFile := filepath.Join(dir, "token")
+	writeFile(t, tokenFile, "jwt-token\n")
+	t.Setenv("AWS_ENDPOINT_URL_STS", server.URL)
+	t.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", tokenFile)
+	t.Setenv("AWS_ROLE_ARN", "arn:aws:iam::123456789012:role/pod")
+	t.Setenv("AWS_ROLE_SESSION_NAME", "pod")
+	credentials, err := DefaultCredentials().Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("Credentials returned error: %v", err)
+	}
+	if credentials.AccessKeyID != "ASIAROLE" || credentials.Expires.IsZero() {
+		t.Fatalf("credentials = %+v", credentials)
+	}
+}
+
+func TestContainerCredentials(t *testing.T) {
+	dir := isolateAWSEnv(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/creds" || r.Header.Get("Authorization") != "pod-identity-token" {
+			t.Fatalf("request = %s %v", r.URL.Path, r.Header)
+		}
+		fmt.Fprint(w, `{"AccessKeyId":"ECSKEY","SecretAccessKey":"ecs-secret","Token":"ecs-token","Expiration":"2030-01-02T03:04:05Z"}`)
+	}))
+	defer server.Close()
+	tokenFile := filepath.Join(dir, "token")
+	writeFile(t, tokenFile, "pod-identity-token")
+	t.Setenv("AWS_CONTAINER_CREDENTIALS_FULL_URI", server.URL+"/creds")
+	t.Setenv("AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE", tokenFile)
+	credentials, err := DefaultCredentials().Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("Credentials returned error: %v", err)
+	}
+	if credentials.AccessKeyID != "ECSKEY" || credentials.SessionToken != "ecs-token" {
+		t.Fatalf("credentials = %+v", credentials)
+	}
+
+	t.Setenv("AWS_CONTAINER_CREDENTIALS_FULL_URI", "http://example.com/creds")
+	if _, err := DefaultCredentials().Credentials(context.Background()); err == nil || !strings.Contains(err.Error(), "must be loopback") {
+		t.Fatalf("Credentials error = %v, want loopback error", err)
+	}
+}
+
+func TestIMDSCredentialsUseSessionToken(t *testing.T) {
+	isolateAWSEnv(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/latest/api/token":
+			if r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds") == "" {
+				t.Fatalf("token TTL header missing")
+			}
+			fmt.Fprint(w, "session-token")
+			return
+		case r.Header.Get("X-aws-ec2-metadata-token") != "session-token":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/latest/meta-data/iam/security-credentials/":
+			fmt.Fprint(w, "instance-role\n")
+		case r.URL.Path == "/latest/meta-data/iam/security-credentials/instance-role":
+			fmt.Fprint(w, `{"Code":"Success","AccessKeyId":"EC2KEY","SecretAccessKey":"ec2-secret","Token":"ec2-token","Expiration":"2030-01-02T03:04:05Z"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "")
+	t.Setenv("AWS_EC2_METADATA_SERVICE_ENDPOINT", server.URL)
+	credentials, err := DefaultCredentials().Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("Credentials returned error: %v", err)
+	}
+	if credentials.AccessKeyID != "EC2KEY" || credentials.SessionToken != "ec2-token" {
+		t.Fatalf("credentials = %+v", credentials)
+	}
+}
+
+type expiringCredentials struct {
+	calls atomic.Int32
+	ttl   time.Duration
+}
+
+func (c *expiringCredentials) Credentials(context.Context) (Credentials, error) {
+	n := c.calls.Add(1)
+	time.Sleep(time.Millisecond)
+	return Credentials{AccessKeyID: fmt.Sprintf("KEY%d", n), SecretAccessKey: "secret", Expires: time.Now().Add(c.ttl)}, nil
+}
+
+func TestCachedCredentialsRefreshBeforeExpiry(t *testing.T) {
+	source := &expiringCredentials{ttl: 10 * time.Minute}
+	cached := newCachedCredentials(source)
+	now := time.Now()
+	cached.now = func() time.Time { return now }
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cached.Credentials(context.Background()); err != nil {
+				t.Errorf("Credentials returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := source.calls.Load(); got != 1 {
+		t.Fatalf("calls = %d, want 1", got)
+	}
+	now = now.Add(6 * time.Minute)
+	credentials, err := cached.Credentials(context.Background())
+	if err != nil || credentials.AccessKeyID != "KEY2" {
+		t.Fatalf("credentials = %+v, %v; want refreshed KEY2", credentials, err)
+	}
+}
+
+type scriptedCredentials struct {
+	calls   atomic.Int32
+	respond func(n int32) (Credentials, error)
+}
+
+func (c *scriptedCredentials) Credentials(context.Context) (Credentials, error) {
+	return c.respond(c.calls.Add(1))
+}
+
+func TestCachedCredentialsServeCachedDuringRefresh(t *testing.T) {
+	now := time.Now()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	source := &scriptedCredentials{respond: func(n int32) (Credentials, error) {
+		if n == 1 {
+			return Credentials{AccessKeyID: "KEY1", Expires: now.Add(10 * time.Minute)}, nil
+		}
+		close(started)
+		<-release
+		return Credentials{}, fmt.Errorf("sts unavailable")
+	}}
+	cached := newCachedCredentials(source)
+	cached.now = func() time.Time { return now }
+	if _, err := cached.Credentials(context.Background()); err != nil {
+		t.Fatalf("Credentials returned error: %v", err)
+	}
+	now = now.Add(6 * time.Minute)
+	refreshed := make(chan error)
+	go func() {
+		credentials, err := cached.Credentials(context.Background())
+		if err == nil && credentials.AccessKeyID != "KEY1" {
+			err = fmt.Errorf("credentials = %+v", credentials)
+		}
+		refreshed <- err
+	}()
+	<-started
+	if credentials, err := cached.Credentials(context.Background()); err != nil || credentials.AccessKeyID != "KEY1" {
+		t.Fatalf("credentials during refresh = %+v, %v", credentials, err)
+	}
+	close(release)
+	if err := <-refreshed; err != nil {
+		t.Fatalf("failed refresh should keep unexpired credentials: %v", err)
+	}
+}
+
+func TestCachedCredentialsRememberChainMiss(t *testing.T) {
+	source := &scriptedCredentials{respond: func(int32) (Credentials, error) {
+		return Credentials{}, errNoCredentials
+	}}
+	cached := newCachedCredentials(credentialsChain{source})
+	now := time.Now()
+	cached.now = func() time.Time { return now }
+	for range 3 {
+		if _, err := cached.Credentials(context.Background()); !litellm.IsAuthError(err) {
+			t.Fatalf("expected auth error, got %v", err)
+		}
+	}
+	if got := source.calls.Load(); got != 1 {
+		t.Fatalf("calls = %d, want 1", got)
+	}
+	now = now.Add(credentialsMissTTL + time.Second)
+	_, _ = cached.Credentials(context.Background())
+	if got := source.calls.Load(); got != 2 {
+		t.Fatalf("calls after miss TTL = %d, want 2", got)
+	}
+}
@@ -0,0 +1,186 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/voocel/litellm"
+)
+
+const (
+	ProviderOptionTruncate = "truncate"
+
+	inputTokenCountHeader = "X-Amzn-Bedrock-Input-Token-Count"
+)
+
+type titanEmbedRequest struct {
+	InputText  string `json:"inputText"`
+	Dimensions *int   `json:"dimensions,omitempty"`
+}
+
+type titanEmbedResponse struct {
+	Embedding           []float64 `json:"embedding"`
+	InputTextTokenCount int       `json:"inputTextTokenCount"`
+}
+
+type cohereEmbedRequest struct {
+	Texts           []string `json:"texts"`
+	InputType       string   `json:"input_type"`
+	Truncate        string   `json:"truncate,omitempty"`
+	OutputDimension *int     `json:"output_dimension,omitempty"`
+}
+
+type cohereEmbedResponse struct {
+	Embeddings json.RawMessage `json:"embeddings"`
+}
+
+// Embed calls InvokeModel for Amazon Titan and Cohere embedding models. Titan
+// accepts one text per invocation, so multiple inputs are sent sequentially.
+func (p *Provider) Embed(ctx context.Context, req *litellm.EmbedRequest) (*litellm.EmbedResponse, error) {
+	switch embedFamily(req.Model) {
+	case "titan":
+		return p.embedTitan(ctx, req)
+	case "cohere":
+		return p.embedCohere(ctx, req)
+	default:
+		return nil, litellm.WrapValidationError(p.Name(), fmt.Errorf("bedrock: model %q is not a supported Titan or Cohere embedding model", req.Model))
+	}
+}
+
+func (p *Provider) embedTitan(ctx context.Context, req *litellm.EmbedRequest) (*litellm.EmbedResponse, error) {
+	for key := range req.ProviderOptions {
+		return nil, litellm.WrapValidationError(p.Name(), fmt.Errorf("bedrock: unsupported embed provider option %q", key))
+	}
+	out := &litellm.EmbedResponse{Model: req.Model, Provider: p.Name()}
+	for _, input := range req.Input {
+		body, err := json.Marshal(titanEmbedRequest{InputText: input, Dimensions: req.Dimensions})
+		if err != nil {
+			return nil, fmt.Errorf("bedrock: marshal embed request: %w", err)
+		}
+		data, header, err := p.invoke(ctx, req.Model, body)
+		if err != nil {
+			return nil, err
+		}
+		var parsed titanEmbedResponse
+		if err := json.Unmarshal(data, &parsed); err != nil {
+			return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "bedrock: decode embed response", err)
+		}
+		out.Embeddings = append(out.Embeddings, parsed.Embedding)
+		tokens := parsed.InputTextTokenCount
+		if tokens == 0 {
+			tokens = headerTokenCount(header.Get(inputTokenCountHeader))
+		}
+		out.Usage.InputTokens += tokens
+	}
+	out.Usage.TotalTokens = out.Usage.InputTokens
+	return out, nil
+}
+
+func (p *Provider) embedCohere(ctx context.Context, req *litellm.EmbedRequest) (*litellm.EmbedResponse, error) {
+	wire, err := buildCohereEmbedRequest(req)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	body, err := json.Marshal(wire)
+	if err != nil {
+		return nil, fmt.Errorf("bedrock: marshal embed request: %w", err)
+	}
+	data, header, err := p.invoke(ctx, req.Model, body)
+	if err != nil {
+		return nil, err
+	}
+	var parsed cohereEmbedResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "bedrock: decode embed response", err)
+	}
+	vectors, err := cohereVectors(parsed.Embeddings)
+	if err != nil {
+		return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "bedrock: decode embed vectors", err)
+	}
+	tokens := headerTokenCount(header.Get(inputTokenCountHeader))
+	return &litellm.EmbedResponse{
+		Embeddings: vectors,
+		Model:      req.Model,
+		Provider:   p.Name(),
+		Usage:      litellm.Usage{InputTokens: tokens, TotalTokens: tokens},
+	}, nil
+}
+
+func buildCohereEmbedRequest(req *litellm.EmbedRequest) (*cohereEmbedRequest, error) {
+	inputType, err := cohereInputType(req.TaskType)
+	if err != nil {
+		return nil, err
+	}
+	out := &cohereEmbedRequest{
+		Texts:           append([]string(nil), req.Input...),
+		InputType:       inputType,
+		OutputDimension: req.Dimensions,
+	}
+	for key, value := range req.ProviderOptions {
+		switch key {
+		case ProviderOptionTruncate:
+			v, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("bedrock: provider option %q must be string", key)
+			}
+			out.Truncate = strings.ToUpper(v)
+		default:
+			return nil, fmt.Errorf("bedrock: unsupported embed provider option %q", key)
+		}
+	}
+	return out, nil
+}
+
+// cohereVectors accepts both the v3 shape ([][]float) and the v4 typed shape
+// ({"float": [][]float}).
+func cohereVectors(raw json.RawMessage) ([][]float64, error) {
+	var vectors [][]float64
+	if err := json.Unmarshal(raw, &vectors); err == nil {
+		return vectors, nil
+	}
+	var typed struct {
+		Float [][]float64 `json:"float"`
+	}
+	if err := json.Unmarshal(raw, &typed); err != nil {
+		return nil, err
+	}
+	return typed.Float, nil
+}
+
+func cohereInputType(taskType string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(taskType)) {
+	case "", litellm.EmbedTaskRetrievalDocument:
+		return "search_document", nil
+	case litellm.EmbedTaskRetrievalQuery:
+		return "search_query", nil
+	case litellm.EmbedTaskClassification:
+		return "classification", nil
+	case litellm.EmbedTaskClustering:
+		return "clustering", nil
+	default:
+		return "", fmt.Errorf("bedrock: unsupported cohere embed task type %q", taskType)
+	}
+}
+
+func embedFamily(model string) string {
+	model = strings.ToLower(model)
+	switch {
+	case strings.Contains(model, "amazon.titan-embed"):
+		return "titan"
+	case strings.Contains(model, "cohere.embed"):
+		return "cohere"
+	default:
+		return ""
+	}
+}
+
+func headerTokenCount(value string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
@@ -11,32 +11,92 @@ func (s *stream) readEventStreamMessage() ([]byte, error) {
 }
 
 func readEventStreamMessage(reader *bufio.Reader) ([]byte, error) {
+	_, payload, err := readEventStreamFrame(reader)
+	return payload, err
+}
+
+// readEventStreamFrame reads one message and its string headers, such as
+// :message-type and :exception-type.
+func readEventStreamFrame(reader *bufio.Reader) (map[string]string, []byte, error) {
 	prelude := make([]byte, 12)
 	if _, err := io.ReadFull(reader, prelude); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	totalLength := readBigEndianUint32(prelude[0:4])
 	headersLength := readBigEndianUint32(prelude[4:8])
 	if totalLength < 16 || totalLength > 16*1024*1024 {
-		return nil, fmt.Errorf("invalid message length: %d", totalLength)
+		return nil, nil, fmt.Errorf("invalid message length: %d", totalLength)
 	}
 	if headersLength > totalLength-16 {
-		return nil, fmt.Errorf("invalid headers length: %d > %d", headersLength, totalLength-16)
+		return nil, nil, fmt.Errorf("invalid headers length: %d > %d", headersLength, totalLength-16)
 	}
 	remaining := make([]byte, totalLength-12)
 	if _, err := io.ReadFull(reader, remaining); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	headers, err := parseEventStreamHeaders(remaining[:headersLength])
+	if err != nil {
+		return nil, nil, err
 	}
 	payloadLength := totalLength - headersLength - 16
 	if payloadLength == 0 {
-		return nil, nil
+		return headers, nil, nil
 	}
 	payloadStart := int(headersLength)
 	payloadEnd := payloadStart + int(payloadLength)
 	if payloadEnd > len(remaining)-4 || payloadStart > payloadEnd {
-		return nil, fmt.Errorf("invalid payload bounds: start=%d, end=%d, remaining=%d", payloadStart, payloadEnd, len(remaining))
+		return nil, nil, fmt.Errorf("invalid payload bounds: start=%d, end=%d, remaining=%d", payloadStart, payloadEnd, len(remaining))
+	}
+	return headers, remaining[payloadStart:payloadEnd], nil
+}
+
+// parseEventStreamHeaders keeps string-valued headers and skips the rest.
+func parseEventStreamHeaders(data []byte) (map[string]string, error) {
+	headers := make(map[string]string)
+	for len(data) > 0 {
+		nameLen := int(data[0])
+		if len(data) < 2+nameLen {
+			return nil, fmt.Errorf("invalid header name length: %d", nameLen)
+		}
+		name := string(data[1 : 1+nameLen])
+		valueType := data[1+nameLen]
+		data = data[2+nameLen:]
+		var size int
+		switch valueType {
+		case 0, 1:
+			size = 0
+		case 2:
+			size = 1
+		case 3:
+			size = 2
+		case 4:
+			size = 4
+		case 5, 8:
+			size = 8
+		case 9:
+			size = 16
+		case 6, 7:
+			if len(data) < 2 {
+				return nil, fmt.Errorf("invalid header %q value", name)
+			}
+			n := int(data[0])<<8 | int(data[1])
+			if len(data) < 2+n {
+				return nil, fmt.Errorf("invalid header %q value length: %d", name, n)
+			}
+			if valueType == 7 {
+				headers[name] = string(data[2 : 2+n])
+			}
+			data = data[2+n:]
+			continue
+		default:
+			return nil, fmt.Errorf("invalid header %q type: %d", name, valueType)
+		}
+		if len(data) < size {
+			return nil, fmt.Errorf("invalid header %q value", name)
+		}
+		data = data[size:]
 	}
-	return remaining[payloadStart:payloadEnd], nil
+	return headers, nil
 }
 
 func readBigEndianUint32(b []byte) uint32 {
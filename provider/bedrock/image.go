@@ -0,0 +1,258 @@
+package bedrock
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/voocel/litellm"
+)
+
+// Image provider options. ProviderOptionQuality applies to Titan and Nova
+// Canvas only, e.g. "premium".
+const (
+	ProviderOptionNegativePrompt = "negative_prompt"
+	ProviderOptionSeed           = "seed"
+	ProviderOptionQuality        = "quality"
+)
+
+type canvasRequest struct {
+	TaskType              string                 `json:"taskType"`
+	TextToImageParams     *canvasTextParams      `json:"textToImageParams,omitempty"`
+	InPaintingParams      *canvasInPaintParams   `json:"inPaintingParams,omitempty"`
+	ImageVariationParams  *canvasVariationParams `json:"imageVariationParams,omitempty"`
+	ImageGenerationConfig canvasGenerationConfig `json:"imageGenerationConfig"`
+}
+
+type canvasTextParams struct {
+	Text         string `json:"text"`
+	NegativeText string `json:"negativeText,omitempty"`
+}
+
+type canvasInPaintParams struct {
+	Image        string `json:"image"`
+	MaskImage    string `json:"maskImage"`
+	Text         string `json:"text"`
+	NegativeText string `json:"negativeText,omitempty"`
+}
+
+type canvasVariationParams struct {
+	Images       []string `json:"images"`
+	Text         string   `json:"text"`
+	NegativeText string   `json:"negativeText,omitempty"`
+}
+
+type canvasGenerationConfig struct {
+	NumberOfImages int    `json:"numberOfImages,omitempty"`
+	Width          int    `json:"width,omitempty"`
+	Height         int    `json:"height,omitempty"`
+	Quality        string `json:"quality,omitempty"`
+	Seed           *int   `json:"seed,omitempty"`
+}
+
+type stabilityRequest struct {
+	Prompt         string   `json:"prompt"`
+	Mode           string   `json:"mode,omitempty"`
+	AspectRatio    string   `json:"aspect_ratio,omitempty"`
+	Image          string   `json:"image,omitempty"`
+	Strength       *float64 `json:"strength,omitempty"`
+	NegativePrompt string   `json:"negative_prompt,omitempty"`
+	Seed           *int     `json:"seed,omitempty"`
+	OutputFormat   string   `json:"output_format"`
+}
+
+type imageResponse struct {
+	Images        []string  `json:"images"`
+	FinishReasons []*string `json:"finish_reasons"`
+	Error         string    `json:"error"`
+}
+
+type imageOptions struct {
+	negativePrompt string
+	seed           *int
+	quality        string
+}
+
+// GenerateImage calls InvokeModel for Amazon Titan Image Generator, Nova
+// Canvas and Stability AI image models. Canvas models edit with a mask as
+// INPAINTING and without one as IMAGE_VARIATION; Stability models return one
+// image per invocation, so N images are generated sequentially.
+func (p *Provider) GenerateImage(ctx context.Context, req *litellm.ImageRequest) (*litellm.ImageResponse, error) {
+	options, err := parseImageOptions(req.ProviderOptions)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	var bodies [][]byte
+	switch imageFamily(req.Model) {
+	case "canvas":
+		bodies, err = buildCanvasRequest(req, options)
+	case "stability":
+		bodies, err = buildStabilityRequests(req, options)
+	default:
+		err = fmt.Errorf("bedrock: model %q is not a supported Titan, Nova Canvas or Stability image model", req.Model)
+	}
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	out := &litellm.ImageResponse{Model: req.Model, Provider: p.Name()}
+	for _, body := range bodies {
+		data, _, err := p.invoke(ctx, req.Model, body)
+		if err != nil {
+			return nil, err
+		}
+		var parsed imageResponse
+		if err := json.Unmarshal(data, &parsed); err != nil {
+			return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "bedrock: decode image response", err)
+		}
+		if parsed.Error != "" {
+			return nil, litellm.NewProviderError(p.Name(), litellm.ErrorTypeContentFilter, "bedrock: image generation failed: "+parsed.Error)
+		}
+		for i, encoded := range parsed.Images {
+			if i < len(parsed.FinishReasons) && parsed.FinishReasons[i] != nil {
+				return nil, litellm.NewProviderError(p.Name(), litellm.ErrorTypeContentFilter, "bedrock: image generation failed: "+*parsed.FinishReasons[i])
+			}
+			image, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "bedrock: decode image data", err)
+			}
+			out.Images = append(out.Images, litellm.ImageBlock{Data: image, MIME: "image/png"})
+		}
+	}
+	return out, nil
+}
+
+func buildCanvasRequest(req *litellm.ImageRequest, options imageOptions) ([][]byte, error) {
+	if req.AspectRatio != "" {
+		return nil, fmt.Errorf("bedrock: image aspect ratio is not supported by %s; use Size", req.Model)
+	}
+	wire := canvasRequest{ImageGenerationConfig: canvasGenerationConfig{
+		NumberOfImages: req.N,
+		Quality:        options.quality,
+		Seed:           options.seed,
+	}}
+	if req.Size != "" {
+		width, height, err := litellm.ParseImageSize(req.Size)
+		if err != nil {
+			return nil, err
+		}
+		wire.ImageGenerationConfig.Width = width
+		wire.ImageGenerationConfig.Height = height
+	}
+	switch {
+	case req.Mask != nil:
+		if len(req.Images) != 1 {
+			return nil, fmt.Errorf("bedrock: inpainting takes exactly one source image")
+		}
+		wire.TaskType = "INPAINTING"
+		wire.InPaintingParams = &canvasInPaintParams{
+			Image:        base64.StdEncoding.EncodeToString(req.Images[0].Data),
+			MaskImage:    base64.StdEncoding.EncodeToString(req.Mask.Data),
+			Text:         req.Prompt,
+			NegativeText: options.negativePrompt,
+		}
+	case len(req.Images) > 0:
+		wire.TaskType = "IMAGE_VARIATION"
+		params := &canvasVariationParams{Text: req.Prompt, NegativeText: options.negativePrompt}
+		for _, image := range req.Images {
+			params.Images = append(params.Images, base64.StdEncoding.EncodeToString(image.Data))
+		}
+		wire.ImageVariationParams = params
+	default:
+		wire.TaskType = "TEXT_IMAGE"
+		wire.TextToImageParams = &canvasTextParams{Text: req.Prompt, NegativeText: options.negativePrompt}
+	}
+	body, err := json.Marshal(wire)
+	if err != nil {
+		return nil, fmt.Errorf("bedrock: marshal image request: %w", err)
+	}
+	return [][]byte{body}, nil
+}
+
+func buildStabilityRequests(req *litellm.ImageRequest, options imageOptions) ([][]byte, error) {
+	if req.Size != "" {
+		return nil, fmt.Errorf("bedrock: image size is not supported by %s; use AspectRatio", req.Model)
+	}
+	if req.Mask != nil || len(req.Images) > 1 {
+		return nil, fmt.Errorf("bedrock: %s takes at most one source image and no mask", req.Model)
+	}
+	if options.quality != "" {
+		return nil, fmt.Errorf("bedrock: provider option %q is not supported by %s", ProviderOptionQuality, req.Model)
+	}
+	wire := stabilityRequest{
+		Prompt:         req.Prompt,
+		AspectRatio:    req.AspectRatio,
+		NegativePrompt: options.negativePrompt,
+		Seed:           options.seed,
+		OutputFormat:   "png",
+	}
+	if len(req.Images) == 1 {
+		// Image-to-image derives the output size from the source image and
+		// requires a strength; 0.7 keeps the composition while following the
+		// prompt.
+		wire.Mode = "image-to-image"
+		wire.AspectRatio = ""
+		wire.Image = base64.StdEncoding.EncodeToString(req.Images[0].Data)
+		strength := 0.7
+		wire.Strength = &strength
+	}
+	body, err := json.Marshal(wire)
+	if err != nil {
+		return nil, fmt.Errorf("bedrock: marshal image request: %w", err)
+	}
+	bodies := make([][]byte, max(req.N, 1))
+	for i := range bodies {
+		bodies[i] = body
+	}
+	return bodies, nil
+}
+
+func parseImageOptions(options litellm.ProviderOptions) (imageOptions, error) {
+	var out imageOptions
+	for key, value := range options {
+		switch key {
+		case ProviderOptionNegativePrompt, ProviderOptionQuality:
+			v, ok := value.(string)
+			if !ok {
+				return out, fmt.Errorf("bedrock: provider option %q must be string", key)
+			}
+			if key == ProviderOptionQuality {
+				out.quality = v
+			} else {
+				out.negativePrompt = v
+			}
+		case ProviderOptionSeed:
+			var seed int
+			switch v := value.(type) {
+			case int:
+				seed = v
+			case int64:
+				seed = int(v)
+			case float64:
+				if v != float64(int(v)) {
+					return out, fmt.Errorf("bedrock: provider option %q must be integer", key)
+				}
+				seed = int(v)
+			default:
+				return out, fmt.Errorf("bedrock: provider option %q must be integer", key)
+			}
+			out.seed = &seed
+		default:
+			return out, fmt.Errorf("bedrock: unsupported image provider option %q", key)
+		}
+	}
+	return out, nil
+}
+
+func imageFamily(model string) string {
+	model = strings.ToLower(model)
+	switch {
+	case strings.Contains(model, "amazon.titan-image"), strings.Contains(model, "amazon.nova-canvas"):
+		return "canvas"
+	case strings.Contains(model, "stability."):
+		return "stability"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,324 @@
+package bedrock
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/voocel/litellm"
+	"github.com/voocel/litellm/internal/relabel"
+)
+
+// anthropicVersion is the body field InvokeModel requires in place of the
+// anthropic-version header.
+const anthropicVersion = "bedrock-2023-05-31"
+
+// chatMessages and streamMessages serve APIMessages through the anthropic
+// adapter; its requests reach Bedrock through messagesTransport.
+func (p *Provider) chatMessages(ctx context.Context, req *litellm.Request) (*litellm.Response, error) {
+	if err := validateMessagesRequest(req); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	resp, err := p.messages.Chat(ctx, req)
+	if err != nil {
+		return nil, relabel.Error(err, p.messages.Name(), p.Name())
+	}
+	relabel.Response(resp, p.messages.Name(), p.Name())
+	return resp, nil
+}
+
+func (p *Provider) streamMessages(ctx context.Context, req *litellm.Request) (litellm.Stream, error) {
+	if err := validateMessagesRequest(req); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	inner, err := p.messages.Stream(ctx, req)
+	if err != nil {
+		return nil, relabel.Error(err, p.messages.Name(), p.Name())
+	}
+	return relabel.Stream(inner, p.messages.Name(), p.Name()), nil
+}
+
+// messagesCapabilities follows the anthropic adapter. Bedrock has no
+// Anthropic Files API, so uploaded file IDs are not accepted, and it does
+// not run Anthropic's first-party server tools such as web search.
+func (p *Provider) messagesCapabilities(model string) litellm.Capabilities {
+	caps := p.messages.Capabilities(model)
+	caps.Provider = p.Name()
+	caps.Media.FileURI = litellm.SupportNo
+	caps.Media.DocumentFileURI = litellm.SupportNo
+	caps.Tools.WebSearch = litellm.SupportNo
+	caps.Tools.HostedProviderTools = litellm.SupportPartial
+	return caps
+}
+
+// validateMessagesRequest rejects what messagesCapabilities reports as
+// unsupported before the anthropic adapter would send it to InvokeModel.
+func validateMessagesRequest(req *litellm.Request) error {
+	if req.WebSearch != nil {
+		return fmt.Errorf("bedrock: web search is not supported by the Messages API on Bedrock")
+	}
+	for i, msg := range req.Messages {
+		if err := validateMessagesBlocks(msg.Blocks); err != nil {
+			return fmt.Errorf("bedrock: messages[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateMessagesBlocks(blocks []litellm.Block) error {
+	for _, block := range blocks {
+		switch b := block.(type) {
+		case litellm.ImageBlock:
+			if b.FileURI != "" {
+				return fmt.Errorf("image file URIs are not supported; Bedrock has no Anthropic Files API")
+			}
+		case litellm.DocumentBlock:
+			if b.FileURI != "" {
+				return fmt.Errorf("document file URIs are not supported; Bedrock has no Anthropic Files API")
+			}
+		case litellm.ToolResultBlock:
+			if err := validateMessagesBlocks(b.Content); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Provider) countTokensMessages(ctx context.Context, req *litellm.Request) (*litellm.TokenCount, error) {
+	if err := validateMessagesRequest(req); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	count, err := p.messages.CountTokens(ctx, req)
+	if err != nil {
+		return nil, relabel.Error(err, p.messages.Name(), p.Name())
+	}
+	count.Provider = p.Name()
+	return count, nil
+}
+
+// messagesTransport maps Messages API requests built by the anthropic
+// adapter onto InvokeModel, InvokeModelWithResponseStream and CountTokens,
+// and turns the streamed event-stream response back into Anthropic
+// server-sent events. base signs the rewritten request.
+type messagesTransport struct {
+	baseURL string
+	base    http.RoundTripper
+}
+
+func (t *messagesTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	operation, err := t.rewrite(out)
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(out)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, err
+	}
+	switch operation {
+	case "invoke-with-response-stream":
+		resp.Body = &eventStreamSSE{reader: bufio.NewReader(resp.Body), body: resp.Body}
+		resp.Header.Set("Content-Type", "text/event-stream")
+		resp.ContentLength = -1
+	case "count-tokens":
+		return messagesCountTokensResponse(resp)
+	}
+	return resp, nil
+}
+
+// rewrite points req at the Bedrock operation for its Messages API path and
+// returns that operation.
+func (t *messagesTransport) rewrite(req *http.Request) (string, error) {
+	count := strings.HasSuffix(req.URL.Path, "/v1/messages/count_tokens")
+	if !count && !strings.HasSuffix(req.URL.Path, "/v1/messages") {
+		return "", fmt.Errorf("bedrock: %s is not supported with the Messages API", req.URL.Path)
+	}
+	if req.Body == nil {
+		return "", fmt.Errorf("bedrock: request has no body")
+	}
+	defer req.Body.Close()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return "", fmt.Errorf("bedrock: read request body: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return "", fmt.Errorf("bedrock: decode messages request: %w", err)
+	}
+	var model string
+	if err := json.Unmarshal(fields["model"], &model); err != nil || model == "" {
+		return "", fmt.Errorf("bedrock: messages request has no model")
+	}
+	var stream bool
+	if raw, ok := fields["stream"]; ok {
+		_ = json.Unmarshal(raw, &stream)
+	}
+	delete(fields, "model")
+	delete(fields, "stream")
+	fields["anthropic_version"] = json.RawMessage(`"` + anthropicVersion + `"`)
+	if beta := req.Header.Get("anthropic-beta"); beta != "" {
+		var betas []string
+		for _, name := range strings.Split(beta, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				betas = append(betas, name)
+			}
+		}
+		encoded, err := json.Marshal(betas)
+		if err != nil {
+			return "", fmt.Errorf("bedrock: marshal anthropic_beta: %w", err)
+		}
+		fields["anthropic_beta"] = encoded
+	}
+	operation, accept := "invoke", "application/json"
+	if stream {
+		operation, accept = "invoke-with-response-stream", "application/vnd.amazon.eventstream"
+	}
+	if count {
+		// CountTokens takes the InvokeModel body it would count, which
+		// requires max_tokens.
+		if _, ok := fields["max_tokens"]; !ok {
+			fields["max_tokens"] = json.RawMessage(`1`)
+		}
+	}
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("bedrock: marshal invoke request: %w", err)
+	}
+	if count {
+		operation = "count-tokens"
+		if encoded, err = json.Marshal(countTokensRequest{Input: countTokensInput{InvokeModel: &countInvokeModelInput{Body: encoded}}}); err != nil {
+			return "", fmt.Errorf("bedrock: marshal count tokens request: %w", err)
+		}
+	}
+	endpoint, rawPath, err := runtimeEndpoint(t.baseURL, model, operation)
+	if err != nil {
+		return "", fmt.Errorf("bedrock: create %s endpoint: %w", operation, err)
+	}
+	target, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("bedrock: create %s endpoint: %w", operation, err)
+	}
+	target.RawPath = rawPath
+	req.URL = target
+	req.Host = target.Host
+	req.Header.Del("x-api-key")
+	req.Header.Del("anthropic-version")
+	req.Header.Del("anthropic-beta")
+	req.Header.Set("Accept", accept)
+	req.Body = io.NopCloser(bytes.NewReader(encoded))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(encoded)), nil
+	}
+	req.ContentLength = int64(len(encoded))
+	return operation, nil
+}
+
+// messagesCountTokensResponse rewrites a CountTokens response in the shape
+// of the Messages API count_tokens response.
+func messagesCountTokensResponse(resp *http.Response) (*http.Response, error) {
+	defer resp.Body.Close()
+	var parsed struct {
+		InputTokens int `json:"inputTokens"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("bedrock: decode count tokens response: %w", err)
+	}
+	data, err := json.Marshal(map[string]int{"input_tokens": parsed.InputTokens})
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	resp.ContentLength = int64(len(data))
+	return resp, nil
+}
+
+// eventStreamSSE reads InvokeModelWithResponseStream chunks, each carrying
+// one Anthropic stream event, and writes them as server-sent events.
+// Exceptions become Anthropic error events.
+type eventStreamSSE struct {
+	reader *bufio.Reader
+	body   io.Closer
+	buf    bytes.Buffer
+	err    error
+}
+
+func (r *eventStreamSSE) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 && r.err == nil {
+		r.err = r.fill()
+	}
+	if r.buf.Len() > 0 {
+		return r.buf.Read(p)
+	}
+	return 0, r.err
+}
+
+func (r *eventStreamSSE) Close() error {
+	return r.body.Close()
+}
+
+func (r *eventStreamSSE) fill() error {
+	headers, payload, err := readEventStreamFrame(r.reader)
+	if err != nil {
+		return err
+	}
+	if kind := headers[":message-type"]; kind == "exception" || kind == "error" {
+		var exception struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(payload, &exception)
+		if exception.Message == "" {
+			exception.Message = headers[":error-message"]
+		}
+		name := headers[":exception-type"]
+		if name == "" {
+			name = headers[":error-code"]
+		}
+		data, err := json.Marshal(map[string]any{
+			"type":  "error",
+			"error": map[string]string{"type": name, "message": exception.Message},
+		})
+		if err != nil {
+			return err
+		}
+		r.writeEvent("error", data)
+		return nil
+	}
+	if len(payload) == 0 {
+		return nil
+	}
+	var chunk struct {
+		Bytes []byte `json:"bytes"`
+	}
+	if err := json.Unmarshal(payload, &chunk); err != nil {
+		return fmt.Errorf("bedrock: decode stream chunk: %w", err)
+	}
+	if len(chunk.Bytes) == 0 {
+		return nil
+	}
+	var event struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(chunk.Bytes, &event); err != nil {
+		return fmt.Errorf("bedrock: decode stream event: %w", err)
+	}
+	r.writeEvent(event.Type, chunk.Bytes)
+	return nil
+}
+
+func (r *eventStreamSSE) writeEvent(name string, data []byte) {
+	r.buf.WriteString("event: ")
+	r.buf.WriteString(name)
+	r.buf.WriteString("\ndata: ")
+	r.buf.Write(data)
+	r.buf.WriteString("\n\n")
+}
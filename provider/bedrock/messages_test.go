@@ -0,0 +1,248 @@
+package bedrock
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/voocel/litellm"
+	"github.com/voocel/litellm/internal/testgolden"
+)
+
+func TestMessagesAPIInvokesModelWithAnthropicBody(t *testing.T) {
+	provider, err := New(Config{
+		Region:      "us-west-2",
+		Credentials: StaticCredentials("AKID", "SECRET", ""),
+		API:         APIMessages,
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Host != "bedrock-runtime.us-west-2.amazonaws.com" {
+				t.Fatalf("host = %q", req.URL.Host)
+			}
+			if got := req.URL.EscapedPath(); got != "/model/anthropic.claude-sonnet-4-5-20250929-v1%3A0/invoke" {
+				t.Fatalf("path = %q", got)
+			}
+			if !strings.HasPrefix(req.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+				t.Fatalf("Authorization = %q", req.Header.Get("Authorization"))
+			}
+			if req.Header.Get("x-api-key") != "" || req.Header.Get("anthropic-version") != "" {
+				t.Fatalf("headers = %v", req.Header)
+			}
+			var body map[string]any
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body["anthropic_version"] != anthropicVersion || body["model"] != nil || body["stream"] != nil || body["max_tokens"] != float64(64) {
+				t.Fatalf("body = %v", body)
+			}
+			return jsonResponse(http.StatusOK, `{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-5-20250929","content":[{"type":"text","text":"hi"}],"stop_reason":"end_turn","usage":{"input_tokens":3,"output_tokens":1}}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.Chat(context.Background(), &litellm.Request{
+		Model:     "anthropic.claude-sonnet-4-5-20250929-v1:0",
+		Messages:  []litellm.Message{litellm.UserText("hello")},
+		MaxTokens: litellm.IntPtr(64),
+	})
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	if resp.Text() != "hi" || resp.Provider != "bedrock" || resp.Usage.Provider != "bedrock" {
+		t.Fatalf("response = %+v", resp)
+	}
+	caps := provider.Capabilities("anthropic.claude-sonnet-4-5-20250929-v1:0")
+	if caps.Provider != "bedrock" || caps.Tools.WebSearch != litellm.SupportNo || caps.Media.DocumentFileURI != litellm.SupportNo {
+		t.Fatalf("capabilities = %+v", caps)
+	}
+}
+
+func TestMessagesAPIStreamDecodesEventStream(t *testing.T) {
+	var frames bytes.Buffer
+	for _, line := range strings.Split(testgolden.ReadFixtureString(t, "../../testdata/anthropic/messages_stream.sse"), "\n") {
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			chunk, _ := json.Marshal(map[string][]byte{"bytes": []byte(data)})
+			frames.Write(eventStreamFrame(map[string]string{":message-type": "event", ":event-type": "chunk"}, chunk))
+		}
+	}
+	provider, err := New(Config{
+		Credentials: StaticCredentials("AKID", "SECRET", ""),
+		API:         APIMessages,
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if got := req.URL.EscapedPath(); got != "/model/us.anthropic.claude-sonnet-4-5-20250929-v1%3A0/invoke-with-response-stream" {
+				t.Fatalf("path = %q", got)
+			}
+			if req.Header.Get("Accept") != "application/vnd.amazon.eventstream" {
+				t.Fatalf("Accept = %q", req.Header.Get("Accept"))
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(bytes.NewReader(frames.Bytes()))}, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	stream, err := provider.Stream(context.Background(), &litellm.Request{
+		Model:     "us.anthropic.claude-sonnet-4-5-20250929-v1:0",
+		Messages:  []litellm.Message{litellm.UserText("hello")},
+		MaxTokens: litellm.IntPtr(64),
+	})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	defer stream.Close()
+	resp, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	if !strings.Contains(resp.Text(), "hello") || resp.Provider != "bedrock" || resp.Usage.Provider != "bedrock" {
+		t.Fatalf("response = %+v", resp)
+	}
+}
+
+func TestMessagesAPIStreamSurfacesExceptions(t *testing.T) {
+	provider, err := New(Config{
+		Credentials: StaticCredentials("AKID", "SECRET", ""),
+		API:         APIMessages,
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			body := eventStreamFrame(map[string]string{":message-type": "exception", ":exception-type": "modelStreamErrorException"}, []byte(`{"message":"model stream failed"}`))
+			return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: io.NopCloser(bytes.NewReader(body))}, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	stream, err := provider.Stream(context.Background(), &litellm.Request{
+		Model:     "anthropic.claude-sonnet-4-5-20250929-v1:0",
+		Messages:  []litellm.Message{litellm.UserText("hello")},
+		MaxTokens: litellm.IntPtr(64),
+	})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	defer stream.Close()
+	_, err = litellm.Collect(stream)
+	var e *litellm.LiteLLMError
+	if !litellm.IsProviderError(err) || !strings.Contains(err.Error(), "modelStreamErrorException") || !errors.As(err, &e) || e.Provider != "bedrock" {
+		t.Fatalf("Collect error = %v", err)
+	}
+}
+
+func TestNewRejectsUnknownAPI(t *testing.T) {
+	_, err := New(Config{Credentials: StaticCredentials("AKID", "SECRET", ""), API: "invoke"})
+	if err == nil || !strings.Contains(err.Error(), "converse or messages") {
+		t.Fatalf("New error = %v", err)
+	}
+}
+
+func eventStreamFrame(headers map[string]string, payload []byte) []byte {
+	var encoded bytes.Buffer
+	for name, value := range headers {
+		encoded.WriteByte(byte(len(name)))
+		encoded.WriteString(name)
+		encoded.WriteByte(7)
+		binary.Write(&encoded, binary.BigEndian, uint16(len(value)))
+		encoded.WriteString(value)
+	}
+	var out bytes.Buffer
+	binary.Write(&out, binary.BigEndian, uint32(16+encoded.Len()+len(payload)))
+	binary.Write(&out, binary.BigEndian, uint32(encoded.Len()))
+	out.Write([]byte{0, 0, 0, 0})
+	out.Write(encoded.Bytes())
+	out.Write(payload)
+	out.Write([]byte{0, 0, 0, 0})
+	return out.Bytes()
+}
+
+func TestMessagesAPICountsTokensWithInvokeModelBody(t *testing.T) {
+	provider, err := New(Config{
+		Region:       "us-west-2",
+		Credentials:  StaticCredentials("AKID", "SECRET", ""),
+		API:          APIMessages,
+		BatchRoleARN: "arn:aws:iam::123456789012:role/batch",
+		BatchS3URI:   "s3://evals/batches/",
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if got := req.URL.EscapedPath(); got != "/model/anthropic.claude-sonnet-4-5-20250929-v1%3A0/count-tokens" {
+				t.Fatalf("path = %q", got)
+			}
+			var body struct {
+				Input struct {
+					Converse    json.RawMessage `json:"converse"`
+					InvokeModel struct {
+						Body []byte `json:"body"`
+					} `json:"invokeModel"`
+				} `json:"input"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			var invoke map[string]any
+			if err := json.Unmarshal(body.Input.InvokeModel.Body, &invoke); err != nil {
+				t.Fatalf("decode invoke body: %v", err)
+			}
+			if body.Input.Converse != nil || invoke["anthropic_version"] != anthropicVersion || invoke["model"] != nil || invoke["messages"] == nil {
+				t.Fatalf("count tokens body = %s / %v", body.Input.Converse, invoke)
+			}
+			return jsonResponse(http.StatusOK, `{"inputTokens":11}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	request := litellm.Request{
+		Model:    "anthropic.claude-sonnet-4-5-20250929-v1:0",
+		Messages: []litellm.Message{litellm.UserText("hello")},
+	}
+	count, err := provider.CountTokens(context.Background(), &request)
+	if err != nil {
+		t.Fatalf("CountTokens returned error: %v", err)
+	}
+	if count.InputTokens != 11 || count.Provider != "bedrock" {
+		t.Fatalf("count = %+v", count)
+	}
+	_, err = provider.CreateBatch(context.Background(), &litellm.BatchRequest{Items: []litellm.BatchItem{{CustomID: "a", Request: request}}})
+	if !litellm.IsValidationError(err) {
+		t.Fatalf("expected validation error for Messages API batch, got %v", err)
+	}
+}
+
+func TestMessagesAPIRejectsUnsupportedFeatures(t *testing.T) {
+	provider, err := New(Config{
+		Region:      "us-west-2",
+		Credentials: StaticCredentials("AKID", "SECRET", ""),
+		API:         APIMessages,
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatalf("unexpected request to %s", req.URL)
+			return nil, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	requests := map[string]litellm.Request{
+		"web search": {
+			Model:     "anthropic.claude-sonnet-4-5-20250929-v1:0",
+			Messages:  []litellm.Message{litellm.UserText("news?")},
+			WebSearch: &litellm.WebSearch{},
+		},
+		"file uri": {
+			Model: "anthropic.claude-sonnet-4-5-20250929-v1:0",
+			Messages: []litellm.Message{{Role: litellm.RoleUser, Blocks: []litellm.Block{
+				litellm.DocumentBlock{FileURI: "file_011CNha8iCJcU1wXNR6q4V8w"},
+			}}},
+		},
+	}
+	for name, req := range requests {
+		if _, err := provider.Chat(context.Background(), &req); !litellm.IsValidationError(err) {
+			t.Fatalf("%s: Chat error = %v, want validation error", name, err)
+		}
+		if _, err := provider.Stream(context.Background(), &req); !litellm.IsValidationError(err) {
+			t.Fatalf("%s: Stream error = %v, want validation error", name, err)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package bedrock
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// maxRoleChain bounds source_profile chains so a cycle cannot recurse
+// forever.
+const maxRoleChain = 5
+
+// profileCredentials reads a profile from the shared config and credentials
+// files. An unnamed profile comes from AWS_PROFILE or "default" and is
+// skipped by the chain when neither file defines it.
+type profileCredentials struct {
+	name string
+}
+
+func (p profileCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	name, explicit := p.name, p.name != ""
+	if !explicit {
+		name = os.Getenv("AWS_PROFILE")
+		explicit = name != ""
+	}
+	if name == "" {
+		name = "default"
+	}
+	profiles, err := loadProfiles()
+	if err != nil {
+		return Credentials{}, err
+	}
+	if _, ok := profiles[name]; !ok {
+		if explicit {
+			return Credentials{}, fmt.Errorf("bedrock: profile %q not found in the shared config or credentials file", name)
+		}
+		return Credentials{}, errNoCredentials
+	}
+	return resolveProfile(ctx, profiles, name, 0)
+}
+
+func resolveProfile(ctx context.Context, profiles map[string]map[string]string, name string, depth int) (Credentials, error) {
+	if depth > maxRoleChain {
+		return Credentials{}, fmt.Errorf("bedrock: profile %q: source_profile chain is too deep", name)
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		return Credentials{}, fmt.Errorf("bedrock: profile %q not found", name)
+	}
+	region := profile["region"]
+	if region == "" {
+		region = envRegion()
+	}
+	if roleARN := profile["role_arn"]; roleARN != "" {
+		if tokenFile := profile["web_identity_token_file"]; tokenFile != "" {
+			return assumeRoleWithWebIdentity(ctx, region, roleARN, profile["role_session_name"], tokenFile)
+		}
+		if profile["mfa_serial"] != "" {
+			return Credentials{}, fmt.Errorf("bedrock: profile %q requires MFA, which is not supported", name)
+		}
+		var source Credentials
+		var err error
+		switch sourceProfile := profile["source_profile"]; {
+		case sourceProfile == name:
+			source, err = staticProfileCredentials(name, profile)
+		case sourceProfile != "":
+			source, err = resolveProfile(ctx, profiles, sourceProfile, depth+1)
+		case profile["credential_source"] != "":
+			source, err = credentialSource(ctx, profile["credential_source"])
+		default:
+			err = fmt.Errorf("bedrock: profile %q sets role_arn without source_profile or credential_source", name)
+		}
+		if err != nil {
+			return Credentials{}, err
+		}
+		duration := 0
+		if value := profile["duration_seconds"]; value != "" {
+			if duration, err = strconv.Atoi(value); err != nil {
+				return Credentials{}, fmt.Errorf("bedrock: profile %q: invalid duration_seconds %q", name, value)
+			}
+		}
+		return assumeRole(ctx, region, source, assumeRoleInput{
+			roleARN:     roleARN,
+			sessionName: profile["role_session_name"],
+			externalID:  profile["external_id"],
+			duration:    duration,
+		})
+	}
+	if command := profile["credential_process"]; command != "" {
+		return processCredentials(ctx, command)
+	}
+	return staticProfileCredentials(name, profile)
+}
+
+func staticProfileCredentials(name string, profile map[string]string) (Credentials, error) {
+	if profile["aws_access_key_id"] == "" || profile["aws_secret_access_key"] == "" {
+		return Credentials{}, fmt.Errorf("bedrock: profile %q has no credentials", name)
+	}
+	return Credentials{
+		AccessKeyID:     profile["aws_access_key_id"],
+		SecretAccessKey: profile["aws_secret_access_key"],
+		SessionToken:    profile["aws_session_token"],
+	}, nil
+}
+
+// credentialSource resolves the credential_source a role profile names.
+func credentialSource(ctx context.Context, source string) (Credentials, error) {
+	var provider CredentialsProvider
+	switch source {
+	case "Environment":
+		provider = envCredentials{}
+	case "EcsContainer":
+		provider = containerCredentials{}
+	case "Ec2InstanceMetadata":
+		provider = imdsCredentials{}
+	default:
+		return Credentials{}, fmt.Errorf("bedrock: unsupported credential_source %q", source)
+	}
+	credentials, err := provider.Credentials(ctx)
+	if errors.Is(err, errNoCredentials) {
+		return Credentials{}, fmt.Errorf("bedrock: credential_source %s has no credentials", source)
+	}
+	return credentials, err
+}
+
+// processCredentials runs a credential_process command and reads the
+// version 1 JSON document it prints.
+func processCredentials(ctx context.Context, command string) (Credentials, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd.exe", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return Credentials{}, fmt.Errorf("bedrock: credential_process failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	var out struct {
+		Version         int    `json:"Version"`
+		AccessKeyID     string `json:"AccessKeyId"`
+		SecretAccessKey string `json:"SecretAccessKey"`
+		SessionToken    string `json:"SessionToken"`
+		Expiration      string `json:"Expiration"`
+	}
+	if err := json.Unmarshal(output, &out); err != nil {
+		return Credentials{}, fmt.Errorf("bedrock: decode credential_process output: %w", err)
+	}
+	if out.Version != 1 {
+		return Credentials{}, fmt.Errorf("bedrock: credential_process output version %d is not supported", out.Version)
+	}
+	return metadataCredentials{
+		AccessKeyID:     out.AccessKeyID,
+		SecretAccessKey: out.SecretAccessKey,
+		Token:           out.SessionToken,
+		Expiration:      out.Expiration,
+	}.credentials()
+}
+
+// loadProfiles merges the shared config file ("[profile name]" sections)
+// with the credentials file ("[name]" sections); keys in the credentials
+// file win.
+func loadProfiles() (map[string]map[string]string, error) {
+	home, _ := os.UserHomeDir()
+	configFile := os.Getenv("AWS_CONFIG_FILE")
+	if configFile == "" && home != "" {
+		configFile = filepath.Join(home, ".aws", "config")
+	}
+	credentialsFile := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if credentialsFile == "" && home != "" {
+		credentialsFile = filepath.Join(home, ".aws", "credentials")
+	}
+	profiles := make(map[string]map[string]string)
+	for _, file := range []struct {
+		path   string
+		config bool
+	}{{configFile, true}, {credentialsFile, false}} {
+		if file.path == "" {
+			continue
+		}
+		sections, err := parseINI(file.path)
+		if err != nil {
+			return nil, err
+		}
+		for section, values := range sections {
+			name := section
+			if file.config {
+				var ok bool
+				if name, ok = strings.CutPrefix(section, "profile "); ok {
+					name = strings.TrimSpace(name)
+				} else if section != "default" {
+					continue
+				}
+			}
+			if profiles[name] == nil {
+				profiles[name] = make(map[string]string)
+			}
+			for key, value := range values {
+				profiles[name][key] = value
+			}
+		}
+	}
+	return profiles, nil
+}
+
+// parseINI reads the sections of an AWS shared config file. Indented lines
+// continue nested settings, such as s3 = …, and are skipped.
+func parseINI(path string) (map[string]map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("bedrock: read %s: %w", path, err)
+	}
+	sections := make(map[string]map[string]string)
+	var current map[string]string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if sections[name] == nil {
+				sections[name] = make(map[string]string)
+			}
+			current = sections[name]
+			continue
+		}
+		if current == nil || raw[0] == ' ' || raw[0] == '\t' {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		current[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	return sections, scanner.Err()
+}
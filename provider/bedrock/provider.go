@@ -9,12 +9,20 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/voocel/litellm"
+	"github.com/voocel/litellm/internal/relabel"
+	"github.com/voocel/litellm/provider/anthropic"
 	"github.com/voocel/litellm/retry"
 )
 
-const defaultRegion = "us-east-1"
+const (
+	defaultRegion = "us-east-1"
+
+	APIConverse = "converse"
+	APIMessages = "messages"
+)
 
 type Config struct {
 	Region              string
@@ -24,6 +32,18 @@ type Config struct {
 	HTTPClient          HTTPClient
 	Transport           http.RoundTripper
 	Retry               *retry.Policy
+
+	// API selects how Chat and Stream call the model. APIConverse (the
+	// default) uses Converse and ConverseStream. APIMessages sends the
+	// Anthropic Messages body to InvokeModel and
+	// InvokeModelWithResponseStream, and only serves Anthropic models.
+	API string
+
+	// BatchRoleARN is the IAM role Bedrock assumes to run batch inference
+	// jobs, and BatchS3URI the s3://bucket/prefix where their inputs and
+	// outputs are written. CreateBatch requires both.
+	BatchRoleARN string
+	BatchS3URI   string
 }
 
 type HTTPClient interface {
@@ -35,6 +55,9 @@ type Credentials struct {
 	SecretAccessKey string
 	SessionToken    string
 	Region          string
+	// Expires is when temporary credentials stop working; zero means they
+	// do not expire.
+	Expires time.Time
 }
 
 type CredentialsProvider interface {
@@ -58,7 +81,10 @@ func (p staticCredentials) Credentials(context.Context) (Credentials, error) {
 }
 
 type Provider struct {
-	cfg Config
+	cfg      Config
+	messages *anthropic.Provider
+	// s3 signs for S3, which batch inference reads and writes through.
+	s3 *http.Client
 }
 
 func New(cfg Config) (*Provider, error) {
@@ -74,6 +100,11 @@ func New(cfg Config) (*Provider, error) {
 	if cfg.ControlPlaneBaseURL == "" {
 		cfg.ControlPlaneBaseURL = fmt.Sprintf("https://bedrock.%s.amazonaws.com", cfg.Region)
 	}
+	api, err := normalizeAPI(cfg.API)
+	if err != nil {
+		return nil, err
+	}
+	cfg.API = api
 	if cfg.HTTPClient != nil && cfg.Transport != nil {
 		return nil, fmt.Errorf("bedrock: HTTPClient and Transport are mutually exclusive")
 	}
@@ -83,14 +114,43 @@ func New(cfg Config) (*Provider, error) {
 	base := cfg.Transport
 	if base == nil {
 		if cfg.HTTPClient != nil {
-			base = clientTransport{client: cfg.HTTPClient}
+			base = relabel.ClientTransport{Client: cfg.HTTPClient}
 		} else {
 			base = http.DefaultTransport
 		}
 	}
 	signed := SigningTransport(cfg.Credentials, cfg.Region, base)
 	cfg.HTTPClient = &http.Client{Transport: retry.NewTransport(signed, cfg.Retry)}
-	return &Provider{cfg: cfg}, nil
+	p := &Provider{
+		cfg: cfg,
+		s3:  &http.Client{Transport: retry.NewTransport(newSigningTransport(cfg.Credentials, cfg.Region, "s3", base), cfg.Retry)},
+	}
+	if cfg.API == APIMessages {
+		p.messages, err = anthropic.New(anthropic.Config{
+			// The adapter requires a key; requests are signed with SigV4
+			// instead and the key header is dropped.
+			APIKey:    "sigv4",
+			BaseURL:   cfg.BaseURL,
+			Transport: &messagesTransport{baseURL: cfg.BaseURL, base: signed},
+			Retry:     cfg.Retry,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("bedrock: %w", err)
+		}
+	}
+	return p, nil
+}
+
+func normalizeAPI(api string) (string, error) {
+	api = strings.ToLower(strings.TrimSpace(api))
+	switch api {
+	case "", APIConverse:
+		return APIConverse, nil
+	case APIMessages:
+		return APIMessages, nil
+	default:
+		return "", fmt.Errorf("bedrock: api must be converse or messages, got %q", api)
+	}
 }
 
 func Factory(cfg Config) (litellm.Provider, error) {
@@ -102,10 +162,14 @@ func (p *Provider) Name() string {
 }
 
 func (p *Provider) Chat(ctx context.Context, req *litellm.Request) (*litellm.Response, error) {
+	if p.messages != nil {
+		return p.chatMessages(ctx, req)
+	}
 	wire, err := p.buildRequest(req)
 	if err != nil {
 		return nil, litellm.WrapValidationError(p.Name(), err)
 	}
+	wire.dropStreamSettings()
 	body, err := json.Marshal(wire)
 	if err != nil {
 		return nil, fmt.Errorf("bedrock: marshal request: %w", err)
@@ -145,6 +209,9 @@ func (p *Provider) Chat(ctx context.Context, req *litellm.Request) (*litellm.Res
 }
 
 func (p *Provider) Stream(ctx context.Context, req *litellm.Request) (litellm.Stream, error) {
+	if p.messages != nil {
+		return p.streamMessages(ctx, req)
+	}
 	wire, err := p.buildRequest(req)
 	if err != nil {
 		return nil, litellm.WrapValidationError(p.Name(), err)
@@ -209,12 +276,31 @@ func (p *Provider) ListModels(ctx context.Context) ([]litellm.ModelInfo, error)
 	return models, nil
 }
 
-type clientTransport struct {
-	client HTTPClient
-}
-
-func (t clientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	return t.client.Do(req)
+func (p *Provider) invoke(ctx context.Context, model string, body []byte) ([]byte, http.Header, error) {
+	endpoint, rawPath, err := runtimeEndpoint(p.cfg.BaseURL, model, "invoke")
+	if err != nil {
+		return nil, nil, fmt.Errorf("bedrock: create invoke endpoint: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("bedrock: create invoke request: %w", err)
+	}
+	httpReq.URL.RawPath = rawPath
+	httpReq.Header.Set("Accept", "application/json")
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, nil, litellm.NewNetworkError(p.Name(), "invoke request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, litellm.NewNetworkError(p.Name(), "read response failed", err)
+	}
+	return data, resp.Header, nil
 }
 
 func runtimeEndpoint(baseURL, model, operation string) (string, string, error) {
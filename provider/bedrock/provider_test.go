@@ -211,6 +211,18 @@ func TestBuildRequestRejectsNonClaudeThinking(t *testing.T) {
 	}
 }
 
+func TestBuildRequestRejectsWebSearch(t *testing.T) {
+	provider := mustProvider(t)
+	_, err := provider.buildRequest(&litellm.Request{
+		Model:     "anthropic.claude-sonnet-4-20250514-v1:0",
+		Messages:  []litellm.Message{litellm.UserText("hi")},
+		WebSearch: &litellm.WebSearch{},
+	})
+	if err == nil || !strings.Contains(err.Error(), "web search is not supported") {
+		t.Fatalf("expected web search error, got %v", err)
+	}
+}
+
 func TestBuildRequestRejectsUnknownProviderOption(t *testing.T) {
 	provider := mustProvider(t)
 	_, err := provider.buildRequest(&litellm.Request{
@@ -223,6 +235,42 @@ func TestBuildRequestRejectsUnknownProviderOption(t *testing.T) {
 	}
 }
 
+func TestBuildRequestEncodesDocumentBlocks(t *testing.T) {
+	provider := mustProvider(t)
+	wire, err := provider.buildRequest(&litellm.Request{
+		Model: "anthropic.claude-sonnet-4-20250514-v1:0",
+		Messages: []litellm.Message{litellm.User(
+			litellm.DocumentBlock{Data: []byte("%PDF"), MIME: "application/pdf", Title: "Report", Citations: true},
+			litellm.DocumentBlock{FileURI: "s3://bucket/notes.md", MIME: "text/markdown"},
+			litellm.Text("summarize"),
+		)},
+	})
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	data, err := json.Marshal(wire)
+	if err != nil {
+		t.Fatalf("marshal wire: %v", err)
+	}
+	jsonText := string(data)
+	for _, want := range []string{
+		`{"document":{"format":"pdf","name":"Report","source":{"bytes":"JVBERg=="},"citations":{"enabled":true}}}`,
+		`{"document":{"format":"md","name":"document","source":{"s3Location":{"uri":"s3://bucket/notes.md"}}}}`,
+	} {
+		if !strings.Contains(jsonText, want) {
+			t.Fatalf("request missing %s:\n%s", want, jsonText)
+		}
+	}
+
+	_, err = provider.buildRequest(&litellm.Request{
+		Model:    "anthropic.claude-sonnet-4-20250514-v1:0",
+		Messages: []litellm.Message{litellm.User(litellm.DocumentURL("https://example.test/a.pdf"))},
+	})
+	if err == nil || !strings.Contains(err.Error(), "data URL") {
+		t.Fatalf("expected document URL error, got %v", err)
+	}
+}
+
 func TestBuildRequestRejectsInvalidCacheRetention(t *testing.T) {
 	provider := mustProvider(t)
 	_, err := provider.buildRequest(&litellm.Request{
@@ -261,6 +309,43 @@ func TestBuildRequestRoundTripsReasoningBlockHistory(t *testing.T) {
 	}
 }
 
+func TestConvertResponseRoundTripsServerToolBlocks(t *testing.T) {
+	var resp response
+	if err := json.Unmarshal([]byte(`{"stopReason":"end_turn","output":{"message":{"role":"assistant","content":[`+
+		`{"toolUse":{"toolUseId":"srv_1","name":"nova_grounding","type":"server_tool_use","input":{"query":"go"}}},`+
+		`{"toolResult":{"toolUseId":"srv_1","type":"nova_grounding_result","content":[{"json":{"url":"https://go.dev"}}]}},`+
+		`{"text":"Go"}]}}}`), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	out, err := convertResponse(&resp, "amazon.nova-premier-v1:0")
+	if err != nil {
+		t.Fatalf("convertResponse returned error: %v", err)
+	}
+	if len(out.Blocks) != 3 || len(out.ToolCalls()) != 0 || len(out.ServerToolCalls()) != 1 {
+		t.Fatalf("blocks = %#v", out.Blocks)
+	}
+	provider := mustProvider(t)
+	wire, err := provider.buildRequest(&litellm.Request{
+		Model:    "amazon.nova-premier-v1:0",
+		Tools:    []litellm.Tool{{Name: "grounding", Server: &litellm.ServerTool{Type: "nova_grounding"}}},
+		Messages: []litellm.Message{litellm.UserText("q"), litellm.Assistant(out.Blocks...), litellm.UserText("more")},
+	})
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	if got := wire.ToolConfig.Tools[0].SystemTool; got == nil || got.Name != "nova_grounding" {
+		t.Fatalf("tools = %#v", wire.ToolConfig.Tools)
+	}
+	content := wire.Messages[1].Content
+	if content[0].ToolUse == nil || content[0].ToolUse.Type != "server_tool_use" {
+		t.Fatalf("tool use = %#v", content[0])
+	}
+	result := content[1].ToolResult
+	if result == nil || result.Type != "nova_grounding_result" || len(result.Content) != 1 || result.Content[0].JSON == nil {
+		t.Fatalf("tool result = %#v", content[1])
+	}
+}
+
 func TestChatSignsRequestAndConvertsResponse(t *testing.T) {
 	var authHeader string
 	var tokenHeader string
@@ -396,6 +481,84 @@ func TestStreamExposesUnknownContentBlockAsProviderEvent(t *testing.T) {
 	}
 }
 
+func TestStreamCollectsServerToolBlocks(t *testing.T) {
+	stream := newStream(&http.Response{
+		Body: io.NopCloser(bytes.NewReader(eventStream(
+			`{"contentBlockStart":{"contentBlockIndex":0,"start":{"toolUse":{"toolUseId":"srv_1","name":"nova_grounding","type":"server_tool_use"}}}}`,
+			`{"contentBlockDelta":{"contentBlockIndex":0,"delta":{"toolUse":{"input":"{\"query\":\"go\"}"}}}}`,
+			`{"contentBlockStop":{"contentBlockIndex":0}}`,
+			`{"contentBlockStart":{"contentBlockIndex":1,"start":{"toolResult":{"toolUseId":"srv_1","type":"nova_grounding_result"}}}}`,
+			`{"contentBlockDelta":{"contentBlockIndex":1,"delta":{"toolResult":[{"json":{"url":"https://go.dev"}}]}}}`,
+			`{"contentBlockStop":{"contentBlockIndex":1}}`,
+			`{"messageStop":{"stopReason":"end_turn"}}`,
+			`{"metadata":{"usage":{"inputTokens":1,"outputTokens":1,"totalTokens":2}}}`,
+		))),
+	}, "amazon.nova-premier-v1:0")
+	resp, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	if len(resp.Blocks) != 2 {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	call, ok := resp.Blocks[0].(litellm.ServerToolUseBlock)
+	if !ok || call.ID != "srv_1" || string(call.Arguments) != `{"query":"go"}` {
+		t.Fatalf("call = %#v", resp.Blocks[0])
+	}
+	result, ok := resp.Blocks[1].(litellm.ServerToolResultBlock)
+	if !ok || result.Type != "nova_grounding_result" || string(result.Content) != `[{"json":{"url":"https://go.dev"}}]` {
+		t.Fatalf("result = %#v", resp.Blocks[1])
+	}
+}
+
+func TestConvertResponseMapsCitationsContent(t *testing.T) {
+	var wire response
+	if err := json.Unmarshal([]byte(`{"output":{"message":{"role":"assistant","content":[
+		{"text":"The report says "},
+		{"citationsContent":{"content":[{"text":"revenue grew"}],"citations":[{"title":"report","sourceContent":[{"text":"Revenue grew 8%."}],"location":{"documentPage":{"documentIndex":0,"start":2,"end":3}}}]}}
+	]}},"stopReason":"end_turn"}`), &wire); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	resp, err := convertResponse(&wire, "anthropic.claude")
+	if err != nil {
+		t.Fatalf("convertResponse returned error: %v", err)
+	}
+	if len(resp.Blocks) != 2 || resp.Text() != "The report says revenue grew" {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	annotations := resp.Blocks[1].(litellm.TextBlock).Annotations
+	want := litellm.CitationLocation{Index: 0, Unit: litellm.CitationUnitPage, Start: 2, End: 3}
+	if len(annotations) != 1 || annotations[0].Type != litellm.AnnotationDocumentCitation || annotations[0].End != len("revenue grew") || annotations[0].Text != "Revenue grew 8%." || annotations[0].Location == nil || *annotations[0].Location != want {
+		t.Fatalf("annotations = %+v", annotations)
+	}
+}
+
+func TestStreamCollectsCitationDeltas(t *testing.T) {
+	stream := newStream(&http.Response{
+		Body: io.NopCloser(bytes.NewReader(eventStream(
+			`{"contentBlockDelta":{"contentBlockIndex":0,"delta":{"text":"Go 1.25 shipped. "}}}`,
+			`{"contentBlockStop":{"contentBlockIndex":0}}`,
+			`{"contentBlockDelta":{"contentBlockIndex":1,"delta":{"citation":{"title":"go.dev","location":{"web":{"url":"https://go.dev/doc/go1.25","domain":"go.dev"}}}}}}`,
+			`{"contentBlockDelta":{"contentBlockIndex":1,"delta":{"text":"It adds a new GC."}}}`,
+			`{"contentBlockStop":{"contentBlockIndex":1}}`,
+			`{"messageStop":{"stopReason":"end_turn"}}`,
+			`{"metadata":{"usage":{"inputTokens":1,"outputTokens":1,"totalTokens":2}}}`,
+		))),
+	}, "amazon.nova-premier-v1:0")
+	resp, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	block, ok := resp.Blocks[0].(litellm.TextBlock)
+	if len(resp.Blocks) != 1 || !ok || len(block.Annotations) != 1 {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	annotation := block.Annotations[0]
+	if annotation.Type != litellm.AnnotationURLCitation || annotation.URL != "https://go.dev/doc/go1.25" || block.Text[annotation.Start:annotation.End] != "It adds a new GC." {
+		t.Fatalf("annotation = %+v", annotation)
+	}
+}
+
 func TestStreamConvertsReasoningDelta(t *testing.T) {
 	stream := newStream(&http.Response{
 		Body: io.NopCloser(bytes.NewReader(eventStream(
@@ -440,6 +603,59 @@ func TestStreamRejectsEOFBeforeMetadata(t *testing.T) {
 	}
 }
 
+func TestGenerateImageCanvasInpaintsWithMask(t *testing.T) {
+	var path string
+	var captured canvasRequest
+	provider, err := New(Config{
+		Region:      "us-east-1",
+		BaseURL:     "https://bedrock-runtime.us-east-1.amazonaws.com",
+		Credentials: StaticCredentials("AKID", "SECRET", ""),
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			path = req.URL.EscapedPath()
+			if err := json.NewDecoder(req.Body).Decode(&captured); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			return jsonResponse(http.StatusOK, `{"images":["iVBORw==","iVBORw=="]}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.GenerateImage(context.Background(), &litellm.ImageRequest{
+		Model:           "amazon.nova-canvas-v1:0",
+		Prompt:          "a blue door",
+		Size:            "1280x720",
+		N:               2,
+		Images:          []litellm.ImageBlock{{Data: []byte("src")}},
+		Mask:            &litellm.ImageBlock{Data: []byte("mask")},
+		ProviderOptions: litellm.ProviderOptions{ProviderOptionSeed: 7},
+	})
+	if err != nil {
+		t.Fatalf("GenerateImage returned error: %v", err)
+	}
+	if path != "/model/amazon.nova-canvas-v1%3A0/invoke" {
+		t.Fatalf("path = %q", path)
+	}
+	config := captured.ImageGenerationConfig
+	if captured.TaskType != "INPAINTING" || captured.InPaintingParams.MaskImage != "bWFzaw==" || config.Width != 1280 || config.NumberOfImages != 2 || *config.Seed != 7 {
+		t.Fatalf("request = %+v", captured)
+	}
+	if len(resp.Images) != 2 || resp.Images[0].MIME != "image/png" {
+		t.Fatalf("images = %#v", resp.Images)
+	}
+}
+
+func TestGenerateImageStabilityRejectsSize(t *testing.T) {
+	provider, err := New(Config{Region: "us-east-1", Credentials: StaticCredentials("AKID", "SECRET", "")})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	_, err = provider.GenerateImage(context.Background(), &litellm.ImageRequest{Model: "stability.sd3-5-large-v1:0", Prompt: "p", Size: "1024x1024"})
+	if !litellm.IsValidationError(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
 func jsonResponse(status int, body string) *http.Response {
 	return &http.Response{
 		StatusCode: status,
@@ -463,6 +679,87 @@ func eventStream(payloads ...string) []byte {
 	return out.Bytes()
 }
 
+func TestEmbedTitanInvokesOncePerInput(t *testing.T) {
+	var paths []string
+	provider, err := New(Config{
+		Region:      "us-east-1",
+		BaseURL:     "https://bedrock-runtime.us-east-1.amazonaws.com",
+		Credentials: StaticCredentials("AKID", "SECRET", ""),
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			paths = append(paths, req.URL.EscapedPath())
+			var body titanEmbedRequest
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			if body.Dimensions == nil || *body.Dimensions != 256 {
+				t.Fatalf("dimensions = %v", body.Dimensions)
+			}
+			return jsonResponse(http.StatusOK, `{"embedding":[0.1,0.2],"inputTextTokenCount":3}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.Embed(context.Background(), &litellm.EmbedRequest{
+		Model:      "amazon.titan-embed-text-v2:0",
+		Input:      []string{"a", "b"},
+		Dimensions: litellm.IntPtr(256),
+	})
+	if err != nil {
+		t.Fatalf("Embed returned error: %v", err)
+	}
+	if len(paths) != 2 || paths[0] != "/model/amazon.titan-embed-text-v2%3A0/invoke" {
+		t.Fatalf("paths = %v", paths)
+	}
+	if len(resp.Embeddings) != 2 || resp.Usage.InputTokens != 6 {
+		t.Fatalf("resp = %+v", resp)
+	}
+}
+
+func TestEmbedCohereMapsTaskTypeAndTypedVectors(t *testing.T) {
+	provider, err := New(Config{
+		Region:      "us-east-1",
+		Credentials: StaticCredentials("AKID", "SECRET", ""),
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			var body cohereEmbedRequest
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			if body.InputType != "search_query" || len(body.Texts) != 2 || body.Truncate != "END" {
+				t.Fatalf("request = %+v", body)
+			}
+			resp := jsonResponse(http.StatusOK, `{"embeddings":{"float":[[1,2],[3,4]]}}`)
+			resp.Header.Set("X-Amzn-Bedrock-Input-Token-Count", "7")
+			return resp, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.Embed(context.Background(), &litellm.EmbedRequest{
+		Model:           "cohere.embed-v4:0",
+		Input:           []string{"a", "b"},
+		TaskType:        litellm.EmbedTaskRetrievalQuery,
+		ProviderOptions: litellm.ProviderOptions{"truncate": "end"},
+	})
+	if err != nil {
+		t.Fatalf("Embed returned error: %v", err)
+	}
+	if len(resp.Embeddings) != 2 || resp.Embeddings[1][1] != 4 || resp.Usage.InputTokens != 7 {
+		t.Fatalf("resp = %+v", resp)
+	}
+}
+
+func TestEmbedRejectsNonEmbeddingModel(t *testing.T) {
+	_, err := mustProvider(t).Embed(context.Background(), &litellm.EmbedRequest{
+		Model: "anthropic.claude-3-5-sonnet-20240620-v1:0",
+		Input: []string{"a"},
+	})
+	if !litellm.IsValidationError(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
 func mustProvider(t *testing.T) *Provider {
 	t.Helper()
 	provider, err := New(Config{
@@ -483,3 +780,298 @@ func mustTool(t *testing.T, name, description string, schema any) litellm.Tool {
 	}
 	return tool
 }
+
+func TestCountTokensWrapsConverseInput(t *testing.T) {
+	provider, err := New(Config{
+		Region:      "us-west-2",
+		BaseURL:     "https://bedrock-runtime.us-west-2.amazonaws.com",
+		Credentials: StaticCredentials("AKID", "SECRET", ""),
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasSuffix(req.URL.EscapedPath(), "/count-tokens") {
+				t.Fatalf("path = %s", req.URL.EscapedPath())
+			}
+			body, _ := io.ReadAll(req.Body)
+			var wire struct {
+				Input struct {
+					Converse struct {
+						Messages []json.RawMessage `json:"messages"`
+					} `json:"converse"`
+				} `json:"input"`
+			}
+			if err := json.Unmarshal(body, &wire); err != nil || len(wire.Input.Converse.Messages) != 1 {
+				t.Fatalf("body = %s, err = %v", body, err)
+			}
+			return jsonResponse(http.StatusOK, `{"inputTokens":17}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	count, err := provider.CountTokens(context.Background(), &litellm.Request{
+		Model:    "anthropic.claude-3-5-sonnet-20240620-v1:0",
+		Messages: []litellm.Message{litellm.UserText("hi")},
+	})
+	if err != nil {
+		t.Fatalf("CountTokens returned error: %v", err)
+	}
+	if count.InputTokens != 17 || count.Provider != "bedrock" {
+		t.Fatalf("count = %+v", count)
+	}
+}
+
+func TestBatchWritesS3InputAndReadsOutput(t *testing.T) {
+	const jobARN = "arn:aws:bedrock:us-west-2:123456789012:model-invocation-job/abc123"
+	objects := map[string]string{}
+	var created map[string]any
+	provider, err := New(Config{
+		Region:       "us-west-2",
+		Credentials:  StaticCredentials("AKID", "SECRET", ""),
+		BatchRoleARN: "arn:aws:iam::123456789012:role/batch",
+		BatchS3URI:   "s3://evals/batches/",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			auth := req.Header.Get("Authorization")
+			if req.URL.Host == "evals.s3.us-west-2.amazonaws.com" {
+				if !strings.Contains(auth, "/us-west-2/s3/aws4_request") {
+					t.Fatalf("S3 request signed as %s", auth)
+				}
+				key := strings.TrimPrefix(req.URL.Path, "/")
+				if req.Method == http.MethodPut {
+					body, _ := io.ReadAll(req.Body)
+					objects[key] = string(body)
+					return jsonResponse(http.StatusOK, ``), nil
+				}
+				body, ok := objects[key]
+				if !ok {
+					return jsonResponse(http.StatusNotFound, `NoSuchKey`), nil
+				}
+				return jsonResponse(http.StatusOK, body), nil
+			}
+			if !strings.Contains(auth, "/us-west-2/bedrock/aws4_request") {
+				t.Fatalf("control plane request signed as %s", auth)
+			}
+			switch {
+			case req.Method == http.MethodPost && req.URL.Path == "/model-invocation-job":
+				body, _ := io.ReadAll(req.Body)
+				if err := json.Unmarshal(body, &created); err != nil {
+					t.Fatalf("decode create body: %v", err)
+				}
+				return jsonResponse(http.StatusOK, `{"jobArn":"`+jobARN+`"}`), nil
+			case req.Method == http.MethodGet && req.URL.Path == "/model-invocation-job/"+jobARN:
+				if !strings.Contains(req.URL.RawPath, "%3A") || strings.Contains(req.URL.RawPath, "job/abc123") {
+					t.Fatalf("job ARN not escaped as one segment: %s", req.URL.RawPath)
+				}
+				input := created["inputDataConfig"].(map[string]any)["s3InputDataConfig"].(map[string]any)["s3Uri"].(string)
+				output := created["outputDataConfig"].(map[string]any)["s3OutputDataConfig"].(map[string]any)["s3Uri"].(string)
+				return jsonResponse(http.StatusOK, `{"jobArn":"`+jobARN+`","modelId":"anthropic.claude-sonnet-4-5","status":"Completed","submitTime":"2026-01-02T03:04:05Z","endTime":"2026-01-02T05:00:00Z",
+					"inputDataConfig":{"s3InputDataConfig":{"s3Uri":"`+input+`"}},"outputDataConfig":{"s3OutputDataConfig":{"s3Uri":"`+output+`"}}}`), nil
+			}
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL)
+			return nil, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	items := []litellm.BatchItem{
+		{CustomID: "first", Request: litellm.Request{Model: "anthropic.claude-sonnet-4-5", Messages: []litellm.Message{litellm.UserText("hi")}}},
+		{CustomID: "second", Request: litellm.Request{Model: "anthropic.claude-sonnet-4-5", Messages: []litellm.Message{litellm.UserText("bye")}}},
+	}
+	batch, err := provider.CreateBatch(context.Background(), &litellm.BatchRequest{Items: items})
+	if err != nil {
+		t.Fatalf("CreateBatch returned error: %v", err)
+	}
+	if batch.ID != jobARN || batch.Status != litellm.BatchStatusValidating || created["modelInvocationType"] != "Converse" || created["roleArn"] != "arn:aws:iam::123456789012:role/batch" {
+		t.Fatalf("batch = %+v, create = %v", batch, created)
+	}
+	jobName := created["jobName"].(string)
+	input := objects["batches/"+jobName+"/input.jsonl"]
+	if !strings.Contains(input, `"recordId":"REC00000000"`) || !strings.Contains(input, `"modelInput":{"messages":[{"role":"user"`) {
+		t.Fatalf("input = %s", input)
+	}
+	objects["batches/"+jobName+"/output/abc123/input.jsonl.out"] = strings.Join([]string{
+		`{"recordId":"REC00000001","error":{"errorCode":400,"errorMessage":"Malformed input request"}}`,
+		`{"recordId":"REC00000000","modelOutput":{"output":{"message":{"role":"assistant","content":[{"text":"hello"}]}},"stopReason":"end_turn","usage":{"inputTokens":3,"outputTokens":1,"totalTokens":4}}}`,
+	}, "\n")
+
+	got, err := provider.GetBatch(context.Background(), jobARN)
+	if err != nil || got.Status != litellm.BatchStatusCompleted || got.EndedAt.IsZero() {
+		t.Fatalf("GetBatch = %+v, %v", got, err)
+	}
+	results, err := provider.BatchResults(context.Background(), jobARN)
+	if err != nil {
+		t.Fatalf("BatchResults returned error: %v", err)
+	}
+	if len(results) != 2 || results[0].CustomID != "second" || !litellm.IsValidationError(results[0].Err) {
+		t.Fatalf("results[0] = %+v", results[0])
+	}
+	if results[1].CustomID != "first" || results[1].Response == nil || results[1].Response.Text() != "hello" || results[1].Response.Model != "anthropic.claude-sonnet-4-5" {
+		t.Fatalf("results[1] = %+v", results[1])
+	}
+
+	items[1].Request.Model = "amazon.nova-pro-v1:0"
+	if _, err := provider.CreateBatch(context.Background(), &litellm.BatchRequest{Items: items}); !litellm.IsValidationError(err) {
+		t.Fatalf("mixed models err = %v", err)
+	}
+}
+
+func TestChatSendsGuardrailOptionsAndReportsTrace(t *testing.T) {
+	var body map[string]any
+	provider, err := New(Config{
+		Region:      "us-west-2",
+		BaseURL:     "https://bedrock-runtime.us-west-2.amazonaws.com",
+		Credentials: StaticCredentials("AKID", "SECRET", ""),
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			return jsonResponse(http.StatusOK, `{
+				"output":{"message":{"role":"assistant","content":[{"text":"Sorry, I can't help with that."}]}},
+				"stopReason":"guardrail_intervened",
+				"usage":{"inputTokens":5,"outputTokens":7,"totalTokens":12},
+				"trace":{"guardrail":{"inputAssessment":{"gr-1":{
+					"topicPolicy":{"topics":[{"name":"Investing","type":"DENY","action":"BLOCKED","detected":true}]},
+					"contentPolicy":{"filters":[{"type":"INSULTS","confidence":"LOW","filterStrength":"HIGH","action":"NONE","detected":false}]},
+					"sensitiveInformationPolicy":{"piiEntities":[{"type":"EMAIL","match":"a@b.c","action":"ANONYMIZED"}]}
+				}}}}
+			}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	maxTokens := 4096
+	resp, err := provider.Chat(context.Background(), &litellm.Request{
+		Model:     "anthropic.claude-sonnet-4-20250514-v1:0",
+		MaxTokens: &maxTokens,
+		Thinking:  &litellm.Thinking{Mode: litellm.ThinkingEnabled, Effort: "low"},
+		Messages: []litellm.Message{
+			{Role: litellm.RoleSystem, Blocks: []litellm.Block{litellm.GuardContentBlock{Text: "policy"}}},
+			litellm.User(
+				litellm.GuardContentBlock{Text: "Paris is the capital.", Qualifiers: []string{GuardQualifierGroundingSource}},
+				litellm.Text("Where should I invest?"),
+			),
+		},
+		ProviderOptions: litellm.ProviderOptions{
+			ProviderOptionGuardrail: map[string]any{
+				"guardrailIdentifier":  "gr-1",
+				"guardrailVersion":     "DRAFT",
+				"streamProcessingMode": GuardrailStreamAsync,
+			},
+			ProviderOptionAdditionalModelRequestFields:      map[string]any{"top_k": 50},
+			ProviderOptionAdditionalModelResponseFieldPaths: []any{"/stop_sequence"},
+			ProviderOptionPerformanceLatency:                LatencyOptimized,
+			ProviderOptionRequestMetadata:                   map[string]any{"team": "search"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+
+	guardrail, _ := body["guardrailConfig"].(map[string]any)
+	if guardrail["guardrailIdentifier"] != "gr-1" || guardrail["guardrailVersion"] != "DRAFT" || guardrail["trace"] != GuardrailTraceEnabled {
+		t.Fatalf("guardrailConfig = %#v", body["guardrailConfig"])
+	}
+	if _, ok := guardrail["streamProcessingMode"]; ok {
+		t.Fatalf("Converse request must not set streamProcessingMode: %#v", guardrail)
+	}
+	fields, _ := body["additionalModelRequestFields"].(map[string]any)
+	if fields["top_k"] != float64(50) || fields["thinking"] == nil {
+		t.Fatalf("additionalModelRequestFields = %#v", body["additionalModelRequestFields"])
+	}
+	if paths, _ := body["additionalModelResponseFieldPaths"].([]any); len(paths) != 1 || paths[0] != "/stop_sequence" {
+		t.Fatalf("additionalModelResponseFieldPaths = %#v", body["additionalModelResponseFieldPaths"])
+	}
+	if performance, _ := body["performanceConfig"].(map[string]any); performance["latency"] != LatencyOptimized {
+		t.Fatalf("performanceConfig = %#v", body["performanceConfig"])
+	}
+	if metadata, _ := body["requestMetadata"].(map[string]any); metadata["team"] != "search" {
+		t.Fatalf("requestMetadata = %#v", body["requestMetadata"])
+	}
+	system, _ := json.Marshal(body["system"])
+	if string(system) != `[{"guardContent":{"text":{"text":"policy"}}}]` {
+		t.Fatalf("system = %s", system)
+	}
+	messages, _ := json.Marshal(body["messages"])
+	if !strings.Contains(string(messages), `{"guardContent":{"text":{"qualifiers":["grounding_source"],"text":"Paris is the capital."}}}`) {
+		t.Fatalf("messages = %s", messages)
+	}
+
+	if resp.FinishReason != litellm.FinishReasonSafety {
+		t.Fatalf("finish reason = %q", resp.FinishReason)
+	}
+	if len(resp.Safety) != 1 {
+		t.Fatalf("safety = %+v", resp.Safety)
+	}
+	safety := resp.Safety[0]
+	if safety.Target != litellm.SafetyTargetPrompt || !safety.Blocked || !strings.Contains(string(safety.Raw), "Investing") {
+		t.Fatalf("safety = %+v", safety)
+	}
+	want := []litellm.SafetyCategory{
+		{Name: "Investing", Filtered: true, Detected: true},
+		{Name: "insults", Severity: "low"},
+		{Name: "email", Filtered: true, Detected: true},
+	}
+	if len(safety.Categories) != len(want) {
+		t.Fatalf("categories = %+v", safety.Categories)
+	}
+	for i := range want {
+		if safety.Categories[i] != want[i] {
+			t.Fatalf("categories[%d] = %+v, want %+v", i, safety.Categories[i], want[i])
+		}
+	}
+}
+
+func TestStreamReportsGuardrailTraceAndResponseFields(t *testing.T) {
+	stream := newStream(&http.Response{
+		Body: io.NopCloser(bytes.NewReader(eventStream(
+			`{"contentBlockDelta":{"contentBlockIndex":0,"delta":{"text":"Sorry."}}}`,
+			`{"messageStop":{"stopReason":"guardrail_intervened","additionalModelResponseFields":{"stop_sequence":null}}}`,
+			`{"metadata":{"usage":{"inputTokens":1,"outputTokens":1,"totalTokens":2},"trace":{"guardrail":{"outputAssessments":{"gr-1":[{"wordPolicy":{"customWords":[{"match":"secret","action":"BLOCKED"}]}}]}}}}}`,
+		))),
+	}, "anthropic.claude")
+	var fields *litellm.ProviderEvent
+	var safety []litellm.SafetyAssessment
+	for {
+		event, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next returned error: %v", err)
+		}
+		switch e := event.(type) {
+		case litellm.ProviderEvent:
+			fields = &e
+		case litellm.SafetyEvent:
+			safety = append(safety, e.Safety)
+		}
+	}
+	if fields == nil || fields.Name != "bedrock.additionalModelResponseFields" || string(fields.Raw) != `{"stop_sequence":null}` {
+		t.Fatalf("provider event = %+v", fields)
+	}
+	if len(safety) != 1 || safety[0].Target != litellm.SafetyTargetOutput || !safety[0].Blocked ||
+		len(safety[0].Categories) != 1 || safety[0].Categories[0].Name != "custom_word" {
+		t.Fatalf("safety = %+v", safety)
+	}
+}
+
+func TestBuildRequestRejectsInvalidConverseOptions(t *testing.T) {
+	provider := mustProvider(t)
+	for name, options := range map[string]litellm.ProviderOptions{
+		"guardrail without version": {ProviderOptionGuardrail: GuardrailConfig{Identifier: "gr-1"}},
+		"guardrail trace":           {ProviderOptionGuardrail: &GuardrailConfig{Identifier: "gr-1", Version: "1", Trace: "verbose"}},
+		"latency":                   {ProviderOptionPerformanceLatency: "fast"},
+		"response field path":       {ProviderOptionAdditionalModelResponseFieldPaths: []string{"stop_sequence"}},
+		"request metadata":          {ProviderOptionRequestMetadata: map[string]any{"count": 1}},
+		"request fields":            {ProviderOptionAdditionalModelRequestFields: "top_k"},
+	} {
+		_, err := provider.buildRequest(&litellm.Request{
+			Model:           "anthropic.claude",
+			Messages:        []litellm.Message{litellm.UserText("hi")},
+			ProviderOptions: options,
+		})
+		if err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
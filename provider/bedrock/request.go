@@ -9,13 +9,58 @@ import (
 	"github.com/voocel/litellm"
 )
 
-const ProviderOptionCacheRetention = "cache_retention"
+const (
+	ProviderOptionCacheRetention = "cache_retention"
+	// ProviderOptionGuardrail takes a GuardrailConfig, or a map in the
+	// Converse guardrailConfig shape.
+	ProviderOptionGuardrail = "guardrail"
+	// ProviderOptionAdditionalModelRequestFields takes a map of
+	// model-specific request fields, merged over those set for thinking.
+	ProviderOptionAdditionalModelRequestFields = "additional_model_request_fields"
+	// ProviderOptionAdditionalModelResponseFieldPaths takes the JSON Pointer
+	// paths of model-specific response fields to return. They are reported
+	// in the raw response and as a "bedrock.additionalModelResponseFields"
+	// stream event.
+	ProviderOptionAdditionalModelResponseFieldPaths = "additional_model_response_field_paths"
+	// ProviderOptionPerformanceLatency takes LatencyStandard or
+	// LatencyOptimized.
+	ProviderOptionPerformanceLatency = "performance_latency"
+	// ProviderOptionRequestMetadata takes string key-value pairs recorded
+	// with the invocation logs.
+	ProviderOptionRequestMetadata = "request_metadata"
+)
+
+const (
+	GuardrailTraceEnabled     = "enabled"
+	GuardrailTraceEnabledFull = "enabled_full"
+	GuardrailTraceDisabled    = "disabled"
+
+	GuardrailStreamSync  = "sync"
+	GuardrailStreamAsync = "async"
+
+	LatencyStandard  = "standard"
+	LatencyOptimized = "optimized"
+
+	// Qualifiers for litellm.GuardContentBlock. Contextual grounding checks
+	// compare the response with the grounding source and the query.
+	GuardQualifierGroundingSource = "grounding_source"
+	GuardQualifierQuery           = "query"
+	GuardQualifierGuardContent    = "guard_content"
+)
+
+// GuardrailConfig applies a Bedrock guardrail to Converse requests. Trace
+// defaults to GuardrailTraceEnabled so interventions are reported in
+// Response.Safety. StreamProcessingMode only applies to streams.
+type GuardrailConfig struct {
+	Identifier           string `json:"guardrailIdentifier"`
+	Version              string `json:"guardrailVersion"`
+	Trace                string `json:"trace,omitempty"`
+	StreamProcessingMode string `json:"streamProcessingMode,omitempty"`
+}
 
 func (p *Provider) buildRequest(req *litellm.Request) (*request, error) {
-	if len(req.ProviderOptions) > 0 {
-		if err := validateProviderOptions(req.ProviderOptions); err != nil {
-			return nil, err
-		}
+	if req.WebSearch != nil {
+		return nil, fmt.Errorf("bedrock: web search is not supported by the Converse API")
 	}
 	out := &request{}
 	if err := convertMessages(out, req.Messages); err != nil {
@@ -26,6 +71,9 @@ func (p *Provider) buildRequest(req *litellm.Request) (*request, error) {
 	if err := applyThinking(out, req); err != nil {
 		return nil, err
 	}
+	if err := applyProviderOptions(out, req.ProviderOptions); err != nil {
+		return nil, err
+	}
 	output, err := convertOutputConfig(req.ResponseFormat)
 	if err != nil {
 		return nil, err
@@ -48,13 +96,65 @@ func (p *Provider) buildRequest(req *litellm.Request) (*request, error) {
 	return out, nil
 }
 
-func validateProviderOptions(options litellm.ProviderOptions) error {
+// dropStreamSettings clears the guardrail setting only ConverseStream
+// accepts, for Converse and batch requests.
+func (r *request) dropStreamSettings() {
+	if r.GuardrailConfig != nil {
+		r.GuardrailConfig.StreamProcessingMode = ""
+	}
+}
+
+func applyProviderOptions(out *request, options litellm.ProviderOptions) error {
 	for key, value := range options {
 		switch key {
 		case ProviderOptionCacheRetention:
+			// Applied with the request's cache settings.
 			if _, ok := value.(string); !ok {
 				return fmt.Errorf("bedrock: provider option %q must be string", key)
 			}
+		case ProviderOptionGuardrail:
+			guardrail, err := guardrailOption(value)
+			if err != nil {
+				return err
+			}
+			out.GuardrailConfig = guardrail
+		case ProviderOptionAdditionalModelRequestFields:
+			fields, ok := value.(map[string]any)
+			if !ok {
+				return fmt.Errorf("bedrock: provider option %q must be object", key)
+			}
+			if out.AdditionalModelRequestFields == nil {
+				out.AdditionalModelRequestFields = make(map[string]any, len(fields))
+			}
+			for name, field := range fields {
+				out.AdditionalModelRequestFields[name] = field
+			}
+		case ProviderOptionAdditionalModelResponseFieldPaths:
+			var paths []string
+			if err := decodeOption(key, value, &paths); err != nil {
+				return err
+			}
+			for _, path := range paths {
+				if !strings.HasPrefix(path, "/") {
+					return fmt.Errorf("bedrock: additional model response field path %q must be a JSON Pointer", path)
+				}
+			}
+			out.AdditionalModelResponseFieldPaths = paths
+		case ProviderOptionPerformanceLatency:
+			latency, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("bedrock: provider option %q must be string", key)
+			}
+			if latency != LatencyStandard && latency != LatencyOptimized {
+				return fmt.Errorf("bedrock: performance latency must be %q or %q, got %q", LatencyStandard, LatencyOptimized, latency)
+			}
+			out.PerformanceConfig = &performanceConfig{Latency: latency}
+		case ProviderOptionRequestMetadata:
+			var metadata map[string]string
+			if err := decodeOption(key, value, &metadata); err != nil {
+				return err
+			}
+			out.RequestMetadata = metadata
 		default:
 			return fmt.Errorf("bedrock: unsupported provider option %q", key)
 		}
@@ -62,6 +162,52 @@ func validateProviderOptions(options litellm.ProviderOptions) error {
 	return nil
 }
 
+func guardrailOption(value any) (*GuardrailConfig, error) {
+	var guardrail GuardrailConfig
+	switch v := value.(type) {
+	case GuardrailConfig:
+		guardrail = v
+	case *GuardrailConfig:
+		if v == nil {
+			return nil, fmt.Errorf("bedrock: provider option %q cannot be nil", ProviderOptionGuardrail)
+		}
+		guardrail = *v
+	default:
+		if err := decodeOption(ProviderOptionGuardrail, value, &guardrail); err != nil {
+			return nil, err
+		}
+	}
+	if guardrail.Identifier == "" || guardrail.Version == "" {
+		return nil, fmt.Errorf("bedrock: guardrail requires an identifier and version")
+	}
+	switch guardrail.Trace {
+	case "":
+		guardrail.Trace = GuardrailTraceEnabled
+	case GuardrailTraceEnabled, GuardrailTraceEnabledFull, GuardrailTraceDisabled:
+	default:
+		return nil, fmt.Errorf("bedrock: unsupported guardrail trace %q", guardrail.Trace)
+	}
+	switch guardrail.StreamProcessingMode {
+	case "", GuardrailStreamSync, GuardrailStreamAsync:
+	default:
+		return nil, fmt.Errorf("bedrock: unsupported guardrail stream processing mode %q", guardrail.StreamProcessingMode)
+	}
+	return &guardrail, nil
+}
+
+// decodeOption converts an option value, such as a map decoded from
+// configuration, into out through its JSON form.
+func decodeOption(key string, value, out any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("bedrock: marshal provider option %q: %w", key, err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("bedrock: provider option %q has the wrong shape: %w", key, err)
+	}
+	return nil
+}
+
 func convertMessages(out *request, messages []litellm.Message) error {
 	for i, msg := range messages {
 		switch msg.Role {
@@ -102,8 +248,10 @@ func convertSystemBlocks(blocks []litellm.Block) ([]systemContent, error) {
 		switch b := block.(type) {
 		case litellm.TextBlock:
 			out = append(out, systemContent{Text: b.Text})
+		case litellm.GuardContentBlock:
+			out = append(out, systemContent{GuardContent: convertGuardContent(b)})
 		default:
-			return nil, fmt.Errorf("system only supports text blocks, got %T", block)
+			return nil, fmt.Errorf("system only supports text and guard content blocks, got %T", block)
 		}
 	}
 	return out, nil
@@ -121,6 +269,14 @@ func convertContentBlocks(blocks []litellm.Block) ([]content, error) {
 				return nil, err
 			}
 			out = append(out, content{Image: image})
+		case litellm.DocumentBlock:
+			doc, err := convertDocument(b)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, content{Document: doc})
+		case litellm.GuardContentBlock:
+			out = append(out, content{GuardContent: convertGuardContent(b)})
 		default:
 			return nil, fmt.Errorf("unsupported user block %T", block)
 		}
@@ -128,6 +284,13 @@ func convertContentBlocks(blocks []litellm.Block) ([]content, error) {
 	return out, nil
 }
 
+func convertGuardContent(block litellm.GuardContentBlock) *guardContent {
+	return &guardContent{Text: guardContentText{
+		Text:       block.Text,
+		Qualifiers: append([]string(nil), block.Qualifiers...),
+	}}
+}
+
 func convertAssistantBlocks(blocks []litellm.Block) ([]content, error) {
 	out := make([]content, 0, len(blocks))
 	for _, block := range blocks {
@@ -144,6 +307,26 @@ func convertAssistantBlocks(blocks []litellm.Block) ([]content, error) {
 				}
 			}
 			out = append(out, content{ToolUse: &toolUse{ToolUseID: b.ID, Name: b.Name, Input: input}})
+		case litellm.ServerToolUseBlock:
+			var input any = map[string]any{}
+			if len(b.Arguments) > 0 {
+				if err := json.Unmarshal(b.Arguments, &input); err != nil {
+					return nil, fmt.Errorf("server tool use %q arguments must be JSON: %w", b.ID, err)
+				}
+			}
+			out = append(out, content{ToolUse: &toolUse{ToolUseID: b.ID, Name: b.Name, Input: input, Type: serverToolUseType}})
+		case litellm.ServerToolResultBlock:
+			var children []content
+			if len(b.Content) > 0 {
+				if err := json.Unmarshal(b.Content, &children); err != nil {
+					return nil, fmt.Errorf("server tool result %q content must be a Converse content array: %w", b.ToolUseID, err)
+				}
+			}
+			result := &toolResult{ToolUseID: b.ToolUseID, Content: children, Type: b.Type}
+			if b.Type == untypedToolResult {
+				result.Type = ""
+			}
+			out = append(out, content{ToolResult: result})
 		default:
 			return nil, fmt.Errorf("unsupported assistant block %T", block)
 		}
@@ -187,13 +370,70 @@ func convertToolResultContent(blocks []litellm.Block) ([]content, error) {
 		switch b := block.(type) {
 		case litellm.TextBlock:
 			out = append(out, content{Text: b.Text})
+		case litellm.DocumentBlock:
+			doc, err := convertDocument(b)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, content{Document: doc})
 		default:
-			return nil, fmt.Errorf("Bedrock tool result only supports text blocks, got %T", block)
+			return nil, fmt.Errorf("Bedrock tool result only supports text and document blocks, got %T", block)
 		}
 	}
 	return out, nil
 }
 
+var documentFormats = map[string]string{
+	"application/pdf":          "pdf",
+	"application/msword":       "doc",
+	"application/vnd.ms-excel": "xls",
+	"text/csv":                 "csv",
+	"text/html":                "html",
+	"text/plain":               "txt",
+	"text/markdown":            "md",
+
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": "docx",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":       "xlsx",
+}
+
+func convertDocument(block litellm.DocumentBlock) (*document, error) {
+	mime := block.MIME
+	var source documentSource
+	switch {
+	case len(block.Data) > 0:
+		source.Bytes = base64.StdEncoding.EncodeToString(block.Data)
+	case strings.HasPrefix(block.FileURI, "s3://"):
+		source.S3Location = &s3Location{URI: block.FileURI}
+	case block.URL != "":
+		dataMIME, encoded, ok := parseDataURL(block.URL)
+		if !ok {
+			return nil, fmt.Errorf("Bedrock document URL must be a data URL; use inline data or an s3:// file URI")
+		}
+		if _, err := base64.StdEncoding.DecodeString(encoded); err != nil {
+			return nil, fmt.Errorf("Bedrock document data URL must be base64: %w", err)
+		}
+		if mime == "" {
+			mime = dataMIME
+		}
+		source.Bytes = encoded
+	default:
+		return nil, fmt.Errorf("Bedrock document requires inline data, a data URL, or an s3:// file URI")
+	}
+	format, ok := documentFormats[strings.ToLower(mime)]
+	if !ok {
+		return nil, fmt.Errorf("Bedrock document MIME %q is not supported", mime)
+	}
+	name := block.Title
+	if name == "" {
+		name = "document"
+	}
+	out := &document{Format: format, Name: name, Source: source}
+	if block.Citations {
+		out.Citations = &documentCitations{Enabled: true}
+	}
+	return out, nil
+}
+
 func convertImage(block litellm.ImageBlock) (*image, error) {
 	var format string
 	var data []byte
@@ -318,6 +558,15 @@ func effortToBudget(effort string) int {
 func convertTools(tools []litellm.Tool) ([]tool, error) {
 	out := make([]tool, 0, len(tools))
 	for _, t := range tools {
+		if t.Server != nil {
+			// Converse system tools, such as "nova_grounding", are named by
+			// their type and take no settings.
+			if len(t.Server.Config) > 0 {
+				return nil, fmt.Errorf("bedrock: system tool %q does not take config", t.Name)
+			}
+			out = append(out, tool{SystemTool: &systemTool{Name: t.Server.Type}})
+			continue
+		}
 		var schema any = map[string]any{"type": "object"}
 		if len(t.Parameters) > 0 {
 			var decoded any
@@ -3,6 +3,8 @@ package bedrock
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/voocel/litellm"
 )
@@ -24,15 +26,37 @@ func convertResponse(resp *response, model string) (*litellm.Response, error) {
 			Provider:         "bedrock",
 			Model:            model,
 		},
+		Safety: convertGuardrailTrace(resp.Trace),
 	}
 	for _, block := range resp.Output.Message.Content {
 		if block.Text != "" {
 			out.Blocks = append(out.Blocks, litellm.TextBlock{Text: block.Text})
 		}
+		if cited := block.CitationsContent; cited != nil {
+			var text string
+			for _, part := range cited.Content {
+				text += part.Text
+			}
+			block := litellm.TextBlock{Text: text}
+			for _, c := range cited.Citations {
+				annotation, err := convertCitation(c, len(text))
+				if err != nil {
+					return nil, err
+				}
+				block.Annotations = append(block.Annotations, annotation)
+			}
+			out.Blocks = append(out.Blocks, block)
+		}
 		if block.ReasoningContent != nil {
 			out.Blocks = append(out.Blocks, convertReasoningBlock(block.ReasoningContent))
 		}
-		if block.ToolUse != nil {
+		if block.ToolUse != nil && block.ToolUse.Type == serverToolUseType {
+			args, err := json.Marshal(block.ToolUse.Input)
+			if err != nil {
+				return nil, fmt.Errorf("bedrock: marshal server tool use %q arguments: %w", block.ToolUse.Name, err)
+			}
+			out.Blocks = append(out.Blocks, litellm.ServerToolUseBlock{ID: block.ToolUse.ToolUseID, Name: block.ToolUse.Name, Arguments: args})
+		} else if block.ToolUse != nil {
 			args, err := json.Marshal(block.ToolUse.Input)
 			if err != nil {
 				return nil, fmt.Errorf("bedrock: marshal tool use %q arguments: %w", block.ToolUse.Name, err)
@@ -43,10 +67,71 @@ func convertResponse(resp *response, model string) (*litellm.Response, error) {
 				Arguments: args,
 			})
 		}
+		if block.ToolResult != nil {
+			result, err := serverToolResultBlock(block.ToolResult.ToolUseID, block.ToolResult.Type, block.ToolResult.Content)
+			if err != nil {
+				return nil, err
+			}
+			out.Blocks = append(out.Blocks, result)
+		}
 	}
 	return out, nil
 }
 
+// convertCitation maps a citation onto the first end bytes of its text block,
+// which the citation supports as a whole.
+func convertCitation(c citation, end int) (litellm.Annotation, error) {
+	extra, err := json.Marshal(c)
+	if err != nil {
+		return litellm.Annotation{}, fmt.Errorf("bedrock: marshal citation: %w", err)
+	}
+	annotation := litellm.Annotation{Type: litellm.AnnotationDocumentCitation, End: end, Title: c.Title, URL: c.Source, Extra: extra}
+	for _, part := range c.SourceContent {
+		annotation.Text += part.Text
+	}
+	document := func(unit litellm.CitationUnit, r *documentRange) {
+		annotation.Location = &litellm.CitationLocation{Index: r.DocumentIndex, Unit: unit, Start: r.Start, End: r.End}
+	}
+	location := c.Location
+	switch {
+	case location.DocumentChar != nil:
+		document(litellm.CitationUnitChar, location.DocumentChar)
+	case location.DocumentPage != nil:
+		document(litellm.CitationUnitPage, location.DocumentPage)
+	case location.DocumentChunk != nil:
+		document(litellm.CitationUnitBlock, location.DocumentChunk)
+	case location.SearchResultLocation != nil:
+		r := location.SearchResultLocation
+		annotation.Type = litellm.AnnotationSearchResultCitation
+		annotation.Location = &litellm.CitationLocation{Index: r.SearchResultIndex, Unit: litellm.CitationUnitBlock, Start: r.Start, End: r.End}
+	case location.Web != nil:
+		annotation.Type = litellm.AnnotationURLCitation
+		annotation.URL = location.Web.URL
+	}
+	return annotation, nil
+}
+
+const (
+	serverToolUseType = "server_tool_use"
+	// untypedToolResult stands in for a server tool result that arrives
+	// without a type, since ServerToolResultBlock requires one; it is omitted
+	// again on the wire.
+	untypedToolResult = "tool_result"
+)
+
+// serverToolResultBlock converts a tool result in model output, which can only
+// come from a server tool.
+func serverToolResultBlock(id, typ string, children []content) (litellm.ServerToolResultBlock, error) {
+	data, err := json.Marshal(children)
+	if err != nil {
+		return litellm.ServerToolResultBlock{}, fmt.Errorf("bedrock: marshal server tool result %q: %w", id, err)
+	}
+	if typ == "" {
+		typ = untypedToolResult
+	}
+	return litellm.ServerToolResultBlock{ToolUseID: id, Type: typ, Content: data}, nil
+}
+
 func convertReasoningBlock(block *reasoningContent) litellm.ReasoningBlock {
 	if block == nil {
 		return litellm.ReasoningBlock{}
@@ -62,3 +147,110 @@ func convertReasoningBlock(block *reasoningContent) litellm.ReasoningBlock {
 	}
 	return litellm.ReasoningBlock{}
 }
+
+// convertGuardrailTrace reports a guardrail trace as one assessment of the
+// prompt per guardrail and one per output assessment. A category is
+// filtered when the guardrail blocked or masked it.
+func convertGuardrailTrace(t *trace) []litellm.SafetyAssessment {
+	if t == nil || t.Guardrail == nil {
+		return nil
+	}
+	var out []litellm.SafetyAssessment
+	for _, id := range sortedKeys(t.Guardrail.InputAssessment) {
+		if assessment, ok := convertGuardrailAssessment(litellm.SafetyTargetPrompt, t.Guardrail.InputAssessment[id]); ok {
+			out = append(out, assessment)
+		}
+	}
+	for _, id := range sortedKeys(t.Guardrail.OutputAssessments) {
+		for _, raw := range t.Guardrail.OutputAssessments[id] {
+			if assessment, ok := convertGuardrailAssessment(litellm.SafetyTargetOutput, raw); ok {
+				out = append(out, assessment)
+			}
+		}
+	}
+	return out
+}
+
+type guardrailAssessment struct {
+	TopicPolicy *struct {
+		Topics []guardrailFinding `json:"topics"`
+	} `json:"topicPolicy"`
+	ContentPolicy *struct {
+		Filters []guardrailFinding `json:"filters"`
+	} `json:"contentPolicy"`
+	WordPolicy *struct {
+		CustomWords      []guardrailFinding `json:"customWords"`
+		ManagedWordLists []guardrailFinding `json:"managedWordLists"`
+	} `json:"wordPolicy"`
+	SensitiveInformationPolicy *struct {
+		PIIEntities []guardrailFinding `json:"piiEntities"`
+		Regexes     []guardrailFinding `json:"regexes"`
+	} `json:"sensitiveInformationPolicy"`
+	ContextualGroundingPolicy *struct {
+		Filters []guardrailFinding `json:"filters"`
+	} `json:"contextualGroundingPolicy"`
+}
+
+// guardrailFinding is an entry of any guardrail policy; each policy sets
+// the fields it uses.
+type guardrailFinding struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Confidence string `json:"confidence"`
+	Action     string `json:"action"`
+	Detected   *bool  `json:"detected"`
+}
+
+func convertGuardrailAssessment(target litellm.SafetyTarget, raw json.RawMessage) (litellm.SafetyAssessment, bool) {
+	var assessment guardrailAssessment
+	if len(raw) == 0 || json.Unmarshal(raw, &assessment) != nil {
+		return litellm.SafetyAssessment{}, false
+	}
+	out := litellm.SafetyAssessment{Target: target, Raw: append(json.RawMessage(nil), raw...)}
+	add := func(findings []guardrailFinding, name func(guardrailFinding) string) {
+		for _, f := range findings {
+			category := litellm.SafetyCategory{
+				Name:     name(f),
+				Severity: strings.ToLower(f.Confidence),
+				Filtered: f.Action == "BLOCKED" || f.Action == "ANONYMIZED",
+				Detected: f.Action != "NONE",
+			}
+			if f.Detected != nil {
+				category.Detected = *f.Detected
+			}
+			if f.Action == "BLOCKED" {
+				out.Blocked = true
+			}
+			out.Categories = append(out.Categories, category)
+		}
+	}
+	byType := func(f guardrailFinding) string { return strings.ToLower(f.Type) }
+	byName := func(f guardrailFinding) string { return f.Name }
+	if p := assessment.TopicPolicy; p != nil {
+		add(p.Topics, byName)
+	}
+	if p := assessment.ContentPolicy; p != nil {
+		add(p.Filters, byType)
+	}
+	if p := assessment.WordPolicy; p != nil {
+		add(p.CustomWords, func(guardrailFinding) string { return "custom_word" })
+		add(p.ManagedWordLists, byType)
+	}
+	if p := assessment.SensitiveInformationPolicy; p != nil {
+		add(p.PIIEntities, byType)
+		add(p.Regexes, byName)
+	}
+	if p := assessment.ContextualGroundingPolicy; p != nil {
+		add(p.Filters, byType)
+	}
+	return out, true
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
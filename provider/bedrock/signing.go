@@ -13,17 +13,24 @@ import (
 	"time"
 )
 
+// signingTransport signs requests for service: "bedrock", or "s3" for the
+// objects batch inference reads and writes.
 type signingTransport struct {
 	credentials CredentialsProvider
 	region      string
+	service     string
 	base        http.RoundTripper
 }
 
 func SigningTransport(credentials CredentialsProvider, region string, base http.RoundTripper) http.RoundTripper {
+	return newSigningTransport(credentials, region, "bedrock", base)
+}
+
+func newSigningTransport(credentials CredentialsProvider, region, service string, base http.RoundTripper) *signingTransport {
 	if base == nil {
 		base = http.DefaultTransport
 	}
-	return &signingTransport{credentials: credentials, region: region, base: base}
+	return &signingTransport{credentials: credentials, region: region, service: service, base: base}
 }
 
 func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -50,7 +57,7 @@ func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error)
 	if credentials.SecretAccessKey == "" {
 		return nil, fmt.Errorf("bedrock: secret access key is required")
 	}
-	if err := signRequest(signedReq, payload, credentials); err != nil {
+	if err := signRequestAs(signedReq, payload, credentials, t.service); err != nil {
 		return nil, fmt.Errorf("bedrock: sign request: %w", err)
 	}
 	return t.base.RoundTrip(signedReq)
@@ -92,11 +99,19 @@ func replayableRequest(req *http.Request) ([]byte, *http.Request, error) {
 }
 
 func signRequest(req *http.Request, payload []byte, credentials Credentials) error {
+	return signRequestAs(req, payload, credentials, "bedrock")
+}
+
+// signRequestAs signs req for service, such as "sts" when assuming a role.
+// Requests without a Content-Type are sent as JSON.
+func signRequestAs(req *http.Request, payload []byte, credentials Credentials, service string) error {
 	now := time.Now().UTC()
 	amzDate := now.Format("20060102T150405Z")
 	dateStamp := now.Format("20060102")
 
-	req.Header.Set("Content-Type", "application/json")
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	req.Header.Set("X-Amz-Date", amzDate)
 	if credentials.SessionToken != "" {
 		req.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
@@ -106,6 +121,10 @@ func signRequest(req *http.Request, payload []byte, credentials Credentials) err
 	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
 
 	canonicalURI := awsEscapePath(req.URL.EscapedPath(), false)
+	if service == "s3" {
+		// S3 signs the path as sent rather than encoding it twice.
+		canonicalURI = req.URL.EscapedPath()
+	}
 	if canonicalURI == "" {
 		canonicalURI = "/"
 	}
@@ -140,7 +159,7 @@ func signRequest(req *http.Request, payload []byte, credentials Credentials) err
 	}, "\n")
 
 	algorithm := "AWS4-HMAC-SHA256"
-	credentialScope := dateStamp + "/" + credentials.Region + "/bedrock/aws4_request"
+	credentialScope := dateStamp + "/" + credentials.Region + "/" + service + "/aws4_request"
 	stringToSign := strings.Join([]string{
 		algorithm,
 		amzDate,
@@ -148,12 +167,13 @@ func signRequest(req *http.Request, payload []byte, credentials Credentials) err
 		sha256Hex([]byte(canonicalRequest)),
 	}, "\n")
 
-	signingKey := signatureKey(credentials.SecretAccessKey, dateStamp, credentials.Region, "bedrock")
+	signingKey := signatureKey(credentials.SecretAccessKey, dateStamp, credentials.Region, service)
 	signature := hmacSHA256Hex(signingKey, []byte(stringToSign))
 	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
 		algorithm, credentials.AccessKeyID, credentialScope, signedHeadersString, signature))
 	return nil
 }
+
 func sha256Hex(data []byte) string {
 	hash := sha256.Sum256(data)
 	return hex.EncodeToString(hash[:])
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/voocel/litellm"
 )
@@ -18,15 +19,32 @@ type stream struct {
 	finish    litellm.FinishReason
 	toolNames map[int]string
 	toolIDs   map[int]string
+	// serverTools and serverResults buffer server tool blocks by index; they
+	// are emitted whole at contentBlockStop.
+	serverTools   map[int]*serverToolCall
+	serverResults map[int]*toolResult
+	// textLens and citations track text blocks by index; citations support
+	// the whole block, so they are emitted at contentBlockStop.
+	textLens  map[int]int
+	citations map[int][]citation
+}
+
+type serverToolCall struct {
+	id, name string
+	input    strings.Builder
 }
 
 func newStream(resp *http.Response, model string) *stream {
 	return &stream{
-		reader:    bufio.NewReader(resp.Body),
-		response:  resp,
-		model:     model,
-		toolNames: make(map[int]string),
-		toolIDs:   make(map[int]string),
+		reader:        bufio.NewReader(resp.Body),
+		response:      resp,
+		model:         model,
+		toolNames:     make(map[int]string),
+		toolIDs:       make(map[int]string),
+		serverTools:   make(map[int]*serverToolCall),
+		serverResults: make(map[int]*toolResult),
+		textLens:      make(map[int]int),
+		citations:     make(map[int][]citation),
 	}
 }
 
@@ -83,17 +101,22 @@ func (s *stream) events(event map[string]json.RawMessage) ([]litellm.Event, erro
 	}
 	if data, ok := event["messageStop"]; ok {
 		var stop struct {
-			StopReason string `json:"stopReason"`
+			StopReason                    string          `json:"stopReason"`
+			AdditionalModelResponseFields json.RawMessage `json:"additionalModelResponseFields"`
 		}
 		if err := json.Unmarshal(data, &stop); err != nil {
 			return nil, bedrockStreamProviderError("bedrock: parse messageStop", err)
 		}
 		s.finish = litellm.NormalizeFinishReason(stop.StopReason)
+		if len(stop.AdditionalModelResponseFields) > 0 && string(stop.AdditionalModelResponseFields) != "null" {
+			return []litellm.Event{bedrockProviderEvent("bedrock.additionalModelResponseFields", stop.AdditionalModelResponseFields)}, nil
+		}
 		return nil, nil
 	}
 	if data, ok := event["metadata"]; ok {
 		var meta struct {
-			Usage usage `json:"usage"`
+			Usage usage  `json:"usage"`
+			Trace *trace `json:"trace"`
 		}
 		if err := json.Unmarshal(data, &meta); err != nil {
 			return nil, litellm.NewProviderErrorWithCause("bedrock", litellm.ErrorTypeProvider, "bedrock: parse metadata", err)
@@ -108,10 +131,14 @@ func (s *stream) events(event map[string]json.RawMessage) ([]litellm.Event, erro
 			Provider:         "bedrock",
 			Model:            s.model,
 		}
-		return []litellm.Event{
+		var events []litellm.Event
+		for _, safety := range convertGuardrailTrace(meta.Trace) {
+			events = append(events, litellm.SafetyEvent{Safety: safety})
+		}
+		return append(events,
 			litellm.UsageEvent{Usage: usage},
 			litellm.DoneEvent{FinishReason: s.finish, Provider: "bedrock", Model: s.model},
-		}, nil
+		), nil
 	}
 	if err := s.streamException(event); err != nil {
 		return nil, err
@@ -130,12 +157,26 @@ func (s *stream) contentBlockStart(data json.RawMessage) ([]litellm.Event, error
 			ToolUse *struct {
 				ToolUseID string `json:"toolUseId"`
 				Name      string `json:"name"`
+				Type      string `json:"type"`
 			} `json:"toolUse"`
+			ToolResult *struct {
+				ToolUseID string `json:"toolUseId"`
+				Type      string `json:"type"`
+				Status    string `json:"status"`
+			} `json:"toolResult"`
 		} `json:"start"`
 	}
 	if err := json.Unmarshal(data, &start); err != nil {
 		return nil, bedrockStreamProviderError("bedrock: parse contentBlockStart", err)
 	}
+	if result := start.Start.ToolResult; result != nil {
+		s.serverResults[start.ContentBlockIndex] = &toolResult{ToolUseID: result.ToolUseID, Type: result.Type, Status: result.Status}
+		return nil, nil
+	}
+	if start.Start.ToolUse != nil && start.Start.ToolUse.Type == serverToolUseType {
+		s.serverTools[start.ContentBlockIndex] = &serverToolCall{id: start.Start.ToolUse.ToolUseID, name: start.Start.ToolUse.Name}
+		return nil, nil
+	}
 	if start.Start.ToolUse == nil {
 		return []litellm.Event{bedrockProviderEvent("bedrock.contentBlockStart", data)}, nil
 	}
@@ -161,12 +202,32 @@ func (s *stream) contentBlockDelta(data json.RawMessage) ([]litellm.Event, error
 			ToolUse *struct {
 				Input string `json:"input"`
 			} `json:"toolUse"`
+			ToolResult []struct {
+				Text string `json:"text"`
+				JSON any    `json:"json"`
+			} `json:"toolResult"`
+			Citation *citation `json:"citation"`
 		} `json:"delta"`
 	}
 	if err := json.Unmarshal(data, &delta); err != nil {
 		return nil, bedrockStreamProviderError("bedrock: parse contentBlockDelta", err)
 	}
+	if call := s.serverTools[delta.ContentBlockIndex]; call != nil && delta.Delta.ToolUse != nil {
+		call.input.WriteString(delta.Delta.ToolUse.Input)
+		return nil, nil
+	}
+	if result := s.serverResults[delta.ContentBlockIndex]; result != nil {
+		for _, part := range delta.Delta.ToolResult {
+			result.Content = append(result.Content, content{Text: part.Text, JSON: part.JSON})
+		}
+		return nil, nil
+	}
+	if delta.Delta.Citation != nil {
+		s.citations[delta.ContentBlockIndex] = append(s.citations[delta.ContentBlockIndex], *delta.Delta.Citation)
+		return nil, nil
+	}
 	if delta.Delta.Text != "" {
+		s.textLens[delta.ContentBlockIndex] += len(delta.Delta.Text)
 		return []litellm.Event{litellm.ContentDelta{Text: delta.Delta.Text, ContentIndex: litellm.IntPtr(delta.ContentBlockIndex)}}, nil
 	}
 	if delta.Delta.ReasoningContent != nil {
@@ -194,6 +255,38 @@ func (s *stream) contentBlockStop(data json.RawMessage) ([]litellm.Event, error)
 	if err := json.Unmarshal(data, &stop); err != nil {
 		return nil, bedrockStreamProviderError("bedrock: parse contentBlockStop", err)
 	}
+	if citations, ok := s.citations[stop.ContentBlockIndex]; ok {
+		delete(s.citations, stop.ContentBlockIndex)
+		events := make([]litellm.Event, 0, len(citations))
+		for _, c := range citations {
+			annotation, err := convertCitation(c, s.textLens[stop.ContentBlockIndex])
+			if err != nil {
+				return nil, bedrockStreamProviderError("bedrock: convert citation", err)
+			}
+			events = append(events, litellm.AnnotationDelta{Annotation: annotation, ContentIndex: litellm.IntPtr(stop.ContentBlockIndex)})
+		}
+		return events, nil
+	}
+	if call := s.serverTools[stop.ContentBlockIndex]; call != nil {
+		delete(s.serverTools, stop.ContentBlockIndex)
+		input := call.input.String()
+		if input == "" {
+			input = "{}"
+		}
+		return []litellm.Event{litellm.ServerToolUseEvent{Block: litellm.ServerToolUseBlock{
+			ID:        call.id,
+			Name:      call.name,
+			Arguments: json.RawMessage(input),
+		}}}, nil
+	}
+	if result := s.serverResults[stop.ContentBlockIndex]; result != nil {
+		delete(s.serverResults, stop.ContentBlockIndex)
+		block, err := serverToolResultBlock(result.ToolUseID, result.Type, result.Content)
+		if err != nil {
+			return nil, bedrockStreamProviderError("bedrock: encode server tool result", err)
+		}
+		return []litellm.Event{litellm.ServerToolResultEvent{Block: block}}, nil
+	}
 	id := s.toolIDs[stop.ContentBlockIndex]
 	if id == "" {
 		return nil, nil
@@ -0,0 +1,82 @@
+package bedrock
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/voocel/litellm"
+)
+
+type countTokensRequest struct {
+	Input countTokensInput `json:"input"`
+}
+
+// countTokensInput holds the Converse form of a request, or the
+// InvokeModel body in Messages mode.
+type countTokensInput struct {
+	Converse    *countConverseInput    `json:"converse,omitempty"`
+	InvokeModel *countInvokeModelInput `json:"invokeModel,omitempty"`
+}
+
+type countInvokeModelInput struct {
+	Body []byte `json:"body"`
+}
+
+type countConverseInput struct {
+	Messages                     []message       `json:"messages"`
+	System                       []systemContent `json:"system,omitempty"`
+	ToolConfig                   *toolConfig     `json:"toolConfig,omitempty"`
+	AdditionalModelRequestFields map[string]any  `json:"additionalModelRequestFields,omitempty"`
+}
+
+type countTokensResponse struct {
+	InputTokens int `json:"inputTokens"`
+}
+
+// CountTokens calls the CountTokens runtime API with the Converse form of
+// req, or its InvokeModel body in Messages mode.
+func (p *Provider) CountTokens(ctx context.Context, req *litellm.Request) (*litellm.TokenCount, error) {
+	if p.messages != nil {
+		return p.countTokensMessages(ctx, req)
+	}
+	wire, err := p.buildRequest(req)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	body, err := json.Marshal(countTokensRequest{Input: countTokensInput{Converse: &countConverseInput{
+		Messages:                     wire.Messages,
+		System:                       wire.System,
+		ToolConfig:                   wire.ToolConfig,
+		AdditionalModelRequestFields: wire.AdditionalModelRequestFields,
+	}}})
+	if err != nil {
+		return nil, fmt.Errorf("bedrock: marshal count tokens request: %w", err)
+	}
+	endpoint, rawPath, err := runtimeEndpoint(p.cfg.BaseURL, req.Model, "count-tokens")
+	if err != nil {
+		return nil, fmt.Errorf("bedrock: create count tokens endpoint: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("bedrock: create count tokens request: %w", err)
+	}
+	httpReq.URL.RawPath = rawPath
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "count tokens request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	var parsed countTokensResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "bedrock: decode count tokens response", err)
+	}
+	return &litellm.TokenCount{InputTokens: parsed.InputTokens, Provider: p.Name(), Model: req.Model}, nil
+}
@@ -1,12 +1,22 @@
 package bedrock
 
+import "encoding/json"
+
 type request struct {
-	Messages                     []message        `json:"messages"`
-	System                       []systemContent  `json:"system,omitempty"`
-	InferenceConfig              *inferenceConfig `json:"inferenceConfig,omitempty"`
-	ToolConfig                   *toolConfig      `json:"toolConfig,omitempty"`
-	OutputConfig                 *outputConfig    `json:"outputConfig,omitempty"`
-	AdditionalModelRequestFields map[string]any   `json:"additionalModelRequestFields,omitempty"`
+	Messages                          []message          `json:"messages"`
+	System                            []systemContent    `json:"system,omitempty"`
+	InferenceConfig                   *inferenceConfig   `json:"inferenceConfig,omitempty"`
+	ToolConfig                        *toolConfig        `json:"toolConfig,omitempty"`
+	OutputConfig                      *outputConfig      `json:"outputConfig,omitempty"`
+	GuardrailConfig                   *GuardrailConfig   `json:"guardrailConfig,omitempty"`
+	AdditionalModelRequestFields      map[string]any     `json:"additionalModelRequestFields,omitempty"`
+	AdditionalModelResponseFieldPaths []string           `json:"additionalModelResponseFieldPaths,omitempty"`
+	PerformanceConfig                 *performanceConfig `json:"performanceConfig,omitempty"`
+	RequestMetadata                   map[string]string  `json:"requestMetadata,omitempty"`
+}
+
+type performanceConfig struct {
+	Latency string `json:"latency"`
 }
 
 type message struct {
@@ -17,10 +27,24 @@ type message struct {
 type content struct {
 	Text             string            `json:"text,omitempty"`
 	Image            *image            `json:"image,omitempty"`
+	Document         *document         `json:"document,omitempty"`
 	ReasoningContent *reasoningContent `json:"reasoningContent,omitempty"`
 	ToolUse          *toolUse          `json:"toolUse,omitempty"`
 	ToolResult       *toolResult       `json:"toolResult,omitempty"`
 	CachePoint       *cachePoint       `json:"cachePoint,omitempty"`
+	JSON             any               `json:"json,omitempty"`
+	CitationsContent *citationsContent `json:"citationsContent,omitempty"`
+	GuardContent     *guardContent     `json:"guardContent,omitempty"`
+}
+
+// guardContent marks text for the request's guardrail to evaluate.
+type guardContent struct {
+	Text guardContentText `json:"text"`
+}
+
+type guardContentText struct {
+	Text       string   `json:"text"`
+	Qualifiers []string `json:"qualifiers,omitempty"`
 }
 
 type cachePoint struct {
@@ -47,21 +71,89 @@ type imageSource struct {
 	Bytes string `json:"bytes,omitempty"`
 }
 
+type document struct {
+	Format    string             `json:"format"`
+	Name      string             `json:"name"`
+	Source    documentSource     `json:"source"`
+	Citations *documentCitations `json:"citations,omitempty"`
+}
+
+type documentSource struct {
+	Bytes      string      `json:"bytes,omitempty"`
+	S3Location *s3Location `json:"s3Location,omitempty"`
+}
+
+type s3Location struct {
+	URI string `json:"uri"`
+}
+
+type documentCitations struct {
+	Enabled bool `json:"enabled"`
+}
+
+// citationsContent is generated text together with the citations that
+// support it.
+type citationsContent struct {
+	Content   []citationText `json:"content"`
+	Citations []citation     `json:"citations"`
+}
+
+type citationText struct {
+	Text string `json:"text"`
+}
+
+type citation struct {
+	Title         string           `json:"title,omitempty"`
+	Source        string           `json:"source,omitempty"`
+	SourceContent []citationText   `json:"sourceContent,omitempty"`
+	Location      citationLocation `json:"location"`
+}
+
+type citationLocation struct {
+	DocumentChar         *documentRange     `json:"documentChar,omitempty"`
+	DocumentPage         *documentRange     `json:"documentPage,omitempty"`
+	DocumentChunk        *documentRange     `json:"documentChunk,omitempty"`
+	SearchResultLocation *searchResultRange `json:"searchResultLocation,omitempty"`
+	Web                  *webLocation       `json:"web,omitempty"`
+}
+
+type documentRange struct {
+	DocumentIndex int `json:"documentIndex"`
+	Start         int `json:"start"`
+	End           int `json:"end"`
+}
+
+type searchResultRange struct {
+	SearchResultIndex int `json:"searchResultIndex"`
+	Start             int `json:"start"`
+	End               int `json:"end"`
+}
+
+type webLocation struct {
+	URL    string `json:"url"`
+	Domain string `json:"domain,omitempty"`
+}
+
+// toolUse and toolResult carry Type "server_tool_use" and the result type for
+// tools the model provider runs itself.
 type toolUse struct {
 	ToolUseID string `json:"toolUseId"`
 	Name      string `json:"name"`
 	Input     any    `json:"input"`
+	Type      string `json:"type,omitempty"`
 }
 
 type toolResult struct {
 	ToolUseID string    `json:"toolUseId"`
 	Content   []content `json:"content"`
 	Status    string    `json:"status,omitempty"`
+	Type      string    `json:"type,omitempty"`
 }
 
 type systemContent struct {
-	Text       string      `json:"text,omitempty"`
-	CachePoint *cachePoint `json:"cachePoint,omitempty"`
+	Text         string        `json:"text,omitempty"`
+	GuardContent *guardContent `json:"guardContent,omitempty"`
+	CachePoint   *cachePoint   `json:"cachePoint,omitempty"`
 }
 
 type inferenceConfig struct {
@@ -78,9 +170,14 @@ type toolConfig struct {
 
 type tool struct {
 	ToolSpec   *toolSpec   `json:"toolSpec,omitempty"`
+	SystemTool *systemTool `json:"systemTool,omitempty"`
 	CachePoint *cachePoint `json:"cachePoint,omitempty"`
 }
 
+type systemTool struct {
+	Name string `json:"name"`
+}
+
 type toolSpec struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
@@ -119,8 +216,21 @@ type response struct {
 	Output struct {
 		Message message `json:"message"`
 	} `json:"output"`
-	StopReason string `json:"stopReason"`
-	Usage      usage  `json:"usage"`
+	StopReason                    string          `json:"stopReason"`
+	Usage                         usage           `json:"usage"`
+	Trace                         *trace          `json:"trace,omitempty"`
+	AdditionalModelResponseFields json.RawMessage `json:"additionalModelResponseFields,omitempty"`
+}
+
+type trace struct {
+	Guardrail *guardrailTrace `json:"guardrail,omitempty"`
+}
+
+// guardrailTrace is the guardrail assessment Bedrock returns when trace is
+// enabled. InputAssessment and OutputAssessments are keyed by guardrail ID.
+type guardrailTrace struct {
+	InputAssessment   map[string]json.RawMessage   `json:"inputAssessment,omitempty"`
+	OutputAssessments map[string][]json.RawMessage `json:"outputAssessments,omitempty"`
 }
 
 type modelList struct {
@@ -20,6 +20,7 @@ func (s Spec) defaultCapabilities(provider, model string) litellm.Capabilities {
 			ParallelCalls: litellm.SupportUnknown,
 			StrictSchema:  strictToolSupport(s.Features.StrictTools),
 			Choice:        litellm.SupportYes,
+			WebSearch:     supportFromBool(s.Request.WebSearch != nil),
 		},
 		Structured: litellm.StructuredCapabilities{
 			JSONObject: litellm.SupportYes,
@@ -27,6 +28,9 @@ func (s Spec) defaultCapabilities(provider, model string) litellm.Capabilities {
 			Strict:     strictJSONSchemaSupport(s.Request),
 			PromptOnly: s.Request.JSONSchemaToPrompt,
 		},
+		Media: litellm.MediaCapabilities{
+			AudioInput: supportFromBool(s.Request.InputAudio),
+		},
 		Streaming: litellm.StreamingCapabilities{
 			Supported:       litellm.SupportYes,
 			Usage:           supportFromBool(!s.Stream.OmitStreamOptions),
@@ -0,0 +1,106 @@
+package compat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/voocel/litellm"
+)
+
+type embeddingResponse struct {
+	Model string          `json:"model"`
+	Data  []embeddingData `json:"data"`
+	Usage usage           `json:"usage"`
+}
+
+type embeddingData struct {
+	Index     int       `json:"index"`
+	Embedding []float64 `json:"embedding"`
+}
+
+func (p *Provider) Embed(ctx context.Context, req *litellm.EmbedRequest) (*litellm.EmbedResponse, error) {
+	if p.spec.Endpoint.EmbeddingsPath == "" {
+		return nil, litellm.NewProviderError(p.Name(), litellm.ErrorTypeValidation, fmt.Sprintf("%s provider does not support embeddings", p.Name()))
+	}
+	body, err := p.buildEmbedRequest(req)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url(p.spec.Endpoint.EmbeddingsPath), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%s: create embeddings request: %w", p.Name(), err)
+	}
+	if err := p.setHeaders(ctx, httpReq, nil, false); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "embeddings request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	var parsed embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, fmt.Sprintf("%s: decode embeddings response", p.Name()), err)
+	}
+	data := append([]embeddingData(nil), parsed.Data...)
+	sort.SliceStable(data, func(i, j int) bool { return data[i].Index < data[j].Index })
+	out := &litellm.EmbedResponse{
+		Embeddings: make([][]float64, 0, len(data)),
+		Model:      req.Model,
+		Provider:   p.Name(),
+	}
+	if p.spec.Response.ModelFromResponse && parsed.Model != "" {
+		out.Model = parsed.Model
+	}
+	for i, item := range data {
+		if item.Index != i {
+			return nil, litellm.NewProviderError(p.Name(), litellm.ErrorTypeProvider, fmt.Sprintf("%s: embeddings response is missing index %d", p.Name(), i))
+		}
+		out.Embeddings = append(out.Embeddings, item.Embedding)
+	}
+	out.Usage = convertUsage(parsed.Usage, p.spec, p.Name(), out.Model)
+	return out, nil
+}
+
+// embedProviderOptions are the provider options an /embeddings body accepts.
+// Chat options are rejected rather than sent to a different endpoint.
+var embedProviderOptions = map[string]bool{
+	"dimensions":      true,
+	"encoding_format": true,
+	"user":            true,
+}
+
+func (p *Provider) buildEmbedRequest(req *litellm.EmbedRequest) ([]byte, error) {
+	body := map[string]any{
+		"model": req.Model,
+		"input": req.Input,
+	}
+	if req.Dimensions != nil {
+		body["dimensions"] = *req.Dimensions
+	}
+	for key, value := range req.ProviderOptions {
+		if !embedProviderOptions[key] {
+			return nil, fmt.Errorf("%s: unsupported embeddings provider option %q", p.Name(), key)
+		}
+		// Embeddings are decoded as float arrays.
+		if key == "encoding_format" && value != "float" {
+			return nil, fmt.Errorf("%s: embeddings encoding_format must be \"float\"", p.Name())
+		}
+		if err := p.putProviderOption(body, key, value); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := body["encoding_format"]; !ok {
+		body["encoding_format"] = "float"
+	}
+	return json.Marshal(body)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -323,6 +324,24 @@ func TestChatReturnsStructuredValidationError(t *testing.T) {
 	}
 }
 
+func TestWebSearchRequiresMapper(t *testing.T) {
+	provider, err := New(Config{BaseURL: "https://compat.example", HTTPClient: roundTripFunc(nil)}, Spec{Name: "strict"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if caps := provider.Capabilities("m"); caps.Tools.WebSearch != litellm.SupportNo {
+		t.Fatalf("web search support = %v, want no", caps.Tools.WebSearch)
+	}
+	_, err = provider.Chat(context.Background(), &litellm.Request{
+		Model:     "m",
+		Messages:  []litellm.Message{litellm.UserText("hi")},
+		WebSearch: &litellm.WebSearch{},
+	})
+	if !litellm.IsValidationError(err) || !strings.Contains(err.Error(), "web search is not supported") {
+		t.Fatalf("expected web search validation error, got %v", err)
+	}
+}
+
 func TestRejectsStopSequencesAboveProviderLimit(t *testing.T) {
 	provider, err := New(Config{BaseURL: "https://compat.example", HTTPClient: roundTripFunc(nil)}, Spec{
 		Name:    "glm",
@@ -574,6 +593,59 @@ func TestChatConvertsRefusalMessageAndContentPart(t *testing.T) {
 	}
 }
 
+func TestChatConvertsAnnotationsAndCitations(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want litellm.Annotation
+	}{
+		{
+			name: "message_annotations",
+			body: `{"choices":[{"message":{"content":"Café opens today.","annotations":[{"type":"url_citation","url_citation":{"start_index":5,"end_index":16,"url":"https://example.com/cafe","title":"Café news","content":"The café opens today."}}]},"finish_reason":"stop"}]}`,
+			want: litellm.Annotation{Type: litellm.AnnotationURLCitation, Start: 6, End: 17, URL: "https://example.com/cafe", Title: "Café news", Text: "The café opens today."},
+		},
+		{
+			name: "search_results",
+			body: `{"citations":["https://example.com/cafe"],"search_results":[{"title":"Café news","url":"https://example.com/cafe","date":"2026-10-01"}],"choices":[{"message":{"content":"Café opens today.[1]"},"finish_reason":"stop"}]}`,
+			want: litellm.Annotation{Type: litellm.AnnotationURLCitation, URL: "https://example.com/cafe", Title: "Café news"},
+		},
+		{
+			name: "citations",
+			body: `{"citations":["https://example.com/cafe"],"choices":[{"message":{"content":"Café opens today.[1]"},"finish_reason":"stop"}]}`,
+			want: litellm.Annotation{Type: litellm.AnnotationURLCitation, URL: "https://example.com/cafe"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := New(Config{
+				BaseURL: "https://compat.example",
+				HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					return jsonResponse(http.StatusOK, tt.body), nil
+				}),
+			}, Spec{Name: "strict"})
+			if err != nil {
+				t.Fatalf("New returned error: %v", err)
+			}
+			resp, err := provider.Chat(context.Background(), &litellm.Request{
+				Model:    "m",
+				Messages: []litellm.Message{litellm.UserText("hi")},
+			})
+			if err != nil {
+				t.Fatalf("Chat returned error: %v", err)
+			}
+			block, ok := resp.Blocks[0].(litellm.TextBlock)
+			if len(resp.Blocks) != 1 || !ok || len(block.Annotations) != 1 {
+				t.Fatalf("blocks = %#v", resp.Blocks)
+			}
+			got := block.Annotations[0]
+			got.Extra = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("annotation = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestInjectJSONSchemaAddsUserMessageWhenMissing(t *testing.T) {
 	messages := injectJSONSchema([]litellm.Message{litellm.System("system only")}, &litellm.JSONSchema{
 		Name:   "result",
@@ -688,6 +760,86 @@ func TestThinkingMapperMustEmitFields(t *testing.T) {
 	}
 }
 
+func TestEmbedUsesConfiguredEmbeddingsPath(t *testing.T) {
+	var capturedBody map[string]any
+	provider, err := New(Config{
+		BaseURL: "https://compat.example/v1",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.String() != "https://compat.example/v1/embeddings" {
+				t.Fatalf("url = %s", req.URL.String())
+			}
+			if err := json.NewDecoder(req.Body).Decode(&capturedBody); err != nil {
+				t.Fatalf("decode request body: %v", err)
+			}
+			return jsonResponse(http.StatusOK, `{
+				"model":"embed-served",
+				"data":[{"index":1,"embedding":[2]},{"index":0,"embedding":[1]}],
+				"usage":{"prompt_tokens":4,"total_tokens":4}
+			}`), nil
+		}),
+	}, Spec{
+		Name:     "testcompat",
+		Endpoint: EndpointSpec{EmbeddingsPath: "/embeddings"},
+		Response: ResponseSpec{ModelFromResponse: true},
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.Embed(context.Background(), &litellm.EmbedRequest{
+		Model:           "embed",
+		Input:           []string{"a", "b"},
+		ProviderOptions: litellm.ProviderOptions{"user": "u-1"},
+	})
+	if err != nil {
+		t.Fatalf("Embed returned error: %v", err)
+	}
+	if capturedBody["encoding_format"] != "float" || capturedBody["user"] != "u-1" || len(capturedBody["input"].([]any)) != 2 {
+		t.Fatalf("body = %#v", capturedBody)
+	}
+	if resp.Model != "embed-served" || resp.Embeddings[0][0] != 1 || resp.Embeddings[1][0] != 2 || resp.Usage.InputTokens != 4 {
+		t.Fatalf("resp = %+v", resp)
+	}
+}
+
+func TestEmbedRejectsChatProviderOptions(t *testing.T) {
+	provider, err := New(Config{
+		BaseURL:                     "https://compat.example/v1",
+		AllowUnknownProviderOptions: true,
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatalf("unexpected request to %s", req.URL)
+			return nil, nil
+		}),
+	}, Spec{
+		Name:     "testcompat",
+		Endpoint: EndpointSpec{EmbeddingsPath: "/embeddings"},
+		Request:  RequestSpec{AllowedProviderOptions: map[string]struct{}{"tool_choice": {}}},
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	for _, options := range []litellm.ProviderOptions{
+		{"tool_choice": "auto"},
+		{"response_format": map[string]any{"type": "json_object"}},
+		{"encoding_format": "base64"},
+	} {
+		_, err := provider.Embed(context.Background(), &litellm.EmbedRequest{Model: "embed", Input: []string{"a"}, ProviderOptions: options})
+		if !litellm.IsValidationError(err) {
+			t.Fatalf("options %v: expected validation error, got %v", options, err)
+		}
+	}
+}
+
+func TestEmbedRequiresEmbeddingsPath(t *testing.T) {
+	provider, err := New(Config{BaseURL: "https://compat.example", HTTPClient: roundTripFunc(nil)}, Spec{Name: "chatonly"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	_, err = provider.Embed(context.Background(), &litellm.EmbedRequest{Model: "m", Input: []string{"a"}})
+	if !litellm.IsValidationError(err) || !strings.Contains(err.Error(), "does not support embeddings") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
 func jsonResponse(status int, body string) *http.Response {
 	return &http.Response{
 		StatusCode: status,
@@ -1,6 +1,7 @@
 package compat
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -111,6 +112,18 @@ func (p *Provider) buildRequest(req *litellm.Request, stream bool) ([]byte, []li
 			body[key] = value
 		}
 	}
+	if req.WebSearch != nil {
+		if p.spec.Request.WebSearch == nil {
+			return nil, nil, fmt.Errorf("%s: web search is not supported", p.Name())
+		}
+		fields, err := p.spec.Request.WebSearch(req.WebSearch, req.Model)
+		if err != nil {
+			return nil, nil, err
+		}
+		for key, value := range fields {
+			body[key] = value
+		}
+	}
 	if p.spec.Request.ProviderOptions != nil {
 		mappedOptions, passthroughOptions := p.splitProviderOptions(req.ProviderOptions)
 		if err := p.spec.Request.ProviderOptions(mappedOptions, body, req); err != nil {
@@ -189,7 +202,7 @@ func convertMessagesWithSpec(messages []litellm.Message, spec Spec) ([]map[strin
 	for i, msg := range messages {
 		switch msg.Role {
 		case litellm.RoleSystem, litellm.RoleUser, litellm.RoleAssistant:
-			content, toolCalls, reasoning, err := convertBlocks(msg.Blocks, spec)
+			content, toolCalls, extra, err := convertBlocks(msg.Blocks, spec)
 			if err != nil {
 				return nil, fmt.Errorf("messages[%d]: %w", i, err)
 			}
@@ -202,7 +215,7 @@ func convertMessagesWithSpec(messages []litellm.Message, spec Spec) ([]map[strin
 			if len(toolCalls) > 0 {
 				converted["tool_calls"] = toolCalls
 			}
-			for key, value := range reasoning {
+			for key, value := range extra {
 				converted[key] = value
 			}
 			out = append(out, converted)
@@ -229,7 +242,7 @@ func convertBlocks(blocks []litellm.Block, spec Spec) (any, []map[string]any, ma
 	parts := make([]map[string]any, 0, len(blocks))
 	var text strings.Builder
 	var tools []map[string]any
-	reasoning := make(map[string]any)
+	extra := make(map[string]any)
 	for _, block := range blocks {
 		switch b := block.(type) {
 		case litellm.TextBlock:
@@ -249,6 +262,30 @@ func convertBlocks(blocks []litellm.Block, spec Spec) (any, []map[string]any, ma
 				image["detail"] = b.Detail
 			}
 			parts = append(parts, map[string]any{"type": "image_url", "image_url": image})
+		case litellm.AudioBlock:
+			if !spec.Request.InputAudio {
+				return nil, nil, nil, fmt.Errorf("audio input is not supported by this compat provider")
+			}
+			data := base64.StdEncoding.EncodeToString(b.Data)
+			if spec.Request.AudioDataURL {
+				data = "data:;base64," + data
+			}
+			parts = append(parts, map[string]any{"type": "input_audio", "input_audio": map[string]any{"data": data, "format": b.Format}})
+		case litellm.AudioOutputBlock:
+			// Replay the transcript where possible; compatible servers rarely
+			// keep generated audio addressable by id.
+			switch {
+			case b.Transcript != "":
+				parts = append(parts, map[string]any{"type": "text", "text": b.Transcript})
+				if text.Len() > 0 {
+					text.WriteString("\n")
+				}
+				text.WriteString(b.Transcript)
+			case b.ID != "":
+				extra["audio"] = map[string]any{"id": b.ID}
+			default:
+				return nil, nil, nil, fmt.Errorf("assistant audio requires a transcript or id")
+			}
 		case litellm.ToolUseBlock:
 			tools = append(tools, map[string]any{
 				"id":   b.ID,
@@ -259,7 +296,7 @@ func convertBlocks(blocks []litellm.Block, spec Spec) (any, []map[string]any, ma
 				},
 			})
 		case litellm.ReasoningBlock:
-			if err := putReasoningBlock(reasoning, b, spec); err != nil {
+			if err := putReasoningBlock(extra, b, spec); err != nil {
 				return nil, nil, nil, err
 			}
 		default:
@@ -267,12 +304,12 @@ func convertBlocks(blocks []litellm.Block, spec Spec) (any, []map[string]any, ma
 		}
 	}
 	if len(parts) == 0 {
-		return nil, tools, reasoning, nil
+		return nil, tools, extra, nil
 	}
 	if len(parts) == 1 && text.Len() > 0 {
-		return text.String(), tools, reasoning, nil
+		return text.String(), tools, extra, nil
 	}
-	return parts, tools, reasoning, nil
+	return parts, tools, extra, nil
 }
 
 func putReasoningBlock(out map[string]any, block litellm.ReasoningBlock, spec Spec) error {
@@ -325,6 +362,9 @@ func convertTools(tools []litellm.Tool, mode StrictToolMode) ([]map[string]any,
 	out := make([]map[string]any, 0, len(tools))
 	var warnings []litellm.Warning
 	for _, tool := range tools {
+		if tool.Server != nil {
+			return nil, nil, fmt.Errorf("server tool %q is not supported", tool.Name)
+		}
 		var params any = map[string]any{"type": "object"}
 		if len(tool.Parameters) > 0 {
 			var decoded any
@@ -1,6 +1,7 @@
 package compat
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 
@@ -35,7 +36,23 @@ func (p *Provider) convertResponse(resp *chatResponse, req *litellm.Request) (*l
 	if err != nil {
 		return nil, fmt.Errorf("%s: convert response content: %w", p.Name(), err)
 	}
+	if err := annotateText(blocks, choice.Message.Annotations, resp.Citations, resp.SearchResults); err != nil {
+		return nil, fmt.Errorf("%s: convert annotations: %w", p.Name(), err)
+	}
 	out.Blocks = append(out.Blocks, blocks...)
+	if audio := choice.Message.Audio; audio != nil {
+		data, err := base64.StdEncoding.DecodeString(audio.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: decode audio data: %w", p.Name(), err)
+		}
+		out.Blocks = append(out.Blocks, litellm.AudioOutputBlock{
+			ID:         audio.ID,
+			Data:       data,
+			Format:     requestedAudioFormat(req),
+			Transcript: audio.Transcript,
+			ExpiresAt:  audio.ExpiresAt,
+		})
+	}
 	out.Refusal = contentRefusal + choice.Message.Refusal
 	if choice.Message.Refusal != "" {
 		out.Blocks = append(out.Blocks, litellm.TextBlock{Text: choice.Message.Refusal})
@@ -110,6 +127,91 @@ func contentBlocks(raw json.RawMessage) ([]litellm.Block, string, error) {
 	return blocks, refusal, nil
 }
 
+// annotateText attaches message annotations and top-level citations to the
+// first text block. Message annotations locate their span with character
+// offsets into the message text; citations list sources without locating
+// them.
+func annotateText(blocks []litellm.Block, raw []map[string]any, citations []string, results []searchResult) error {
+	for i, block := range blocks {
+		text, ok := block.(litellm.TextBlock)
+		if !ok {
+			continue
+		}
+		for _, entry := range raw {
+			annotation, err := convertAnnotation(entry, text.Text)
+			if err != nil {
+				return err
+			}
+			text.Annotations = append(text.Annotations, annotation)
+		}
+		annotations, err := citationAnnotations(citations, results)
+		if err != nil {
+			return err
+		}
+		text.Annotations = append(text.Annotations, annotations...)
+		blocks[i] = text
+		return nil
+	}
+	return nil
+}
+
+// convertAnnotation reads an OpenAI-style annotation, either nested as
+// {"type":"url_citation","url_citation":{...}} or flat.
+func convertAnnotation(raw map[string]any, text string) (litellm.Annotation, error) {
+	extra, err := json.Marshal(raw)
+	if err != nil {
+		return litellm.Annotation{}, err
+	}
+	annotation := litellm.Annotation{Extra: extra}
+	annotation.Type, _ = raw["type"].(string)
+	fields := raw
+	if nested, ok := raw[annotation.Type].(map[string]any); ok {
+		fields = nested
+	}
+	annotation.URL, _ = fields["url"].(string)
+	annotation.Title, _ = fields["title"].(string)
+	annotation.Text, _ = fields["content"].(string)
+	start, _ := fields["start_index"].(float64)
+	end, _ := fields["end_index"].(float64)
+	if end > start {
+		annotation.Start = litellm.ByteOffset(text, int(start))
+		annotation.End = litellm.ByteOffset(text, int(end))
+	}
+	return annotation, nil
+}
+
+// citationAnnotations converts the sources search-backed models list beside
+// the message. search_results carry titles, so they are preferred over the
+// bare citations URLs when both are present.
+func citationAnnotations(citations []string, results []searchResult) ([]litellm.Annotation, error) {
+	var out []litellm.Annotation
+	if len(results) > 0 {
+		for _, result := range results {
+			extra, err := json.Marshal(result)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, litellm.Annotation{Type: litellm.AnnotationURLCitation, URL: result.URL, Title: result.Title, Extra: extra})
+		}
+		return out, nil
+	}
+	for _, url := range citations {
+		out = append(out, litellm.Annotation{Type: litellm.AnnotationURLCitation, URL: url})
+	}
+	return out, nil
+}
+
+// requestedAudioFormat reads the output format from the "audio" provider
+// option; compatible servers do not echo it in the response.
+func requestedAudioFormat(req *litellm.Request) string {
+	if req == nil {
+		return ""
+	}
+	audio, _ := req.ProviderOptions["audio"].(map[string]any)
+	format, _ := audio["format"].(string)
+	return format
+}
+
 func rawReasoningExtra(msg message) (json.RawMessage, error) {
 	if msg.ReasoningDetails == nil {
 		return nil, nil
@@ -45,6 +45,10 @@ type EndpointSpec struct {
 	BaseURL    string
 	ChatPath   string
 	ModelsPath string
+
+	// EmbeddingsPath enables Embed against an OpenAI-compatible embeddings
+	// endpoint. Leave empty for providers without one.
+	EmbeddingsPath string
 }
 
 type AuthSpec struct {
@@ -65,7 +69,14 @@ type RequestSpec struct {
 	SupportsJSONSchema bool
 	JSONSchemaToPrompt bool
 
+	// InputAudio encodes AudioBlock as an OpenAI-style input_audio part.
+	// AudioDataURL wraps the base64 payload in a data URL, as DashScope
+	// requires.
+	InputAudio   bool
+	AudioDataURL bool
+
 	Thinking        ThinkingMapper
+	WebSearch       WebSearchMapper
 	ResponseFormat  ResponseFormatMapper
 	CleanSchema     SchemaMapper
 	ProviderOptions ProviderOptionsMapper
@@ -114,6 +125,7 @@ const (
 )
 
 type ThinkingMapper func(*litellm.Thinking, string) (map[string]any, error)
+type WebSearchMapper func(*litellm.WebSearch, string) (map[string]any, error)
 type CapabilityMapper func(model string, base litellm.Capabilities) litellm.Capabilities
 type ResponseFormatMapper func(*litellm.ResponseFormat) (any, error)
 type SchemaMapper func(litellm.Schema) (any, error)
@@ -2,6 +2,7 @@ package compat
 
 import (
 	"bufio"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -24,6 +25,11 @@ type stream struct {
 	finish        litellm.FinishReason
 	lastContent   string
 	lastReasoning string
+	// texts holds each choice's streamed text, which annotation character
+	// offsets index into.
+	texts         map[int]*strings.Builder
+	citations     []string
+	searchResults []searchResult
 	toolIDs       map[toolKey]string
 	toolStarted   map[toolKey]bool
 	toolPending   map[toolKey]*pendingTool
@@ -51,6 +57,7 @@ func newStream(resp *http.Response, req *litellm.Request, spec Spec) *stream {
 		req:         req,
 		spec:        spec,
 		model:       req.Model,
+		texts:       make(map[int]*strings.Builder),
 		toolIDs:     make(map[toolKey]string),
 		toolStarted: make(map[toolKey]bool),
 		toolPending: make(map[toolKey]*pendingTool),
@@ -88,7 +95,21 @@ func (s *stream) Next() (litellm.Event, error) {
 		}
 		if data == s.spec.doneSentinel() {
 			s.done = true
-			return litellm.DoneEvent{FinishReason: s.finish, Provider: s.spec.providerName(), Model: s.model}, nil
+			done := litellm.DoneEvent{FinishReason: s.finish, Provider: s.spec.providerName(), Model: s.model}
+			// Search-backed models repeat their citations on every chunk, so
+			// they are emitted once the text is complete.
+			annotations, err := citationAnnotations(s.citations, s.searchResults)
+			if err != nil {
+				return nil, litellm.NewProviderErrorWithCause(s.spec.providerName(), litellm.ErrorTypeProvider, fmt.Sprintf("%s: convert citations", s.spec.providerName()), err)
+			}
+			if len(annotations) == 0 {
+				return done, nil
+			}
+			for _, annotation := range annotations[1:] {
+				s.pending = append(s.pending, litellm.AnnotationDelta{Annotation: annotation, OutputIndex: litellm.IntPtr(0)})
+			}
+			s.pending = append(s.pending, done)
+			return litellm.AnnotationDelta{Annotation: annotations[0], OutputIndex: litellm.IntPtr(0)}, nil
 		}
 		var chunk streamChunk
 		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
@@ -120,6 +141,12 @@ func (s *stream) events(chunk streamChunk) ([]litellm.Event, error) {
 	if chunk.Model != "" {
 		s.model = chunk.Model
 	}
+	if len(chunk.Citations) > 0 {
+		s.citations = chunk.Citations
+	}
+	if len(chunk.SearchResults) > 0 {
+		s.searchResults = chunk.SearchResults
+	}
 	if len(chunk.Usage) > 0 {
 		var usage usage
 		if err := json.Unmarshal(chunk.Usage, &usage); err != nil {
@@ -143,9 +170,24 @@ func (s *stream) events(chunk streamChunk) ([]litellm.Event, error) {
 					text = next
 				}
 				if text != "" {
+					s.choiceText(choice.Index).WriteString(text)
 					events = append(events, litellm.ContentDelta{Text: text, OutputIndex: litellm.IntPtr(choice.Index)})
 				}
 			}
+			if rawAnnotations, ok := delta["annotations"].([]any); ok {
+				text := s.choiceText(choice.Index).String()
+				for _, raw := range rawAnnotations {
+					entry, ok := raw.(map[string]any)
+					if !ok {
+						return nil, litellm.NewProviderError(s.spec.providerName(), litellm.ErrorTypeProvider, fmt.Sprintf("%s: stream annotation must be an object", s.spec.providerName()))
+					}
+					annotation, err := convertAnnotation(entry, text)
+					if err != nil {
+						return nil, litellm.NewProviderErrorWithCause(s.spec.providerName(), litellm.ErrorTypeProvider, fmt.Sprintf("%s: convert annotation", s.spec.providerName()), err)
+					}
+					events = append(events, litellm.AnnotationDelta{Annotation: annotation, OutputIndex: litellm.IntPtr(choice.Index)})
+				}
+			}
 			if refusal, _ := delta["refusal"].(string); refusal != "" {
 				events = append(events, litellm.RefusalDelta{Text: refusal, OutputIndex: litellm.IntPtr(choice.Index)})
 			}
@@ -169,6 +211,13 @@ func (s *stream) events(chunk streamChunk) ([]litellm.Event, error) {
 					}
 				}
 			}
+			if audio, ok := delta["audio"].(map[string]any); ok {
+				event, err := s.audioDelta(audio)
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, event)
+			}
 			if rawCalls, ok := delta["tool_calls"].([]any); ok {
 				for _, raw := range rawCalls {
 					toolEvents, err := s.toolEvents(raw, choice.Index)
@@ -235,6 +284,15 @@ func (s *stream) toolDoneEvents(choiceIndex int) []litellm.Event {
 	return events
 }
 
+func (s *stream) choiceText(index int) *strings.Builder {
+	text := s.texts[index]
+	if text == nil {
+		text = &strings.Builder{}
+		s.texts[index] = text
+	}
+	return text
+}
+
 func (s *stream) findContent(delta map[string]any) string {
 	fields := s.spec.Stream.ContentFields
 	if len(fields) == 0 {
@@ -323,6 +381,34 @@ func (s *stream) reasoningDelta(current string) (string, error) {
 	return next, nil
 }
 
+func (s *stream) audioDelta(audio map[string]any) (litellm.AudioDelta, error) {
+	provider := s.spec.providerName()
+	id, err := optionalString(audio, "id", provider, "stream audio")
+	if err != nil {
+		return litellm.AudioDelta{}, err
+	}
+	encoded, err := optionalString(audio, "data", provider, "stream audio")
+	if err != nil {
+		return litellm.AudioDelta{}, err
+	}
+	transcript, err := optionalString(audio, "transcript", provider, "stream audio")
+	if err != nil {
+		return litellm.AudioDelta{}, err
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return litellm.AudioDelta{}, litellm.NewProviderErrorWithCause(provider, litellm.ErrorTypeProvider, fmt.Sprintf("%s: decode stream audio", provider), err)
+	}
+	expiresAt, _ := audio["expires_at"].(float64)
+	return litellm.AudioDelta{
+		ID:         id,
+		Data:       data,
+		Format:     requestedAudioFormat(s.req),
+		Transcript: transcript,
+		ExpiresAt:  int64(expiresAt),
+	}, nil
+}
+
 func (s *stream) toolEvents(raw any, choiceIndex int) ([]litellm.Event, error) {
 	m, ok := raw.(map[string]any)
 	if !ok {
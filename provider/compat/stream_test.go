@@ -114,6 +114,30 @@ func TestStreamConvertsRefusalAndCachedTokens(t *testing.T) {
 	}
 }
 
+func TestStreamCollectsAnnotationsAndCitations(t *testing.T) {
+	stream := streamFromSSE(t, strings.Join([]string{
+		`data: {"citations":["https://example.com/a"],"choices":[{"index":0,"delta":{"content":"Café opens "}}]}`,
+		`data: {"citations":["https://example.com/a"],"choices":[{"index":0,"delta":{"content":"today."}}]}`,
+		`data: {"citations":["https://example.com/a"],"choices":[{"index":0,"delta":{"annotations":[{"type":"url_citation","url_citation":{"start_index":5,"end_index":16,"url":"https://example.com/b"}}]},"finish_reason":"stop"}]}`,
+		`data: [DONE]`,
+		``,
+	}, "\n"), Spec{Name: "strict"}, nil)
+	resp, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	block, ok := resp.Blocks[0].(litellm.TextBlock)
+	if len(resp.Blocks) != 1 || !ok || len(block.Annotations) != 2 {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	if located := block.Annotations[0]; located.URL != "https://example.com/b" || block.Text[located.Start:located.End] != "opens today" {
+		t.Fatalf("annotation = %+v", located)
+	}
+	if cited := block.Annotations[1]; cited.URL != "https://example.com/a" || cited.End != 0 {
+		t.Fatalf("citation = %+v", cited)
+	}
+}
+
 func TestStreamPrependsStrictToolOmittedWarning(t *testing.T) {
 	tool := mustTool(t, "lookup", "Lookup.", map[string]any{"type": "object"})
 	tool.Strict = litellm.StrictEnabled
@@ -372,6 +396,34 @@ func TestStreamRejectsMalformedToolCall(t *testing.T) {
 	}
 }
 
+func TestStreamAssemblesAudioDeltas(t *testing.T) {
+	stream := streamFromSSE(t, strings.Join([]string{
+		`data: {"choices":[{"index":0,"delta":{"audio":{"id":"audio_1","transcript":"hel"}}}]}`,
+		`data: {"choices":[{"index":0,"delta":{"audio":{"data":"UklG","transcript":"lo"}}}]}`,
+		`data: {"choices":[{"index":0,"delta":{"audio":{"data":"Rg==","expires_at":1729234747}},"finish_reason":"stop"}]}`,
+		`data: [DONE]`,
+		``,
+	}, "\n"), Spec{
+		Name:    "audio",
+		Request: RequestSpec{AllowedProviderOptions: map[string]struct{}{"audio": {}}},
+	}, &litellm.Request{
+		Model:           "m",
+		Messages:        []litellm.Message{litellm.UserText("hi")},
+		ProviderOptions: litellm.ProviderOptions{"audio": map[string]any{"voice": "Cherry", "format": "wav"}},
+	})
+	resp, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	if len(resp.Blocks) != 1 {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	audio, ok := resp.Blocks[0].(litellm.AudioOutputBlock)
+	if !ok || audio.ID != "audio_1" || string(audio.Data) != "RIFF" || audio.Format != "wav" || audio.Transcript != "hello" || audio.ExpiresAt != 1729234747 {
+		t.Fatalf("audio block = %#v", resp.Blocks[0])
+	}
+}
+
 func TestStreamRejectsEOFBeforeDoneSentinel(t *testing.T) {
 	stream := streamFromSSE(t, `data: {"choices":[{"delta":{"content":"partial"}}]}`, Spec{Name: "strict"}, nil)
 	_, err := litellm.Collect(stream)
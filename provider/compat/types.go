@@ -3,10 +3,20 @@ package compat
 import "encoding/json"
 
 type chatResponse struct {
-	ID      string   `json:"id"`
-	Model   string   `json:"model"`
-	Choices []choice `json:"choices"`
-	Usage   usage    `json:"usage"`
+	ID            string         `json:"id"`
+	Model         string         `json:"model"`
+	Choices       []choice       `json:"choices"`
+	Usage         usage          `json:"usage"`
+	Citations     []string       `json:"citations,omitempty"`
+	SearchResults []searchResult `json:"search_results,omitempty"`
+}
+
+// searchResult is a source listed by search-backed models such as Perplexity
+// Sonar, alongside or instead of the top-level citations URLs.
+type searchResult struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+	Date  string `json:"date,omitempty"`
 }
 
 type choice struct {
@@ -17,16 +27,25 @@ type choice struct {
 }
 
 type message struct {
-	Role             string          `json:"role"`
-	Content          json.RawMessage `json:"content,omitempty"`
-	Refusal          string          `json:"refusal,omitempty"`
-	ToolCalls        []toolCall      `json:"tool_calls,omitempty"`
-	ReasoningSummary any             `json:"reasoning_summary,omitempty"`
-	ReasoningDetails any             `json:"reasoning_details,omitempty"`
-	ReasoningContent string          `json:"reasoning_content,omitempty"`
-	Reasoning        string          `json:"reasoning,omitempty"`
-	ReasoningText    string          `json:"reasoning_text,omitempty"`
-	Thinking         string          `json:"thinking,omitempty"`
+	Role             string           `json:"role"`
+	Content          json.RawMessage  `json:"content,omitempty"`
+	Refusal          string           `json:"refusal,omitempty"`
+	ToolCalls        []toolCall       `json:"tool_calls,omitempty"`
+	ReasoningSummary any              `json:"reasoning_summary,omitempty"`
+	ReasoningDetails any              `json:"reasoning_details,omitempty"`
+	ReasoningContent string           `json:"reasoning_content,omitempty"`
+	Reasoning        string           `json:"reasoning,omitempty"`
+	ReasoningText    string           `json:"reasoning_text,omitempty"`
+	Thinking         string           `json:"thinking,omitempty"`
+	Audio            *messageAudio    `json:"audio,omitempty"`
+	Annotations      []map[string]any `json:"annotations,omitempty"`
+}
+
+type messageAudio struct {
+	ID         string `json:"id,omitempty"`
+	Data       string `json:"data,omitempty"`
+	ExpiresAt  int64  `json:"expires_at,omitempty"`
+	Transcript string `json:"transcript,omitempty"`
 }
 
 type toolCall struct {
@@ -60,10 +79,12 @@ type completionTokensDetails struct {
 }
 
 type streamChunk struct {
-	ID      string          `json:"id"`
-	Model   string          `json:"model"`
-	Choices []streamChoice  `json:"choices"`
-	Usage   json.RawMessage `json:"usage,omitempty"`
+	ID            string          `json:"id"`
+	Model         string          `json:"model"`
+	Choices       []streamChoice  `json:"choices"`
+	Usage         json.RawMessage `json:"usage,omitempty"`
+	Citations     []string        `json:"citations,omitempty"`
+	SearchResults []searchResult  `json:"search_results,omitempty"`
 }
 
 type streamChoice struct {
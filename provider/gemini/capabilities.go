@@ -23,6 +23,7 @@ func (p *Provider) Capabilities(model string) litellm.Capabilities {
 			StrictSchema:        litellm.SupportNo,
 			Choice:              litellm.SupportYes,
 			RoundTripSignatures: litellm.SupportYes,
+			WebSearch:           litellm.SupportPartial,
 		},
 		Structured: litellm.StructuredCapabilities{
 			JSONObject: litellm.SupportYes,
@@ -30,10 +31,16 @@ func (p *Provider) Capabilities(model string) litellm.Capabilities {
 			Strict:     litellm.SupportNo,
 		},
 		Media: litellm.MediaCapabilities{
-			ImageURL:    litellm.SupportYes,
-			ImageBytes:  litellm.SupportYes,
-			FileURI:     litellm.SupportYes,
-			ImageDetail: litellm.SupportNo,
+			ImageURL:          litellm.SupportYes,
+			ImageBytes:        litellm.SupportYes,
+			FileURI:           litellm.SupportYes,
+			ImageDetail:       litellm.SupportNo,
+			DocumentURL:       litellm.SupportPartial,
+			DocumentBytes:     litellm.SupportYes,
+			DocumentFileURI:   litellm.SupportYes,
+			DocumentCitations: litellm.SupportNo,
+			AudioInput:        litellm.SupportYes,
+			AudioOutput:       litellm.SupportPartial,
 		},
 		Cache: litellm.CacheCapabilities{
 			UsageRead: litellm.SupportYes,
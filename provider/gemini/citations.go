@@ -0,0 +1,137 @@
+package gemini
+
+import (
+	"encoding/json"
+
+	"github.com/voocel/litellm"
+)
+
+// partAnnotation is an annotation located within the candidate part at part.
+type partAnnotation struct {
+	part       int
+	annotation litellm.Annotation
+}
+
+// groundingAnnotations returns a URL citation for each grounding chunk that
+// supports a segment of the response.
+func groundingAnnotations(meta *groundingMetadata) ([]partAnnotation, error) {
+	if meta == nil {
+		return nil, nil
+	}
+	var out []partAnnotation
+	for _, support := range meta.GroundingSupports {
+		extra, err := json.Marshal(support)
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range support.GroundingChunkIndices {
+			if index < 0 || index >= len(meta.GroundingChunks) {
+				continue
+			}
+			source := groundingChunkSource(meta.GroundingChunks[index])
+			if source == nil {
+				continue
+			}
+			out = append(out, partAnnotation{part: support.Segment.PartIndex, annotation: litellm.Annotation{
+				Type:  litellm.AnnotationURLCitation,
+				Start: support.Segment.StartIndex,
+				End:   support.Segment.EndIndex,
+				Text:  source.Text,
+				URL:   source.URI,
+				Title: source.Title,
+				Extra: extra,
+			}})
+		}
+	}
+	return out, nil
+}
+
+func groundingChunkSource(chunk groundingChunk) *groundingSource {
+	switch {
+	case chunk.Web != nil:
+		return chunk.Web
+	case chunk.RetrievedContext != nil:
+		return chunk.RetrievedContext
+	default:
+		return chunk.Maps
+	}
+}
+
+// citationAnnotations returns URL citations for the sources a response
+// recites. Their offsets index into the candidate's text as a whole.
+func citationAnnotations(meta *citationMetadata) ([]litellm.Annotation, error) {
+	if meta == nil {
+		return nil, nil
+	}
+	sources := append(append([]citationSource(nil), meta.CitationSources...), meta.Citations...)
+	out := make([]litellm.Annotation, 0, len(sources))
+	for _, source := range sources {
+		extra, err := json.Marshal(source)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, litellm.Annotation{
+			Type:  litellm.AnnotationURLCitation,
+			Start: source.StartIndex,
+			End:   source.EndIndex,
+			URL:   source.URI,
+			Title: source.Title,
+			Extra: extra,
+		})
+	}
+	return out, nil
+}
+
+func convertGrounding(meta *groundingMetadata) *litellm.Grounding {
+	if meta == nil || (len(meta.WebSearchQueries) == 0 && meta.SearchEntryPoint == nil) {
+		return nil
+	}
+	grounding := &litellm.Grounding{SearchQueries: append([]string(nil), meta.WebSearchQueries...)}
+	if meta.SearchEntryPoint != nil {
+		grounding.SearchEntryPoint = meta.SearchEntryPoint.RenderedContent
+	}
+	return grounding
+}
+
+// annotateResponse attaches grounding and recitation citations to the text
+// blocks built from the candidate's parts; textBlocks maps part indexes to
+// block indexes in order.
+func annotateResponse(out *litellm.Response, candidate candidate, textBlocks map[int]int) error {
+	grounded, err := groundingAnnotations(candidate.GroundingMetadata)
+	if err != nil {
+		return err
+	}
+	for _, item := range grounded {
+		if index, ok := textBlocks[item.part]; ok {
+			block := out.Blocks[index].(litellm.TextBlock)
+			block.Annotations = append(block.Annotations, item.annotation)
+			out.Blocks[index] = block
+		}
+	}
+	recited, err := citationAnnotations(candidate.CitationMetadata)
+	if err != nil {
+		return err
+	}
+	for _, annotation := range recited {
+		offset := 0
+		for part := range candidate.Content.Parts {
+			index, ok := textBlocks[part]
+			if !ok {
+				continue
+			}
+			block := out.Blocks[index].(litellm.TextBlock)
+			if annotation.Start < offset+len(block.Text) || annotation.End == 0 {
+				if annotation.End > 0 {
+					annotation.Start -= offset
+					annotation.End = min(annotation.End-offset, len(block.Text))
+				}
+				block.Annotations = append(block.Annotations, annotation)
+				out.Blocks[index] = block
+				break
+			}
+			offset += len(block.Text)
+		}
+	}
+	out.Grounding = convertGrounding(candidate.GroundingMetadata)
+	return nil
+}
@@ -0,0 +1,142 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/voocel/litellm"
+)
+
+type embedContentRequest struct {
+	Model                string  `json:"model,omitempty"`
+	Content              content `json:"content"`
+	TaskType             string  `json:"taskType,omitempty"`
+	Title                string  `json:"title,omitempty"`
+	OutputDimensionality *int    `json:"outputDimensionality,omitempty"`
+}
+
+type batchEmbedContentsRequest struct {
+	Requests []embedContentRequest `json:"requests"`
+}
+
+type contentEmbedding struct {
+	Values []float64 `json:"values"`
+}
+
+type embedContentResponse struct {
+	Embedding  *contentEmbedding  `json:"embedding,omitempty"`
+	Embeddings []contentEmbedding `json:"embeddings,omitempty"`
+}
+
+// ProviderOptionTitle sets the document title for retrieval_document
+// embeddings.
+const ProviderOptionTitle = "title"
+
+// Embed calls embedContent for a single input and batchEmbedContents for
+// several.
+func (p *Provider) Embed(ctx context.Context, req *litellm.EmbedRequest) (*litellm.EmbedResponse, error) {
+	method, wire, err := buildEmbedRequest(req)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	body, err := json.Marshal(wire)
+	if err != nil {
+		return nil, fmt.Errorf("gemini: marshal embed request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url(req.Model, method), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("gemini: create embed request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "embed request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	var parsed embedContentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "gemini: decode embed response", err)
+	}
+	out := &litellm.EmbedResponse{Model: req.Model, Provider: p.Name()}
+	if parsed.Embedding != nil {
+		out.Embeddings = append(out.Embeddings, parsed.Embedding.Values)
+	}
+	for _, item := range parsed.Embeddings {
+		out.Embeddings = append(out.Embeddings, item.Values)
+	}
+	return out, nil
+}
+
+func buildEmbedRequest(req *litellm.EmbedRequest) (string, any, error) {
+	taskType, err := embedTaskType(req.TaskType)
+	if err != nil {
+		return "", nil, err
+	}
+	var title string
+	for key, value := range req.ProviderOptions {
+		switch key {
+		case ProviderOptionTitle:
+			v, ok := value.(string)
+			if !ok {
+				return "", nil, fmt.Errorf("gemini: provider option %q must be string", key)
+			}
+			title = v
+		default:
+			return "", nil, fmt.Errorf("gemini: unsupported embed provider option %q", key)
+		}
+	}
+	if title != "" && taskType != "RETRIEVAL_DOCUMENT" {
+		return "", nil, fmt.Errorf("gemini: provider option %q requires task type %q", ProviderOptionTitle, litellm.EmbedTaskRetrievalDocument)
+	}
+	items := make([]embedContentRequest, 0, len(req.Input))
+	for _, input := range req.Input {
+		items = append(items, embedContentRequest{
+			Content:              content{Parts: []part{{Text: input}}},
+			TaskType:             taskType,
+			Title:                title,
+			OutputDimensionality: req.Dimensions,
+		})
+	}
+	if len(items) == 1 {
+		return "embedContent", items[0], nil
+	}
+	model := req.Model
+	if !strings.HasPrefix(model, "models/") {
+		model = "models/" + model
+	}
+	for i := range items {
+		items[i].Model = model
+	}
+	return "batchEmbedContents", batchEmbedContentsRequest{Requests: items}, nil
+}
+
+func embedTaskType(taskType string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(taskType)) {
+	case "":
+		return "", nil
+	case litellm.EmbedTaskRetrievalQuery:
+		return "RETRIEVAL_QUERY", nil
+	case litellm.EmbedTaskRetrievalDocument:
+		return "RETRIEVAL_DOCUMENT", nil
+	case litellm.EmbedTaskSimilarity:
+		return "SEMANTIC_SIMILARITY", nil
+	case litellm.EmbedTaskClassification:
+		return "CLASSIFICATION", nil
+	case litellm.EmbedTaskClustering:
+		return "CLUSTERING", nil
+	default:
+		return "", fmt.Errorf("gemini: unsupported embed task type %q", taskType)
+	}
+}
@@ -0,0 +1,205 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/voocel/litellm"
+)
+
+// filePollInterval is how often UploadFile checks a processing file.
+var filePollInterval = 2 * time.Second
+
+type fileResource struct {
+	Name           string    `json:"name"`
+	DisplayName    string    `json:"displayName"`
+	MimeType       string    `json:"mimeType"`
+	SizeBytes      string    `json:"sizeBytes"`
+	CreateTime     time.Time `json:"createTime"`
+	ExpirationTime time.Time `json:"expirationTime"`
+	URI            string    `json:"uri"`
+	State          string    `json:"state"`
+	Error          *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type fileList struct {
+	Files []fileResource `json:"files"`
+}
+
+// UploadFile uses the File API's resumable upload protocol, then waits until
+// the file leaves the PROCESSING state. A file that fails processing is
+// returned as an error.
+func (p *Provider) UploadFile(ctx context.Context, req *litellm.FileUpload) (*litellm.File, error) {
+	if req.MIME == "" {
+		return nil, litellm.NewValidationError(p.Name(), "gemini: file upload requires MIME")
+	}
+	metadata, err := json.Marshal(map[string]any{"file": map[string]any{"displayName": req.Name}})
+	if err != nil {
+		return nil, fmt.Errorf("gemini: marshal file metadata: %w", err)
+	}
+	start, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(p.cfg.BaseURL, "/")+"/upload/v1beta/files", bytes.NewReader(metadata))
+	if err != nil {
+		return nil, fmt.Errorf("gemini: create file upload request: %w", err)
+	}
+	start.Header.Set("Content-Type", "application/json")
+	start.Header.Set("X-Goog-Upload-Protocol", "resumable")
+	start.Header.Set("X-Goog-Upload-Command", "start")
+	start.Header.Set("X-Goog-Upload-Header-Content-Length", strconv.Itoa(len(req.Data)))
+	start.Header.Set("X-Goog-Upload-Header-Content-Type", req.MIME)
+	if err := p.setHeaders(ctx, start); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	resp, err := p.cfg.HTTPClient.Do(start)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "file upload request failed", err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	uploadURL := resp.Header.Get("X-Goog-Upload-URL")
+	if uploadURL == "" {
+		return nil, litellm.NewProviderError(p.Name(), litellm.ErrorTypeProvider, "gemini: file upload returned no upload URL")
+	}
+	upload, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(req.Data))
+	if err != nil {
+		return nil, fmt.Errorf("gemini: create file upload request: %w", err)
+	}
+	upload.Header.Set("X-Goog-Upload-Offset", "0")
+	upload.Header.Set("X-Goog-Upload-Command", "upload, finalize")
+	if err := p.setHeaders(ctx, upload); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	var uploaded struct {
+		File fileResource `json:"file"`
+	}
+	if err := p.doFileRequest(upload, &uploaded); err != nil {
+		return nil, err
+	}
+	file := uploaded.File
+	for file.State == "PROCESSING" {
+		timer := time.NewTimer(filePollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, litellm.WrapError(ctx.Err(), p.Name())
+		case <-timer.C:
+		}
+		if err := p.fileCall(ctx, http.MethodGet, file.Name, &file); err != nil {
+			return nil, err
+		}
+	}
+	if file.State == "FAILED" {
+		message := "gemini: file processing failed"
+		if file.Error != nil && file.Error.Message != "" {
+			message += ": " + file.Error.Message
+		}
+		return nil, litellm.NewProviderError(p.Name(), litellm.ErrorTypeProvider, message)
+	}
+	return p.convertFile(file), nil
+}
+
+// GetFile accepts a file name such as "files/abc" or its bare ID.
+func (p *Provider) GetFile(ctx context.Context, id string) (*litellm.File, error) {
+	var file fileResource
+	if err := p.fileCall(ctx, http.MethodGet, fileName(id), &file); err != nil {
+		return nil, err
+	}
+	return p.convertFile(file), nil
+}
+
+func (p *Provider) ListFiles(ctx context.Context, limit int) ([]litellm.File, error) {
+	path := "files"
+	if limit > 0 {
+		path += "?pageSize=" + strconv.Itoa(limit)
+	}
+	var list fileList
+	if err := p.fileCall(ctx, http.MethodGet, path, &list); err != nil {
+		return nil, err
+	}
+	out := make([]litellm.File, 0, len(list.Files))
+	for _, file := range list.Files {
+		out = append(out, *p.convertFile(file))
+	}
+	return out, nil
+}
+
+func (p *Provider) DeleteFile(ctx context.Context, id string) error {
+	var empty struct{}
+	return p.fileCall(ctx, http.MethodDelete, fileName(id), &empty)
+}
+
+func (p *Provider) convertFile(file fileResource) *litellm.File {
+	size, _ := strconv.ParseInt(file.SizeBytes, 10, 64)
+	out := &litellm.File{
+		ID:        file.Name,
+		Provider:  p.Name(),
+		URI:       file.URI,
+		Name:      file.DisplayName,
+		MIME:      file.MimeType,
+		Size:      size,
+		CreatedAt: file.CreateTime,
+		ExpiresAt: file.ExpirationTime,
+	}
+	switch file.State {
+	case "PROCESSING":
+		out.State = litellm.FileStateProcessing
+	case "ACTIVE":
+		out.State = litellm.FileStateActive
+	case "FAILED":
+		out.State = litellm.FileStateFailed
+	}
+	return out
+}
+
+func fileName(id string) string {
+	if strings.HasPrefix(id, "files/") {
+		return id
+	}
+	return "files/" + id
+}
+
+// checkFileURI rejects file references issued by another provider.
+func checkFileURI(uri string) error {
+	if owner := litellm.FileOwner(uri); owner != "" && owner != "gemini" {
+		return fmt.Errorf("gemini: file %q belongs to %s", uri, owner)
+	}
+	return nil
+}
+
+func (p *Provider) fileCall(ctx context.Context, method, path string, out any) error {
+	httpReq, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(p.cfg.BaseURL, "/")+"/v1beta/"+path, nil)
+	if err != nil {
+		return fmt.Errorf("gemini: create file request: %w", err)
+	}
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return litellm.WrapValidationError(p.Name(), err)
+	}
+	return p.doFileRequest(httpReq, out)
+}
+
+func (p *Provider) doFileRequest(httpReq *http.Request, out any) error {
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return litellm.NewNetworkError(p.Name(), "file request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "gemini: decode file response", err)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/voocel/litellm"
+)
+
+const (
+	// ProviderOptionPersonGeneration and ProviderOptionImageSize are Imagen
+	// parameters, e.g. "allow_adult" and "2K".
+	ProviderOptionPersonGeneration = "person_generation"
+	ProviderOptionImageSize        = "image_size"
+)
+
+type imagenRequest struct {
+	Instances  []imagenInstance `json:"instances"`
+	Parameters imagenParameters `json:"parameters"`
+}
+
+type imagenInstance struct {
+	Prompt string `json:"prompt"`
+}
+
+type imagenParameters struct {
+	SampleCount      int    `json:"sampleCount,omitempty"`
+	AspectRatio      string `json:"aspectRatio,omitempty"`
+	PersonGeneration string `json:"personGeneration,omitempty"`
+	SampleImageSize  string `json:"sampleImageSize,omitempty"`
+	IncludeRAIReason bool   `json:"includeRaiReason,omitempty"`
+}
+
+type imagenResponse struct {
+	Predictions []struct {
+		BytesBase64Encoded string `json:"bytesBase64Encoded"`
+		MimeType           string `json:"mimeType"`
+		RAIFilteredReason  string `json:"raiFilteredReason"`
+	} `json:"predictions"`
+}
+
+// GenerateImage calls :predict for Imagen models. Other models are Gemini
+// image models: each image is one generateContent call with the prompt and
+// any source images, returning the image parts of the reply.
+func (p *Provider) GenerateImage(ctx context.Context, req *litellm.ImageRequest) (*litellm.ImageResponse, error) {
+	ratio, err := imageAspectRatio(req)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	if req.Mask != nil {
+		return nil, litellm.NewValidationError(p.Name(), "gemini: image masks are not supported")
+	}
+	if strings.Contains(req.Model, "imagen") {
+		return p.generateImagen(ctx, req, ratio)
+	}
+	chatReq, err := imageChatRequest(req, ratio)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	out := &litellm.ImageResponse{Model: req.Model, Provider: p.Name()}
+	for range max(req.N, 1) {
+		resp, err := p.Chat(ctx, chatReq)
+		if err != nil {
+			return nil, err
+		}
+		for _, block := range resp.Blocks {
+			if image, ok := block.(litellm.ImageBlock); ok {
+				out.Images = append(out.Images, image)
+			}
+		}
+		out.Usage.InputTokens += resp.Usage.InputTokens
+		out.Usage.OutputTokens += resp.Usage.OutputTokens
+		out.Usage.TotalTokens += resp.Usage.TotalTokens
+	}
+	return out, nil
+}
+
+func imageChatRequest(req *litellm.ImageRequest, ratio string) (*litellm.Request, error) {
+	blocks := []litellm.Block{litellm.Text(req.Prompt)}
+	for _, image := range req.Images {
+		blocks = append(blocks, image)
+	}
+	options := litellm.ProviderOptions{ProviderOptionResponseModalities: []string{"TEXT", "IMAGE"}}
+	for key, value := range req.ProviderOptions {
+		options[key] = value
+	}
+	if ratio != "" {
+		config := map[string]any{}
+		if existing, ok := options[ProviderOptionImageConfig].(map[string]any); ok {
+			for k, v := range existing {
+				config[k] = v
+			}
+		} else if options[ProviderOptionImageConfig] != nil {
+			return nil, fmt.Errorf("gemini: provider option %q must be object", ProviderOptionImageConfig)
+		}
+		config["aspectRatio"] = ratio
+		options[ProviderOptionImageConfig] = config
+	}
+	return &litellm.Request{
+		Model:           req.Model,
+		Messages:        []litellm.Message{litellm.User(blocks...)},
+		ProviderOptions: options,
+	}, nil
+}
+
+func (p *Provider) generateImagen(ctx context.Context, req *litellm.ImageRequest, ratio string) (*litellm.ImageResponse, error) {
+	if len(req.Images) > 0 {
+		return nil, litellm.NewValidationError(p.Name(), "gemini: imagen models do not support image edits; use a Gemini image model")
+	}
+	wire := imagenRequest{
+		Instances:  []imagenInstance{{Prompt: req.Prompt}},
+		Parameters: imagenParameters{SampleCount: req.N, AspectRatio: ratio, IncludeRAIReason: true},
+	}
+	for key, value := range req.ProviderOptions {
+		v, ok := value.(string)
+		if !ok {
+			return nil, litellm.NewValidationError(p.Name(), fmt.Sprintf("gemini: provider option %q must be string", key))
+		}
+		switch key {
+		case ProviderOptionPersonGeneration:
+			wire.Parameters.PersonGeneration = v
+		case ProviderOptionImageSize:
+			wire.Parameters.SampleImageSize = v
+		default:
+			return nil, litellm.NewValidationError(p.Name(), fmt.Sprintf("gemini: unsupported image provider option %q", key))
+		}
+	}
+	body, err := json.Marshal(wire)
+	if err != nil {
+		return nil, fmt.Errorf("gemini: marshal image request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url(req.Model, "predict"), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("gemini: create image request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "image request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	var parsed imagenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "gemini: decode image response", err)
+	}
+	out := &litellm.ImageResponse{Model: req.Model, Provider: p.Name()}
+	var filtered []string
+	for _, prediction := range parsed.Predictions {
+		if prediction.BytesBase64Encoded == "" {
+			if prediction.RAIFilteredReason != "" {
+				filtered = append(filtered, prediction.RAIFilteredReason)
+			}
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(prediction.BytesBase64Encoded)
+		if err != nil {
+			return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "gemini: decode image data", err)
+		}
+		out.Images = append(out.Images, litellm.ImageBlock{Data: data, MIME: prediction.MimeType})
+	}
+	if len(out.Images) == 0 && len(filtered) > 0 {
+		return nil, litellm.NewProviderError(p.Name(), litellm.ErrorTypeContentFilter, "gemini: images filtered: "+strings.Join(filtered, "; "))
+	}
+	return out, nil
+}
+
+// imageAspectRatio returns req.AspectRatio, or reduces req.Size to a ratio
+// such as "16:9" since Gemini sizes images by ratio.
+func imageAspectRatio(req *litellm.ImageRequest) (string, error) {
+	if req.AspectRatio != "" || req.Size == "" {
+		return req.AspectRatio, nil
+	}
+	width, height, err := litellm.ParseImageSize(req.Size)
+	if err != nil {
+		return "", err
+	}
+	a, b := width, height
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return strconv.Itoa(width/a) + ":" + strconv.Itoa(height/a), nil
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/voocel/litellm"
 	"github.com/voocel/litellm/internal/testgolden"
@@ -226,6 +227,34 @@ func TestBuildRequestRejectsToolResultWithoutPrecedingToolUse(t *testing.T) {
 	}
 }
 
+func TestBuildRequestMapsWebSearch(t *testing.T) {
+	provider := mustProvider(t)
+	wire, err := provider.buildRequest(&litellm.Request{
+		Model:     "gemini-3-pro",
+		Messages:  []litellm.Message{litellm.UserText("news?")},
+		WebSearch: &litellm.WebSearch{},
+	})
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	data, err := json.Marshal(wire.Tools)
+	if err != nil {
+		t.Fatalf("marshal tools: %v", err)
+	}
+	if string(data) != `[{"googleSearch":{}}]` {
+		t.Fatalf("tools = %s", data)
+	}
+
+	_, err = provider.buildRequest(&litellm.Request{
+		Model:     "gemini-3-pro",
+		Messages:  []litellm.Message{litellm.UserText("news?")},
+		WebSearch: &litellm.WebSearch{BlockedDomains: []string{"example.com"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "domain filters are not supported") {
+		t.Fatalf("expected domain filter error, got %v", err)
+	}
+}
+
 func TestBuildRequestRejectsStrictTool(t *testing.T) {
 	provider := mustProvider(t)
 	tool := mustTool(t, "lookup", "Lookup.", map[string]any{"type": "object"})
@@ -279,6 +308,36 @@ func TestBuildRequestRejectsUnknownProviderOption(t *testing.T) {
 	}
 }
 
+func TestBuildRequestEncodesDocumentBlocks(t *testing.T) {
+	provider := mustProvider(t)
+	wire, err := provider.buildRequest(&litellm.Request{
+		Model: "gemini-2.5-pro",
+		Messages: []litellm.Message{litellm.User(
+			litellm.DocumentData([]byte("%PDF"), "application/pdf"),
+			litellm.DocumentBlock{FileURI: "gs://bucket/report.pdf", MIME: "application/pdf"},
+			litellm.Text("compare"),
+		)},
+	})
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	parts := wire.Contents[0].Parts
+	if len(parts) != 3 || parts[0].InlineData == nil || parts[0].InlineData.Data != "JVBERg==" || parts[0].InlineData.MimeType != "application/pdf" {
+		t.Fatalf("inline document part = %+v", parts)
+	}
+	if parts[1].FileData == nil || parts[1].FileData.FileURI != "gs://bucket/report.pdf" {
+		t.Fatalf("file document part = %+v", parts[1])
+	}
+
+	_, err = provider.buildRequest(&litellm.Request{
+		Model:    "gemini-2.5-pro",
+		Messages: []litellm.Message{litellm.User(litellm.DocumentBlock{URL: "https://example.test/a.pdf", Citations: true})},
+	})
+	if err == nil || !strings.Contains(err.Error(), "citations") {
+		t.Fatalf("expected citations error, got %v", err)
+	}
+}
+
 func TestChatConvertsThinkingToolAndUsage(t *testing.T) {
 	provider, err := New(Config{
 		APIKey:  "test-key",
@@ -334,6 +393,89 @@ func TestChatConvertsThinkingToolAndUsage(t *testing.T) {
 	}
 }
 
+func TestBuildRequestEncodesAudioInputAndModalities(t *testing.T) {
+	provider := mustProvider(t)
+	wire, err := provider.buildRequest(&litellm.Request{
+		Model:    "gemini-2.5-flash-preview-tts",
+		Messages: []litellm.Message{litellm.User(litellm.Audio([]byte("RIFF"), "wav"), litellm.Text("repeat"))},
+		ProviderOptions: litellm.ProviderOptions{
+			ProviderOptionResponseModalities: []any{"AUDIO"},
+			ProviderOptionSpeechConfig: map[string]any{
+				"voiceConfig": map[string]any{"prebuiltVoiceConfig": map[string]any{"voiceName": "Kore"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	audio := wire.Contents[0].Parts[0].InlineData
+	if audio == nil || audio.MimeType != "audio/wav" || audio.Data != "UklGRg==" {
+		t.Fatalf("audio part = %+v", wire.Contents[0].Parts[0])
+	}
+	if wire.GenerationConfig == nil || len(wire.GenerationConfig.ResponseModalities) != 1 || wire.GenerationConfig.ResponseModalities[0] != "AUDIO" || wire.GenerationConfig.SpeechConfig == nil {
+		t.Fatalf("generation config = %+v", wire.GenerationConfig)
+	}
+}
+
+func TestConvertResponseDecodesAudioParts(t *testing.T) {
+	resp, err := convertResponse(&response{Candidates: []candidate{{
+		Content:      content{Parts: []part{{InlineData: &inlineData{MimeType: "audio/L16;codec=pcm;rate=24000", Data: "UklGRg=="}}}},
+		FinishReason: "STOP",
+	}}}, &litellm.Request{Model: "gemini-2.5-flash-preview-tts"})
+	if err != nil {
+		t.Fatalf("convertResponse: %v", err)
+	}
+	audio, ok := resp.Blocks[0].(litellm.AudioOutputBlock)
+	if len(resp.Blocks) != 1 || !ok || string(audio.Data) != "RIFF" || audio.Format != "L16;codec=pcm;rate=24000" {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+}
+
+func TestConvertResponseDecodesImageParts(t *testing.T) {
+	resp, err := convertResponse(&response{Candidates: []candidate{{
+		Content: content{Parts: []part{
+			{Text: "Here you go"},
+			{InlineData: &inlineData{MimeType: "image/png", Data: "iVBORw=="}},
+		}},
+		FinishReason: "STOP",
+	}}}, &litellm.Request{Model: "gemini-2.5-flash-image"})
+	if err != nil {
+		t.Fatalf("convertResponse: %v", err)
+	}
+	image, ok := resp.Blocks[1].(litellm.ImageBlock)
+	if len(resp.Blocks) != 2 || !ok || image.MIME != "image/png" || string(image.Data) != "\x89PNG" {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+}
+
+func TestConvertResponseMapsGroundingMetadata(t *testing.T) {
+	var wire response
+	if err := json.Unmarshal([]byte(`{"candidates":[{"content":{"parts":[{"text":"Spain won Euro 2024."}]},"finishReason":"STOP",
+		"groundingMetadata":{
+			"webSearchQueries":["euro 2024 winner"],
+			"searchEntryPoint":{"renderedContent":"<div>chips</div>"},
+			"groundingChunks":[{"web":{"uri":"https://example.com/euro","title":"example.com"}}],
+			"groundingSupports":[{"segment":{"startIndex":0,"endIndex":19,"text":"Spain won Euro 2024"},"groundingChunkIndices":[0],"confidenceScores":[0.9]}]
+		}}]}`), &wire); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	resp, err := convertResponse(&wire, &litellm.Request{Model: "gemini-2.5-flash"})
+	if err != nil {
+		t.Fatalf("convertResponse: %v", err)
+	}
+	block, ok := resp.Blocks[0].(litellm.TextBlock)
+	if len(resp.Blocks) != 1 || !ok || len(block.Annotations) != 1 {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	annotation := block.Annotations[0]
+	if annotation.Type != litellm.AnnotationURLCitation || annotation.URL != "https://example.com/euro" || block.Text[annotation.Start:annotation.End] != "Spain won Euro 2024" {
+		t.Fatalf("annotation = %+v", annotation)
+	}
+	if resp.Grounding == nil || len(resp.Grounding.SearchQueries) != 1 || resp.Grounding.SearchEntryPoint != "<div>chips</div>" {
+		t.Fatalf("grounding = %+v", resp.Grounding)
+	}
+}
+
 func TestConvertResponseRejectsNil(t *testing.T) {
 	_, err := convertResponse(nil, &litellm.Request{Model: "gemini-3-pro"})
 	if err == nil || !strings.Contains(err.Error(), "response cannot be nil") {
@@ -593,6 +735,43 @@ func TestStreamParsesJSONArrayChunks(t *testing.T) {
 	}
 }
 
+func TestStreamCollectsGroundingMetadata(t *testing.T) {
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return streamResponse(strings.Join([]string{
+				`data: {"candidates":[{"content":{"parts":[{"text":"Spain won "}]}}]}`,
+				`data: {"candidates":[{"content":{"parts":[{"text":"Euro 2024."}]},"finishReason":"STOP","groundingMetadata":{"webSearchQueries":["euro 2024 winner"],"groundingChunks":[{"web":{"uri":"https://example.com/euro","title":"example.com"}}],"groundingSupports":[{"segment":{"startIndex":0,"endIndex":19},"groundingChunkIndices":[0]}]}}]}`,
+			}, "\n\n")), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	stream, err := provider.Stream(context.Background(), &litellm.Request{
+		Model:    "gemini-2.5-flash",
+		Messages: []litellm.Message{litellm.UserText("who won euro 2024?")},
+	})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	resp, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	block, ok := resp.Blocks[0].(litellm.TextBlock)
+	if len(resp.Blocks) != 1 || !ok || len(block.Annotations) != 1 {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	if annotation := block.Annotations[0]; block.Text[annotation.Start:annotation.End] != "Spain won Euro 2024" || annotation.URL != "https://example.com/euro" {
+		t.Fatalf("annotation = %+v", annotation)
+	}
+	if resp.Grounding == nil || resp.Grounding.SearchQueries[0] != "euro 2024 winner" {
+		t.Fatalf("grounding = %+v", resp.Grounding)
+	}
+}
+
 func TestStreamPromptFeedbackReturnsProviderError(t *testing.T) {
 	provider, err := New(Config{
 		APIKey:  "test-key",
@@ -620,6 +799,145 @@ func TestStreamPromptFeedbackReturnsProviderError(t *testing.T) {
 	}
 }
 
+func TestEmbedBatchesMultipleInputs(t *testing.T) {
+	var capturedPath string
+	var captured batchEmbedContentsRequest
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			capturedPath = req.URL.Path
+			if err := json.NewDecoder(req.Body).Decode(&captured); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			return jsonResponse(http.StatusOK, `{"embeddings":[{"values":[0.1,0.2]},{"values":[0.3,0.4]}]}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.Embed(context.Background(), &litellm.EmbedRequest{
+		Model:      "gemini-embedding-001",
+		Input:      []string{"a", "b"},
+		Dimensions: litellm.IntPtr(2),
+		TaskType:   litellm.EmbedTaskRetrievalQuery,
+	})
+	if err != nil {
+		t.Fatalf("Embed returned error: %v", err)
+	}
+	if capturedPath != "/v1beta/models/gemini-embedding-001:batchEmbedContents" {
+		t.Fatalf("path = %q", capturedPath)
+	}
+	if len(captured.Requests) != 2 || captured.Requests[0].Model != "models/gemini-embedding-001" || captured.Requests[1].TaskType != "RETRIEVAL_QUERY" || *captured.Requests[0].OutputDimensionality != 2 {
+		t.Fatalf("request = %+v", captured)
+	}
+	if len(resp.Embeddings) != 2 || resp.Embeddings[1][0] != 0.3 {
+		t.Fatalf("embeddings = %#v", resp.Embeddings)
+	}
+}
+
+func TestEmbedSingleInputUsesEmbedContent(t *testing.T) {
+	var capturedPath string
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			capturedPath = req.URL.Path
+			return jsonResponse(http.StatusOK, `{"embedding":{"values":[0.5]}}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.Embed(context.Background(), &litellm.EmbedRequest{Model: "gemini-embedding-001", Input: []string{"a"}})
+	if err != nil {
+		t.Fatalf("Embed returned error: %v", err)
+	}
+	if capturedPath != "/v1beta/models/gemini-embedding-001:embedContent" {
+		t.Fatalf("path = %q", capturedPath)
+	}
+	if len(resp.Embeddings) != 1 || resp.Embeddings[0][0] != 0.5 {
+		t.Fatalf("embeddings = %#v", resp.Embeddings)
+	}
+}
+
+func TestGenerateImageImagenUsesPredict(t *testing.T) {
+	var capturedPath string
+	var captured imagenRequest
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			capturedPath = req.URL.Path
+			if err := json.NewDecoder(req.Body).Decode(&captured); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			return jsonResponse(http.StatusOK, `{"predictions":[{"bytesBase64Encoded":"iVBORw==","mimeType":"image/png"},{"raiFilteredReason":"blocked"}]}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.GenerateImage(context.Background(), &litellm.ImageRequest{
+		Model:           "imagen-4.0-generate-001",
+		Prompt:          "a lighthouse",
+		Size:            "1920x1080",
+		N:               2,
+		ProviderOptions: litellm.ProviderOptions{ProviderOptionPersonGeneration: "dont_allow"},
+	})
+	if err != nil {
+		t.Fatalf("GenerateImage returned error: %v", err)
+	}
+	if capturedPath != "/v1beta/models/imagen-4.0-generate-001:predict" {
+		t.Fatalf("path = %q", capturedPath)
+	}
+	if captured.Instances[0].Prompt != "a lighthouse" || captured.Parameters.SampleCount != 2 || captured.Parameters.AspectRatio != "16:9" || captured.Parameters.PersonGeneration != "dont_allow" {
+		t.Fatalf("request = %+v", captured)
+	}
+	if len(resp.Images) != 1 || resp.Images[0].MIME != "image/png" {
+		t.Fatalf("images = %#v", resp.Images)
+	}
+}
+
+func TestGenerateImageGeminiModelRequestsImageModality(t *testing.T) {
+	var captured request
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasSuffix(req.URL.Path, ":generateContent") {
+				t.Fatalf("path = %q", req.URL.Path)
+			}
+			if err := json.NewDecoder(req.Body).Decode(&captured); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			return jsonResponse(http.StatusOK, `{"candidates":[{"content":{"parts":[{"inlineData":{"mimeType":"image/png","data":"iVBORw=="}}]},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":5,"candidatesTokenCount":1290,"totalTokenCount":1295}}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.GenerateImage(context.Background(), &litellm.ImageRequest{
+		Model:       "gemini-2.5-flash-image",
+		Prompt:      "make it night",
+		AspectRatio: "4:3",
+		Images:      []litellm.ImageBlock{{Data: []byte("src"), MIME: "image/jpeg"}},
+	})
+	if err != nil {
+		t.Fatalf("GenerateImage returned error: %v", err)
+	}
+	config := captured.GenerationConfig
+	if config == nil || len(config.ResponseModalities) != 2 || config.ImageConfig.(map[string]any)["aspectRatio"] != "4:3" {
+		t.Fatalf("generation config = %#v", config)
+	}
+	if parts := captured.Contents[0].Parts; len(parts) != 2 || parts[1].InlineData == nil || parts[1].InlineData.MimeType != "image/jpeg" {
+		t.Fatalf("parts = %#v", captured.Contents[0].Parts)
+	}
+	if len(resp.Images) != 1 || resp.Usage.OutputTokens != 1290 {
+		t.Fatalf("resp = %+v", resp)
+	}
+}
+
 func jsonResponse(status int, body string) *http.Response {
 	return &http.Response{
 		StatusCode: status,
@@ -651,3 +969,110 @@ func mustTool(t *testing.T, name, description string, schema any) litellm.Tool {
 	}
 	return tool
 }
+
+func TestCountTokensReportsImageTokens(t *testing.T) {
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/v1beta/models/gemini-2.5-flash:countTokens" {
+				t.Fatalf("path = %s", req.URL.Path)
+			}
+			body, _ := io.ReadAll(req.Body)
+			var wire struct {
+				GenerateContentRequest struct {
+					Model    string            `json:"model"`
+					Contents []json.RawMessage `json:"contents"`
+				} `json:"generateContentRequest"`
+			}
+			if err := json.Unmarshal(body, &wire); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if wire.GenerateContentRequest.Model != "models/gemini-2.5-flash" || len(wire.GenerateContentRequest.Contents) != 1 {
+				t.Fatalf("body = %s", body)
+			}
+			return jsonResponse(http.StatusOK, `{"totalTokens":300,"promptTokensDetails":[{"modality":"TEXT","tokenCount":42},{"modality":"IMAGE","tokenCount":258}]}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	count, err := provider.CountTokens(context.Background(), &litellm.Request{
+		Model:    "gemini-2.5-flash",
+		Messages: []litellm.Message{litellm.UserText("describe")},
+	})
+	if err != nil {
+		t.Fatalf("CountTokens returned error: %v", err)
+	}
+	if count.InputTokens != 300 || count.ImageTokens != 258 {
+		t.Fatalf("count = %+v", count)
+	}
+}
+
+func TestUploadFileWaitsUntilActive(t *testing.T) {
+	interval := filePollInterval
+	filePollInterval = time.Millisecond
+	t.Cleanup(func() { filePollInterval = interval })
+	polls := 0
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("x-goog-api-key") != "test-key" {
+				t.Fatalf("missing api key on %s", req.URL.Path)
+			}
+			switch req.Method + " " + req.URL.Path {
+			case "POST /upload/v1beta/files":
+				if req.Header.Get("X-Goog-Upload-Command") != "start" || req.Header.Get("X-Goog-Upload-Header-Content-Type") != "video/mp4" || req.Header.Get("X-Goog-Upload-Header-Content-Length") != "5" {
+					t.Fatalf("start headers = %v", req.Header)
+				}
+				resp := jsonResponse(http.StatusOK, `{}`)
+				resp.Header.Set("X-Goog-Upload-URL", "https://example.test/upload/session-1")
+				return resp, nil
+			case "POST /upload/session-1":
+				data, _ := io.ReadAll(req.Body)
+				if req.Header.Get("X-Goog-Upload-Command") != "upload, finalize" || string(data) != "video" {
+					t.Fatalf("upload = %v %q", req.Header, data)
+				}
+				return jsonResponse(http.StatusOK, `{"file":{"name":"files/abc","mimeType":"video/mp4","sizeBytes":"5","uri":"https://generativelanguage.googleapis.com/v1beta/files/abc","state":"PROCESSING"}}`), nil
+			case "GET /v1beta/files/abc":
+				polls++
+				state := "PROCESSING"
+				if polls == 2 {
+					state = "ACTIVE"
+				}
+				return jsonResponse(http.StatusOK, `{"name":"files/abc","displayName":"clip","mimeType":"video/mp4","sizeBytes":"5","uri":"https://generativelanguage.googleapis.com/v1beta/files/abc","state":"`+state+`"}`), nil
+			}
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+			return nil, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	file, err := provider.UploadFile(context.Background(), &litellm.FileUpload{Name: "clip", MIME: "video/mp4", Data: []byte("video")})
+	if err != nil {
+		t.Fatalf("UploadFile returned error: %v", err)
+	}
+	if polls != 2 || file.ID != "files/abc" || file.State != litellm.FileStateActive || file.Size != 5 || file.Name != "clip" {
+		t.Fatalf("polls = %d, file = %+v", polls, file)
+	}
+	wire, err := provider.buildRequest(&litellm.Request{
+		Model:    "gemini-3-pro",
+		Messages: []litellm.Message{litellm.User(file.Document(), litellm.Text("summarize"))},
+	})
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	if fd := wire.Contents[0].Parts[0].FileData; fd == nil || fd.FileURI != file.URI || fd.MimeType != "video/mp4" {
+		t.Fatalf("file data = %+v", fd)
+	}
+
+	_, err = provider.buildRequest(&litellm.Request{
+		Model:    "gemini-3-pro",
+		Messages: []litellm.Message{litellm.User(litellm.ImageBlock{FileURI: "file-abc", MIME: "image/png"})},
+	})
+	if err == nil || !strings.Contains(err.Error(), "belongs to openai") {
+		t.Fatalf("expected foreign file error, got %v", err)
+	}
+}
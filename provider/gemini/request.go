@@ -16,6 +16,13 @@ const (
 	ProviderOptionSafetySettings = "safety_settings"
 	ProviderOptionTopK           = "top_k"
 	ProviderOptionCandidateCount = "candidate_count"
+
+	// ProviderOptionResponseModalities requests output modalities such as
+	// []string{"AUDIO"}; ProviderOptionSpeechConfig is sent as speechConfig
+	// and ProviderOptionImageConfig as imageConfig.
+	ProviderOptionResponseModalities = "response_modalities"
+	ProviderOptionSpeechConfig       = "speech_config"
+	ProviderOptionImageConfig        = "image_config"
 )
 
 func (p *Provider) buildRequest(req *litellm.Request) (*request, error) {
@@ -44,9 +51,30 @@ func (p *Provider) buildRequest(req *litellm.Request) (*request, error) {
 		out.Tools = converted
 		out.ToolConfig = convertToolChoice(req.ToolChoice)
 	}
+	if req.WebSearch != nil {
+		search, err := convertWebSearch(req.WebSearch)
+		if err != nil {
+			return nil, err
+		}
+		out.Tools = append(out.Tools, search)
+	}
 	return out, nil
 }
 
+// convertWebSearch enables Google Search grounding. The tool takes no
+// domain, use-limit or location settings, so those are rejected.
+func convertWebSearch(search *litellm.WebSearch) (tool, error) {
+	switch {
+	case search.MaxUses > 0:
+		return tool{}, fmt.Errorf("gemini: web search max uses is not supported")
+	case len(search.AllowedDomains) > 0 || len(search.BlockedDomains) > 0:
+		return tool{}, fmt.Errorf("gemini: web search domain filters are not supported")
+	case !search.UserLocation.IsZero():
+		return tool{}, fmt.Errorf("gemini: web search user location is not supported")
+	}
+	return tool{GoogleSearch: &googleSearch{}}, nil
+}
+
 func applyProviderOptions(out *request, options litellm.ProviderOptions) error {
 	for key, value := range options {
 		switch key {
@@ -74,6 +102,31 @@ func applyProviderOptions(out *request, options litellm.ProviderOptions) error {
 				out.GenerationConfig = &generationConfig{}
 			}
 			out.GenerationConfig.CandidateCount = &count
+		case ProviderOptionResponseModalities:
+			modalities, err := stringSliceOption(key, value)
+			if err != nil {
+				return err
+			}
+			if out.GenerationConfig == nil {
+				out.GenerationConfig = &generationConfig{}
+			}
+			out.GenerationConfig.ResponseModalities = modalities
+		case ProviderOptionSpeechConfig:
+			if _, ok := value.(map[string]any); !ok {
+				return fmt.Errorf("gemini: provider option %q must be object", key)
+			}
+			if out.GenerationConfig == nil {
+				out.GenerationConfig = &generationConfig{}
+			}
+			out.GenerationConfig.SpeechConfig = value
+		case ProviderOptionImageConfig:
+			if _, ok := value.(map[string]any); !ok {
+				return fmt.Errorf("gemini: provider option %q must be object", key)
+			}
+			if out.GenerationConfig == nil {
+				out.GenerationConfig = &generationConfig{}
+			}
+			out.GenerationConfig.ImageConfig = value
 		default:
 			return fmt.Errorf("gemini: unsupported provider option %q", key)
 		}
@@ -122,6 +175,25 @@ func intOption(provider, key string, value any) (int, error) {
 	}
 }
 
+func stringSliceOption(key string, value any) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return append([]string(nil), v...), nil
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			text, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("gemini: provider option %q must be string array", key)
+			}
+			out = append(out, text)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("gemini: provider option %q must be string array", key)
+	}
+}
+
 func convertMessages(model string, messages []litellm.Message) ([]content, []part, error) {
 	out := make([]content, 0, len(messages))
 	system := make([]part, 0)
@@ -179,6 +251,17 @@ func convertContentBlocks(blocks []litellm.Block) ([]part, error) {
 				return nil, err
 			}
 			out = append(out, converted)
+		case litellm.AudioBlock:
+			out = append(out, part{InlineData: &inlineData{
+				MimeType: "audio/" + b.Format,
+				Data:     base64.StdEncoding.EncodeToString(b.Data),
+			}})
+		case litellm.DocumentBlock:
+			converted, err := convertDocument(b)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, converted)
 		default:
 			return nil, fmt.Errorf("unsupported block %T", block)
 		}
@@ -220,6 +303,18 @@ func convertAssistantBlocks(model string, blocks []litellm.Block, callNames map[
 			}
 			callIndex++
 			out = append(out, converted)
+		case litellm.AudioOutputBlock:
+			switch {
+			case len(b.Data) > 0 && b.Format != "":
+				out = append(out, part{InlineData: &inlineData{
+					MimeType: "audio/" + b.Format,
+					Data:     base64.StdEncoding.EncodeToString(b.Data),
+				}})
+			case b.Transcript != "":
+				out = append(out, part{Text: b.Transcript})
+			default:
+				return nil, fmt.Errorf("assistant audio requires data with format or a transcript")
+			}
 		default:
 			return nil, fmt.Errorf("unsupported assistant block %T", block)
 		}
@@ -292,12 +387,42 @@ func convertImage(block litellm.ImageBlock) (part, error) {
 		}
 		return part{FileData: &fileData{MimeType: inferMimeType(block.URL), FileURI: block.URL}}, nil
 	case block.FileURI != "":
+		if err := checkFileURI(block.FileURI); err != nil {
+			return part{}, err
+		}
 		return part{FileData: &fileData{MimeType: block.MIME, FileURI: block.FileURI}}, nil
 	default:
 		return part{}, fmt.Errorf("image requires URL, data, or file URI")
 	}
 }
 
+func convertDocument(block litellm.DocumentBlock) (part, error) {
+	if block.Citations {
+		return part{}, fmt.Errorf("document citations are not supported")
+	}
+	switch {
+	case len(block.Data) > 0:
+		return part{InlineData: &inlineData{
+			MimeType: block.MIME,
+			Data:     base64.StdEncoding.EncodeToString(block.Data),
+		}}, nil
+	case block.URL != "":
+		if mime, data, ok := parseDataURL(block.URL); ok {
+			return part{InlineData: &inlineData{MimeType: mime, Data: data}}, nil
+		}
+		mime := block.MIME
+		if mime == "" {
+			mime = inferMimeType(block.URL)
+		}
+		return part{FileData: &fileData{MimeType: mime, FileURI: block.URL}}, nil
+	default:
+		if err := checkFileURI(block.FileURI); err != nil {
+			return part{}, err
+		}
+		return part{FileData: &fileData{MimeType: block.MIME, FileURI: block.FileURI}}, nil
+	}
+}
+
 func convertGenerationConfig(req *litellm.Request) (*generationConfig, error) {
 	if req.MaxTokens == nil && req.Temperature == nil && req.TopP == nil && len(req.Stop) == 0 && req.ResponseFormat == nil && req.Thinking == nil {
 		return nil, nil
@@ -360,6 +485,9 @@ func convertGenerationConfig(req *litellm.Request) (*generationConfig, error) {
 func convertTools(tools []litellm.Tool) ([]tool, error) {
 	out := tool{FunctionDeclarations: make([]functionDeclaration, 0, len(tools))}
 	for _, t := range tools {
+		if t.Server != nil {
+			return nil, fmt.Errorf("gemini: server tool %q is not supported", t.Name)
+		}
 		if t.Strict == litellm.StrictEnabled {
 			return nil, fmt.Errorf("gemini: strict tool calling is not supported")
 		}
@@ -479,6 +607,8 @@ func inferMimeType(url string) string {
 		return "image/heif"
 	case strings.HasSuffix(url, ".bmp"):
 		return "image/bmp"
+	case strings.HasSuffix(url, ".pdf"):
+		return "application/pdf"
 	default:
 		return ""
 	}
@@ -1,6 +1,7 @@
 package gemini
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -43,16 +44,32 @@ func convertResponse(resp *response, req *litellm.Request) (*litellm.Response, e
 		return nil, candidateFinishError(candidate)
 	}
 	out.FinishReason = litellm.NormalizeFinishReason(candidate.FinishReason)
-	for _, part := range candidate.Content.Parts {
+	textBlocks := make(map[int]int)
+	for i, part := range candidate.Content.Parts {
 		if part.Text != "" {
 			if part.Thought != nil && *part.Thought {
 				if thinkingEnabled(req) {
 					out.Blocks = append(out.Blocks, litellm.ReasoningBlock{Text: part.Text, Signature: part.ThoughtSignature})
 				}
 			} else {
+				textBlocks[i] = len(out.Blocks)
 				out.Blocks = append(out.Blocks, litellm.TextBlock{Text: part.Text})
 			}
 		}
+		if format, ok := audioFormat(part.InlineData); ok {
+			data, err := base64.StdEncoding.DecodeString(part.InlineData.Data)
+			if err != nil {
+				return nil, fmt.Errorf("gemini: decode audio data: %w", err)
+			}
+			out.Blocks = append(out.Blocks, litellm.AudioOutputBlock{Data: data, Format: format})
+		}
+		if isImage(part.InlineData) {
+			data, err := base64.StdEncoding.DecodeString(part.InlineData.Data)
+			if err != nil {
+				return nil, fmt.Errorf("gemini: decode image data: %w", err)
+			}
+			out.Blocks = append(out.Blocks, litellm.ImageBlock{Data: data, MIME: part.InlineData.MimeType})
+		}
 		if part.FunctionCall != nil {
 			args, err := json.Marshal(part.FunctionCall.Args)
 			if err != nil {
@@ -71,6 +88,9 @@ func convertResponse(resp *response, req *litellm.Request) (*litellm.Response, e
 			})
 		}
 	}
+	if err := annotateResponse(out, candidate, textBlocks); err != nil {
+		return nil, fmt.Errorf("gemini: convert citations: %w", err)
+	}
 	return out, nil
 }
 
@@ -83,6 +103,19 @@ func generatedToolCallIDWarning(name, id string) litellm.Warning {
 	}
 }
 
+// audioFormat reports the subtype of an inline audio part, e.g.
+// "L16;codec=pcm;rate=24000" for TTS output.
+func audioFormat(data *inlineData) (string, bool) {
+	if data == nil {
+		return "", false
+	}
+	return strings.CutPrefix(data.MimeType, "audio/")
+}
+
+func isImage(data *inlineData) bool {
+	return data != nil && strings.HasPrefix(data.MimeType, "image/")
+}
+
 func thinkingEnabled(req *litellm.Request) bool {
 	return req == nil || req.Thinking == nil || req.Thinking.Mode != litellm.ThinkingDisabled
 }
@@ -2,6 +2,7 @@ package gemini
 
 import (
 	"bufio"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -25,6 +26,9 @@ type stream struct {
 	emittedOutput    bool
 	nextToolIndex    int
 	toolIndexByID    map[string]int
+	// annotated records emitted citations; Gemini may repeat its metadata on
+	// several chunks.
+	annotated map[string]bool
 }
 
 type promptFeedback struct {
@@ -48,6 +52,7 @@ func newStream(resp *http.Response, req *litellm.Request) *stream {
 		includeReasoning: thinkingEnabled(req),
 		model:            req.Model,
 		toolIndexByID:    make(map[string]int),
+		annotated:        make(map[string]bool),
 	}
 }
 
@@ -199,6 +204,22 @@ func (s *stream) events(resp response) ([]litellm.Event, error) {
 				s.emittedOutput = true
 			}
 		}
+		if format, ok := audioFormat(part.InlineData); ok {
+			data, err := base64.StdEncoding.DecodeString(part.InlineData.Data)
+			if err != nil {
+				return nil, litellm.NewProviderErrorWithCause("gemini", litellm.ErrorTypeProvider, "gemini: decode stream audio", err)
+			}
+			events = append(events, litellm.AudioDelta{Data: data, Format: format})
+			s.emittedOutput = true
+		}
+		if isImage(part.InlineData) {
+			data, err := base64.StdEncoding.DecodeString(part.InlineData.Data)
+			if err != nil {
+				return nil, litellm.NewProviderErrorWithCause("gemini", litellm.ErrorTypeProvider, "gemini: decode stream image", err)
+			}
+			events = append(events, litellm.ImageDelta{Data: data, MIME: part.InlineData.MimeType})
+			s.emittedOutput = true
+		}
 		if part.FunctionCall != nil {
 			args, err := json.Marshal(part.FunctionCall.Args)
 			if err != nil {
@@ -223,6 +244,11 @@ func (s *stream) events(resp response) ([]litellm.Event, error) {
 			s.emittedOutput = true
 		}
 	}
+	annotations, err := s.annotationEvents(candidate)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, annotations...)
 	if candidate.FinishReason != "" {
 		finish := litellm.NormalizeFinishReason(candidate.FinishReason)
 		if !s.emittedOutput && len(events) == 0 && finish != litellm.FinishReasonStop && finish != litellm.FinishReasonToolCall {
@@ -235,6 +261,36 @@ func (s *stream) events(resp response) ([]litellm.Event, error) {
 	return events, nil
 }
 
+// annotationEvents emits the candidate's citations once each. Streamed offsets
+// index into the text streamed so far rather than a single part.
+func (s *stream) annotationEvents(candidate candidate) ([]litellm.Event, error) {
+	grounded, err := groundingAnnotations(candidate.GroundingMetadata)
+	if err != nil {
+		return nil, litellm.NewProviderErrorWithCause("gemini", litellm.ErrorTypeProvider, "gemini: convert grounding metadata", err)
+	}
+	recited, err := citationAnnotations(candidate.CitationMetadata)
+	if err != nil {
+		return nil, litellm.NewProviderErrorWithCause("gemini", litellm.ErrorTypeProvider, "gemini: convert citation metadata", err)
+	}
+	annotations := recited
+	for _, item := range grounded {
+		annotations = append(annotations, item.annotation)
+	}
+	var events []litellm.Event
+	for _, annotation := range annotations {
+		key := fmt.Sprintf("%d:%d:%s:%s", annotation.Start, annotation.End, annotation.URL, annotation.Extra)
+		if s.annotated[key] {
+			continue
+		}
+		s.annotated[key] = true
+		events = append(events, litellm.AnnotationDelta{Annotation: annotation})
+	}
+	if grounding := convertGrounding(candidate.GroundingMetadata); grounding != nil {
+		events = append(events, litellm.GroundingEvent{Grounding: *grounding})
+	}
+	return events, nil
+}
+
 func (s *stream) toolIndex(id string) int {
 	if index, ok := s.toolIndexByID[id]; ok {
 		return index
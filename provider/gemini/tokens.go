@@ -0,0 +1,77 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/voocel/litellm"
+)
+
+type countTokensRequest struct {
+	GenerateContentRequest countContentRequest `json:"generateContentRequest"`
+}
+
+type countContentRequest struct {
+	Model string `json:"model"`
+	*request
+}
+
+type countTokensResponse struct {
+	TotalTokens         int                  `json:"totalTokens"`
+	PromptTokensDetails []modalityTokenCount `json:"promptTokensDetails"`
+}
+
+type modalityTokenCount struct {
+	Modality   string `json:"modality"`
+	TokenCount int    `json:"tokenCount"`
+}
+
+// CountTokens calls models/{model}:countTokens. Gemini reports image tokens
+// separately; the rest of the breakdown is apportioned by the client.
+func (p *Provider) CountTokens(ctx context.Context, req *litellm.Request) (*litellm.TokenCount, error) {
+	wire, err := p.buildRequest(req)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	model := req.Model
+	if !strings.Contains(model, "/") {
+		model = "models/" + model
+	}
+	body, err := json.Marshal(countTokensRequest{GenerateContentRequest: countContentRequest{Model: model, request: wire}})
+	if err != nil {
+		return nil, fmt.Errorf("gemini: marshal count tokens request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url(req.Model, "countTokens"), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("gemini: create count tokens request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "count tokens request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	var parsed countTokensResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "gemini: decode count tokens response", err)
+	}
+	out := &litellm.TokenCount{InputTokens: parsed.TotalTokens, Provider: p.Name(), Model: req.Model}
+	for _, detail := range parsed.PromptTokensDetails {
+		if detail.Modality == "IMAGE" {
+			out.ImageTokens += detail.TokenCount
+		}
+	}
+	return out, nil
+}
@@ -56,6 +56,10 @@ type generationConfig struct {
 	ResponseMimeType string          `json:"responseMimeType,omitempty"`
 	ResponseSchema   any             `json:"responseJsonSchema,omitempty"`
 	ThinkingConfig   *thinkingConfig `json:"thinkingConfig,omitempty"`
+
+	ResponseModalities []string `json:"responseModalities,omitempty"`
+	SpeechConfig       any      `json:"speechConfig,omitempty"`
+	ImageConfig        any      `json:"imageConfig,omitempty"`
 }
 
 type safetySetting struct {
@@ -70,9 +74,12 @@ type thinkingConfig struct {
 }
 
 type tool struct {
-	FunctionDeclarations []functionDeclaration `json:"functionDeclarations"`
+	FunctionDeclarations []functionDeclaration `json:"functionDeclarations,omitempty"`
+	GoogleSearch         *googleSearch         `json:"googleSearch,omitempty"`
 }
 
+type googleSearch struct{}
+
 type functionDeclaration struct {
 	Name        string         `json:"name"`
 	Description string         `json:"description"`
@@ -95,11 +102,65 @@ type response struct {
 }
 
 type candidate struct {
-	Content       content        `json:"content"`
-	FinishReason  string         `json:"finishReason,omitempty"`
-	FinishMessage string         `json:"finishMessage,omitempty"`
-	Index         int            `json:"index,omitempty"`
-	SafetyRatings []safetyRating `json:"safetyRatings,omitempty"`
+	Content           content            `json:"content"`
+	FinishReason      string             `json:"finishReason,omitempty"`
+	FinishMessage     string             `json:"finishMessage,omitempty"`
+	Index             int                `json:"index,omitempty"`
+	SafetyRatings     []safetyRating     `json:"safetyRatings,omitempty"`
+	GroundingMetadata *groundingMetadata `json:"groundingMetadata,omitempty"`
+	CitationMetadata  *citationMetadata  `json:"citationMetadata,omitempty"`
+}
+
+type groundingMetadata struct {
+	WebSearchQueries  []string           `json:"webSearchQueries,omitempty"`
+	SearchEntryPoint  *searchEntryPoint  `json:"searchEntryPoint,omitempty"`
+	GroundingChunks   []groundingChunk   `json:"groundingChunks,omitempty"`
+	GroundingSupports []groundingSupport `json:"groundingSupports,omitempty"`
+}
+
+type searchEntryPoint struct {
+	RenderedContent string `json:"renderedContent,omitempty"`
+}
+
+type groundingChunk struct {
+	Web              *groundingSource `json:"web,omitempty"`
+	RetrievedContext *groundingSource `json:"retrievedContext,omitempty"`
+	Maps             *groundingSource `json:"maps,omitempty"`
+}
+
+type groundingSource struct {
+	URI   string `json:"uri,omitempty"`
+	Title string `json:"title,omitempty"`
+	Text  string `json:"text,omitempty"`
+}
+
+type groundingSupport struct {
+	Segment               segment   `json:"segment"`
+	GroundingChunkIndices []int     `json:"groundingChunkIndices,omitempty"`
+	ConfidenceScores      []float64 `json:"confidenceScores,omitempty"`
+}
+
+// segment locates grounded text with byte offsets into the part at PartIndex.
+type segment struct {
+	PartIndex  int    `json:"partIndex,omitempty"`
+	StartIndex int    `json:"startIndex,omitempty"`
+	EndIndex   int    `json:"endIndex,omitempty"`
+	Text       string `json:"text,omitempty"`
+}
+
+// citationMetadata lists recitations of sources; the Gemini API calls the
+// list citationSources and Vertex AI calls it citations.
+type citationMetadata struct {
+	CitationSources []citationSource `json:"citationSources,omitempty"`
+	Citations       []citationSource `json:"citations,omitempty"`
+}
+
+type citationSource struct {
+	StartIndex int    `json:"startIndex,omitempty"`
+	EndIndex   int    `json:"endIndex,omitempty"`
+	URI        string `json:"uri,omitempty"`
+	Title      string `json:"title,omitempty"`
+	License    string `json:"license,omitempty"`
 }
 
 type usageMetadata struct {
@@ -22,6 +22,7 @@ func New(cfg Config) (*compat.Provider, error) {
 		Request: compat.RequestSpec{
 			SupportsJSONSchema: true,
 			Thinking:           mapThinking,
+			WebSearch:          mapWebSearch,
 			ProviderOptions:    mapProviderOptions,
 		},
 		Response: compat.ResponseSpec{
@@ -42,6 +43,7 @@ func New(cfg Config) (*compat.Provider, error) {
 			caps.Thinking.Notes = []string{"reasoning_effort is supported for grok-4.3 and aliases; use ThinkingDisabled to send none"}
 			caps.Structured.JSONSchema = litellm.SupportYes
 			caps.Structured.Strict = litellm.SupportYes
+			caps.Tools.WebSearch = litellm.SupportPartial
 			return caps
 		},
 	})
@@ -90,6 +92,32 @@ func mapThinking(thinking *litellm.Thinking, model string) (map[string]any, erro
 	return nil, fmt.Errorf("grok: thinking effort is required")
 }
 
+// mapWebSearch maps WebSearch to xAI Live Search parameters. Mode "auto"
+// lets the model decide when to search, like the hosted search tools of
+// other providers.
+func mapWebSearch(search *litellm.WebSearch, _ string) (map[string]any, error) {
+	if search.MaxUses > 0 {
+		return nil, fmt.Errorf("grok: web search max uses is not supported")
+	}
+	source := map[string]any{"type": "web"}
+	if len(search.AllowedDomains) > 0 {
+		source["allowed_websites"] = append([]string(nil), search.AllowedDomains...)
+	}
+	if len(search.BlockedDomains) > 0 {
+		source["excluded_websites"] = append([]string(nil), search.BlockedDomains...)
+	}
+	if loc := search.UserLocation; !loc.IsZero() {
+		if loc.City != "" || loc.Region != "" || loc.Timezone != "" {
+			return nil, fmt.Errorf("grok: web search user location only supports country")
+		}
+		source["country"] = loc.Country
+	}
+	return map[string]any{"search_parameters": map[string]any{
+		"mode":    "auto",
+		"sources": []map[string]any{source},
+	}}, nil
+}
+
 func supportsReasoningEffort(model string) bool {
 	model = strings.ToLower(strings.TrimSpace(model))
 	switch model {
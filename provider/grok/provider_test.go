@@ -57,6 +57,27 @@ func TestReasoningEffortRejectsUnsupportedModel(t *testing.T) {
 	}
 }
 
+func TestWebSearchMapsSearchParameters(t *testing.T) {
+	body := captureBody(t, &litellm.Request{
+		Model:    "grok-4",
+		Messages: []litellm.Message{litellm.UserText("hi")},
+		WebSearch: &litellm.WebSearch{
+			BlockedDomains: []string{"example.com"},
+			UserLocation:   &litellm.UserLocation{Country: "CH"},
+		},
+	})
+	params, _ := body["search_parameters"].(map[string]any)
+	sources, _ := params["sources"].([]any)
+	if params["mode"] != "auto" || len(sources) != 1 {
+		t.Fatalf("search_parameters = %#v", body["search_parameters"])
+	}
+	source := sources[0].(map[string]any)
+	excluded, _ := source["excluded_websites"].([]any)
+	if source["type"] != "web" || source["country"] != "CH" || len(excluded) != 1 || excluded[0] != "example.com" {
+		t.Fatalf("source = %#v", source)
+	}
+}
+
 func TestThinkingRequiresEffort(t *testing.T) {
 	p, err := New(compat.Config{APIKey: "key", BaseURL: "https://grok.test", HTTPClient: roundTripFunc(nil)})
 	if err != nil {
@@ -60,6 +60,7 @@ func New(cfg Config) (*compat.Provider, error) {
 			caps.Thinking.IncludeOutput = litellm.SupportNo
 			caps.Thinking.Notes = []string{"thinking is a provider switch; effort and budget controls are rejected"}
 			caps.Streaming.Usage = litellm.SupportNo
+			caps.Media.AudioOutput = litellm.SupportPartial
 			return caps
 		},
 	})
@@ -261,6 +261,37 @@ func TestResponseReasoningContent(t *testing.T) {
 	}
 }
 
+func TestResponseAudio(t *testing.T) {
+	p, err := New(compat.Config{
+		APIKey:  "key",
+		BaseURL: "https://mimo.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`{
+					"choices":[{"message":{"content":"","audio":{"id":"audio_1","data":"UklGRg=="}},"finish_reason":"stop"}]
+				}`)),
+				Header: make(http.Header),
+			}, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	resp, err := p.Chat(context.Background(), &litellm.Request{
+		Model:           "mimo-v2-tts",
+		Messages:        []litellm.Message{litellm.AssistantText("read this")},
+		ProviderOptions: litellm.ProviderOptions{ProviderOptionAudio: map[string]any{"format": "wav"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat: %v", err)
+	}
+	audio, ok := resp.Blocks[0].(litellm.AudioOutputBlock)
+	if len(resp.Blocks) != 1 || !ok || audio.ID != "audio_1" || string(audio.Data) != "RIFF" || audio.Format != "wav" {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+}
+
 func TestRoundTripsReasoningContentHistory(t *testing.T) {
 	body := captureBody(t, &litellm.Request{
 		Model: "mimo-v2.5-pro",
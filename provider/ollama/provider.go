@@ -16,7 +16,8 @@ func New(cfg Config) (*compat.Provider, error) {
 	return compat.New(cfg, compat.Spec{
 		Name: "ollama",
 		Endpoint: compat.EndpointSpec{
-			BaseURL: defaultBaseURL,
+			BaseURL:        defaultBaseURL,
+			EmbeddingsPath: "/embeddings",
 		},
 		Request: compat.RequestSpec{
 			Thinking: mapThinking,
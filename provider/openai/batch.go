@@ -0,0 +1,247 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/voocel/litellm"
+)
+
+const batchEndpoint = "/v1/chat/completions"
+
+type batchLine struct {
+	CustomID string       `json:"custom_id"`
+	Method   string       `json:"method"`
+	URL      string       `json:"url"`
+	Body     *chatRequest `json:"body"`
+}
+
+type batchCreateRequest struct {
+	InputFileID      string `json:"input_file_id"`
+	Endpoint         string `json:"endpoint"`
+	CompletionWindow string `json:"completion_window"`
+}
+
+type batchObject struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	OutputFileID  string `json:"output_file_id"`
+	ErrorFileID   string `json:"error_file_id"`
+	CreatedAt     int64  `json:"created_at"`
+	CompletedAt   int64  `json:"completed_at"`
+	FailedAt      int64  `json:"failed_at"`
+	ExpiredAt     int64  `json:"expired_at"`
+	CancelledAt   int64  `json:"cancelled_at"`
+	ExpiresAt     int64  `json:"expires_at"`
+	RequestCounts struct {
+		Total     int `json:"total"`
+		Completed int `json:"completed"`
+		Failed    int `json:"failed"`
+	} `json:"request_counts"`
+	Errors *struct {
+		Data []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"data"`
+	} `json:"errors"`
+}
+
+type batchList struct {
+	Data []batchObject `json:"data"`
+}
+
+type batchOutputLine struct {
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int             `json:"status_code"`
+		Body       json.RawMessage `json:"body"`
+	} `json:"response"`
+	Error *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CreateBatch uploads the items as a JSONL file and starts a Chat Completions
+// batch with a 24h completion window. Items are always sent to
+// /v1/chat/completions, whatever Config.API selects.
+func (p *Provider) CreateBatch(ctx context.Context, req *litellm.BatchRequest) (*litellm.Batch, error) {
+	var input bytes.Buffer
+	encoder := json.NewEncoder(&input)
+	for _, item := range req.Items {
+		wire, err := p.buildRequest(&item.Request, false)
+		if err != nil {
+			return nil, litellm.WrapValidationError(p.Name(), fmt.Errorf("batch item %q: %w", item.CustomID, err))
+		}
+		if err := encoder.Encode(batchLine{CustomID: item.CustomID, Method: http.MethodPost, URL: batchEndpoint, Body: wire}); err != nil {
+			return nil, fmt.Errorf("openai: marshal batch item %q: %w", item.CustomID, err)
+		}
+	}
+	file, err := p.UploadFile(ctx, &litellm.FileUpload{Name: "batch.jsonl", Data: input.Bytes(), Purpose: "batch"})
+	if err != nil {
+		return nil, err
+	}
+	var batch batchObject
+	body := batchCreateRequest{InputFileID: file.ID, Endpoint: batchEndpoint, CompletionWindow: "24h"}
+	if err := p.apiCall(ctx, http.MethodPost, "/batches", body, &batch); err != nil {
+		return nil, err
+	}
+	return p.convertBatch(batch), nil
+}
+
+func (p *Provider) GetBatch(ctx context.Context, id string) (*litellm.Batch, error) {
+	var batch batchObject
+	if err := p.apiCall(ctx, http.MethodGet, "/batches/"+url.PathEscape(id), nil, &batch); err != nil {
+		return nil, err
+	}
+	return p.convertBatch(batch), nil
+}
+
+func (p *Provider) ListBatches(ctx context.Context, limit int) ([]litellm.Batch, error) {
+	path := "/batches"
+	if limit > 0 {
+		path += "?limit=" + strconv.Itoa(limit)
+	}
+	var list batchList
+	if err := p.apiCall(ctx, http.MethodGet, path, nil, &list); err != nil {
+		return nil, err
+	}
+	out := make([]litellm.Batch, 0, len(list.Data))
+	for _, batch := range list.Data {
+		out = append(out, *p.convertBatch(batch))
+	}
+	return out, nil
+}
+
+func (p *Provider) CancelBatch(ctx context.Context, id string) (*litellm.Batch, error) {
+	var batch batchObject
+	if err := p.apiCall(ctx, http.MethodPost, "/batches/"+url.PathEscape(id)+"/cancel", nil, &batch); err != nil {
+		return nil, err
+	}
+	return p.convertBatch(batch), nil
+}
+
+// BatchResults reads the batch's output and error files.
+func (p *Provider) BatchResults(ctx context.Context, id string) ([]litellm.BatchResult, error) {
+	var batch batchObject
+	if err := p.apiCall(ctx, http.MethodGet, "/batches/"+url.PathEscape(id), nil, &batch); err != nil {
+		return nil, err
+	}
+	if batch.OutputFileID == "" && batch.ErrorFileID == "" {
+		return nil, litellm.NewValidationError(p.Name(), fmt.Sprintf("openai: batch %s has no results (status %s)", id, batch.Status))
+	}
+	var results []litellm.BatchResult
+	for _, fileID := range []string{batch.OutputFileID, batch.ErrorFileID} {
+		if fileID == "" {
+			continue
+		}
+		data, err := p.fileContent(ctx, fileID)
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+		for scanner.Scan() {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			var line batchOutputLine
+			if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+				return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "openai: decode batch result", err)
+			}
+			results = append(results, p.convertBatchResult(line))
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, litellm.NewNetworkError(p.Name(), "read batch results failed", err)
+		}
+	}
+	return results, nil
+}
+
+func (p *Provider) convertBatchResult(line batchOutputLine) litellm.BatchResult {
+	result := litellm.BatchResult{CustomID: line.CustomID}
+	switch {
+	case line.Error != nil:
+		result.Err = &litellm.LiteLLMError{Type: litellm.ErrorTypeProvider, Code: line.Error.Code, Message: line.Error.Message, Provider: p.Name()}
+	case line.Response == nil:
+		result.Err = litellm.NewProviderError(p.Name(), litellm.ErrorTypeProvider, "openai: batch result has neither response nor error")
+	case line.Response.StatusCode < 200 || line.Response.StatusCode >= 300:
+		result.Err = litellm.NewHTTPError(p.Name(), line.Response.StatusCode, string(line.Response.Body))
+	default:
+		var parsed chatResponse
+		if err := json.Unmarshal(line.Response.Body, &parsed); err != nil {
+			result.Err = litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "openai: decode batch response", err)
+			break
+		}
+		resp, err := convertResponse(&parsed, nil)
+		if err != nil {
+			result.Err = litellm.WrapError(err, p.Name())
+			break
+		}
+		result.Response = resp
+	}
+	return result
+}
+
+func (p *Provider) convertBatch(batch batchObject) *litellm.Batch {
+	out := &litellm.Batch{
+		ID:       batch.ID,
+		Provider: p.Name(),
+		Status:   litellm.BatchStatus(batch.Status),
+		Counts: litellm.BatchCounts{
+			Total:     batch.RequestCounts.Total,
+			Succeeded: batch.RequestCounts.Completed,
+			Failed:    batch.RequestCounts.Failed,
+		},
+		CreatedAt: unixTime(batch.CreatedAt),
+		ExpiresAt: unixTime(batch.ExpiresAt),
+	}
+	for _, ended := range []int64{batch.CompletedAt, batch.FailedAt, batch.ExpiredAt, batch.CancelledAt} {
+		if ended != 0 {
+			out.EndedAt = unixTime(ended)
+			break
+		}
+	}
+	if batch.Errors != nil && len(batch.Errors.Data) > 0 {
+		out.Message = batch.Errors.Data[0].Message
+	}
+	return out
+}
+
+func unixTime(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0).UTC()
+}
+
+func (p *Provider) fileContent(ctx context.Context, fileID string) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url("/files/"+url.PathEscape(fileID)+"/content"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("openai: create file content request: %w", err)
+	}
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "file content request failed", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "read file content failed", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	return data, nil
+}
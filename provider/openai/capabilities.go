@@ -73,13 +73,20 @@ func (p *Provider) Capabilities(model string) litellm.Capabilities {
 			StrictSchema:        litellm.SupportYes,
 			Choice:              litellm.SupportYes,
 			HostedProviderTools: litellm.SupportPartial,
+			WebSearch:           litellm.SupportPartial,
 		},
 		Structured: p.structuredSupport(),
 		Media: litellm.MediaCapabilities{
-			ImageURL:    litellm.SupportYes,
-			ImageBytes:  litellm.SupportYes,
-			FileURI:     litellm.SupportNo,
-			ImageDetail: litellm.SupportYes,
+			ImageURL:          litellm.SupportYes,
+			ImageBytes:        litellm.SupportYes,
+			FileURI:           litellm.SupportPartial,
+			ImageDetail:       litellm.SupportYes,
+			DocumentURL:       litellm.SupportPartial,
+			DocumentBytes:     litellm.SupportYes,
+			DocumentFileURI:   litellm.SupportYes,
+			DocumentCitations: litellm.SupportNo,
+			AudioInput:        litellm.SupportPartial,
+			AudioOutput:       litellm.SupportPartial,
 		},
 		Cache: litellm.CacheCapabilities{
 			Block:      litellm.SupportNo,
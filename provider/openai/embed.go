@@ -0,0 +1,115 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/voocel/litellm"
+)
+
+type embeddingRequest struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	EncodingFormat string   `json:"encoding_format"`
+	Dimensions     *int     `json:"dimensions,omitempty"`
+	User           string   `json:"user,omitempty"`
+}
+
+type embeddingResponse struct {
+	Model string          `json:"model"`
+	Data  []embeddingData `json:"data"`
+	Usage struct {
+		PromptTokens int `json:"prompt_tokens"`
+		TotalTokens  int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+type embeddingData struct {
+	Index     int       `json:"index"`
+	Embedding []float64 `json:"embedding"`
+}
+
+func (p *Provider) Embed(ctx context.Context, req *litellm.EmbedRequest) (*litellm.EmbedResponse, error) {
+	wire, err := buildEmbeddingRequest(req)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	body, err := json.Marshal(wire)
+	if err != nil {
+		return nil, fmt.Errorf("openai: marshal embeddings request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url("/embeddings"), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("openai: create embeddings request: %w", err)
+	}
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "embeddings request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	var parsed embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "openai: decode embeddings response", err)
+	}
+	return convertEmbeddingResponse(&parsed, req, p.Name())
+}
+
+func buildEmbeddingRequest(req *litellm.EmbedRequest) (*embeddingRequest, error) {
+	out := &embeddingRequest{
+		Model:          req.Model,
+		Input:          append([]string(nil), req.Input...),
+		EncodingFormat: "float",
+		Dimensions:     req.Dimensions,
+	}
+	for key, value := range req.ProviderOptions {
+		switch key {
+		case ProviderOptionUser:
+			v, err := optionString(key, value)
+			if err != nil {
+				return nil, err
+			}
+			out.User = v
+		default:
+			return nil, fmt.Errorf("openai: unsupported embeddings provider option %q", key)
+		}
+	}
+	return out, nil
+}
+
+func convertEmbeddingResponse(resp *embeddingResponse, req *litellm.EmbedRequest, provider string) (*litellm.EmbedResponse, error) {
+	data := append([]embeddingData(nil), resp.Data...)
+	sort.SliceStable(data, func(i, j int) bool { return data[i].Index < data[j].Index })
+	out := &litellm.EmbedResponse{
+		Embeddings: make([][]float64, 0, len(data)),
+		Model:      req.Model,
+		Provider:   provider,
+	}
+	if resp.Model != "" {
+		out.Model = resp.Model
+	}
+	for i, item := range data {
+		if item.Index != i {
+			return nil, litellm.NewProviderError(provider, litellm.ErrorTypeProvider, fmt.Sprintf("%s: embeddings response is missing index %d", provider, i))
+		}
+		out.Embeddings = append(out.Embeddings, item.Embedding)
+	}
+	out.Usage = litellm.Usage{
+		InputTokens: resp.Usage.PromptTokens,
+		TotalTokens: resp.Usage.TotalTokens,
+		Provider:    provider,
+		Model:       out.Model,
+	}
+	return out, nil
+}
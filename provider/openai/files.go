@@ -0,0 +1,134 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+
+	"github.com/voocel/litellm"
+)
+
+type fileObject struct {
+	ID        string `json:"id"`
+	Bytes     int64  `json:"bytes"`
+	CreatedAt int64  `json:"created_at"`
+	ExpiresAt int64  `json:"expires_at"`
+	Filename  string `json:"filename"`
+	Purpose   string `json:"purpose"`
+	Status    string `json:"status"`
+}
+
+type fileList struct {
+	Data []fileObject `json:"data"`
+}
+
+// UploadFile uploads to /v1/files with purpose "user_data" unless
+// FileUpload.Purpose says otherwise.
+func (p *Provider) UploadFile(ctx context.Context, req *litellm.FileUpload) (*litellm.File, error) {
+	purpose := req.Purpose
+	if purpose == "" {
+		purpose = "user_data"
+	}
+	name := req.Name
+	if name == "" {
+		name = "file"
+	}
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if err := form.WriteField("purpose", purpose); err != nil {
+		return nil, fmt.Errorf("openai: write file upload: %w", err)
+	}
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", multipart.FileContentDisposition("file", name))
+	if req.MIME != "" {
+		header.Set("Content-Type", req.MIME)
+	} else {
+		header.Set("Content-Type", "application/octet-stream")
+	}
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("openai: write file upload: %w", err)
+	}
+	if _, err := part.Write(req.Data); err != nil {
+		return nil, fmt.Errorf("openai: write file upload: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("openai: write file upload: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url("/files"), &body)
+	if err != nil {
+		return nil, fmt.Errorf("openai: create file upload request: %w", err)
+	}
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	httpReq.Header.Set("Content-Type", form.FormDataContentType())
+	var file fileObject
+	if err := p.doAPIRequest(httpReq, &file); err != nil {
+		return nil, err
+	}
+	out := p.convertFile(file)
+	out.MIME = req.MIME
+	return out, nil
+}
+
+func (p *Provider) GetFile(ctx context.Context, id string) (*litellm.File, error) {
+	var file fileObject
+	if err := p.apiCall(ctx, http.MethodGet, "/files/"+url.PathEscape(id), nil, &file); err != nil {
+		return nil, err
+	}
+	return p.convertFile(file), nil
+}
+
+func (p *Provider) ListFiles(ctx context.Context, limit int) ([]litellm.File, error) {
+	path := "/files"
+	if limit > 0 {
+		path += "?limit=" + strconv.Itoa(limit)
+	}
+	var list fileList
+	if err := p.apiCall(ctx, http.MethodGet, path, nil, &list); err != nil {
+		return nil, err
+	}
+	out := make([]litellm.File, 0, len(list.Data))
+	for _, file := range list.Data {
+		out = append(out, *p.convertFile(file))
+	}
+	return out, nil
+}
+
+func (p *Provider) DeleteFile(ctx context.Context, id string) error {
+	var deleted struct {
+		Deleted bool `json:"deleted"`
+	}
+	return p.apiCall(ctx, http.MethodDelete, "/files/"+url.PathEscape(id), nil, &deleted)
+}
+
+func (p *Provider) convertFile(file fileObject) *litellm.File {
+	out := &litellm.File{
+		ID:        file.ID,
+		Provider:  p.Name(),
+		URI:       file.ID,
+		Name:      file.Filename,
+		Size:      file.Bytes,
+		State:     litellm.FileStateActive,
+		CreatedAt: unixTime(file.CreatedAt),
+		ExpiresAt: unixTime(file.ExpiresAt),
+	}
+	if file.Status == "error" {
+		out.State = litellm.FileStateFailed
+	}
+	return out
+}
+
+// fileID checks that a FileURI was not issued by another provider.
+func fileID(uri string) (string, error) {
+	if owner := litellm.FileOwner(uri); owner != "" && owner != "openai" {
+		return "", fmt.Errorf("openai: file %q belongs to %s", uri, owner)
+	}
+	return uri, nil
+}
@@ -0,0 +1,212 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/voocel/litellm"
+)
+
+// Image provider options are sent as the image API field of the same name.
+const (
+	ProviderOptionQuality      = "quality"
+	ProviderOptionBackground   = "background"
+	ProviderOptionOutputFormat = "output_format"
+	ProviderOptionStyle        = "style"
+)
+
+var imageOptionKeys = map[string]struct{}{
+	ProviderOptionQuality:      {},
+	ProviderOptionBackground:   {},
+	ProviderOptionOutputFormat: {},
+	ProviderOptionStyle:        {},
+	ProviderOptionModeration:   {},
+	ProviderOptionUser:         {},
+}
+
+type imageResponse struct {
+	Data []struct {
+		B64JSON string `json:"b64_json"`
+		URL     string `json:"url"`
+	} `json:"data"`
+	OutputFormat string `json:"output_format"`
+	Usage        struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+		TotalTokens  int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// GenerateImage calls /images/generations, or /images/edits when req has
+// source images.
+func (p *Provider) GenerateImage(ctx context.Context, req *litellm.ImageRequest) (*litellm.ImageResponse, error) {
+	fields, err := buildImageFields(req)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	var httpReq *http.Request
+	var contentType string
+	if len(req.Images) > 0 {
+		httpReq, contentType, err = p.imageEditRequest(ctx, req, fields)
+	} else {
+		httpReq, err = p.imageGenerationRequest(ctx, fields)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	if contentType != "" {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, litellm.NewNetworkError(p.Name(), "image request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	var parsed imageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "openai: decode image response", err)
+	}
+	format := parsed.OutputFormat
+	if format == "" {
+		format, _ = fields[ProviderOptionOutputFormat].(string)
+	}
+	mime := imageMIME(format)
+	out := &litellm.ImageResponse{
+		Model:    req.Model,
+		Provider: p.Name(),
+		Usage: litellm.Usage{
+			InputTokens:  parsed.Usage.InputTokens,
+			OutputTokens: parsed.Usage.OutputTokens,
+			TotalTokens:  parsed.Usage.TotalTokens,
+		},
+	}
+	for _, item := range parsed.Data {
+		if item.B64JSON == "" {
+			out.Images = append(out.Images, litellm.ImageBlock{URL: item.URL})
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(item.B64JSON)
+		if err != nil {
+			return nil, litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "openai: decode image data", err)
+		}
+		out.Images = append(out.Images, litellm.ImageBlock{Data: data, MIME: mime})
+	}
+	return out, nil
+}
+
+func buildImageFields(req *litellm.ImageRequest) (map[string]any, error) {
+	if req.AspectRatio != "" {
+		return nil, fmt.Errorf("openai: image aspect ratio is not supported; use Size")
+	}
+	fields := map[string]any{"model": req.Model, "prompt": req.Prompt}
+	if req.Size != "" {
+		fields["size"] = req.Size
+	}
+	if req.N > 0 {
+		fields["n"] = req.N
+	}
+	// DALL-E defaults to URLs that expire after an hour; gpt-image models
+	// always return base64 and reject response_format.
+	if strings.HasPrefix(req.Model, "dall-e") {
+		fields["response_format"] = "b64_json"
+	}
+	for key, value := range req.ProviderOptions {
+		if _, ok := imageOptionKeys[key]; !ok {
+			return nil, fmt.Errorf("openai: unsupported image provider option %q", key)
+		}
+		v, err := optionString(key, value)
+		if err != nil {
+			return nil, err
+		}
+		fields[key] = v
+	}
+	return fields, nil
+}
+
+func (p *Provider) imageGenerationRequest(ctx context.Context, fields map[string]any) (*http.Request, error) {
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("openai: marshal image request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url("/images/generations"), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("openai: create image request: %w", err)
+	}
+	return httpReq, nil
+}
+
+func (p *Provider) imageEditRequest(ctx context.Context, req *litellm.ImageRequest, fields map[string]any) (*http.Request, string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	for key, value := range fields {
+		if err := form.WriteField(key, fmt.Sprint(value)); err != nil {
+			return nil, "", fmt.Errorf("openai: write image edit request: %w", err)
+		}
+	}
+	field := "image"
+	if len(req.Images) > 1 {
+		field = "image[]"
+	}
+	for i, image := range req.Images {
+		if err := writeImagePart(form, field, fmt.Sprintf("image_%d", i), image); err != nil {
+			return nil, "", err
+		}
+	}
+	if req.Mask != nil {
+		if err := writeImagePart(form, "mask", "mask", *req.Mask); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := form.Close(); err != nil {
+		return nil, "", fmt.Errorf("openai: write image edit request: %w", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url("/images/edits"), &body)
+	if err != nil {
+		return nil, "", fmt.Errorf("openai: create image edit request: %w", err)
+	}
+	return httpReq, form.FormDataContentType(), nil
+}
+
+func writeImagePart(form *multipart.Writer, field, name string, image litellm.ImageBlock) error {
+	mime := image.MIME
+	if mime == "" {
+		mime = "image/png"
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename="%s.%s"`, field, name, strings.TrimPrefix(mime, "image/")))
+	header.Set("Content-Type", mime)
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return fmt.Errorf("openai: write image edit request: %w", err)
+	}
+	if _, err := part.Write(image.Data); err != nil {
+		return fmt.Errorf("openai: write image edit request: %w", err)
+	}
+	return nil
+}
+
+func imageMIME(format string) string {
+	switch format {
+	case "", "png":
+		return "image/png"
+	case "jpg", "jpeg":
+		return "image/jpeg"
+	default:
+		return "image/" + format
+	}
+}
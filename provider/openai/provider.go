@@ -252,3 +252,40 @@ func (p *Provider) url(path string) string {
 	}
 	return baseURL + path
 }
+
+// apiCall sends in, when set, as a JSON body to path and decodes the JSON
+// response into out. The files and batches endpoints go through it.
+func (p *Provider) apiCall(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("openai: marshal request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, p.url(path), body)
+	if err != nil {
+		return fmt.Errorf("openai: create request: %w", err)
+	}
+	if err := p.setHeaders(ctx, httpReq); err != nil {
+		return litellm.WrapValidationError(p.Name(), err)
+	}
+	return p.doAPIRequest(httpReq, out)
+}
+
+func (p *Provider) doAPIRequest(httpReq *http.Request, out any) error {
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return litellm.NewNetworkError(p.Name(), "request failed", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return litellm.NewHTTPError(p.Name(), resp.StatusCode, string(data))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return litellm.NewProviderErrorWithCause(p.Name(), litellm.ErrorTypeProvider, "openai: decode response", err)
+	}
+	return nil
+}
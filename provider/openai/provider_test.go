@@ -3,6 +3,7 @@ package openai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
@@ -89,6 +90,70 @@ func TestBuildRequestTextImageToolsAndOptions(t *testing.T) {
 	}
 }
 
+func TestBuildRequestEncodesDocumentBlocks(t *testing.T) {
+	provider := mustProvider(t)
+	wire, err := provider.buildRequest(&litellm.Request{
+		Model: "gpt-4.1",
+		Messages: []litellm.Message{litellm.User(
+			litellm.DocumentBlock{Data: []byte("%PDF"), MIME: "application/pdf", Title: "report.pdf"},
+			litellm.DocumentBlock{FileURI: "file-abc"},
+			litellm.Text("summarize"),
+		)},
+	}, false)
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	data, err := json.Marshal(wire)
+	if err != nil {
+		t.Fatalf("marshal wire: %v", err)
+	}
+	jsonText := string(data)
+	for _, want := range []string{
+		`{"type":"file","file":{"filename":"report.pdf","file_data":"data:application/pdf;base64,JVBERg=="}}`,
+		`{"type":"file","file":{"file_id":"file-abc"}}`,
+	} {
+		if !strings.Contains(jsonText, want) {
+			t.Fatalf("wire JSON missing %s:\n%s", want, jsonText)
+		}
+	}
+
+	_, err = provider.buildRequest(&litellm.Request{
+		Model:    "gpt-4.1",
+		Messages: []litellm.Message{litellm.User(litellm.DocumentURL("https://example.test/a.pdf"))},
+	}, false)
+	if err == nil || !strings.Contains(err.Error(), "data URLs") {
+		t.Fatalf("expected document URL error, got %v", err)
+	}
+}
+
+func TestBuildRequestEncodesAudioBlocks(t *testing.T) {
+	provider := mustProvider(t)
+	wire, err := provider.buildRequest(&litellm.Request{
+		Model: "gpt-4o-audio-preview",
+		Messages: []litellm.Message{
+			litellm.User(litellm.Audio([]byte("RIFF"), "wav")),
+			litellm.Assistant(litellm.AudioOutputBlock{ID: "audio_1", Transcript: "hello"}),
+			litellm.UserText("again"),
+		},
+	}, false)
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	data, err := json.Marshal(wire)
+	if err != nil {
+		t.Fatalf("marshal wire: %v", err)
+	}
+	jsonText := string(data)
+	for _, want := range []string{
+		`{"type":"input_audio","input_audio":{"data":"UklGRg==","format":"wav"}}`,
+		`{"role":"assistant","audio":{"id":"audio_1"}}`,
+	} {
+		if !strings.Contains(jsonText, want) {
+			t.Fatalf("wire JSON missing %s:\n%s", want, jsonText)
+		}
+	}
+}
+
 func TestBuildRequestOpenAIProviderOptions(t *testing.T) {
 	provider := mustProvider(t)
 	wire, err := provider.buildRequest(&litellm.Request{
@@ -186,6 +251,34 @@ func TestBuildRequestRejectsOpaqueReasoningBlockHistory(t *testing.T) {
 	}
 }
 
+func TestBuildRequestMapsWebSearchOptions(t *testing.T) {
+	provider := mustProvider(t)
+	wire, err := provider.buildRequest(&litellm.Request{
+		Model:     "gpt-4o-search-preview",
+		Messages:  []litellm.Message{litellm.UserText("news?")},
+		WebSearch: &litellm.WebSearch{UserLocation: &litellm.UserLocation{City: "Paris", Country: "FR"}},
+	}, false)
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	data, err := json.Marshal(wire.WebSearchOptions)
+	if err != nil {
+		t.Fatalf("marshal web_search_options: %v", err)
+	}
+	if string(data) != `{"user_location":{"approximate":{"city":"Paris","country":"FR"},"type":"approximate"}}` {
+		t.Fatalf("web_search_options = %s", data)
+	}
+
+	_, err = provider.buildRequest(&litellm.Request{
+		Model:     "gpt-4o-search-preview",
+		Messages:  []litellm.Message{litellm.UserText("news?")},
+		WebSearch: &litellm.WebSearch{AllowedDomains: []string{"go.dev"}},
+	}, false)
+	if err == nil || !strings.Contains(err.Error(), "use the responses API") {
+		t.Fatalf("expected chat web search error, got %v", err)
+	}
+}
+
 func TestBuildRequestRejectsUnknownProviderOption(t *testing.T) {
 	provider := mustProvider(t)
 	_, err := provider.buildRequest(&litellm.Request{
@@ -543,6 +636,54 @@ func TestConvertResponsePreservesRefusal(t *testing.T) {
 	}
 }
 
+func TestConvertResponseMapsMessageAnnotations(t *testing.T) {
+	var wire chatResponse
+	if err := json.Unmarshal([]byte(`{"model":"gpt-5-search-api","choices":[{"finish_reason":"stop","message":{"role":"assistant",
+		"content":"Café opens today.",
+		"annotations":[{"type":"url_citation","url_citation":{"start_index":5,"end_index":16,"url":"https://example.com/cafe","title":"Café news"}}]}}]}`), &wire); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	resp, err := convertResponse(&wire, nil)
+	if err != nil {
+		t.Fatalf("convertResponse: %v", err)
+	}
+	block, ok := resp.Blocks[0].(litellm.TextBlock)
+	if len(resp.Blocks) != 1 || !ok || len(block.Annotations) != 1 {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	annotation := block.Annotations[0]
+	if annotation.Type != litellm.AnnotationURLCitation || annotation.URL != "https://example.com/cafe" || annotation.Title != "Café news" || block.Text[annotation.Start:annotation.End] != "opens today" {
+		t.Fatalf("annotation = %+v", annotation)
+	}
+}
+
+func TestConvertResponseDecodesAudio(t *testing.T) {
+	resp, err := convertResponse(&chatResponse{
+		Model: "gpt-4o-audio-preview",
+		Choices: []choice{{
+			Message: responseMessage{Audio: &messageAudio{
+				ID:         "audio_1",
+				Data:       "UklGRg==",
+				ExpiresAt:  1729234747,
+				Transcript: "hello",
+			}},
+			FinishReason: "stop",
+		}},
+	}, &litellm.Request{ProviderOptions: litellm.ProviderOptions{
+		ProviderOptionAudio: map[string]any{"voice": "alloy", "format": "wav"},
+	}})
+	if err != nil {
+		t.Fatalf("convertResponse: %v", err)
+	}
+	if len(resp.Blocks) != 1 {
+		t.Fatalf("blocks = %+v", resp.Blocks)
+	}
+	audio, ok := resp.Blocks[0].(litellm.AudioOutputBlock)
+	if !ok || audio.ID != "audio_1" || string(audio.Data) != "RIFF" || audio.Format != "wav" || audio.Transcript != "hello" || audio.ExpiresAt != 1729234747 {
+		t.Fatalf("audio block = %#v", resp.Blocks[0])
+	}
+}
+
 func TestChatRejectsUnsupportedResponseContent(t *testing.T) {
 	provider, err := New(Config{
 		APIKey:  "test-key",
@@ -687,6 +828,195 @@ func TestStreamPreservesRefusal(t *testing.T) {
 	}
 }
 
+func TestStreamReportsContentFilterResults(t *testing.T) {
+	stream := newStream(streamResponse(strings.Join([]string{
+		`data: {"choices":[],"prompt_filter_results":[{"prompt_index":0,"content_filter_results":{"hate":{"filtered":false,"severity":"safe"},"jailbreak":{"filtered":false,"detected":false}}}]}`,
+		`data: {"choices":[{"index":0,"delta":{"content":"par"}}]}`,
+		`data: {"choices":[{"index":0,"delta":{},"finish_reason":"content_filter","content_filter_results":{"violence":{"filtered":true,"severity":"high"}}}]}`,
+		`data: [DONE]`,
+		``,
+	}, "\n")), &litellm.Request{Model: "gpt-4.1"})
+	resp, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if resp.FinishReason != litellm.FinishReasonSafety || len(resp.Safety) != 2 {
+		t.Fatalf("finish/safety = %q/%+v", resp.FinishReason, resp.Safety)
+	}
+	prompt, output := resp.Safety[0], resp.Safety[1]
+	if prompt.Target != litellm.SafetyTargetPrompt || prompt.Blocked || len(prompt.Categories) != 2 || prompt.Categories[0].Name != "hate" {
+		t.Fatalf("prompt assessment = %+v", prompt)
+	}
+	if output.Target != litellm.SafetyTargetOutput || !output.Blocked || output.Categories[0].Severity != "high" {
+		t.Fatalf("output assessment = %+v", output)
+	}
+}
+
+func TestStreamSurfacesErrorChunk(t *testing.T) {
+	stream := newStream(streamResponse(strings.Join([]string{
+		`data: {"choices":[{"index":0,"delta":{"content":"par"}}]}`,
+		`data: {"error":{"code":"content_filter","message":"The response was filtered."}}`,
+		``,
+	}, "\n")), &litellm.Request{Model: "gpt-4.1"})
+	_, err := litellm.Collect(stream)
+	if !litellm.IsContentFilterError(err) {
+		t.Fatalf("Collect error = %v, want content filter error", err)
+	}
+}
+
+func TestStreamCollectsAnnotations(t *testing.T) {
+	stream := newStream(streamResponse(strings.Join([]string{
+		`data: {"choices":[{"index":0,"delta":{"content":"Café opens "}}]}`,
+		`data: {"choices":[{"index":0,"delta":{"content":"today."}}]}`,
+		`data: {"choices":[{"index":0,"delta":{"annotations":[{"type":"url_citation","url_citation":{"start_index":5,"end_index":16,"url":"https://example.com/cafe"}}]},"finish_reason":"stop"}]}`,
+		`data: [DONE]`,
+		``,
+	}, "\n")), &litellm.Request{Model: "gpt-5-search-api"})
+	resp, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	block, ok := resp.Blocks[0].(litellm.TextBlock)
+	if len(resp.Blocks) != 1 || !ok || len(block.Annotations) != 1 {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	if annotation := block.Annotations[0]; block.Text[annotation.Start:annotation.End] != "opens today" {
+		t.Fatalf("annotation = %+v", annotation)
+	}
+}
+
+func TestStreamAssemblesAudioDeltas(t *testing.T) {
+	stream := newStream(streamResponse(strings.Join([]string{
+		`data: {"choices":[{"index":0,"delta":{"audio":{"id":"audio_1","transcript":"hel"}}}]}`,
+		`data: {"choices":[{"index":0,"delta":{"audio":{"data":"UklG","transcript":"lo"}}}]}`,
+		`data: {"choices":[{"index":0,"delta":{"audio":{"data":"Rg==","expires_at":1729234747}},"finish_reason":"stop"}]}`,
+		`data: [DONE]`,
+		``,
+	}, "\n")), &litellm.Request{Model: "gpt-4o-audio-preview", ProviderOptions: litellm.ProviderOptions{
+		ProviderOptionAudio: map[string]any{"format": "pcm16"},
+	}})
+	resp, err := litellm.Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if len(resp.Blocks) != 1 {
+		t.Fatalf("blocks = %+v", resp.Blocks)
+	}
+	audio, ok := resp.Blocks[0].(litellm.AudioOutputBlock)
+	if !ok || audio.ID != "audio_1" || string(audio.Data) != "RIFF" || audio.Format != "pcm16" || audio.Transcript != "hello" || audio.ExpiresAt != 1729234747 {
+		t.Fatalf("audio block = %#v", resp.Blocks[0])
+	}
+}
+
+func TestEmbedOrdersVectorsByIndex(t *testing.T) {
+	var captured map[string]any
+	var capturedPath string
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			capturedPath = req.URL.Path
+			if err := json.NewDecoder(req.Body).Decode(&captured); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			return jsonResponse(http.StatusOK, `{
+				"model":"text-embedding-3-small",
+				"data":[{"index":1,"embedding":[0.3,0.4]},{"index":0,"embedding":[0.1,0.2]}],
+				"usage":{"prompt_tokens":5,"total_tokens":5}
+			}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.Embed(context.Background(), &litellm.EmbedRequest{
+		Model:           "text-embedding-3-small",
+		Input:           []string{"a", "b"},
+		Dimensions:      litellm.IntPtr(2),
+		ProviderOptions: litellm.ProviderOptions{"user": "u1"},
+	})
+	if err != nil {
+		t.Fatalf("Embed returned error: %v", err)
+	}
+	if capturedPath != "/v1/embeddings" {
+		t.Fatalf("path = %q, want /v1/embeddings", capturedPath)
+	}
+	if captured["encoding_format"] != "float" || captured["dimensions"] != float64(2) || captured["user"] != "u1" {
+		t.Fatalf("request = %#v", captured)
+	}
+	if len(resp.Embeddings) != 2 || resp.Embeddings[0][0] != 0.1 || resp.Embeddings[1][1] != 0.4 {
+		t.Fatalf("embeddings = %#v", resp.Embeddings)
+	}
+	if resp.Usage.InputTokens != 5 || resp.Usage.Provider != "openai" {
+		t.Fatalf("usage = %#v", resp.Usage)
+	}
+}
+
+func TestEmbedRejectsUnknownProviderOption(t *testing.T) {
+	provider := mustProvider(t)
+	_, err := provider.Embed(context.Background(), &litellm.EmbedRequest{
+		Model:           "text-embedding-3-small",
+		Input:           []string{"a"},
+		ProviderOptions: litellm.ProviderOptions{"seed": 1},
+	})
+	if !litellm.IsValidationError(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestGenerateImageEditsSendMultipart(t *testing.T) {
+	var fields map[string]string
+	var files []string
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/v1/images/edits" {
+				t.Fatalf("path = %q", req.URL.Path)
+			}
+			if err := req.ParseMultipartForm(1 << 20); err != nil {
+				t.Fatalf("parse multipart: %v", err)
+			}
+			fields = map[string]string{}
+			for key, values := range req.MultipartForm.Value {
+				fields[key] = values[0]
+			}
+			for key, headers := range req.MultipartForm.File {
+				files = append(files, key+":"+headers[0].Header.Get("Content-Type"))
+			}
+			return jsonResponse(http.StatusOK, `{"data":[{"b64_json":"UklGRg=="}],"output_format":"webp","usage":{"input_tokens":50,"output_tokens":1056,"total_tokens":1106}}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := provider.GenerateImage(context.Background(), &litellm.ImageRequest{
+		Model:           "gpt-image-1",
+		Prompt:          "add a hat",
+		Size:            "1024x1024",
+		Images:          []litellm.ImageBlock{{Data: []byte("src"), MIME: "image/png"}},
+		Mask:            &litellm.ImageBlock{Data: []byte("mask")},
+		ProviderOptions: litellm.ProviderOptions{ProviderOptionQuality: "high"},
+	})
+	if err != nil {
+		t.Fatalf("GenerateImage returned error: %v", err)
+	}
+	if fields["model"] != "gpt-image-1" || fields["prompt"] != "add a hat" || fields["size"] != "1024x1024" || fields["quality"] != "high" || fields["response_format"] != "" {
+		t.Fatalf("fields = %#v", fields)
+	}
+	if len(files) != 2 {
+		t.Fatalf("files = %v", files)
+	}
+	if len(resp.Images) != 1 || string(resp.Images[0].Data) != "RIFF" || resp.Images[0].MIME != "image/webp" || resp.Usage.OutputTokens != 1056 {
+		t.Fatalf("resp = %+v", resp)
+	}
+
+	_, err = provider.GenerateImage(context.Background(), &litellm.ImageRequest{Model: "dall-e-3", Prompt: "p", AspectRatio: "16:9"})
+	if !litellm.IsValidationError(err) {
+		t.Fatalf("expected aspect ratio validation error, got %v", err)
+	}
+}
+
 func jsonResponse(status int, body string) *http.Response {
 	return &http.Response{
 		StatusCode: status,
@@ -718,3 +1048,131 @@ func mustTool(t *testing.T, name, description string, schema any) litellm.Tool {
 	}
 	return tool
 }
+
+func TestBatchUploadsJSONLAndReadsResults(t *testing.T) {
+	var uploaded string
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch req.Method + " " + req.URL.Path {
+			case "POST /v1/files":
+				if err := req.ParseMultipartForm(1 << 20); err != nil {
+					t.Fatalf("ParseMultipartForm: %v", err)
+				}
+				if req.FormValue("purpose") != "batch" {
+					t.Fatalf("purpose = %q", req.FormValue("purpose"))
+				}
+				file, _, err := req.FormFile("file")
+				if err != nil {
+					t.Fatalf("FormFile: %v", err)
+				}
+				data, _ := io.ReadAll(file)
+				uploaded = string(data)
+				return jsonResponse(http.StatusOK, `{"id":"file-in"}`), nil
+			case "POST /v1/batches":
+				body, _ := io.ReadAll(req.Body)
+				if !strings.Contains(string(body), `"input_file_id":"file-in"`) || !strings.Contains(string(body), `"endpoint":"/v1/chat/completions"`) {
+					t.Fatalf("create body = %s", body)
+				}
+				return jsonResponse(http.StatusOK, `{"id":"batch_1","status":"validating","created_at":1700000000,"request_counts":{"total":2}}`), nil
+			case "GET /v1/batches/batch_1":
+				return jsonResponse(http.StatusOK, `{"id":"batch_1","status":"completed","output_file_id":"file-out","error_file_id":"file-err","completed_at":1700000100,"request_counts":{"total":2,"completed":1,"failed":1}}`), nil
+			case "GET /v1/files/file-out/content":
+				return jsonResponse(http.StatusOK, `{"custom_id":"a","response":{"status_code":200,"body":{"model":"gpt-4.1","choices":[{"message":{"content":"hello"},"finish_reason":"stop"}],"usage":{"prompt_tokens":3,"completion_tokens":1,"total_tokens":4}}}}`+"\n"), nil
+			case "GET /v1/files/file-err/content":
+				return jsonResponse(http.StatusOK, `{"custom_id":"b","response":{"status_code":400,"body":{"error":{"code":"invalid_value","message":"bad request"}}}}`+"\n"), nil
+			}
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+			return nil, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	batch, err := provider.CreateBatch(context.Background(), &litellm.BatchRequest{Items: []litellm.BatchItem{
+		{CustomID: "a", Request: litellm.Request{Model: "gpt-4.1", Messages: []litellm.Message{litellm.UserText("hi")}}},
+		{CustomID: "b", Request: litellm.Request{Model: "gpt-4.1", Messages: []litellm.Message{litellm.UserText("bye")}}},
+	}})
+	if err != nil {
+		t.Fatalf("CreateBatch returned error: %v", err)
+	}
+	if batch.ID != "batch_1" || batch.Status != litellm.BatchStatusValidating || batch.Counts.Total != 2 || batch.CreatedAt.Unix() != 1700000000 {
+		t.Fatalf("batch = %+v", batch)
+	}
+	lines := strings.Split(strings.TrimSpace(uploaded), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"custom_id":"a"`) || !strings.Contains(lines[0], `"url":"/v1/chat/completions"`) || !strings.Contains(lines[0], `"messages":[`) {
+		t.Fatalf("uploaded = %s", uploaded)
+	}
+	results, err := provider.BatchResults(context.Background(), "batch_1")
+	if err != nil {
+		t.Fatalf("BatchResults returned error: %v", err)
+	}
+	if len(results) != 2 || results[0].Response == nil || results[0].Response.Text() != "hello" || results[0].Response.Usage.TotalTokens != 4 {
+		t.Fatalf("results[0] = %+v", results[0])
+	}
+	var itemErr *litellm.LiteLLMError
+	if results[1].CustomID != "b" || !errors.As(results[1].Err, &itemErr) || itemErr.Type != litellm.ErrorTypeValidation || itemErr.Code != "invalid_value" {
+		t.Fatalf("results[1] = %+v", results[1])
+	}
+}
+
+func TestFilesUploadAndReference(t *testing.T) {
+	provider, err := New(Config{
+		APIKey:  "test-key",
+		BaseURL: "https://example.test",
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			switch req.Method + " " + req.URL.Path {
+			case "POST /v1/files":
+				if err := req.ParseMultipartForm(1 << 20); err != nil {
+					t.Fatalf("ParseMultipartForm: %v", err)
+				}
+				file, header, err := req.FormFile("file")
+				if err != nil {
+					t.Fatalf("FormFile: %v", err)
+				}
+				data, _ := io.ReadAll(file)
+				if req.FormValue("purpose") != "user_data" || header.Filename != "résumé\u00a0\"final\".pdf" || header.Header.Get("Content-Type") != "application/pdf" || string(data) != "%PDF" {
+					t.Fatalf("upload = %q %q %q %q", req.FormValue("purpose"), header.Filename, header.Header.Get("Content-Type"), data)
+				}
+				return jsonResponse(http.StatusOK, `{"id":"file-abc","bytes":4,"created_at":1700000000,"filename":"report.pdf","purpose":"user_data","status":"processed"}`), nil
+			case "DELETE /v1/files/file-abc":
+				return jsonResponse(http.StatusOK, `{"id":"file-abc","deleted":true}`), nil
+			}
+			t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+			return nil, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	file, err := provider.UploadFile(context.Background(), &litellm.FileUpload{Name: "résumé\u00a0\"final\".pdf", MIME: "application/pdf", Data: []byte("%PDF")})
+	if err != nil {
+		t.Fatalf("UploadFile returned error: %v", err)
+	}
+	if file.ID != "file-abc" || file.URI != "file-abc" || file.Size != 4 || file.State != litellm.FileStateActive {
+		t.Fatalf("file = %+v", file)
+	}
+	wire, err := provider.buildRequest(&litellm.Request{
+		Model:    "gpt-4.1",
+		Messages: []litellm.Message{litellm.User(file.Document(), litellm.Text("summarize"))},
+	}, false)
+	if err != nil {
+		t.Fatalf("buildRequest returned error: %v", err)
+	}
+	data, _ := json.Marshal(wire.Messages)
+	if !strings.Contains(string(data), `"file":{"file_id":"file-abc"}`) {
+		t.Fatalf("messages = %s", data)
+	}
+	if err := provider.DeleteFile(context.Background(), "file-abc"); err != nil {
+		t.Fatalf("DeleteFile returned error: %v", err)
+	}
+
+	_, err = provider.buildRequest(&litellm.Request{
+		Model:    "gpt-4.1",
+		Messages: []litellm.Message{litellm.User(litellm.DocumentBlock{FileURI: "file_011anthropic"})},
+	}, false)
+	if err == nil || !strings.Contains(err.Error(), "belongs to anthropic") {
+		t.Fatalf("expected foreign file error, got %v", err)
+	}
+}
@@ -56,6 +56,13 @@ func (p *Provider) buildRequest(req *litellm.Request, stream bool) (*chatRequest
 		}
 		out.ResponseFormat = converted
 	}
+	if req.WebSearch != nil {
+		options, err := webSearchOptions(req.WebSearch)
+		if err != nil {
+			return nil, err
+		}
+		out.WebSearchOptions = options
+	}
 	if len(req.ProviderOptions) > 0 {
 		if err := applyProviderOptions(out, req.ProviderOptions); err != nil {
 			return nil, err
@@ -76,6 +83,38 @@ func (p *Provider) buildRequest(req *litellm.Request, stream bool) (*chatRequest
 	return out, nil
 }
 
+// webSearchOptions maps WebSearch to Chat Completions' web_search_options,
+// which only accepts a user location; domain filters and use limits need
+// the Responses API.
+func webSearchOptions(search *litellm.WebSearch) (map[string]any, error) {
+	if search.MaxUses > 0 || len(search.AllowedDomains) > 0 || len(search.BlockedDomains) > 0 {
+		return nil, fmt.Errorf("openai: chat web search only supports user location; use the responses API for domain filters and max uses")
+	}
+	out := map[string]any{}
+	if loc := approximateLocation(search.UserLocation); loc != nil {
+		out["user_location"] = map[string]any{"type": "approximate", "approximate": loc}
+	}
+	return out, nil
+}
+
+func approximateLocation(loc *litellm.UserLocation) map[string]any {
+	if loc.IsZero() {
+		return nil
+	}
+	out := map[string]any{}
+	for key, value := range map[string]string{
+		"city":     loc.City,
+		"region":   loc.Region,
+		"country":  loc.Country,
+		"timezone": loc.Timezone,
+	} {
+		if value != "" {
+			out[key] = value
+		}
+	}
+	return out
+}
+
 func (p *Provider) isReasoningModel(model string) bool {
 	model = strings.ToLower(strings.TrimSpace(model))
 	if _, after, ok := strings.Cut(model, "/"); ok {
@@ -106,13 +145,14 @@ func convertMessages(messages []litellm.Message) ([]chatMessage, error) {
 		converted := chatMessage{Role: string(msg.Role)}
 		switch msg.Role {
 		case litellm.RoleSystem, litellm.RoleUser, litellm.RoleAssistant:
-			content, toolCalls, reasoningContent, err := convertMessageBlocks(msg.Blocks)
+			content, toolCalls, reasoningContent, audio, err := convertMessageBlocks(msg.Blocks)
 			if err != nil {
 				return nil, fmt.Errorf("openai: messages[%d]: %w", i, err)
 			}
 			converted.Content = content
 			converted.ToolCalls = toolCalls
 			converted.ReasoningContent = reasoningContent
+			converted.Audio = audio
 		case litellm.RoleTool:
 			toolMessages, err := convertToolMessage(msg.Blocks)
 			if err != nil {
@@ -128,11 +168,12 @@ func convertMessages(messages []litellm.Message) ([]chatMessage, error) {
 	return out, nil
 }
 
-func convertMessageBlocks(blocks []litellm.Block) (any, []toolCall, string, error) {
+func convertMessageBlocks(blocks []litellm.Block) (any, []toolCall, string, *audioRef, error) {
 	parts := make([]contentPart, 0, len(blocks))
 	var text strings.Builder
 	var toolCalls []toolCall
 	var reasoning strings.Builder
+	var audio *audioRef
 	for _, block := range blocks {
 		switch b := block.(type) {
 		case litellm.TextBlock:
@@ -147,7 +188,7 @@ func convertMessageBlocks(blocks []litellm.Block) (any, []toolCall, string, erro
 		case litellm.ImageBlock:
 			url, err := imageURLValue(b)
 			if err != nil {
-				return nil, nil, "", err
+				return nil, nil, "", nil, err
 			}
 			parts = append(parts, contentPart{
 				Type: "image_url",
@@ -156,6 +197,35 @@ func convertMessageBlocks(blocks []litellm.Block) (any, []toolCall, string, erro
 					Detail: b.Detail,
 				},
 			})
+		case litellm.DocumentBlock:
+			file, err := chatFilePart(b)
+			if err != nil {
+				return nil, nil, "", nil, err
+			}
+			parts = append(parts, contentPart{Type: "file", File: file})
+		case litellm.AudioBlock:
+			parts = append(parts, contentPart{
+				Type: "input_audio",
+				InputAudio: &inputAudio{
+					Data:   base64.StdEncoding.EncodeToString(b.Data),
+					Format: b.Format,
+				},
+			})
+		case litellm.AudioOutputBlock:
+			// Chat references earlier audio replies by id; without one the
+			// transcript is the only replayable content.
+			if b.ID != "" {
+				audio = &audioRef{ID: b.ID}
+				continue
+			}
+			if b.Transcript == "" {
+				return nil, nil, "", nil, fmt.Errorf("OpenAI Chat assistant audio requires an id or transcript")
+			}
+			parts = append(parts, contentPart{Type: "text", Text: b.Transcript})
+			if text.Len() > 0 {
+				text.WriteString("\n")
+			}
+			text.WriteString(b.Transcript)
 		case litellm.ToolUseBlock:
 			toolCalls = append(toolCalls, toolCall{
 				ID:   b.ID,
@@ -167,7 +237,7 @@ func convertMessageBlocks(blocks []litellm.Block) (any, []toolCall, string, erro
 			})
 		case litellm.ReasoningBlock:
 			if b.Signature != "" || len(b.Redacted) > 0 || len(b.Extra) > 0 {
-				return nil, nil, "", fmt.Errorf("OpenAI Chat does not accept signed, redacted, or provider-extra reasoning blocks in message history")
+				return nil, nil, "", nil, fmt.Errorf("OpenAI Chat does not accept signed, redacted, or provider-extra reasoning blocks in message history")
 			}
 			if b.Text != "" {
 				if reasoning.Len() > 0 {
@@ -176,17 +246,17 @@ func convertMessageBlocks(blocks []litellm.Block) (any, []toolCall, string, erro
 				reasoning.WriteString(b.Text)
 			}
 		default:
-			return nil, nil, "", fmt.Errorf("unsupported block %T", block)
+			return nil, nil, "", nil, fmt.Errorf("unsupported block %T", block)
 		}
 	}
 	reasoningText := reasoning.String()
 	if len(parts) == 0 {
-		return nil, toolCalls, reasoningText, nil
+		return nil, toolCalls, reasoningText, audio, nil
 	}
 	if len(parts) == 1 && parts[0].Type == "text" {
-		return text.String(), toolCalls, reasoningText, nil
+		return text.String(), toolCalls, reasoningText, audio, nil
 	}
-	return parts, toolCalls, reasoningText, nil
+	return parts, toolCalls, reasoningText, audio, nil
 }
 
 func convertToolMessage(blocks []litellm.Block) ([]chatMessage, error) {
@@ -235,18 +305,52 @@ func imageURLValue(block litellm.ImageBlock) (string, error) {
 		}
 		return "data:" + block.MIME + ";base64," + base64.StdEncoding.EncodeToString(block.Data), nil
 	case block.FileURI != "":
-		return block.FileURI, nil
+		return "", fmt.Errorf("OpenAI Chat images cannot reference uploaded files; use the Responses API")
 	default:
 		return "", fmt.Errorf("image requires URL, data, or file URI")
 	}
 }
 
+func chatFilePart(block litellm.DocumentBlock) (*filePart, error) {
+	if block.Citations {
+		return nil, fmt.Errorf("OpenAI does not support document citations")
+	}
+	switch {
+	case block.FileURI != "":
+		id, err := fileID(block.FileURI)
+		if err != nil {
+			return nil, err
+		}
+		return &filePart{FileID: id}, nil
+	case len(block.Data) > 0:
+		return &filePart{Filename: documentFilename(block), FileData: documentDataURL(block)}, nil
+	case strings.HasPrefix(block.URL, "data:"):
+		return &filePart{Filename: documentFilename(block), FileData: block.URL}, nil
+	default:
+		return nil, fmt.Errorf("OpenAI Chat document URLs must be data URLs; use the Responses API for remote files")
+	}
+}
+
+func documentDataURL(block litellm.DocumentBlock) string {
+	return "data:" + block.MIME + ";base64," + base64.StdEncoding.EncodeToString(block.Data)
+}
+
+func documentFilename(block litellm.DocumentBlock) string {
+	if block.Title != "" {
+		return block.Title
+	}
+	return "document"
+}
+
 func convertTools(tools []litellm.Tool) ([]tool, error) {
 	out := make([]tool, 0, len(tools))
 	for _, t := range tools {
 		if t.Name == "" {
 			return nil, fmt.Errorf("openai: tool name is required")
 		}
+		if t.Server != nil {
+			return nil, fmt.Errorf("openai: server tool %q is not supported by chat completions; use the Responses API", t.Name)
+		}
 		var params any = map[string]any{"type": "object"}
 		if len(t.Parameters) > 0 {
 			var decoded any
@@ -1,8 +1,10 @@
 package openai
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/voocel/litellm"
 )
@@ -21,9 +23,17 @@ func convertResponse(resp *chatResponse, req *litellm.Request) (*litellm.Respons
 		out.Model = req.Model
 		out.Usage.Model = req.Model
 	}
+	for _, result := range resp.PromptFilterResults {
+		if safety, ok := convertContentFilter(litellm.SafetyTargetPrompt, result.ContentFilterResults); ok {
+			out.Safety = append(out.Safety, safety)
+		}
+	}
 	if len(resp.Choices) == 0 {
 		return out, nil
 	}
+	if safety, ok := convertContentFilter(litellm.SafetyTargetOutput, resp.Choices[0].ContentFilterResults); ok {
+		out.Safety = append(out.Safety, safety)
+	}
 	choice := resp.Choices[0]
 	out.FinishReason = litellm.NormalizeFinishReason(choice.FinishReason)
 	out.FinishReasonRaw = choice.FinishReason
@@ -34,7 +44,17 @@ func convertResponse(resp *chatResponse, req *litellm.Request) (*litellm.Respons
 	if err != nil {
 		return nil, err
 	}
+	if err := annotateText(blocks, choice.Message.Annotations); err != nil {
+		return nil, err
+	}
 	out.Blocks = append(out.Blocks, blocks...)
+	if audio := choice.Message.Audio; audio != nil {
+		block, err := convertAudio(audio, requestedAudioFormat(req))
+		if err != nil {
+			return nil, err
+		}
+		out.Blocks = append(out.Blocks, block)
+	}
 	if choice.Message.Refusal != "" {
 		out.Refusal = choice.Message.Refusal
 		out.Blocks = append(out.Blocks, litellm.Text(choice.Message.Refusal))
@@ -92,7 +112,7 @@ func convertContent(raw json.RawMessage) ([]litellm.Block, error) {
 		case "text":
 			text, _ := part["text"].(string)
 			if text != "" {
-				annotations, err := annotations(part)
+				annotations, err := annotations(part, text)
 				if err != nil {
 					return nil, err
 				}
@@ -117,7 +137,7 @@ func convertContent(raw json.RawMessage) ([]litellm.Block, error) {
 	return blocks, nil
 }
 
-func annotations(part map[string]any) ([]litellm.Annotation, error) {
+func annotations(part map[string]any, text string) ([]litellm.Annotation, error) {
 	raw, ok := part["annotations"].([]any)
 	if !ok || len(raw) == 0 {
 		return nil, nil
@@ -128,19 +148,75 @@ func annotations(part map[string]any) ([]litellm.Annotation, error) {
 		if !ok {
 			continue
 		}
-		extra, err := marshalRaw(data)
+		ann, err := convertAnnotation(data, text)
 		if err != nil {
-			return nil, fmt.Errorf("openai: marshal annotation: %w", err)
+			return nil, err
 		}
-		ann := litellm.Annotation{Extra: extra}
-		ann.Type, _ = data["type"].(string)
-		ann.Text, _ = data["text"].(string)
-		ann.URL, _ = data["url"].(string)
 		out = append(out, ann)
 	}
 	return out, nil
 }
 
+// convertAnnotation reads a chat annotation, nested as
+// {"type":"url_citation","url_citation":{...}}, or a flat Responses API one.
+// Character indexes become byte offsets into text. File citations map to
+// AnnotationDocumentCitation; a file_citation marks a point, so its Start
+// equals its End.
+func convertAnnotation(raw map[string]any, text string) (litellm.Annotation, error) {
+	extra, err := marshalRaw(raw)
+	if err != nil {
+		return litellm.Annotation{}, fmt.Errorf("openai: marshal annotation: %w", err)
+	}
+	ann := litellm.Annotation{Extra: extra}
+	ann.Type, _ = raw["type"].(string)
+	fields := raw
+	if nested, ok := raw[ann.Type].(map[string]any); ok {
+		fields = nested
+	}
+	ann.Text, _ = fields["text"].(string)
+	ann.URL, _ = fields["url"].(string)
+	ann.Title, _ = fields["title"].(string)
+	start, _ := fields["start_index"].(float64)
+	end, _ := fields["end_index"].(float64)
+	switch ann.Type {
+	case "file_citation":
+		ann.Type = litellm.AnnotationDocumentCitation
+		ann.Title, _ = fields["filename"].(string)
+		if index, ok := fields["index"].(float64); ok {
+			start, end = index, index
+		}
+	case "container_file_citation":
+		ann.Type = litellm.AnnotationDocumentCitation
+		ann.Title, _ = fields["filename"].(string)
+	}
+	if end > 0 && end >= start {
+		ann.Start = litellm.ByteOffset(text, int(start))
+		ann.End = litellm.ByteOffset(text, int(end))
+	}
+	return ann, nil
+}
+
+// annotateText attaches message-level annotations, which index into the
+// message text, to its first text block.
+func annotateText(blocks []litellm.Block, raw []map[string]any) error {
+	for i, block := range blocks {
+		text, ok := block.(litellm.TextBlock)
+		if !ok {
+			continue
+		}
+		for _, entry := range raw {
+			ann, err := convertAnnotation(entry, text.Text)
+			if err != nil {
+				return err
+			}
+			text.Annotations = append(text.Annotations, ann)
+		}
+		blocks[i] = text
+		return nil
+	}
+	return nil
+}
+
 func rawField(part map[string]any, key string) (json.RawMessage, error) {
 	value, ok := part[key]
 	if !ok {
@@ -181,6 +257,71 @@ func extractDeltaReasoning(delta delta) (string, bool) {
 	return delta.ReasoningContent, false
 }
 
+func convertAudio(audio *messageAudio, format string) (litellm.AudioOutputBlock, error) {
+	data, err := base64.StdEncoding.DecodeString(audio.Data)
+	if err != nil {
+		return litellm.AudioOutputBlock{}, fmt.Errorf("openai: decode audio data: %w", err)
+	}
+	return litellm.AudioOutputBlock{
+		ID:         audio.ID,
+		Data:       data,
+		Format:     format,
+		Transcript: audio.Transcript,
+		ExpiresAt:  audio.ExpiresAt,
+	}, nil
+}
+
+// requestedAudioFormat reads the output format from the audio provider option;
+// OpenAI does not echo it in the response.
+func requestedAudioFormat(req *litellm.Request) string {
+	if req == nil {
+		return ""
+	}
+	audio, _ := req.ProviderOptions[ProviderOptionAudio].(map[string]any)
+	format, _ := audio["format"].(string)
+	return format
+}
+
 func thinkingEnabled(req *litellm.Request) bool {
 	return req == nil || req.Thinking == nil || req.Thinking.Mode != litellm.ThinkingDisabled
 }
+
+// convertContentFilter reads Azure OpenAI content_filter_results: an object
+// keyed by category whose entries carry filtered and either severity or
+// detected. Entries that are not categories, such as a filtering error, are
+// skipped.
+func convertContentFilter(target litellm.SafetyTarget, raw json.RawMessage) (litellm.SafetyAssessment, bool) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return litellm.SafetyAssessment{}, false
+	}
+	var results map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &results); err != nil || len(results) == 0 {
+		return litellm.SafetyAssessment{}, false
+	}
+	out := litellm.SafetyAssessment{Target: target, Raw: append(json.RawMessage(nil), raw...)}
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var result struct {
+			Filtered *bool  `json:"filtered"`
+			Severity string `json:"severity"`
+			Detected bool   `json:"detected"`
+		}
+		if err := json.Unmarshal(results[name], &result); err != nil || result.Filtered == nil {
+			continue
+		}
+		out.Categories = append(out.Categories, litellm.SafetyCategory{
+			Name:     name,
+			Severity: result.Severity,
+			Filtered: *result.Filtered,
+			Detected: result.Detected,
+		})
+		if *result.Filtered {
+			out.Blocked = true
+		}
+	}
+	return out, true
+}
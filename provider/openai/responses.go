@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -37,6 +38,7 @@ type ResponsesRequest struct {
 
 	Tools             []litellm.Tool
 	OpenAITools       []ResponsesTool
+	WebSearch         *litellm.WebSearch
 	ToolChoice        any
 	ParallelToolCalls *bool
 
@@ -79,6 +81,7 @@ func responsesRequestFromChat(req *litellm.Request) *ResponsesRequest {
 		Temperature:        req.Temperature,
 		TopP:               req.TopP,
 		Tools:              append([]litellm.Tool(nil), req.Tools...),
+		WebSearch:          req.WebSearch,
 		ToolChoice:         req.ToolChoice,
 		ResponseFormat:     req.ResponseFormat,
 		Thinking:           req.Thinking,
@@ -196,6 +199,8 @@ type responsesInputItem struct {
 	Status           string                 `json:"status,omitempty"`
 	Summary          []responsesSummaryItem `json:"summary,omitempty"`
 	EncryptedContent string                 `json:"encrypted_content,omitempty"`
+	Result           string                 `json:"result,omitempty"`
+	OutputFormat     string                 `json:"output_format,omitempty"`
 	Raw              json.RawMessage        `json:"-"`
 }
 
@@ -212,6 +217,10 @@ type responsesContentItem struct {
 	Text        string                   `json:"text,omitempty"`
 	Refusal     string                   `json:"refusal,omitempty"`
 	ImageURL    *responsesImageURL       `json:"image_url,omitempty"`
+	FileID      string                   `json:"file_id,omitempty"`
+	FileURL     string                   `json:"file_url,omitempty"`
+	Filename    string                   `json:"filename,omitempty"`
+	FileData    string                   `json:"file_data,omitempty"`
 	Annotations []map[string]interface{} `json:"annotations,omitempty"`
 	Logprobs    []map[string]interface{} `json:"logprobs,omitempty"`
 }
@@ -269,6 +278,8 @@ type responsesOutputItem struct {
 	Summary          []responsesSummaryItem `json:"summary,omitempty"`
 	Status           string                 `json:"status,omitempty"`
 	EncryptedContent string                 `json:"encrypted_content,omitempty"`
+	Result           string                 `json:"result,omitempty"`
+	OutputFormat     string                 `json:"output_format,omitempty"`
 	Raw              json.RawMessage        `json:"-"`
 }
 
@@ -477,6 +488,13 @@ func (p *Provider) buildResponsesRequest(req *ResponsesRequest, stream bool) (*r
 	if err != nil {
 		return nil, err
 	}
+	if effective.WebSearch != nil {
+		tool, err := responsesWebSearchTool(effective.WebSearch)
+		if err != nil {
+			return nil, err
+		}
+		tools = append(tools, tool)
+	}
 	out.Tools = tools
 	return out, nil
 }
@@ -719,11 +737,25 @@ func responsesContent(blocks []litellm.Block, textType string) ([]responsesConte
 				items = append(items, responsesContentItem{Type: textType, Text: b.Text})
 			}
 		case litellm.ImageBlock:
+			if b.FileURI != "" {
+				id, err := fileID(b.FileURI)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, responsesContentItem{Type: "input_image", FileID: id})
+				continue
+			}
 			url, err := imageURLValue(b)
 			if err != nil {
 				return nil, err
 			}
 			items = append(items, responsesContentItem{Type: "input_image", ImageURL: &responsesImageURL{URL: url, Detail: b.Detail}})
+		case litellm.DocumentBlock:
+			item, err := responsesFileItem(b)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
 		case litellm.ToolUseBlock:
 			continue
 		case litellm.ReasoningBlock:
@@ -735,6 +767,30 @@ func responsesContent(blocks []litellm.Block, textType string) ([]responsesConte
 	return items, nil
 }
 
+func responsesFileItem(block litellm.DocumentBlock) (responsesContentItem, error) {
+	if block.Citations {
+		return responsesContentItem{}, fmt.Errorf("OpenAI does not support document citations")
+	}
+	item := responsesContentItem{Type: "input_file"}
+	switch {
+	case block.FileURI != "":
+		id, err := fileID(block.FileURI)
+		if err != nil {
+			return responsesContentItem{}, err
+		}
+		item.FileID = id
+	case len(block.Data) > 0:
+		item.Filename = documentFilename(block)
+		item.FileData = documentDataURL(block)
+	case strings.HasPrefix(block.URL, "data:"):
+		item.Filename = documentFilename(block)
+		item.FileData = block.URL
+	default:
+		item.FileURL = block.URL
+	}
+	return item, nil
+}
+
 func responsesReasoningInputItem(block litellm.ReasoningBlock) (responsesInputItem, error) {
 	if len(block.Redacted) > 0 || block.Signature != "" {
 		return responsesInputItem{}, fmt.Errorf("OpenAI Responses reasoning blocks only support text summary or provider extra state")
@@ -852,6 +908,17 @@ func responsesReasoningConfig(req *ResponsesRequest) (*responsesReasoning, error
 func responsesTools(tools []litellm.Tool, hosted []ResponsesTool) ([]responsesToolWire, error) {
 	out := make([]responsesToolWire, 0, len(tools)+len(hosted))
 	for _, t := range tools {
+		if t.Server != nil {
+			// Hosted Responses tools take their type and settings at the top
+			// level, e.g. {"type":"web_search","search_context_size":"low"}.
+			raw := cloneMapAny(t.Server.Config)
+			if raw == nil {
+				raw = map[string]any{}
+			}
+			raw["type"] = t.Server.Type
+			out = append(out, responsesToolWire{Raw: raw})
+			continue
+		}
 		var params any = map[string]any{"type": "object"}
 		if len(t.Parameters) > 0 {
 			if err := json.Unmarshal(t.Parameters, &params); err != nil {
@@ -885,6 +952,26 @@ func responsesTools(tools []litellm.Tool, hosted []ResponsesTool) ([]responsesTo
 	return out, nil
 }
 
+// responsesWebSearchTool maps WebSearch to the hosted web_search tool. The
+// tool filters by allowed domains only and has no per-tool use limit.
+func responsesWebSearchTool(search *litellm.WebSearch) (responsesToolWire, error) {
+	if search.MaxUses > 0 {
+		return responsesToolWire{}, fmt.Errorf("openai: web search max uses is not supported; use max_tool_calls")
+	}
+	if len(search.BlockedDomains) > 0 {
+		return responsesToolWire{}, fmt.Errorf("openai: web search blocked domains are not supported")
+	}
+	raw := map[string]any{"type": "web_search"}
+	if len(search.AllowedDomains) > 0 {
+		raw["filters"] = map[string]any{"allowed_domains": append([]string(nil), search.AllowedDomains...)}
+	}
+	if loc := approximateLocation(search.UserLocation); loc != nil {
+		loc["type"] = "approximate"
+		raw["user_location"] = loc
+	}
+	return responsesToolWire{Raw: raw}, nil
+}
+
 func convertResponsesResponse(resp *responsesResponse, fallbackModel string) (*litellm.Response, error) {
 	if resp == nil {
 		return nil, fmt.Errorf("openai: responses response cannot be nil")
@@ -964,6 +1051,15 @@ func convertResponsesResponse(resp *responsesResponse, fallbackModel string) (*l
 					Extra:   responsesReasoningExtra(item),
 				})
 			}
+		case "image_generation_call":
+			if item.Result == "" {
+				continue
+			}
+			data, err := base64.StdEncoding.DecodeString(item.Result)
+			if err != nil {
+				return nil, fmt.Errorf("openai: decode generated image: %w", err)
+			}
+			out.Blocks = append(out.Blocks, litellm.ImageBlock{Data: data, MIME: imageMIME(item.OutputFormat)})
 		default:
 			return nil, fmt.Errorf("openai: unsupported responses output item type %q", item.Type)
 		}
@@ -990,6 +1086,9 @@ type responsesStream struct {
 	currentEvent string
 	toolSeen     map[string]bool
 	toolIDs      map[string]string
+	// texts holds output text per output and content index, which streamed
+	// annotation character offsets index into.
+	texts        map[[2]int]*strings.Builder
 	lastSequence int
 }
 
@@ -1003,6 +1102,7 @@ func newResponsesStream(resp *http.Response, model string) *responsesStream {
 		model:    model,
 		toolSeen: make(map[string]bool),
 		toolIDs:  make(map[string]string),
+		texts:    make(map[[2]int]*strings.Builder),
 	}
 }
 
@@ -1088,7 +1188,26 @@ func (s *responsesStream) events(name string, raw json.RawMessage) ([]litellm.Ev
 		if !s.shouldEmit(delta.Sequence) {
 			return nil, nil
 		}
+		s.text(delta.OutputIndex, delta.ContentIndex).WriteString(delta.Delta)
 		return []litellm.Event{litellm.ContentDelta{Text: delta.Delta, OutputIndex: delta.OutputIndex, ContentIndex: delta.ContentIndex}}, nil
+	case "response.output_text.annotation.added":
+		var added struct {
+			Annotation   map[string]any `json:"annotation"`
+			OutputIndex  *int           `json:"output_index,omitempty"`
+			ContentIndex *int           `json:"content_index,omitempty"`
+			Sequence     int            `json:"sequence_number,omitempty"`
+		}
+		if err := json.Unmarshal(raw, &added); err != nil {
+			return nil, responsesStreamParseError("openai: parse responses annotation", err)
+		}
+		if !s.shouldEmit(added.Sequence) || added.Annotation == nil {
+			return nil, nil
+		}
+		ann, err := convertAnnotation(added.Annotation, s.text(added.OutputIndex, added.ContentIndex).String())
+		if err != nil {
+			return nil, responsesStreamParseError("openai: convert responses annotation", err)
+		}
+		return []litellm.Event{litellm.AnnotationDelta{Annotation: ann, OutputIndex: added.OutputIndex, ContentIndex: added.ContentIndex}}, nil
 	case "response.refusal.delta":
 		var delta struct {
 			Delta        string `json:"delta"`
@@ -1203,6 +1322,44 @@ func (s *responsesStream) events(name string, raw json.RawMessage) ([]litellm.Ev
 			}, nil
 		}
 		return []litellm.Event{litellm.ToolUseDone{ID: s.toolID(done.ItemID), OutputIndex: done.OutputIndex, ItemID: done.ItemID}}, nil
+	case "response.image_generation_call.partial_image":
+		var partial struct {
+			ItemID       string `json:"item_id"`
+			Image        string `json:"partial_image_b64"`
+			OutputFormat string `json:"output_format,omitempty"`
+			Sequence     int    `json:"sequence_number,omitempty"`
+		}
+		if err := json.Unmarshal(raw, &partial); err != nil {
+			return nil, responsesStreamParseError("openai: parse responses partial image", err)
+		}
+		if !s.shouldEmit(partial.Sequence) {
+			return nil, nil
+		}
+		data, err := base64.StdEncoding.DecodeString(partial.Image)
+		if err != nil {
+			return nil, responsesStreamParseError("openai: decode responses partial image", err)
+		}
+		return []litellm.Event{litellm.ImageDelta{ID: partial.ItemID, Data: data, MIME: imageMIME(partial.OutputFormat), Partial: true}}, nil
+	case "response.output_item.done":
+		var done struct {
+			Item     responsesOutputItem `json:"item"`
+			Sequence int                 `json:"sequence_number,omitempty"`
+		}
+		if err := json.Unmarshal(raw, &done); err != nil {
+			return nil, responsesStreamParseError("openai: parse responses output item done", err)
+		}
+		if !s.shouldEmit(done.Sequence) {
+			return nil, nil
+		}
+		events := []litellm.Event{litellm.ProviderEvent{Name: name, Raw: raw}}
+		if done.Item.Type == "image_generation_call" && done.Item.Result != "" {
+			data, err := base64.StdEncoding.DecodeString(done.Item.Result)
+			if err != nil {
+				return nil, responsesStreamParseError("openai: decode responses generated image", err)
+			}
+			events = append(events, litellm.ImageDelta{ID: done.Item.ID, Data: data, MIME: imageMIME(done.Item.OutputFormat)})
+		}
+		return events, nil
 	case "response.completed":
 		var completed responsesCompletedEvent
 		if err := json.Unmarshal(raw, &completed); err != nil {
@@ -1261,7 +1418,6 @@ func (s *responsesStream) events(name string, raw json.RawMessage) ([]litellm.Ev
 		}
 		return []litellm.Event{litellm.ErrorEvent{Err: litellm.NewProviderError("openai", litellm.ErrorTypeProvider, "openai: stream error: "+responseErr.Error.Message)}}, nil
 	case "response.created", "response.in_progress", "response.queued",
-		"response.output_item.done",
 		"response.content_part.added", "response.content_part.done",
 		"response.output_text.done", "response.refusal.done",
 		"response.reasoning_text.done", "response.reasoning_summary_text.done",
@@ -1270,7 +1426,7 @@ func (s *responsesStream) events(name string, raw json.RawMessage) ([]litellm.Ev
 		"response.code_interpreter_call.in_progress", "response.code_interpreter_call.interpreting", "response.code_interpreter_call.completed",
 		"response.code_interpreter_call.code.delta", "response.code_interpreter_call.code.done",
 		"response.web_search_call.in_progress", "response.web_search_call.searching", "response.web_search_call.completed",
-		"response.image_generation_call.in_progress", "response.image_generation_call.generating", "response.image_generation_call.partial_image", "response.image_generation_call.completed",
+		"response.image_generation_call.in_progress", "response.image_generation_call.generating", "response.image_generation_call.completed",
 		"response.mcp_call.in_progress", "response.mcp_call.completed", "response.mcp_call.failed",
 		"response.mcp_call_arguments.delta", "response.mcp_call_arguments.done",
 		"response.mcp_list_tools.in_progress", "response.mcp_list_tools.completed", "response.mcp_list_tools.failed":
@@ -1283,6 +1439,22 @@ func (s *responsesStream) events(name string, raw json.RawMessage) ([]litellm.Ev
 	}
 }
 
+func (s *responsesStream) text(outputIndex, contentIndex *int) *strings.Builder {
+	var key [2]int
+	if outputIndex != nil {
+		key[0] = *outputIndex
+	}
+	if contentIndex != nil {
+		key[1] = *contentIndex
+	}
+	text := s.texts[key]
+	if text == nil {
+		text = &strings.Builder{}
+		s.texts[key] = text
+	}
+	return text
+}
+
 func (s *responsesStream) shouldEmit(sequence int) bool {
 	if sequence == 0 {
 		return true
@@ -1366,14 +1538,10 @@ func responseAnnotations(item responsesContentItem) ([]litellm.Annotation, error
 	}
 	out := make([]litellm.Annotation, 0, len(item.Annotations))
 	for _, raw := range item.Annotations {
-		extra, err := marshalRaw(raw)
+		ann, err := convertAnnotation(raw, item.Text)
 		if err != nil {
-			return nil, fmt.Errorf("openai: marshal response annotation: %w", err)
+			return nil, err
 		}
-		ann := litellm.Annotation{Extra: extra}
-		ann.Type, _ = raw["type"].(string)
-		ann.Text, _ = raw["text"].(string)
-		ann.URL, _ = raw["url"].(string)
 		out = append(out, ann)
 	}
 	return out, nil
@@ -87,6 +87,38 @@ func TestResponsesBuildRequestMapsCoreFields(t *testing.T) {
 	}
 }
 
+func TestBuildResponsesRequestMapsWebSearch(t *testing.T) {
+	provider := mustProvider(t)
+	wire, err := provider.buildResponsesRequest(responsesRequestFromChat(&litellm.Request{
+		Model:    "gpt-5.1",
+		Messages: []litellm.Message{litellm.UserText("news?")},
+		WebSearch: &litellm.WebSearch{
+			AllowedDomains: []string{"go.dev"},
+			UserLocation:   &litellm.UserLocation{Country: "US", Timezone: "America/New_York"},
+		},
+	}), false)
+	if err != nil {
+		t.Fatalf("buildResponsesRequest: %v", err)
+	}
+	data, err := json.Marshal(wire.Tools)
+	if err != nil {
+		t.Fatalf("marshal tools: %v", err)
+	}
+	want := `[{"filters":{"allowed_domains":["go.dev"]},"type":"web_search","user_location":{"country":"US","timezone":"America/New_York","type":"approximate"}}]`
+	if string(data) != want {
+		t.Fatalf("tools = %s, want %s", data, want)
+	}
+
+	_, err = provider.buildResponsesRequest(&ResponsesRequest{
+		Model:     "gpt-5.1",
+		Input:     "news?",
+		WebSearch: &litellm.WebSearch{BlockedDomains: []string{"example.com"}},
+	}, false)
+	if err == nil || !strings.Contains(err.Error(), "blocked domains are not supported") {
+		t.Fatalf("expected blocked domains error, got %v", err)
+	}
+}
+
 func TestResponsesAcceptsNativeInputWithoutMessages(t *testing.T) {
 	provider := mustProvider(t)
 	wire, err := provider.buildResponsesRequest(&ResponsesRequest{
@@ -268,6 +300,35 @@ func TestResponsesTextFormatUsesResponsesJSONSchemaShape(t *testing.T) {
 	}
 }
 
+func TestResponsesBuildRequestEncodesDocumentBlocks(t *testing.T) {
+	provider := mustProvider(t)
+	wire, err := provider.buildResponsesRequest(&ResponsesRequest{
+		Model: "gpt-5.1",
+		Messages: []litellm.Message{litellm.User(
+			litellm.DocumentURL("https://example.test/a.pdf"),
+			litellm.DocumentData([]byte("%PDF"), "application/pdf"),
+			litellm.DocumentBlock{FileURI: "file-abc"},
+		)},
+	}, false)
+	if err != nil {
+		t.Fatalf("buildResponsesRequest returned error: %v", err)
+	}
+	data, err := json.Marshal(wire)
+	if err != nil {
+		t.Fatalf("marshal wire: %v", err)
+	}
+	jsonText := string(data)
+	for _, want := range []string{
+		`{"type":"input_file","file_url":"https://example.test/a.pdf"}`,
+		`{"type":"input_file","filename":"document","file_data":"data:application/pdf;base64,JVBERg=="}`,
+		`{"type":"input_file","file_id":"file-abc"}`,
+	} {
+		if !strings.Contains(jsonText, want) {
+			t.Fatalf("wire JSON missing %s:\n%s", want, jsonText)
+		}
+	}
+}
+
 func TestResponsesBuildRequestDeepClonesNativeMaps(t *testing.T) {
 	provider := mustProvider(t)
 	req := &ResponsesRequest{
@@ -794,6 +855,22 @@ func TestResponsesRejectsUnsupportedOutputItem(t *testing.T) {
 	}
 }
 
+func TestResponsesConvertsGeneratedImage(t *testing.T) {
+	resp, err := convertResponsesResponse(&responsesResponse{
+		Model: "gpt-5.1",
+		Output: []responsesOutputItem{
+			{Type: "image_generation_call", ID: "ig_1", Status: "completed", Result: "UklGRg=="},
+		},
+	}, "")
+	if err != nil {
+		t.Fatalf("convertResponsesResponse: %v", err)
+	}
+	image, ok := resp.Blocks[0].(litellm.ImageBlock)
+	if len(resp.Blocks) != 1 || !ok || string(image.Data) != "RIFF" || image.MIME != "image/png" {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+}
+
 func TestResponsesRejectsUnsupportedContentItem(t *testing.T) {
 	_, err := convertResponsesResponse(&responsesResponse{
 		Model: "gpt-5.1",
@@ -945,6 +1022,81 @@ func TestResponsesStreamCollectsTypedEvents(t *testing.T) {
 	}
 }
 
+func TestResponsesStreamReplacesPartialGeneratedImage(t *testing.T) {
+	body := `event: response.image_generation_call.partial_image
+data: {"type":"response.image_generation_call.partial_image","item_id":"ig_1","output_index":0,"partial_image_index":0,"partial_image_b64":"cA==","sequence_number":1}
+
+event: response.output_item.done
+data: {"type":"response.output_item.done","output_index":0,"item":{"id":"ig_1","type":"image_generation_call","status":"completed","output_format":"jpeg","result":"UklGRg=="},"sequence_number":2}
+
+event: response.completed
+data: {"type":"response.completed","response":{"model":"gpt-5.1","status":"completed","usage":{"input_tokens":1,"output_tokens":2}},"sequence_number":3}
+
+`
+	stream := newResponsesStream(streamResponse(body), "gpt-5.1")
+	var partial bool
+	var events []litellm.Event
+	for {
+		event, err := stream.Next()
+		if err != nil {
+			t.Fatalf("Next: %v", err)
+		}
+		events = append(events, event)
+		if image, ok := event.(litellm.ImageDelta); ok && image.Partial {
+			partial = true
+		}
+		if _, ok := event.(litellm.DoneEvent); ok {
+			break
+		}
+	}
+	if !partial {
+		t.Fatalf("expected partial image event, got %#v", events)
+	}
+	resp, err := litellm.Collect(&sliceStream{events: events})
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	image, ok := resp.Blocks[0].(litellm.ImageBlock)
+	if len(resp.Blocks) != 1 || !ok || string(image.Data) != "RIFF" || image.MIME != "image/jpeg" {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+}
+
+func TestResponsesStreamCollectsAnnotations(t *testing.T) {
+	body := `event: response.output_text.delta
+data: {"type":"response.output_text.delta","item_id":"msg_1","output_index":1,"content_index":0,"delta":"Read the ","sequence_number":1}
+
+event: response.output_text.delta
+data: {"type":"response.output_text.delta","item_id":"msg_1","output_index":1,"content_index":0,"delta":"naïve guide.","sequence_number":2}
+
+event: response.output_text.annotation.added
+data: {"type":"response.output_text.annotation.added","item_id":"msg_1","output_index":1,"content_index":0,"annotation_index":0,"annotation":{"type":"url_citation","start_index":9,"end_index":20,"url":"https://example.com/guide","title":"Guide"},"sequence_number":3}
+
+event: response.output_text.annotation.added
+data: {"type":"response.output_text.annotation.added","item_id":"msg_1","output_index":1,"content_index":0,"annotation_index":1,"annotation":{"type":"file_citation","file_id":"file_1","filename":"guide.pdf","index":21},"sequence_number":4}
+
+event: response.completed
+data: {"type":"response.completed","response":{"model":"gpt-5.1","status":"completed","usage":{"input_tokens":1,"output_tokens":2}},"sequence_number":5}
+
+`
+	resp, err := litellm.Collect(newResponsesStream(streamResponse(body), "gpt-5.1"))
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	block, ok := resp.Blocks[0].(litellm.TextBlock)
+	if len(resp.Blocks) != 1 || !ok || len(block.Annotations) != 2 {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	url := block.Annotations[0]
+	if url.Type != litellm.AnnotationURLCitation || url.Title != "Guide" || block.Text[url.Start:url.End] != "naïve guide" {
+		t.Fatalf("url annotation = %+v", url)
+	}
+	file := block.Annotations[1]
+	if file.Type != litellm.AnnotationDocumentCitation || file.Title != "guide.pdf" || file.Start != len(block.Text) || file.End != len(block.Text) {
+		t.Fatalf("file annotation = %+v", file)
+	}
+}
+
 func TestResponsesStreamIdleTimeout(t *testing.T) {
 	provider, err := New(Config{
 		APIKey:            "test-key",
@@ -2,6 +2,7 @@ package openai
 
 import (
 	"bufio"
+	"encoding/base64"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -14,11 +15,15 @@ type stream struct {
 	resp             *http.Response
 	scanner          *bufio.Scanner
 	includeReasoning bool
+	audioFormat      string
 	pending          []litellm.Event
 	done             bool
 	model            string
 	toolIDs          map[int]string
-	finish           litellm.FinishReason
+	// texts holds each choice's streamed text, which annotation character
+	// offsets index into.
+	texts  map[int]*strings.Builder
+	finish litellm.FinishReason
 }
 
 func newStream(resp *http.Response, req *litellm.Request) *stream {
@@ -29,8 +34,10 @@ func newStream(resp *http.Response, req *litellm.Request) *stream {
 		resp:             resp,
 		scanner:          scanner,
 		includeReasoning: thinkingEnabled(req),
+		audioFormat:      requestedAudioFormat(req),
 		model:            req.Model,
 		toolIDs:          make(map[int]string),
+		texts:            make(map[int]*strings.Builder),
 	}
 }
 
@@ -66,10 +73,17 @@ func (s *stream) Next() (litellm.Event, error) {
 		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
 			return nil, litellm.NewProviderErrorWithCause("openai", litellm.ErrorTypeProvider, "openai: parse stream chunk", err)
 		}
+		if len(chunk.Error) > 0 && string(chunk.Error) != "null" {
+			s.done = true
+			return nil, litellm.NewHTTPError("openai", s.resp.StatusCode, data)
+		}
 		if chunk.Model != "" {
 			s.model = chunk.Model
 		}
-		events := s.events(chunk)
+		events, err := s.events(chunk)
+		if err != nil {
+			return nil, err
+		}
 		if len(events) == 0 {
 			continue
 		}
@@ -83,22 +97,44 @@ func (s *stream) Next() (litellm.Event, error) {
 	return nil, litellm.NewProviderError("openai", litellm.ErrorTypeProvider, "openai: stream ended before [DONE]")
 }
 
+func (s *stream) text(index int) *strings.Builder {
+	text := s.texts[index]
+	if text == nil {
+		text = &strings.Builder{}
+		s.texts[index] = text
+	}
+	return text
+}
+
 func (s *stream) Close() error {
 	return s.resp.Body.Close()
 }
 
-func (s *stream) events(chunk streamChunk) []litellm.Event {
+func (s *stream) events(chunk streamChunk) ([]litellm.Event, error) {
 	events := make([]litellm.Event, 0, 4)
+	for _, result := range chunk.PromptFilterResults {
+		if safety, ok := convertContentFilter(litellm.SafetyTargetPrompt, result.ContentFilterResults); ok {
+			events = append(events, litellm.SafetyEvent{Safety: safety})
+		}
+	}
 	if chunk.Usage != nil {
 		events = append(events, litellm.UsageEvent{Usage: convertUsage(chunk.Usage, s.model)})
 	}
 	for _, choice := range chunk.Choices {
 		if choice.Delta.Content != "" {
+			s.text(choice.Index).WriteString(choice.Delta.Content)
 			events = append(events, litellm.ContentDelta{
 				Text:        choice.Delta.Content,
 				OutputIndex: litellm.IntPtr(choice.Index),
 			})
 		}
+		for _, raw := range choice.Delta.Annotations {
+			ann, err := convertAnnotation(raw, s.text(choice.Index).String())
+			if err != nil {
+				return nil, litellm.NewProviderErrorWithCause("openai", litellm.ErrorTypeProvider, "openai: convert stream annotation", err)
+			}
+			events = append(events, litellm.AnnotationDelta{Annotation: ann, OutputIndex: litellm.IntPtr(choice.Index)})
+		}
 		if choice.Delta.Refusal != "" {
 			events = append(events, litellm.RefusalDelta{
 				Text:        choice.Delta.Refusal,
@@ -114,6 +150,19 @@ func (s *stream) events(chunk streamChunk) []litellm.Event {
 				})
 			}
 		}
+		if audio := choice.Delta.Audio; audio != nil {
+			data, err := base64.StdEncoding.DecodeString(audio.Data)
+			if err != nil {
+				return nil, litellm.NewProviderErrorWithCause("openai", litellm.ErrorTypeProvider, "openai: decode stream audio", err)
+			}
+			events = append(events, litellm.AudioDelta{
+				ID:         audio.ID,
+				Data:       data,
+				Format:     s.audioFormat,
+				Transcript: audio.Transcript,
+				ExpiresAt:  audio.ExpiresAt,
+			})
+		}
 		for _, call := range choice.Delta.ToolCalls {
 			index := call.Index
 			id := call.ID
@@ -141,9 +190,14 @@ func (s *stream) events(chunk streamChunk) []litellm.Event {
 				})
 			}
 		}
+		// Azure annotates every output chunk; only assessments that filtered
+		// something are worth an event.
+		if safety, ok := convertContentFilter(litellm.SafetyTargetOutput, choice.ContentFilterResults); ok && safety.Blocked {
+			events = append(events, litellm.SafetyEvent{Safety: safety})
+		}
 		if choice.FinishReason != "" {
 			s.finish = litellm.NormalizeFinishReason(choice.FinishReason)
 		}
 	}
-	return events
+	return events, nil
 }
@@ -80,12 +80,24 @@ type chatMessage struct {
 	ToolCalls        []toolCall `json:"tool_calls,omitempty"`
 	ToolCallID       string     `json:"tool_call_id,omitempty"`
 	ReasoningContent string     `json:"reasoning_content,omitempty"`
+	Audio            *audioRef  `json:"audio,omitempty"`
+}
+
+type audioRef struct {
+	ID string `json:"id"`
 }
 
 type contentPart struct {
-	Type     string    `json:"type"`
-	Text     string    `json:"text,omitempty"`
-	ImageURL *imageURL `json:"image_url,omitempty"`
+	Type       string      `json:"type"`
+	Text       string      `json:"text,omitempty"`
+	ImageURL   *imageURL   `json:"image_url,omitempty"`
+	File       *filePart   `json:"file,omitempty"`
+	InputAudio *inputAudio `json:"input_audio,omitempty"`
+}
+
+type inputAudio struct {
+	Data   string `json:"data"`
+	Format string `json:"format"`
 }
 
 type imageURL struct {
@@ -93,6 +105,12 @@ type imageURL struct {
 	Detail string `json:"detail,omitempty"`
 }
 
+type filePart struct {
+	FileID   string `json:"file_id,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	FileData string `json:"file_data,omitempty"`
+}
+
 type tool struct {
 	Type     string        `json:"type"`
 	Function *toolFunction `json:"function,omitempty"`
@@ -129,12 +147,20 @@ type toolCallFuncDelta struct {
 }
 
 type chatResponse struct {
-	ID      string   `json:"id"`
-	Object  string   `json:"object"`
-	Model   string   `json:"model"`
-	Choices []choice `json:"choices"`
-	Usage   usage    `json:"usage"`
-	Raw     json.RawMessage
+	ID                  string               `json:"id"`
+	Object              string               `json:"object"`
+	Model               string               `json:"model"`
+	Choices             []choice             `json:"choices"`
+	Usage               usage                `json:"usage"`
+	PromptFilterResults []promptFilterResult `json:"prompt_filter_results,omitempty"`
+	Raw                 json.RawMessage
+}
+
+// promptFilterResult and choice.ContentFilterResults are Azure OpenAI's
+// content filter annotations; OpenAI does not send them.
+type promptFilterResult struct {
+	PromptIndex          int             `json:"prompt_index"`
+	ContentFilterResults json.RawMessage `json:"content_filter_results,omitempty"`
 }
 
 type choice struct {
@@ -143,15 +169,26 @@ type choice struct {
 	Delta            delta             `json:"delta,omitempty"`
 	ReasoningSummary *reasoningSummary `json:"reasoning_summary,omitempty"`
 	FinishReason     string            `json:"finish_reason,omitempty"`
+
+	ContentFilterResults json.RawMessage `json:"content_filter_results,omitempty"`
 }
 
 type responseMessage struct {
-	Role             string          `json:"role"`
-	Content          json.RawMessage `json:"content,omitempty"`
-	Refusal          string          `json:"refusal,omitempty"`
-	ToolCalls        []toolCall      `json:"tool_calls,omitempty"`
-	Reasoning        string          `json:"reasoning,omitempty"`
-	ReasoningContent string          `json:"reasoning_content,omitempty"`
+	Role             string           `json:"role"`
+	Content          json.RawMessage  `json:"content,omitempty"`
+	Refusal          string           `json:"refusal,omitempty"`
+	ToolCalls        []toolCall       `json:"tool_calls,omitempty"`
+	Reasoning        string           `json:"reasoning,omitempty"`
+	ReasoningContent string           `json:"reasoning_content,omitempty"`
+	Audio            *messageAudio    `json:"audio,omitempty"`
+	Annotations      []map[string]any `json:"annotations,omitempty"`
+}
+
+type messageAudio struct {
+	ID         string `json:"id,omitempty"`
+	Data       string `json:"data,omitempty"`
+	ExpiresAt  int64  `json:"expires_at,omitempty"`
+	Transcript string `json:"transcript,omitempty"`
 }
 
 type reasoningSummary struct {
@@ -165,6 +202,8 @@ type delta struct {
 	ReasoningSummary *reasoningSummary `json:"reasoning_summary,omitempty"`
 	Reasoning        string            `json:"reasoning,omitempty"`
 	ReasoningContent string            `json:"reasoning_content,omitempty"`
+	Audio            *messageAudio     `json:"audio,omitempty"`
+	Annotations      []map[string]any  `json:"annotations,omitempty"`
 }
 
 type usage struct {
@@ -184,11 +223,13 @@ type completionTokensDetails struct {
 }
 
 type streamChunk struct {
-	ID      string   `json:"id"`
-	Object  string   `json:"object"`
-	Model   string   `json:"model"`
-	Choices []choice `json:"choices"`
-	Usage   *usage   `json:"usage,omitempty"`
+	ID                  string               `json:"id"`
+	Object              string               `json:"object"`
+	Model               string               `json:"model"`
+	Choices             []choice             `json:"choices"`
+	Usage               *usage               `json:"usage,omitempty"`
+	PromptFilterResults []promptFilterResult `json:"prompt_filter_results,omitempty"`
+	Error               json.RawMessage      `json:"error,omitempty"`
 }
 
 type modelList struct {
@@ -35,6 +35,7 @@ func New(cfg Config) (*compat.Provider, error) {
 		Request: compat.RequestSpec{
 			SupportsJSONSchema: true,
 			Thinking:           mapThinking,
+			WebSearch:          mapWebSearch,
 			ProviderOptions:    mapExtra,
 			Messages:           mapMessages,
 			CleanSchema:        cleanStrictSchema,
@@ -62,6 +63,7 @@ func New(cfg Config) (*compat.Provider, error) {
 			caps.Usage.CacheWriteTokens = litellm.SupportYes
 			caps.Structured.JSONSchema = litellm.SupportYes
 			caps.Structured.Strict = litellm.SupportYes
+			caps.Tools.WebSearch = litellm.SupportPartial
 			return caps
 		},
 	})
@@ -99,6 +101,22 @@ func mapThinking(thinking *litellm.Thinking, _ string) (map[string]any, error) {
 	return map[string]any{"reasoning": reasoning}, nil
 }
 
+// mapWebSearch enables OpenRouter's web plugin, which works with any model
+// and is equivalent to the ":online" model suffix.
+func mapWebSearch(search *litellm.WebSearch, model string) (map[string]any, error) {
+	switch {
+	case strings.HasSuffix(model, ":online"):
+		return nil, fmt.Errorf("openrouter: WebSearch cannot be combined with an :online model")
+	case search.MaxUses > 0:
+		return nil, fmt.Errorf("openrouter: web search max uses is not supported")
+	case len(search.AllowedDomains) > 0 || len(search.BlockedDomains) > 0:
+		return nil, fmt.Errorf("openrouter: web search domain filters are not supported")
+	case !search.UserLocation.IsZero():
+		return nil, fmt.Errorf("openrouter: web search user location is not supported")
+	}
+	return map[string]any{"plugins": []map[string]any{{"id": "web"}}}, nil
+}
+
 func reasoningEffort(effort string) (string, error) {
 	normalized := strings.ToLower(strings.TrimSpace(effort))
 	switch normalized {
@@ -134,6 +134,31 @@ func TestSessionIDProviderOption(t *testing.T) {
 	}
 }
 
+func TestWebSearchAddsWebPlugin(t *testing.T) {
+	body := captureBody(t, nil, nil, &litellm.Request{
+		Model:     "openai/gpt-5",
+		Messages:  []litellm.Message{litellm.UserText("hi")},
+		WebSearch: &litellm.WebSearch{},
+	})
+	plugins, _ := body["plugins"].([]any)
+	if len(plugins) != 1 || plugins[0].(map[string]any)["id"] != "web" {
+		t.Fatalf("plugins = %#v", body["plugins"])
+	}
+
+	p, err := New(compat.Config{APIKey: "key", BaseURL: "https://openrouter.test", HTTPClient: roundTripFunc(nil)})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	_, err = p.Chat(context.Background(), &litellm.Request{
+		Model:     "openai/gpt-5:online",
+		Messages:  []litellm.Message{litellm.UserText("hi")},
+		WebSearch: &litellm.WebSearch{},
+	})
+	if err == nil || !strings.Contains(err.Error(), ":online") {
+		t.Fatalf("expected :online conflict, got %v", err)
+	}
+}
+
 func TestBlockCacheValidation(t *testing.T) {
 	_, _, _, err := mapBlocks([]litellm.Block{
 		litellm.TextBlock{
@@ -55,12 +55,16 @@ func New(cfg Config) (*compat.Provider, error) {
 	return compat.New(cfg, compat.Spec{
 		Name: "qwen",
 		Endpoint: compat.EndpointSpec{
-			BaseURL: defaultBaseURL,
+			BaseURL:        defaultBaseURL,
+			EmbeddingsPath: "/embeddings",
 		},
 		Auth: compat.AuthSpec{APIKeyRequired: true},
 		Request: compat.RequestSpec{
 			MaxTokensField:         "max_completion_tokens",
 			Thinking:               mapThinking,
+			WebSearch:              mapWebSearch,
+			InputAudio:             true,
+			AudioDataURL:           true,
 			AllowedProviderOptions: allowedProviderOptions,
 		},
 		Response: compat.ResponseSpec{
@@ -76,6 +80,8 @@ func New(cfg Config) (*compat.Provider, error) {
 			caps.Thinking.BudgetTokens = litellm.SupportYes
 			caps.Thinking.IncludeOutput = litellm.SupportNo
 			caps.Thinking.Notes = []string{"use BudgetTokens; Effort is rejected"}
+			caps.Media.AudioOutput = litellm.SupportPartial
+			caps.Tools.WebSearch = litellm.SupportPartial
 			return caps
 		},
 	})
@@ -104,3 +110,17 @@ func mapThinking(thinking *litellm.Thinking, _ string) (map[string]any, error) {
 	}
 	return body, nil
 }
+
+// mapWebSearch turns on DashScope's built-in search. Source and strategy
+// tuning stays with ProviderOptionSearchOptions.
+func mapWebSearch(search *litellm.WebSearch, _ string) (map[string]any, error) {
+	switch {
+	case search.MaxUses > 0:
+		return nil, fmt.Errorf("qwen: web search max uses is not supported")
+	case len(search.AllowedDomains) > 0 || len(search.BlockedDomains) > 0:
+		return nil, fmt.Errorf("qwen: web search domain filters are not supported")
+	case !search.UserLocation.IsZero():
+		return nil, fmt.Errorf("qwen: web search user location is not supported")
+	}
+	return map[string]any{"enable_search": true}, nil
+}
@@ -133,6 +133,30 @@ func TestRejectsThinkingEffort(t *testing.T) {
 	}
 }
 
+func TestWebSearchEnablesSearch(t *testing.T) {
+	body := captureBody(t, &litellm.Request{
+		Model:     "qwen3.7-plus",
+		Messages:  []litellm.Message{litellm.UserText("hi")},
+		WebSearch: &litellm.WebSearch{},
+	})
+	if body["enable_search"] != true {
+		t.Fatalf("body = %#v", body)
+	}
+
+	p, err := New(compat.Config{APIKey: "key", BaseURL: "https://qwen.test", HTTPClient: roundTripFunc(nil)})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	_, err = p.Chat(context.Background(), &litellm.Request{
+		Model:     "qwen3.7-plus",
+		Messages:  []litellm.Message{litellm.UserText("hi")},
+		WebSearch: &litellm.WebSearch{AllowedDomains: []string{"example.com"}},
+	})
+	if !litellm.IsValidationError(err) || !strings.Contains(err.Error(), "domain filters") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
 func TestCapabilities(t *testing.T) {
 	p, err := New(compat.Config{APIKey: "key", BaseURL: "https://qwen.test", HTTPClient: roundTripFunc(nil)})
 	if err != nil {
@@ -147,6 +171,20 @@ func TestCapabilities(t *testing.T) {
 	}
 }
 
+func TestInputAudioUsesDataURL(t *testing.T) {
+	body := captureBody(t, &litellm.Request{
+		Model:    "qwen3-omni-flash",
+		Messages: []litellm.Message{litellm.User(litellm.Audio([]byte("RIFF"), "wav"), litellm.Text("transcribe"))},
+	})
+	messages := body["messages"].([]any)
+	parts := messages[0].(map[string]any)["content"].([]any)
+	audio := parts[0].(map[string]any)
+	input, _ := audio["input_audio"].(map[string]any)
+	if audio["type"] != "input_audio" || input["data"] != "data:;base64,UklGRg==" || input["format"] != "wav" {
+		t.Fatalf("audio part = %#v", audio)
+	}
+}
+
 func TestResponseReasoningContent(t *testing.T) {
 	p, err := New(compat.Config{
 		APIKey:  "key",
@@ -0,0 +1,219 @@
+package vertex
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/voocel/litellm"
+)
+
+const (
+	defaultTokenURI = "https://oauth2.googleapis.com/token"
+	cloudPlatform   = "https://www.googleapis.com/auth/cloud-platform"
+
+	// tokenRefreshWindow is how long before expiry a cached token is
+	// replaced.
+	tokenRefreshWindow = 5 * time.Minute
+)
+
+// Token is an OAuth2 access token. A zero Expiry means it does not expire.
+type Token struct {
+	AccessToken string
+	Expiry      time.Time
+}
+
+// TokenSource supplies access tokens. The provider caches tokens until
+// shortly before they expire, so implementations need not cache.
+type TokenSource interface {
+	Token(context.Context) (Token, error)
+}
+
+type staticToken struct {
+	token Token
+}
+
+func StaticToken(accessToken string) TokenSource {
+	return staticToken{token: Token{AccessToken: accessToken}}
+}
+
+func (s staticToken) Token(context.Context) (Token, error) {
+	return s.token, nil
+}
+
+type serviceAccountKey struct {
+	Type         string `json:"type"`
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	TokenURI     string `json:"token_uri"`
+}
+
+type serviceAccount struct {
+	email    string
+	keyID    string
+	tokenURI string
+	key      *rsa.PrivateKey
+	client   HTTPClient
+}
+
+// ServiceAccount returns a TokenSource that signs a JWT with a service
+// account JSON key and exchanges it for an access token at the key's
+// token_uri.
+func ServiceAccount(keyJSON []byte) (TokenSource, error) {
+	var key serviceAccountKey
+	if err := json.Unmarshal(keyJSON, &key); err != nil {
+		return nil, fmt.Errorf("vertex: parse service account key: %w", err)
+	}
+	if key.Type != "" && key.Type != "service_account" {
+		return nil, fmt.Errorf("vertex: credentials type %q is not a service account", key.Type)
+	}
+	if key.ClientEmail == "" {
+		return nil, fmt.Errorf("vertex: service account key has no client_email")
+	}
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("vertex: service account key has no PEM private key")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("vertex: parse service account private key: %w", err)
+	}
+	rsaKey, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("vertex: service account private key must be RSA")
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = defaultTokenURI
+	}
+	return &serviceAccount{
+		email:    key.ClientEmail,
+		keyID:    key.PrivateKeyID,
+		tokenURI: key.TokenURI,
+		key:      rsaKey,
+		client:   http.DefaultClient,
+	}, nil
+}
+
+func (s *serviceAccount) Token(ctx context.Context) (Token, error) {
+	now := time.Now()
+	assertion, err := s.assertion(now)
+	if err != nil {
+		return Token{}, err
+	}
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.tokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Token{}, fmt.Errorf("vertex: create token request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return Token{}, litellm.NewNetworkError("vertex", "token request failed", err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// The token endpoint answers 400 for bad grants; they are
+		// credential problems, not malformed requests.
+		if resp.StatusCode == http.StatusBadRequest {
+			return Token{}, litellm.NewAuthError("vertex", "token request rejected: "+string(data))
+		}
+		return Token{}, litellm.NewHTTPError("vertex", resp.StatusCode, string(data))
+	}
+	var parsed struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return Token{}, litellm.NewProviderErrorWithCause("vertex", litellm.ErrorTypeAuth, "vertex: decode token response", err)
+	}
+	if parsed.AccessToken == "" {
+		return Token{}, litellm.NewAuthError("vertex", "token response has no access_token")
+	}
+	token := Token{AccessToken: parsed.AccessToken}
+	if parsed.ExpiresIn > 0 {
+		token.Expiry = now.Add(time.Duration(parsed.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// assertion builds the RS256-signed JWT for the jwt-bearer grant.
+func (s *serviceAccount) assertion(now time.Time) (string, error) {
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	if s.keyID != "" {
+		header["kid"] = s.keyID
+	}
+	claims := map[string]any{
+		"iss":   s.email,
+		"scope": cloudPlatform,
+		"aud":   s.tokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", fmt.Errorf("vertex: marshal jwt header: %w", err)
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("vertex: marshal jwt claims: %w", err)
+	}
+	signing := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON)
+	digest := sha256.Sum256([]byte(signing))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("vertex: sign jwt: %w", err)
+	}
+	return signing + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// cachedTokenSource reuses a token until tokenRefreshWindow before it
+// expires. Concurrent callers share one refresh.
+type cachedTokenSource struct {
+	source TokenSource
+	mu     sync.Mutex
+	token  Token
+	valid  bool
+	now    func() time.Time
+}
+
+func newCachedTokenSource(source TokenSource) *cachedTokenSource {
+	return &cachedTokenSource{source: source, now: time.Now}
+}
+
+func (c *cachedTokenSource) Token(ctx context.Context) (Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.valid && (c.token.Expiry.IsZero() || c.now().Add(tokenRefreshWindow).Before(c.token.Expiry)) {
+		return c.token, nil
+	}
+	token, err := c.source.Token(ctx)
+	if err != nil {
+		return Token{}, err
+	}
+	if token.AccessToken == "" {
+		return Token{}, litellm.NewAuthError("vertex", "token source returned an empty access token")
+	}
+	c.token, c.valid = token, true
+	return token, nil
+}
@@ -0,0 +1,26 @@
+package vertex
+
+import "github.com/voocel/litellm"
+
+// Capabilities follows the adapter the model routes to. Vertex AI has no
+// file upload API: Gemini models read Cloud Storage URIs and Claude models
+// take no file references.
+func (p *Provider) Capabilities(model string) litellm.Capabilities {
+	publisher, id, err := splitModel(model)
+	if err != nil {
+		return litellm.Capabilities{Provider: p.Name(), Model: model}
+	}
+	var caps litellm.Capabilities
+	if publisher == publisherAnthropic {
+		caps = p.anthropic.Capabilities(id)
+		caps.Media.FileURI = litellm.SupportNo
+		caps.Media.DocumentFileURI = litellm.SupportNo
+	} else {
+		caps = p.gemini.Capabilities(id)
+		caps.Media.FileURI = litellm.SupportPartial
+		caps.Media.DocumentFileURI = litellm.SupportPartial
+	}
+	caps.Provider = p.Name()
+	caps.Model = model
+	return caps
+}
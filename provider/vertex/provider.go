@@ -0,0 +1,221 @@
+package vertex
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/voocel/litellm"
+	"github.com/voocel/litellm/internal/relabel"
+	"github.com/voocel/litellm/provider/anthropic"
+	"github.com/voocel/litellm/provider/gemini"
+	"github.com/voocel/litellm/retry"
+)
+
+const (
+	defaultLocation = "us-central1"
+
+	publisherGoogle    = "google"
+	publisherAnthropic = "anthropic"
+)
+
+type Config struct {
+	Project  string
+	Location string
+	BaseURL  string
+	// TokenSource supplies OAuth2 access tokens, for example
+	// ServiceAccount(keyJSON). Tokens are cached until shortly before they
+	// expire.
+	TokenSource TokenSource
+	HTTPClient  HTTPClient
+	Transport   http.RoundTripper
+	Retry       *retry.Policy
+}
+
+type HTTPClient interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// backend is the part of the gemini and anthropic adapters Vertex AI
+// routes to.
+type backend interface {
+	litellm.Provider
+	litellm.TokenCounter
+	Capabilities(model string) litellm.Capabilities
+}
+
+// Provider calls Gemini and partner models on Vertex AI. Models are routed by
+// publisher: "publishers/anthropic/models/claude-…" or a bare "claude-…" ID
+// uses the Anthropic Messages format, anything else the Gemini format.
+type Provider struct {
+	cfg       Config
+	tokens    TokenSource
+	gemini    backend
+	anthropic backend
+}
+
+func New(cfg Config) (*Provider, error) {
+	if cfg.Project == "" {
+		return nil, fmt.Errorf("vertex: project is required")
+	}
+	if cfg.TokenSource == nil {
+		return nil, fmt.Errorf("vertex: token source is required")
+	}
+	if cfg.HTTPClient != nil && cfg.Transport != nil {
+		return nil, fmt.Errorf("vertex: HTTPClient and Transport are mutually exclusive")
+	}
+	if cfg.HTTPClient != nil && cfg.Retry != nil {
+		return nil, fmt.Errorf("vertex: Retry cannot be used with a custom HTTPClient; use Transport or configure retry on the client")
+	}
+	if cfg.Location == "" {
+		cfg.Location = defaultLocation
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = fmt.Sprintf("https://%s-aiplatform.googleapis.com", cfg.Location)
+		if cfg.Location == "global" {
+			cfg.BaseURL = "https://aiplatform.googleapis.com"
+		}
+	}
+	base := cfg.Transport
+	if base == nil {
+		if cfg.HTTPClient != nil {
+			base = relabel.ClientTransport{Client: cfg.HTTPClient}
+		} else {
+			base = http.DefaultTransport
+		}
+	}
+	p := &Provider{cfg: cfg, tokens: newCachedTokenSource(cfg.TokenSource)}
+	prefix := fmt.Sprintf("/v1/projects/%s/locations/%s", cfg.Project, cfg.Location)
+	geminiProvider, err := gemini.New(gemini.Config{
+		APIKeyFunc: p.accessToken,
+		BaseURL:    cfg.BaseURL,
+		Transport:  &transport{publisher: publisherGoogle, prefix: prefix, base: base},
+		Retry:      cfg.Retry,
+	})
+	if err != nil {
+		return nil, err
+	}
+	anthropicProvider, err := anthropic.New(anthropic.Config{
+		APIKeyFunc: p.accessToken,
+		BaseURL:    cfg.BaseURL,
+		Transport:  &transport{publisher: publisherAnthropic, prefix: prefix, base: base},
+		Retry:      cfg.Retry,
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.gemini, p.anthropic = geminiProvider, anthropicProvider
+	return p, nil
+}
+
+func Factory(cfg Config) (litellm.Provider, error) {
+	return New(cfg)
+}
+
+func (p *Provider) Name() string {
+	return "vertex"
+}
+
+func (p *Provider) Chat(ctx context.Context, req *litellm.Request) (*litellm.Response, error) {
+	target, routed, err := p.route(req)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	resp, err := target.Chat(ctx, routed)
+	if err != nil {
+		return nil, relabel.Error(err, target.Name(), p.Name())
+	}
+	relabel.Response(resp, target.Name(), p.Name())
+	return resp, nil
+}
+
+func (p *Provider) Stream(ctx context.Context, req *litellm.Request) (litellm.Stream, error) {
+	target, routed, err := p.route(req)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	inner, err := target.Stream(ctx, routed)
+	if err != nil {
+		return nil, relabel.Error(err, target.Name(), p.Name())
+	}
+	return relabel.Stream(inner, target.Name(), p.Name()), nil
+}
+
+// CountTokens calls the publisher's token counting endpoint.
+func (p *Provider) CountTokens(ctx context.Context, req *litellm.Request) (*litellm.TokenCount, error) {
+	target, routed, err := p.route(req)
+	if err != nil {
+		return nil, litellm.WrapValidationError(p.Name(), err)
+	}
+	count, err := target.CountTokens(ctx, routed)
+	if err != nil {
+		return nil, relabel.Error(err, target.Name(), p.Name())
+	}
+	count.Provider = p.Name()
+	count.Model = req.Model
+	return count, nil
+}
+
+// route picks the adapter for req.Model and returns a copy of req naming
+// the bare model ID.
+func (p *Provider) route(req *litellm.Request) (backend, *litellm.Request, error) {
+	publisher, model, err := splitModel(req.Model)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkFileURIs(req.Messages); err != nil {
+		return nil, nil, err
+	}
+	routed := *req
+	routed.Model = model
+	if publisher == publisherAnthropic {
+		return p.anthropic, &routed, nil
+	}
+	return p.gemini, &routed, nil
+}
+
+func splitModel(model string) (publisher, id string, err error) {
+	if rest, ok := strings.CutPrefix(model, "publishers/"); ok {
+		publisher, id, ok = strings.Cut(rest, "/models/")
+		if !ok || id == "" {
+			return "", "", fmt.Errorf("vertex: model %q must look like publishers/{publisher}/models/{model}", model)
+		}
+		if publisher != publisherGoogle && publisher != publisherAnthropic {
+			return "", "", fmt.Errorf("vertex: publisher %q is not supported", publisher)
+		}
+		return publisher, id, nil
+	}
+	if strings.HasPrefix(model, "claude-") {
+		return publisherAnthropic, model, nil
+	}
+	return publisherGoogle, model, nil
+}
+
+// checkFileURIs rejects files uploaded to another provider's file API;
+// Vertex AI reads Cloud Storage URIs instead.
+func checkFileURIs(messages []litellm.Message) error {
+	for _, msg := range messages {
+		for _, block := range msg.Blocks {
+			var uri string
+			switch b := block.(type) {
+			case litellm.ImageBlock:
+				uri = b.FileURI
+			case litellm.DocumentBlock:
+				uri = b.FileURI
+			}
+			if owner := litellm.FileOwner(uri); owner != "" {
+				return fmt.Errorf("vertex: file %q belongs to %s", uri, owner)
+			}
+		}
+	}
+	return nil
+}
+
+func (p *Provider) accessToken(ctx context.Context) (string, error) {
+	token, err := p.tokens.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
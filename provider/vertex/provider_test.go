@@ -0,0 +1,286 @@
+package vertex
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/voocel/litellm"
+	"github.com/voocel/litellm/internal/testgolden"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type countingTokenSource struct {
+	calls atomic.Int32
+	ttl   time.Duration
+}
+
+func (s *countingTokenSource) Token(context.Context) (Token, error) {
+	n := s.calls.Add(1)
+	return Token{AccessToken: fmt.Sprintf("tok-%d", n), Expiry: time.Now().Add(s.ttl)}, nil
+}
+
+func TestChatRoutesGeminiToPublisherEndpoint(t *testing.T) {
+	tokens := &countingTokenSource{ttl: time.Hour}
+	provider, err := New(Config{
+		Project:     "proj",
+		Location:    "europe-west4",
+		TokenSource: tokens,
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Host != "europe-west4-aiplatform.googleapis.com" {
+				t.Fatalf("host = %q", req.URL.Host)
+			}
+			if want := "/v1/projects/proj/locations/europe-west4/publishers/google/models/gemini-2.5-flash:generateContent"; req.URL.Path != want {
+				t.Fatalf("path = %q, want %q", req.URL.Path, want)
+			}
+			if got := req.Header.Get("Authorization"); got != "Bearer tok-1" {
+				t.Fatalf("Authorization = %q", got)
+			}
+			if got := req.Header.Get("x-goog-api-key"); got != "" {
+				t.Fatalf("x-goog-api-key = %q, want empty", got)
+			}
+			return jsonResponse(`{"candidates":[{"content":{"role":"model","parts":[{"text":"hi"}]},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":3,"candidatesTokenCount":1,"totalTokenCount":4}}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	for range 2 {
+		resp, err := provider.Chat(context.Background(), &litellm.Request{
+			Model:    "gemini-2.5-flash",
+			Messages: []litellm.Message{litellm.UserText("hello")},
+		})
+		if err != nil {
+			t.Fatalf("Chat returned error: %v", err)
+		}
+		if resp.Text() != "hi" || resp.Provider != "vertex" || resp.Usage.Provider != "vertex" {
+			t.Fatalf("response = %+v", resp)
+		}
+	}
+	if got := tokens.calls.Load(); got != 1 {
+		t.Fatalf("token source calls = %d, want 1 (cached)", got)
+	}
+}
+
+func TestStreamRoutesClaudeThroughMessagesFormat(t *testing.T) {
+	provider, err := New(Config{
+		Project:     "proj",
+		Location:    "global",
+		TokenSource: StaticToken("tok"),
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Host != "aiplatform.googleapis.com" {
+				t.Fatalf("host = %q", req.URL.Host)
+			}
+			if want := "/v1/projects/proj/locations/global/publishers/anthropic/models/claude-sonnet-4-5@20250929:streamRawPredict"; req.URL.Path != want {
+				t.Fatalf("path = %q, want %q", req.URL.Path, want)
+			}
+			if req.Header.Get("Authorization") != "Bearer tok" || req.Header.Get("x-api-key") != "" || req.Header.Get("anthropic-version") != "" {
+				t.Fatalf("headers = %v", req.Header)
+			}
+			var body map[string]any
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body["anthropic_version"] != anthropicVersion || body["model"] != nil || body["stream"] != true {
+				t.Fatalf("body = %v", body)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(testgolden.ReadFixtureString(t, "../../testdata/anthropic/messages_stream.sse"))),
+			}, nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	stream, err := provider.Stream(context.Background(), &litellm.Request{
+		Model:     "publishers/anthropic/models/claude-sonnet-4-5@20250929",
+		Messages:  []litellm.Message{litellm.UserText("hello")},
+		MaxTokens: litellm.IntPtr(64),
+	})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	defer stream.Close()
+	var done *litellm.DoneEvent
+	for {
+		event, err := stream.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next returned error: %v", err)
+		}
+		if e, ok := event.(litellm.DoneEvent); ok {
+			done = &e
+		}
+	}
+	if done == nil || done.Provider != "vertex" {
+		t.Fatalf("done event = %+v", done)
+	}
+}
+
+func TestCountTokensUnwrapsGeminiRequest(t *testing.T) {
+	provider, err := New(Config{
+		Project:     "proj",
+		TokenSource: StaticToken("tok"),
+		HTTPClient: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			if !strings.HasSuffix(req.URL.Path, "/locations/us-central1/publishers/google/models/gemini-2.5-flash:countTokens") {
+				t.Fatalf("path = %q", req.URL.Path)
+			}
+			var body map[string]any
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body["contents"] == nil || body["generateContentRequest"] != nil || body["model"] != nil {
+				t.Fatalf("body = %v", body)
+			}
+			return jsonResponse(`{"totalTokens":7}`), nil
+		}),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	count, err := provider.CountTokens(context.Background(), &litellm.Request{
+		Model:    "gemini-2.5-flash",
+		Messages: []litellm.Message{litellm.UserText("hello")},
+	})
+	if err != nil {
+		t.Fatalf("CountTokens returned error: %v", err)
+	}
+	if count.InputTokens != 7 || count.Provider != "vertex" {
+		t.Fatalf("count = %+v", count)
+	}
+}
+
+func TestRejectsForeignFilesAndPublishers(t *testing.T) {
+	provider, err := New(Config{Project: "proj", TokenSource: StaticToken("tok")})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	_, err = provider.Chat(context.Background(), &litellm.Request{
+		Model:    "gemini-2.5-flash",
+		Messages: []litellm.Message{litellm.User(litellm.DocumentBlock{FileURI: "files/abc", MIME: "application/pdf"})},
+	})
+	if !litellm.IsValidationError(err) || !strings.Contains(err.Error(), "belongs to gemini") {
+		t.Fatalf("Chat error = %v, want foreign file error", err)
+	}
+	_, err = provider.Chat(context.Background(), &litellm.Request{
+		Model:    "publishers/meta/models/llama-4",
+		Messages: []litellm.Message{litellm.UserText("hello")},
+	})
+	if !litellm.IsValidationError(err) || !strings.Contains(err.Error(), `publisher "meta"`) {
+		t.Fatalf("Chat error = %v, want publisher error", err)
+	}
+	if caps := provider.Capabilities("claude-opus-4-1@20250805"); caps.Provider != "vertex" || caps.Media.DocumentFileURI != litellm.SupportNo {
+		t.Fatalf("capabilities = %+v", caps)
+	}
+}
+
+func TestServiceAccountSignsJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	var tokenURI string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		if got := r.PostForm.Get("grant_type"); got != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+			t.Fatalf("grant_type = %q", got)
+		}
+		parts := strings.Split(r.PostForm.Get("assertion"), ".")
+		if len(parts) != 3 {
+			t.Fatalf("assertion has %d parts", len(parts))
+		}
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+			t.Fatalf("verify signature: %v", err)
+		}
+		claimsJSON, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		var claims map[string]any
+		if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+			t.Fatalf("decode claims: %v", err)
+		}
+		if claims["iss"] != "sa@proj.iam.gserviceaccount.com" || claims["aud"] != tokenURI || claims["scope"] != cloudPlatform {
+			t.Fatalf("claims = %v", claims)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"ya29.test","expires_in":3600,"token_type":"Bearer"}`)
+	}))
+	defer server.Close()
+	tokenURI = server.URL + "/token"
+
+	keyJSON, _ := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "sa@proj.iam.gserviceaccount.com",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"private_key_id": "kid-1",
+		"token_uri":      tokenURI,
+	})
+	source, err := ServiceAccount(keyJSON)
+	if err != nil {
+		t.Fatalf("ServiceAccount returned error: %v", err)
+	}
+	token, err := source.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if token.AccessToken != "ya29.test" || time.Until(token.Expiry) < 59*time.Minute {
+		t.Fatalf("token = %+v", token)
+	}
+}
+
+func TestCachedTokenSourceRefreshesBeforeExpiry(t *testing.T) {
+	tokens := &countingTokenSource{ttl: 10 * time.Minute}
+	cached := newCachedTokenSource(tokens)
+	now := time.Now()
+	cached.now = func() time.Time { return now }
+	for range 3 {
+		if _, err := cached.Token(context.Background()); err != nil {
+			t.Fatalf("Token returned error: %v", err)
+		}
+	}
+	if got := tokens.calls.Load(); got != 1 {
+		t.Fatalf("calls = %d, want 1", got)
+	}
+	now = now.Add(6 * time.Minute)
+	token, err := cached.Token(context.Background())
+	if err != nil || token.AccessToken != "tok-2" {
+		t.Fatalf("token = %+v, %v; want refreshed tok-2", token, err)
+	}
+}
+
+func jsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
@@ -0,0 +1,145 @@
+package vertex
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// anthropicVersion is the body field Vertex AI requires in place of the
+// anthropic-version header.
+const anthropicVersion = "vertex-2023-10-16"
+
+// transport maps requests built by the gemini and anthropic adapters onto
+// Vertex AI publisher model endpoints. The adapters send the access token
+// as their API key; transport moves it to the Authorization header.
+type transport struct {
+	publisher string
+	prefix    string
+	base      http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	var err error
+	switch t.publisher {
+	case publisherGoogle:
+		err = t.rewriteGemini(out)
+	case publisherAnthropic:
+		err = t.rewriteAnthropic(out)
+	default:
+		err = fmt.Errorf("vertex: unsupported publisher %q", t.publisher)
+	}
+	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	return t.base.RoundTrip(out)
+}
+
+// rewriteGemini maps /v1beta/models/{model}:{method}. countTokens takes the
+// request fields at the top level on Vertex AI rather than wrapped in
+// generateContentRequest.
+func (t *transport) rewriteGemini(req *http.Request) error {
+	rest, ok := strings.CutPrefix(req.URL.Path, "/v1beta/models/")
+	if !ok {
+		return fmt.Errorf("vertex: %s is not supported on Vertex AI", req.URL.Path)
+	}
+	authorize(req, "x-goog-api-key")
+	setPath(req, t.prefix+"/publishers/google/models/"+rest)
+	if !strings.HasSuffix(rest, ":countTokens") {
+		return nil
+	}
+	body, err := readBody(req)
+	if err != nil {
+		return err
+	}
+	var wrapped struct {
+		GenerateContentRequest map[string]json.RawMessage `json:"generateContentRequest"`
+	}
+	if err := json.Unmarshal(body, &wrapped); err != nil {
+		return fmt.Errorf("vertex: decode count tokens request: %w", err)
+	}
+	delete(wrapped.GenerateContentRequest, "model")
+	return setJSONBody(req, wrapped.GenerateContentRequest)
+}
+
+// rewriteAnthropic maps /v1/messages to rawPredict or streamRawPredict on
+// the model named in the body, and /v1/messages/count_tokens to the
+// count-tokens model.
+func (t *transport) rewriteAnthropic(req *http.Request) error {
+	body, err := readBody(req)
+	if err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return fmt.Errorf("vertex: decode anthropic request: %w", err)
+	}
+	var model string
+	if err := json.Unmarshal(fields["model"], &model); err != nil || model == "" {
+		return fmt.Errorf("vertex: anthropic request has no model")
+	}
+	fields["anthropic_version"] = json.RawMessage(`"` + anthropicVersion + `"`)
+	switch req.URL.Path {
+	case "/v1/messages":
+		var stream bool
+		if raw, ok := fields["stream"]; ok {
+			_ = json.Unmarshal(raw, &stream)
+		}
+		method := "rawPredict"
+		if stream {
+			method = "streamRawPredict"
+		}
+		delete(fields, "model")
+		setPath(req, t.prefix+"/publishers/anthropic/models/"+model+":"+method)
+	case "/v1/messages/count_tokens":
+		setPath(req, t.prefix+"/publishers/anthropic/models/count-tokens:rawPredict")
+	default:
+		return fmt.Errorf("vertex: %s is not supported on Vertex AI", req.URL.Path)
+	}
+	authorize(req, "x-api-key")
+	req.Header.Del("anthropic-version")
+	return setJSONBody(req, fields)
+}
+
+func authorize(req *http.Request, keyHeader string) {
+	token := req.Header.Get(keyHeader)
+	req.Header.Del(keyHeader)
+	req.Header.Set("Authorization", "Bearer "+token)
+}
+
+func setPath(req *http.Request, path string) {
+	req.URL.Path = path
+	req.URL.RawPath = ""
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, fmt.Errorf("vertex: request has no body")
+	}
+	defer req.Body.Close()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("vertex: read request body: %w", err)
+	}
+	return body, nil
+}
+
+func setJSONBody(req *http.Request, fields any) error {
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("vertex: marshal request: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.ContentLength = int64(len(body))
+	return nil
+}
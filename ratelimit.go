@@ -0,0 +1,295 @@
+package litellm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimits caps calls per minute. Zero fields are unlimited.
+type RateLimits struct {
+	RequestsPerMinute     int
+	InputTokensPerMinute  int
+	OutputTokensPerMinute int
+}
+
+func (l RateLimits) validate() error {
+	if l.RequestsPerMinute < 0 || l.InputTokensPerMinute < 0 || l.OutputTokensPerMinute < 0 {
+		return fmt.Errorf("rate limits cannot be negative")
+	}
+	return nil
+}
+
+// RateLimiter is a Provider that paces calls with token buckets keyed by
+// provider and model. Input tokens are estimated before each call and
+// reconciled, together with output tokens, from the reported Usage.
+type RateLimiter struct {
+	provider  Provider
+	limits    RateLimits
+	models    map[string]RateLimits
+	failFast  bool
+	estimator func(*Request) int
+	now       func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*rateBuckets
+}
+
+type RateLimitOption func(*RateLimiter) error
+
+func NewRateLimiter(provider Provider, limits RateLimits, opts ...RateLimitOption) (*RateLimiter, error) {
+	if provider == nil {
+		return nil, fmt.Errorf("rate limiter provider cannot be nil")
+	}
+	if err := limits.validate(); err != nil {
+		return nil, err
+	}
+	limiter := &RateLimiter{
+		provider:  provider,
+		limits:    limits,
+		models:    map[string]RateLimits{},
+		estimator: estimateInputTokens,
+		now:       time.Now,
+		buckets:   map[string]*rateBuckets{},
+	}
+	for _, opt := range opts {
+		if err := opt(limiter); err != nil {
+			return nil, fmt.Errorf("apply rate limit option: %w", err)
+		}
+	}
+	return limiter, nil
+}
+
+// WithModelRateLimits overrides the default limits for one model.
+func WithModelRateLimits(model string, limits RateLimits) RateLimitOption {
+	return func(r *RateLimiter) error {
+		if model == "" {
+			return fmt.Errorf("rate limit model cannot be empty")
+		}
+		if err := limits.validate(); err != nil {
+			return err
+		}
+		r.models[model] = limits
+		return nil
+	}
+}
+
+// WithRateLimitFailFast returns an ErrorTypeRateLimit error with a computed
+// RetryAfter instead of waiting for capacity.
+func WithRateLimitFailFast() RateLimitOption {
+	return func(r *RateLimiter) error {
+		r.failFast = true
+		return nil
+	}
+}
+
+// WithTokenEstimator replaces EstimateTokens for pre-call input charges.
+func WithTokenEstimator(fn func(*Request) int) RateLimitOption {
+	return func(r *RateLimiter) error {
+		if fn == nil {
+			return fmt.Errorf("token estimator cannot be nil")
+		}
+		r.estimator = fn
+		return nil
+	}
+}
+
+func (r *RateLimiter) Name() string {
+	return r.provider.Name()
+}
+
+func (r *RateLimiter) Capabilities(model string) Capabilities {
+	return GetCapabilities(r.provider, model)
+}
+
+func (r *RateLimiter) Chat(ctx context.Context, req *Request) (*Response, error) {
+	reservation, err := r.reserve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.provider.Chat(ctx, req)
+	if resp != nil {
+		reservation.settle(resp.Usage)
+	}
+	return resp, err
+}
+
+func (r *RateLimiter) Stream(ctx context.Context, req *Request) (Stream, error) {
+	reservation, err := r.reserve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := r.provider.Stream(ctx, req)
+	if err != nil || stream == nil {
+		return stream, err
+	}
+	return &rateLimitedStream{inner: stream, reservation: reservation}, nil
+}
+
+func (r *RateLimiter) reserve(ctx context.Context, req *Request) (*rateReservation, error) {
+	estimate := r.estimator(req)
+	for {
+		r.mu.Lock()
+		buckets := r.bucketsFor(req.Model)
+		wait := buckets.wait(r.now(), estimate)
+		if wait == 0 {
+			buckets.take(estimate)
+			r.mu.Unlock()
+			return &rateReservation{limiter: r, buckets: buckets, estimate: estimate}, nil
+		}
+		r.mu.Unlock()
+		if r.failFast {
+			seconds := int(math.Ceil(wait.Seconds()))
+			return nil, NewRateLimitError(r.Name(), fmt.Sprintf("client rate limit exceeded for %s; retry in %s", req.Model, wait.Round(time.Millisecond)), seconds)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, WrapError(ctx.Err(), r.Name())
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *RateLimiter) bucketsFor(model string) *rateBuckets {
+	key := r.provider.Name() + "/" + model
+	if buckets, ok := r.buckets[key]; ok {
+		return buckets
+	}
+	limits := r.limits
+	if override, ok := r.models[model]; ok {
+		limits = override
+	}
+	now := r.now()
+	buckets := &rateBuckets{
+		requests: newTokenBucket(limits.RequestsPerMinute, now),
+		input:    newTokenBucket(limits.InputTokensPerMinute, now),
+		output:   newTokenBucket(limits.OutputTokensPerMinute, now),
+	}
+	r.buckets[key] = buckets
+	return buckets
+}
+
+type rateBuckets struct {
+	requests *tokenBucket
+	input    *tokenBucket
+	output   *tokenBucket
+}
+
+// wait reports how long until one request carrying estimate input tokens fits.
+// Output usage is unknown up front, so the output bucket only has to be out of
+// debt.
+func (b *rateBuckets) wait(now time.Time, estimate int) time.Duration {
+	wait := b.requests.wait(now, 1)
+	wait = max(wait, b.input.wait(now, estimate))
+	wait = max(wait, b.output.wait(now, 0))
+	return wait
+}
+
+func (b *rateBuckets) take(estimate int) {
+	b.requests.take(1)
+	b.input.take(float64(estimate))
+}
+
+type rateReservation struct {
+	limiter  *RateLimiter
+	buckets  *rateBuckets
+	estimate int
+	once     sync.Once
+}
+
+// settle replaces the input estimate with reported usage. Without usage the
+// estimate stands.
+func (r *rateReservation) settle(usage Usage) {
+	r.once.Do(func() {
+		if !usage.HasTokens() {
+			return
+		}
+		r.limiter.mu.Lock()
+		defer r.limiter.mu.Unlock()
+		r.buckets.input.take(float64(usage.InputTokens - r.estimate))
+		r.buckets.output.take(float64(usage.OutputTokens))
+	})
+}
+
+// tokenBucket refills limit tokens per minute up to limit. Balances may go
+// negative so oversized requests still run once and later calls pay the debt.
+type tokenBucket struct {
+	limit   float64
+	balance float64
+	updated time.Time
+}
+
+func newTokenBucket(perMinute int, now time.Time) *tokenBucket {
+	if perMinute == 0 {
+		return nil
+	}
+	return &tokenBucket{limit: float64(perMinute), balance: float64(perMinute), updated: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.balance = math.Min(b.limit, b.balance+elapsed.Minutes()*b.limit)
+		b.updated = now
+	}
+}
+
+func (b *tokenBucket) wait(now time.Time, need int) time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.refill(now)
+	target := math.Min(float64(need), b.limit)
+	if b.balance >= target && (need > 0 || b.balance > 0) {
+		return 0
+	}
+	missing := target - b.balance
+	if missing <= 0 {
+		missing = 1
+	}
+	return time.Duration(math.Ceil(missing / b.limit * float64(time.Minute)))
+}
+
+func (b *tokenBucket) take(n float64) {
+	if b != nil {
+		b.balance -= n
+	}
+}
+
+type rateLimitedStream struct {
+	inner       Stream
+	reservation *rateReservation
+	usage       Usage
+}
+
+func (s *rateLimitedStream) Next() (Event, error) {
+	event, err := s.inner.Next()
+	switch e := event.(type) {
+	case UsageEvent:
+		s.usage = e.Usage
+	case DoneEvent:
+		s.reservation.settle(s.usage)
+	}
+	if errors.Is(err, io.EOF) {
+		s.reservation.settle(s.usage)
+	}
+	return event, err
+}
+
+func (s *rateLimitedStream) ServedBy() (string, string) {
+	return streamServedBy(s.inner)
+}
+
+func (s *rateLimitedStream) Close() error {
+	s.reservation.settle(s.usage)
+	return s.inner.Close()
+}
+
+func estimateInputTokens(req *Request) int {
+	return EstimateTokens(req).InputTokens
+}
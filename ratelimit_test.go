@@ -0,0 +1,126 @@
+package litellm
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterFailFastReportsRetryAfter(t *testing.T) {
+	now := time.Unix(1000, 0)
+	limiter, err := NewRateLimiter(&testProvider{name: "openai"}, RateLimits{RequestsPerMinute: 2}, WithRateLimitFailFast())
+	if err != nil {
+		t.Fatalf("NewRateLimiter returned error: %v", err)
+	}
+	limiter.now = func() time.Time { return now }
+	req := &Request{Model: "m", Messages: []Message{UserText("hi")}}
+	for i := 0; i < 2; i++ {
+		if _, err := limiter.Chat(context.Background(), req); err != nil {
+			t.Fatalf("Chat %d returned error: %v", i, err)
+		}
+	}
+	_, err = limiter.Chat(context.Background(), req)
+	if !IsRateLimitError(err) || GetRetryAfter(err) != 30 {
+		t.Fatalf("expected rate limit error with 30s retry after, got %v (%d)", err, GetRetryAfter(err))
+	}
+	now = now.Add(30 * time.Second)
+	if _, err := limiter.Chat(context.Background(), req); err != nil {
+		t.Fatalf("Chat after refill returned error: %v", err)
+	}
+}
+
+func TestRateLimiterKeysBucketsByModel(t *testing.T) {
+	limiter, err := NewRateLimiter(&testProvider{name: "openai"}, RateLimits{RequestsPerMinute: 1},
+		WithRateLimitFailFast(), WithModelRateLimits("big", RateLimits{RequestsPerMinute: 5}))
+	if err != nil {
+		t.Fatalf("NewRateLimiter returned error: %v", err)
+	}
+	if _, err := limiter.Chat(context.Background(), &Request{Model: "a"}); err != nil {
+		t.Fatalf("Chat a returned error: %v", err)
+	}
+	if _, err := limiter.Chat(context.Background(), &Request{Model: "b"}); err != nil {
+		t.Fatalf("Chat b should use its own bucket: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := limiter.Chat(context.Background(), &Request{Model: "big"}); err != nil {
+			t.Fatalf("Chat big %d returned error: %v", i, err)
+		}
+	}
+	if _, err := limiter.Chat(context.Background(), &Request{Model: "a"}); !IsRateLimitError(err) {
+		t.Fatalf("expected rate limit error, got %v", err)
+	}
+}
+
+func TestRateLimiterReconcilesTokensFromUsage(t *testing.T) {
+	now := time.Unix(1000, 0)
+	provider := &testProvider{name: "anthropic", chatFunc: func(context.Context, *Request) (*Response, error) {
+		return &Response{Blocks: []Block{TextBlock{Text: "ok"}}, Usage: Usage{InputTokens: 900, OutputTokens: 500}}, nil
+	}}
+	limiter, err := NewRateLimiter(provider, RateLimits{InputTokensPerMinute: 1000, OutputTokensPerMinute: 400},
+		WithRateLimitFailFast(), WithTokenEstimator(func(*Request) int { return 10 }))
+	if err != nil {
+		t.Fatalf("NewRateLimiter returned error: %v", err)
+	}
+	limiter.now = func() time.Time { return now }
+	if _, err := limiter.Chat(context.Background(), &Request{Model: "m"}); err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	// 900 input tokens leave 100 of 1000; 500 output tokens put the output
+	// bucket 100 into debt, which takes 15s of a 400/min refill to clear.
+	_, err = limiter.Chat(context.Background(), &Request{Model: "m"})
+	if !IsRateLimitError(err) || GetRetryAfter(err) != 15 {
+		t.Fatalf("expected output TPM rate limit, got %v (%d)", err, GetRetryAfter(err))
+	}
+}
+
+func TestRateLimiterReconcilesStreamUsage(t *testing.T) {
+	now := time.Unix(1000, 0)
+	provider := &testProvider{name: "p", streamFunc: func(_ context.Context, req *Request) (Stream, error) {
+		return &testStream{events: []Event{
+			ContentDelta{Text: "ok"},
+			UsageEvent{Usage: Usage{InputTokens: 50, OutputTokens: 120}},
+			DoneEvent{FinishReason: FinishReasonStop, Provider: "p", Model: req.Model},
+		}}, nil
+	}}
+	limiter, err := NewRateLimiter(provider, RateLimits{OutputTokensPerMinute: 100}, WithRateLimitFailFast())
+	if err != nil {
+		t.Fatalf("NewRateLimiter returned error: %v", err)
+	}
+	limiter.now = func() time.Time { return now }
+	stream, err := limiter.Stream(context.Background(), &Request{Model: "m"})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	if _, err := Collect(stream); err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	if _, err := limiter.Stream(context.Background(), &Request{Model: "m"}); !IsRateLimitError(err) {
+		t.Fatalf("expected rate limit after stream usage, got %v", err)
+	}
+}
+
+func TestRateLimiterWaitHonorsContext(t *testing.T) {
+	limiter, err := NewRateLimiter(&testProvider{name: "p"}, RateLimits{RequestsPerMinute: 1})
+	if err != nil {
+		t.Fatalf("NewRateLimiter returned error: %v", err)
+	}
+	if _, err := limiter.Chat(context.Background(), &Request{Model: "m"}); err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.Chat(ctx, &Request{Model: "m"}); !IsTimeoutError(err) {
+		t.Fatalf("expected timeout error while waiting, got %v", err)
+	}
+}
+
+func TestRateLimiterChargesEstimateTokens(t *testing.T) {
+	limiter, err := NewRateLimiter(&testProvider{name: "p"}, RateLimits{InputTokensPerMinute: 1000})
+	if err != nil {
+		t.Fatalf("NewRateLimiter returned error: %v", err)
+	}
+	req := &Request{Model: "gpt-4o", Messages: []Message{UserText("abcdefghijklmnop")}, Tools: []Tool{{Name: "lookup"}}}
+	if got, want := limiter.estimator(req), EstimateTokens(req).InputTokens; got != want || got == 0 {
+		t.Fatalf("estimator = %d, want EstimateTokens input of %d", got, want)
+	}
+}
@@ -19,13 +19,61 @@ type Block interface {
 	isBlock()
 }
 
+// Annotation types for citations. Providers pass other annotation kinds
+// through under their native type, with the raw payload in Extra.
+const (
+	AnnotationURLCitation          = "url_citation"
+	AnnotationDocumentCitation     = "document_citation"
+	AnnotationSearchResultCitation = "search_result_citation"
+)
+
+// Annotation marks a span of TextBlock.Text. Start and End are byte offsets
+// into the text, End exclusive; both are zero when the provider cites the
+// whole block without locating a span. Text is the quoted source passage and
+// Title the source title, when the provider returns them.
 type Annotation struct {
-	Type  string
-	Text  string
-	URL   string
-	Extra json.RawMessage
+	Type     string
+	Start    int
+	End      int
+	Text     string
+	URL      string
+	Title    string
+	Location *CitationLocation
+	Extra    json.RawMessage
+}
+
+// CitationLocation identifies the cited part of a request document or search
+// result. Index is its position among the request's documents or search
+// results, and Start and End (exclusive) count Unit: characters of a text
+// document, 1-based pages of a PDF, or content blocks.
+type CitationLocation struct {
+	Index int
+	Unit  CitationUnit
+	Start int
+	End   int
+}
+
+type CitationUnit string
+
+// ByteOffset converts an offset counted in characters (Unicode code points),
+// as OpenAI-compatible APIs report annotation indexes, to a byte offset into
+// text. Offsets past the end of text clamp to len(text).
+func ByteOffset(text string, chars int) int {
+	for i := range text {
+		if chars <= 0 {
+			return i
+		}
+		chars--
+	}
+	return len(text)
 }
 
+const (
+	CitationUnitChar  CitationUnit = "char"
+	CitationUnitPage  CitationUnit = "page"
+	CitationUnitBlock CitationUnit = "block"
+)
+
 type TextBlock struct {
 	Text        string
 	Annotations []Annotation
@@ -42,6 +90,38 @@ type ImageBlock struct {
 	Cache   *CacheControl
 }
 
+// DocumentBlock carries a document such as a PDF or plain text file. Exactly
+// one of Data, URL or FileURI must be set; MIME is required with Data.
+// Citations asks providers that support it to cite passages from the document.
+type DocumentBlock struct {
+	URL       string
+	Data      []byte
+	MIME      string
+	FileURI   string
+	Title     string
+	Citations bool
+	Cache     *CacheControl
+}
+
+// AudioBlock carries input audio. Format names the encoding, such as "wav" or
+// "mp3".
+type AudioBlock struct {
+	Data   []byte
+	Format string
+	Cache  *CacheControl
+}
+
+// AudioOutputBlock is audio generated by the model. ID lets providers that keep
+// generated audio server-side reference it in later turns; Transcript is the
+// spoken text when the provider returns one.
+type AudioOutputBlock struct {
+	ID         string
+	Data       []byte
+	Format     string
+	Transcript string
+	ExpiresAt  int64
+}
+
 type ReasoningBlock struct {
 	Text      string
 	Summary   bool
@@ -73,12 +153,47 @@ type ToolReferenceBlock struct {
 	Cache    *CacheControl
 }
 
-func (TextBlock) isBlock()          {}
-func (ImageBlock) isBlock()         {}
-func (ReasoningBlock) isBlock()     {}
-func (ToolUseBlock) isBlock()       {}
-func (ToolResultBlock) isBlock()    {}
-func (ToolReferenceBlock) isBlock() {}
+// ServerToolUseBlock is a call to a tool the provider runs itself, such as web
+// search or code execution. It appears in assistant turns and must be sent
+// back unchanged, with its ServerToolResultBlock, when the conversation
+// continues.
+type ServerToolUseBlock struct {
+	ID        string
+	Name      string
+	Arguments json.RawMessage
+	Cache     *CacheControl
+}
+
+// ServerToolResultBlock is the result of a ServerToolUseBlock. Type is the
+// provider's result block type, such as "web_search_tool_result", and Content
+// its JSON payload as returned.
+type ServerToolResultBlock struct {
+	ToolUseID string
+	Type      string
+	Content   json.RawMessage
+	Cache     *CacheControl
+}
+
+// GuardContentBlock is text that a provider guardrail, such as a Bedrock
+// guardrail, evaluates on its own. Qualifiers are provider-specific, such as
+// "grounding_source" or "query" for Bedrock contextual grounding checks.
+type GuardContentBlock struct {
+	Text       string
+	Qualifiers []string
+}
+
+func (TextBlock) isBlock()             {}
+func (ImageBlock) isBlock()            {}
+func (DocumentBlock) isBlock()         {}
+func (AudioBlock) isBlock()            {}
+func (AudioOutputBlock) isBlock()      {}
+func (ReasoningBlock) isBlock()        {}
+func (ToolUseBlock) isBlock()          {}
+func (ToolResultBlock) isBlock()       {}
+func (ToolReferenceBlock) isBlock()    {}
+func (ServerToolUseBlock) isBlock()    {}
+func (ServerToolResultBlock) isBlock() {}
+func (GuardContentBlock) isBlock()     {}
 
 type Message struct {
 	Role   Role
@@ -145,6 +260,17 @@ type Tool struct {
 	Description string
 	Parameters  Schema
 	Strict      StrictMode
+
+	// Server makes this a tool the provider runs itself; Parameters must be
+	// empty. Provider packages offer typed constructors.
+	Server *ServerTool
+}
+
+// ServerTool identifies a provider-hosted tool. Type is the provider's tool
+// type, such as "web_search_20250305", and Config its settings, sent as-is.
+type ServerTool struct {
+	Type   string
+	Config map[string]any
 }
 
 func NewTool(name, description string, parameters any) (Tool, error) {
@@ -223,6 +349,29 @@ const (
 	CachePlacementPrefix CachePlacement = "prefix"
 )
 
+// WebSearch turns on the provider's hosted web search for a request; nil
+// leaves it off. Zero fields keep the provider defaults, and providers
+// return a validation error for settings they cannot express.
+type WebSearch struct {
+	MaxUses        int
+	AllowedDomains []string
+	BlockedDomains []string
+	UserLocation   *UserLocation
+}
+
+// UserLocation is an approximate location used to localise search results.
+// Country is an ISO 3166-1 alpha-2 code and Timezone an IANA name.
+type UserLocation struct {
+	City     string
+	Region   string
+	Country  string
+	Timezone string
+}
+
+func (l *UserLocation) IsZero() bool {
+	return l == nil || *l == UserLocation{}
+}
+
 type ProviderOptions map[string]any
 
 type Request struct {
@@ -240,10 +389,12 @@ type Request struct {
 	ResponseFormat *ResponseFormat
 	Thinking       *Thinking
 	Cache          *CachePolicy
+	WebSearch      *WebSearch
 
 	ProviderOptions ProviderOptions
 
 	captureRawResponse bool
+	cacheBypass        bool
 }
 
 func (r *Request) CaptureRawResponse() bool {
@@ -0,0 +1,106 @@
+package litellm
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// blockKinds holds one populated value of every Block implementation with
+// what the local helpers are expected to make of it. A new block type fails
+// TestBlockKindsCoverEveryBlock until it is listed here, which in turn walks
+// it through the cache, token estimate and context trim switches.
+var blockKinds = []struct {
+	block      Block
+	tokens     bool
+	transcript bool
+}{
+	{block: TextBlock{Text: "hello there", Logprobs: []byte(`[]`), Cache: &CacheControl{Type: "ephemeral"}}, tokens: true, transcript: true},
+	{block: ImageBlock{URL: "https://example.com/cat.png", Detail: "low"}, tokens: true},
+	{block: DocumentBlock{Data: []byte("plain text document"), MIME: "text/plain", Title: "notes"}, tokens: true},
+	{block: AudioBlock{Data: make([]byte, 4000), Format: "wav"}, tokens: true},
+	{block: AudioOutputBlock{ID: "audio_1", Data: []byte{1, 2}, Format: "wav", Transcript: "hi", ExpiresAt: 1}},
+	{block: ReasoningBlock{Text: "thinking it through", Signature: "sig", Extra: []byte(`{}`)}, tokens: true},
+	{block: ToolUseBlock{ID: "call_1", Name: "lookup", Arguments: []byte(`{"q":"x"}`), Extra: []byte(`{}`)}, tokens: true, transcript: true},
+	{block: ToolResultBlock{ToolUseID: "call_1", Content: []Block{TextBlock{Text: "found", Logprobs: []byte(`[]`)}}}, tokens: true, transcript: true},
+	{block: ToolReferenceBlock{ToolName: "lookup", Extra: []byte(`{}`)}},
+	{block: ServerToolUseBlock{ID: "srv_1", Name: "web_search", Arguments: []byte(`{"query":"x"}`)}},
+	{block: ServerToolResultBlock{ToolUseID: "srv_1", Type: "web_search_tool_result", Content: []byte(`[]`)}},
+	{block: GuardContentBlock{Text: "checked text", Qualifiers: []string{"query"}}, tokens: true, transcript: true},
+}
+
+func TestBlockKindsCoverEveryBlock(t *testing.T) {
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("list package files: %v", err)
+	}
+	fset := token.NewFileSet()
+	var declared []string
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatalf("parse %s: %v", path, err)
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Name.Name != "isBlock" || fn.Recv == nil {
+				continue
+			}
+			if ident, ok := fn.Recv.List[0].Type.(*ast.Ident); ok {
+				declared = append(declared, ident.Name)
+			}
+		}
+	}
+	var listed []string
+	for _, kind := range blockKinds {
+		listed = append(listed, reflect.TypeOf(kind.block).Name())
+	}
+	sort.Strings(declared)
+	sort.Strings(listed)
+	if !reflect.DeepEqual(declared, listed) {
+		t.Fatalf("blockKinds lists %v, package declares %v", listed, declared)
+	}
+}
+
+func TestEveryBlockKindRoundTripsThroughCache(t *testing.T) {
+	for _, kind := range blockKinds {
+		t.Run(fmt.Sprintf("%T", kind.block), func(t *testing.T) {
+			encoded, err := encodeCachedBlocks([]Block{kind.block})
+			if err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+			decoded, err := decodeCachedBlocks(encoded)
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(decoded) != 1 || !reflect.DeepEqual(decoded[0], kind.block) {
+				t.Fatalf("decoded = %#v, want %#v", decoded, kind.block)
+			}
+		})
+	}
+}
+
+func TestEveryBlockKindIsEstimatedAndTranscribed(t *testing.T) {
+	for _, kind := range blockKinds {
+		t.Run(fmt.Sprintf("%T", kind.block), func(t *testing.T) {
+			tokens, images := estimateBlockTokens(kind.block, func(s string) int { return len(s) })
+			if got := tokens+images > 0; got != kind.tokens {
+				t.Fatalf("estimate = %d text + %d image tokens, want counted = %v", tokens, images, kind.tokens)
+			}
+			var b strings.Builder
+			writeTranscriptMessage(&b, Message{Role: RoleUser, Blocks: []Block{kind.block}})
+			if got := b.Len() > 0; got != kind.transcript {
+				t.Fatalf("transcript = %q, want written = %v", b.String(), kind.transcript)
+			}
+		})
+	}
+}
@@ -15,7 +15,48 @@ type Response struct {
 	FinishReason    FinishReason
 	FinishReasonRaw string
 	Warnings        []Warning
-	Raw             json.RawMessage
+	// Grounding is set when the provider grounded the response in search
+	// results; the citations themselves are TextBlock annotations.
+	Grounding *Grounding
+	// Safety holds the provider's content-safety assessments of the prompt
+	// and output, when it reports them.
+	Safety []SafetyAssessment
+	Raw    json.RawMessage
+}
+
+// SafetyAssessment is a provider's content-safety verdict on the prompt or
+// the output. Blocked reports that the provider filtered content because of
+// it. Raw keeps the provider's assessment as sent.
+type SafetyAssessment struct {
+	Target     SafetyTarget
+	Blocked    bool
+	Categories []SafetyCategory
+	Raw        json.RawMessage
+}
+
+type SafetyTarget string
+
+const (
+	SafetyTargetPrompt SafetyTarget = "prompt"
+	SafetyTargetOutput SafetyTarget = "output"
+)
+
+// SafetyCategory is one category of a safety assessment, such as "hate" or
+// "jailbreak". Severity is provider-specific; Detected is set for categories
+// that are flagged rather than graded.
+type SafetyCategory struct {
+	Name     string
+	Severity string
+	Filtered bool
+	Detected bool
+}
+
+// Grounding describes the searches behind a grounded response.
+// SearchEntryPoint is provider-rendered HTML that some providers require
+// applications to display alongside grounded answers.
+type Grounding struct {
+	SearchQueries    []string
+	SearchEntryPoint string
 }
 
 func CaptureRawResponse(req *Request, resp *Response, raw []byte) {
@@ -51,6 +92,20 @@ func (r *Response) ToolCalls() []ToolUseBlock {
 	return calls
 }
 
+// ServerToolCalls returns the provider-hosted tool calls in the response.
+func (r *Response) ServerToolCalls() []ServerToolUseBlock {
+	if r == nil {
+		return nil
+	}
+	var calls []ServerToolUseBlock
+	for _, block := range r.Blocks {
+		if call, ok := block.(ServerToolUseBlock); ok {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
 func (r *Response) Reasoning() string {
 	if r == nil {
 		return ""
@@ -98,6 +153,25 @@ func (u *Usage) StampModel(provider, model string) {
 	}
 }
 
+// Add accumulates other into u, keeping the first provider and model seen.
+func (u *Usage) Add(other Usage) {
+	if u == nil {
+		return
+	}
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.TotalTokens += other.TotalTokens
+	u.ReasoningTokens += other.ReasoningTokens
+	u.CacheReadTokens += other.CacheReadTokens
+	u.CacheWriteTokens += other.CacheWriteTokens
+	if u.Provider == "" {
+		u.Provider = other.Provider
+	}
+	if u.Model == "" {
+		u.Model = other.Model
+	}
+}
+
 type FinishReason string
 
 const (
@@ -106,6 +180,9 @@ const (
 	FinishReasonToolCall FinishReason = "tool_calls"
 	FinishReasonError    FinishReason = "error"
 	FinishReasonSafety   FinishReason = "safety"
+	// FinishReasonPause means the provider paused a long server tool turn.
+	// Send the response back as an assistant message to let it continue.
+	FinishReasonPause FinishReason = "pause_turn"
 )
 
 func NormalizeFinishReason(raw string) FinishReason {
@@ -116,6 +193,8 @@ func NormalizeFinishReason(raw string) FinishReason {
 		return FinishReasonLength
 	case "tool_calls", "tool_use", "FUNCTION_CALLING":
 		return FinishReasonToolCall
+	case "pause_turn":
+		return FinishReasonPause
 	case "completed":
 		return FinishReasonStop
 	case "incomplete":
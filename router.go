@@ -0,0 +1,259 @@
+package litellm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// RouteTarget is one entry in a Router fallback chain. Model, when set,
+// replaces the request model for this target; otherwise Models maps request
+// models to target models and unmapped models pass through unchanged.
+type RouteTarget struct {
+	Provider Provider
+	Model    string
+	Models   map[string]string
+}
+
+func (t RouteTarget) model(requested string) string {
+	if t.Model != "" {
+		return t.Model
+	}
+	if mapped, ok := t.Models[requested]; ok && mapped != "" {
+		return mapped
+	}
+	return requested
+}
+
+// Router is a Provider that tries an ordered list of targets, falling back to
+// the next one when a call fails with an overloaded, rate-limit or retryable
+// error. Streams only fall back while no content has been delivered: Stream
+// waits for the first content event before returning.
+type Router struct {
+	name     string
+	targets  []RouteTarget
+	fallback func(error) bool
+}
+
+type RouterOption func(*Router) error
+
+func NewRouter(targets []RouteTarget, opts ...RouterOption) (*Router, error) {
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("router requires at least one target")
+	}
+	for i, target := range targets {
+		if target.Provider == nil {
+			return nil, fmt.Errorf("router target %d provider cannot be nil", i)
+		}
+	}
+	router := &Router{
+		name:     "router",
+		targets:  append([]RouteTarget(nil), targets...),
+		fallback: ShouldFallback,
+	}
+	for _, opt := range opts {
+		if err := opt(router); err != nil {
+			return nil, fmt.Errorf("apply router option: %w", err)
+		}
+	}
+	return router, nil
+}
+
+// WithRouterName sets the name reported by Router.Name.
+func WithRouterName(name string) RouterOption {
+	return func(r *Router) error {
+		if name == "" {
+			return fmt.Errorf("router name cannot be empty")
+		}
+		r.name = name
+		return nil
+	}
+}
+
+// WithFallbackPolicy replaces ShouldFallback as the test for moving on to the
+// next target.
+func WithFallbackPolicy(fn func(error) bool) RouterOption {
+	return func(r *Router) error {
+		if fn == nil {
+			return fmt.Errorf("fallback policy cannot be nil")
+		}
+		r.fallback = fn
+		return nil
+	}
+}
+
+// ShouldFallback is the default Router policy: overloaded, rate-limited and
+// retryable failures move on to the next target.
+func ShouldFallback(err error) bool {
+	return IsOverloadedError(err) || IsRateLimitError(err) || IsRetryableError(err)
+}
+
+func (r *Router) Name() string {
+	return r.name
+}
+
+// Capabilities reports the primary target's capabilities for model.
+func (r *Router) Capabilities(model string) Capabilities {
+	target := r.targets[0]
+	return GetCapabilities(target.Provider, target.model(model))
+}
+
+func (r *Router) Chat(ctx context.Context, req *Request) (*Response, error) {
+	var warnings []Warning
+	var lastErr error
+	for i, target := range r.targets {
+		attempt := r.attemptRequest(req, target)
+		resp, err := target.Provider.Chat(ctx, attempt)
+		if err == nil && resp == nil {
+			err = NewProviderError(target.Provider.Name(), ErrorTypeInternal, "provider returned nil response without error")
+		}
+		if err != nil {
+			err = WrapError(err, target.Provider.Name())
+			if !r.shouldTryNext(ctx, i, err) {
+				return nil, err
+			}
+			warnings = append(warnings, fallbackWarning(target.Provider.Name(), attempt.Model, err))
+			lastErr = err
+			continue
+		}
+		if resp.Provider == "" {
+			resp.Provider = target.Provider.Name()
+		}
+		if resp.Model == "" {
+			resp.Model = attempt.Model
+		}
+		resp.Warnings = append(warnings, resp.Warnings...)
+		return resp, nil
+	}
+	return nil, r.exhaustedError(lastErr)
+}
+
+func (r *Router) Stream(ctx context.Context, req *Request) (Stream, error) {
+	var warnings []Warning
+	var lastErr error
+	for i, target := range r.targets {
+		attempt := r.attemptRequest(req, target)
+		stream, err := r.openStream(ctx, target, attempt)
+		if err != nil {
+			err = WrapError(err, target.Provider.Name())
+			if !r.shouldTryNext(ctx, i, err) {
+				return nil, err
+			}
+			warnings = append(warnings, fallbackWarning(target.Provider.Name(), attempt.Model, err))
+			lastErr = err
+			continue
+		}
+		stream.prefix = append(warningEvents(warnings), stream.prefix...)
+		return stream, nil
+	}
+	return nil, r.exhaustedError(lastErr)
+}
+
+// exhaustedError is returned if the loop over targets ends without a result,
+// which shouldTryNext prevents for the last target.
+func (r *Router) exhaustedError(lastErr error) error {
+	if lastErr != nil {
+		return lastErr
+	}
+	return NewProviderError(r.name, ErrorTypeProvider, "router exhausted targets without a result")
+}
+
+func (r *Router) attemptRequest(req *Request, target RouteTarget) *Request {
+	attempt := cloneRequest(*req)
+	attempt.Model = target.model(req.Model)
+	return attempt
+}
+
+func (r *Router) shouldTryNext(ctx context.Context, index int, err error) bool {
+	return index < len(r.targets)-1 && ctx.Err() == nil && r.fallback(err)
+}
+
+// openStream opens target's stream and reads ahead to the first content
+// event so a failure before any output can still fall back.
+func (r *Router) openStream(ctx context.Context, target RouteTarget, req *Request) (*routedStream, error) {
+	inner, err := target.Provider.Stream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if inner == nil {
+		return nil, NewProviderError(target.Provider.Name(), ErrorTypeInternal, "provider returned nil stream without error")
+	}
+	out := &routedStream{inner: inner, provider: target.Provider.Name(), model: req.Model}
+	for {
+		event, err := inner.Next()
+		if err == nil && event == nil {
+			err = fmt.Errorf("stream returned nil event without error")
+		}
+		if errEvent, ok := event.(ErrorEvent); ok && err == nil {
+			err = errEvent.Err
+		}
+		if errors.Is(err, io.EOF) {
+			out.end = err
+			return out, nil
+		}
+		if err != nil {
+			inner.Close()
+			return nil, err
+		}
+		out.prefix = append(out.prefix, event)
+		if isContentEvent(event) {
+			return out, nil
+		}
+	}
+}
+
+func isContentEvent(event Event) bool {
+	switch event.(type) {
+	case UsageEvent, WarningEvent, ProviderEvent, SafetyEvent:
+		return false
+	default:
+		return true
+	}
+}
+
+func fallbackWarning(provider, model string, err error) Warning {
+	return Warning{
+		Code:     "router.fallback",
+		Provider: provider,
+		Message:  fmt.Sprintf("%s (%s) failed, falling back: %v", provider, model, err),
+	}
+}
+
+func warningEvents(warnings []Warning) []Event {
+	events := make([]Event, 0, len(warnings))
+	for _, warning := range warnings {
+		events = append(events, WarningEvent{Warning: warning})
+	}
+	return events
+}
+
+// routedStream replays the events read while choosing a target, then
+// delegates to the target's stream.
+type routedStream struct {
+	inner    Stream
+	prefix   []Event
+	provider string
+	model    string
+	end      error
+}
+
+func (s *routedStream) Next() (Event, error) {
+	if len(s.prefix) > 0 {
+		event := s.prefix[0]
+		s.prefix = s.prefix[1:]
+		return event, nil
+	}
+	if s.end != nil {
+		return nil, s.end
+	}
+	return s.inner.Next()
+}
+
+func (s *routedStream) Close() error {
+	return s.inner.Close()
+}
+
+func (s *routedStream) ServedBy() (string, string) {
+	return s.provider, s.model
+}
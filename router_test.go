@@ -0,0 +1,208 @@
+package litellm
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestRouterChatFallsBackOnOverload(t *testing.T) {
+	primary := &testProvider{name: "anthropic", chatFunc: func(context.Context, *Request) (*Response, error) {
+		return nil, NewHTTPError("anthropic", 529, "overloaded")
+	}}
+	secondary := &testProvider{name: "bedrock"}
+	router, err := NewRouter([]RouteTarget{
+		{Provider: primary, Model: "claude"},
+		{Provider: secondary, Models: map[string]string{"sonnet": "anthropic.claude-v1"}},
+	})
+	if err != nil {
+		t.Fatalf("NewRouter returned error: %v", err)
+	}
+	var after CallMeta
+	client, err := New(router, WithHook(HookFuncs{AfterResponseFunc: func(_ context.Context, meta CallMeta, _ *Response, _ error) {
+		after = meta
+	}}))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	resp, err := client.Chat(context.Background(), Request{Model: "sonnet", Messages: []Message{UserText("hi")}})
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	if primary.lastReq.Model != "claude" || secondary.lastReq.Model != "anthropic.claude-v1" {
+		t.Fatalf("models = %q/%q", primary.lastReq.Model, secondary.lastReq.Model)
+	}
+	if resp.Provider != "bedrock" || resp.Model != "anthropic.claude-v1" {
+		t.Fatalf("resp provider/model = %q/%q", resp.Provider, resp.Model)
+	}
+	if after.Provider != "router" || after.ServedProvider != "bedrock" || after.ServedModel != "anthropic.claude-v1" {
+		t.Fatalf("meta = %+v", after)
+	}
+	if len(resp.Warnings) != 1 || resp.Warnings[0].Code != "router.fallback" || resp.Warnings[0].Provider != "anthropic" {
+		t.Fatalf("warnings = %+v", resp.Warnings)
+	}
+}
+
+func TestRouterChatDoesNotFallBackOnValidationError(t *testing.T) {
+	primary := &testProvider{name: "anthropic", chatFunc: func(context.Context, *Request) (*Response, error) {
+		return nil, NewProviderError("anthropic", ErrorTypeValidation, "bad request")
+	}}
+	secondary := &testProvider{name: "bedrock"}
+	router, err := NewRouter([]RouteTarget{{Provider: primary}, {Provider: secondary}})
+	if err != nil {
+		t.Fatalf("NewRouter returned error: %v", err)
+	}
+	_, err = router.Chat(context.Background(), &Request{Model: "m", Messages: []Message{UserText("hi")}})
+	if !IsValidationError(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if secondary.lastReq != nil {
+		t.Fatal("secondary target should not be called")
+	}
+}
+
+func TestRouterChatReturnsLastErrorWhenAllTargetsFail(t *testing.T) {
+	fail := func(name string) *testProvider {
+		return &testProvider{name: name, chatFunc: func(context.Context, *Request) (*Response, error) {
+			return nil, NewHTTPError(name, 429, "slow down")
+		}}
+	}
+	router, err := NewRouter([]RouteTarget{{Provider: fail("a")}, {Provider: fail("b")}})
+	if err != nil {
+		t.Fatalf("NewRouter returned error: %v", err)
+	}
+	_, err = router.Chat(context.Background(), &Request{Model: "m"})
+	var llmErr *LiteLLMError
+	if !errors.As(err, &llmErr) || llmErr.Provider != "b" || !IsRateLimitError(err) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestRouterStreamFallsBackBeforeFirstContent(t *testing.T) {
+	primary := &testProvider{name: "anthropic", streamFunc: func(context.Context, *Request) (Stream, error) {
+		return &testStreamWithError{
+			events: []Event{UsageEvent{Usage: Usage{InputTokens: 3}}},
+			err:    NewHTTPError("anthropic", 503, "unavailable"),
+		}, nil
+	}}
+	secondary := &testProvider{name: "openrouter"}
+	router, err := NewRouter([]RouteTarget{{Provider: primary}, {Provider: secondary, Model: "fallback-model"}})
+	if err != nil {
+		t.Fatalf("NewRouter returned error: %v", err)
+	}
+	var before CallMeta
+	client, err := New(router, WithHook(HookFuncs{AfterResponseFunc: func(_ context.Context, meta CallMeta, _ *Response, _ error) {
+		before = meta
+	}}))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	stream, err := client.Stream(context.Background(), Request{Model: "m", Messages: []Message{UserText("hi")}})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	defer stream.Close()
+	if before.ServedProvider != "openrouter" || before.ServedModel != "fallback-model" {
+		t.Fatalf("meta = %+v", before)
+	}
+	resp, err := Collect(stream)
+	if err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	if resp.Provider != "openrouter" || resp.Model != "fallback-model" || resp.Usage.InputTokens != 0 {
+		t.Fatalf("resp = %+v", resp)
+	}
+	if len(resp.Warnings) != 1 || resp.Warnings[0].Code != "router.fallback" {
+		t.Fatalf("warnings = %+v", resp.Warnings)
+	}
+}
+
+func TestRouterStreamDoesNotFallBackAfterContent(t *testing.T) {
+	primary := &testProvider{name: "anthropic", streamFunc: func(context.Context, *Request) (Stream, error) {
+		return &testStreamWithError{
+			events: []Event{ContentDelta{Text: "partial"}},
+			err:    NewHTTPError("anthropic", 503, "unavailable"),
+		}, nil
+	}}
+	secondary := &testProvider{name: "openrouter"}
+	router, err := NewRouter([]RouteTarget{{Provider: primary}, {Provider: secondary}})
+	if err != nil {
+		t.Fatalf("NewRouter returned error: %v", err)
+	}
+	stream, err := router.Stream(context.Background(), &Request{Model: "m"})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	event, err := stream.Next()
+	if err != nil || event.(ContentDelta).Text != "partial" {
+		t.Fatalf("first event = %#v, %v", event, err)
+	}
+	if _, err := stream.Next(); !IsRetryableError(err) {
+		t.Fatalf("expected mid-stream error, got %v", err)
+	}
+	if secondary.lastReq != nil {
+		t.Fatal("secondary target should not be called after content")
+	}
+}
+
+func TestRouterStreamPassesThroughEmptyStream(t *testing.T) {
+	router, err := NewRouter([]RouteTarget{{Provider: &testProvider{name: "a", streamFunc: func(context.Context, *Request) (Stream, error) {
+		return &testStream{}, nil
+	}}}})
+	if err != nil {
+		t.Fatalf("NewRouter returned error: %v", err)
+	}
+	stream, err := router.Stream(context.Background(), &Request{Model: "m"})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	if _, err := stream.Next(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestNewRouterValidatesTargets(t *testing.T) {
+	if _, err := NewRouter(nil); err == nil {
+		t.Fatal("expected error for empty targets")
+	}
+	if _, err := NewRouter([]RouteTarget{{}}); err == nil {
+		t.Fatal("expected error for nil provider")
+	}
+	if _, err := NewRouter([]RouteTarget{{Provider: &testProvider{name: "a"}}}, WithFallbackPolicy(nil)); err == nil {
+		t.Fatal("expected error for nil fallback policy")
+	}
+}
+
+func TestRouterStreamServedTargetSurvivesWrappers(t *testing.T) {
+	primary := &testProvider{name: "anthropic", streamFunc: func(context.Context, *Request) (Stream, error) {
+		return nil, NewHTTPError("anthropic", 503, "unavailable")
+	}}
+	router, err := NewRouter([]RouteTarget{{Provider: primary}, {Provider: &testProvider{name: "openrouter"}, Model: "fallback-model"}})
+	if err != nil {
+		t.Fatalf("NewRouter returned error: %v", err)
+	}
+	pool, err := NewPool([]PoolMember{{Provider: router}})
+	if err != nil {
+		t.Fatalf("NewPool returned error: %v", err)
+	}
+	limiter, err := NewRateLimiter(pool, RateLimits{RequestsPerMinute: 10})
+	if err != nil {
+		t.Fatalf("NewRateLimiter returned error: %v", err)
+	}
+	var meta CallMeta
+	client, err := New(limiter, WithHook(HookFuncs{AfterResponseFunc: func(_ context.Context, m CallMeta, _ *Response, _ error) {
+		meta = m
+	}}))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	stream, err := client.Stream(context.Background(), Request{Model: "m", Messages: []Message{UserText("hi")}})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	defer stream.Close()
+	if meta.ServedProvider != "openrouter" || meta.ServedModel != "fallback-model" {
+		t.Fatalf("meta = %+v", meta)
+	}
+}
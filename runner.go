@@ -0,0 +1,285 @@
+package litellm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrMaxSteps is returned by Runner when the model is still calling tools
+// after the configured number of steps.
+var ErrMaxSteps = errors.New("tool runner exceeded max steps")
+
+const defaultRunnerMaxSteps = 10
+
+// ToolFunc is a Go function exposed to the model as a tool. Build one with
+// NewToolFunc.
+type ToolFunc struct {
+	tool Tool
+	call func(ctx context.Context, args json.RawMessage) ([]Block, error)
+}
+
+// NewToolFunc wraps fn as a tool whose parameters schema is generated from
+// Args (see SchemaFor). A string result is sent as text, Block and []Block are
+// sent as is, and any other result is sent as JSON text.
+func NewToolFunc[Args, Result any](name, description string, fn func(context.Context, Args) (Result, error)) (*ToolFunc, error) {
+	if name == "" {
+		return nil, fmt.Errorf("tool name cannot be empty")
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("tool %s: handler cannot be nil", name)
+	}
+	schema, err := SchemaFor[Args]()
+	if err != nil {
+		return nil, fmt.Errorf("tool %s: %w", name, err)
+	}
+	return &ToolFunc{
+		tool: Tool{Name: name, Description: description, Parameters: schema},
+		call: func(ctx context.Context, raw json.RawMessage) ([]Block, error) {
+			var args Args
+			if len(raw) > 0 {
+				if err := json.Unmarshal(raw, &args); err != nil {
+					return nil, fmt.Errorf("invalid arguments: %w", err)
+				}
+			}
+			result, err := fn(ctx, args)
+			if err != nil {
+				return nil, err
+			}
+			return toolResultBlocks(result)
+		},
+	}, nil
+}
+
+// Tool returns the definition sent to the model.
+func (t *ToolFunc) Tool() Tool {
+	return t.tool
+}
+
+func toolResultBlocks(result any) ([]Block, error) {
+	switch v := result.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []Block{Text(v)}, nil
+	case Block:
+		return []Block{v}, nil
+	case []Block:
+		return v, nil
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal result: %w", err)
+	}
+	return []Block{Text(string(data))}, nil
+}
+
+// Runner drives the tool-use loop: it calls the model, executes the tool calls
+// it asks for, feeds the results back and repeats until the model answers
+// without calling a tool.
+type Runner struct {
+	client   *Client
+	tools    []*ToolFunc
+	byName   map[string]*ToolFunc
+	maxSteps int
+}
+
+type RunnerOption func(*Runner) error
+
+func NewRunner(client *Client, tools []*ToolFunc, opts ...RunnerOption) (*Runner, error) {
+	if client == nil {
+		return nil, fmt.Errorf("runner client cannot be nil")
+	}
+	r := &Runner{
+		client:   client,
+		byName:   make(map[string]*ToolFunc, len(tools)),
+		maxSteps: defaultRunnerMaxSteps,
+	}
+	for i, tool := range tools {
+		if tool == nil {
+			return nil, fmt.Errorf("tools[%d] cannot be nil", i)
+		}
+		if _, ok := r.byName[tool.tool.Name]; ok {
+			return nil, fmt.Errorf("duplicate tool %q", tool.tool.Name)
+		}
+		r.byName[tool.tool.Name] = tool
+		r.tools = append(r.tools, tool)
+	}
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, fmt.Errorf("apply runner option: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// WithMaxSteps bounds the number of model calls in one run. The default is 10.
+func WithMaxSteps(n int) RunnerOption {
+	return func(r *Runner) error {
+		if n <= 0 {
+			return fmt.Errorf("max steps must be positive")
+		}
+		r.maxSteps = n
+		return nil
+	}
+}
+
+// RunResult is the outcome of a run. Messages is the full transcript,
+// starting with the request messages; Response is the last model response and
+// Usage sums every step. Pending holds calls to request tools the runner
+// does not execute; see Run.
+type RunResult struct {
+	Messages []Message
+	Response *Response
+	Usage    Usage
+	Steps    int
+	Pending  []ToolUseBlock
+}
+
+// Run executes the tool loop for req. The registered tools are added to
+// req.Tools, which must not define a tool with the same name, and a
+// FinishReasonPause response is continued automatically. When the model calls
+// a tool from req.Tools, Run executes the registered calls of that turn and
+// returns with the others in RunResult.Pending; append their results to
+// Messages and run again to continue. On error the partial result is
+// returned alongside it; exceeding the step limit returns ErrMaxSteps with a
+// transcript that can be resumed.
+func (r *Runner) Run(ctx context.Context, req Request) (*RunResult, error) {
+	return r.run(ctx, req, func(ctx context.Context, req Request) (*Response, error) {
+		return r.client.Chat(ctx, req)
+	})
+}
+
+// RunStream is Run with each model call streamed; every event is forwarded to
+// fn as it arrives.
+func (r *Runner) RunStream(ctx context.Context, req Request, fn func(Event) error) (*RunResult, error) {
+	return r.run(ctx, req, func(ctx context.Context, req Request) (resp *Response, err error) {
+		stream, err := r.client.Stream(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if closeErr := stream.Close(); err == nil && closeErr != nil {
+				err = closeErr
+			}
+		}()
+		return Handle(stream, fn)
+	})
+}
+
+func (r *Runner) run(ctx context.Context, req Request, call func(context.Context, Request) (*Response, error)) (*RunResult, error) {
+	result := &RunResult{Messages: append([]Message(nil), req.Messages...)}
+	tools, err := r.mergeTools(req.Tools)
+	if err != nil {
+		return result, err
+	}
+	external := make(map[string]bool, len(req.Tools))
+	for _, tool := range req.Tools {
+		external[tool.Name] = true
+	}
+	req.Tools = tools
+	caps := r.client.Capabilities(req.Model).Tools.ParallelCalls
+	parallel := caps == SupportYes || caps == SupportPartial
+	for {
+		step := req
+		step.Messages = append([]Message(nil), result.Messages...)
+		resp, err := call(ctx, step)
+		if err != nil {
+			return result, err
+		}
+		result.Steps++
+		result.Response = resp
+		result.Usage.Add(resp.Usage)
+		result.Messages = append(result.Messages, Assistant(resp.Blocks...))
+
+		// A paused server tool turn resumes by sending the partial assistant
+		// message back unchanged.
+		calls := resp.ToolCalls()
+		if len(calls) == 0 && resp.FinishReason != FinishReasonPause {
+			return result, nil
+		}
+		var handled []ToolUseBlock
+		for _, call := range calls {
+			if external[call.Name] {
+				result.Pending = append(result.Pending, call)
+			} else {
+				handled = append(handled, call)
+			}
+		}
+		result.Messages = append(result.Messages, r.execute(ctx, handled, parallel)...)
+		if err := ctx.Err(); err != nil {
+			return result, WrapError(err, r.client.ProviderName())
+		}
+		if len(result.Pending) > 0 {
+			return result, nil
+		}
+		if result.Steps >= r.maxSteps {
+			return result, fmt.Errorf("%w (%d)", ErrMaxSteps, r.maxSteps)
+		}
+	}
+}
+
+// mergeTools adds the registered tools to the request's own. A request tool
+// sharing a registered tool's name is rejected: the model would see its
+// schema while the registered handler ran the call.
+func (r *Runner) mergeTools(tools []Tool) ([]Tool, error) {
+	for _, tool := range tools {
+		if _, ok := r.byName[tool.Name]; ok {
+			return nil, NewError(ErrorTypeValidation, fmt.Sprintf("request tool %q clashes with a registered tool", tool.Name))
+		}
+	}
+	out := append([]Tool(nil), tools...)
+	for _, tool := range r.tools {
+		out = append(out, tool.tool)
+	}
+	return out, nil
+}
+
+func (r *Runner) execute(ctx context.Context, calls []ToolUseBlock, parallel bool) []Message {
+	results := make([]Message, len(calls))
+	if !parallel || len(calls) == 1 {
+		for i, call := range calls {
+			results[i] = r.invoke(ctx, call)
+		}
+		return results
+	}
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.invoke(ctx, call)
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// invoke runs one tool call. Tools that are neither registered nor in the
+// request, bad arguments, handler errors and panics are reported to the model
+// as error results rather than ending the run.
+func (r *Runner) invoke(ctx context.Context, call ToolUseBlock) (msg Message) {
+	tool, ok := r.byName[call.Name]
+	if !ok {
+		return toolErrorResult(call.ID, fmt.Errorf("unknown tool %q", call.Name))
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			msg = toolErrorResult(call.ID, fmt.Errorf("tool %s panicked: %v", call.Name, p))
+		}
+	}()
+	blocks, err := tool.call(ctx, call.Arguments)
+	if err != nil {
+		return toolErrorResult(call.ID, err)
+	}
+	return ToolResult(call.ID, blocks...)
+}
+
+func toolErrorResult(toolUseID string, err error) Message {
+	return Message{
+		Role:   RoleTool,
+		Blocks: []Block{ToolResultBlock{ToolUseID: toolUseID, Content: []Block{Text(err.Error())}, IsError: true}},
+	}
+}
@@ -0,0 +1,314 @@
+package litellm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type weatherArgs struct {
+	City string `json:"city" description:"City name"`
+	Unit string `json:"unit,omitempty" enum:"c,f"`
+}
+
+type weatherResult struct {
+	TempC int `json:"temp_c"`
+}
+
+type parallelToolProvider struct {
+	*testProvider
+}
+
+func (p *parallelToolProvider) Capabilities(model string) Capabilities {
+	return Capabilities{Tools: ToolCapabilities{Calls: SupportYes, ParallelCalls: SupportYes}}
+}
+
+func toolCallResponse(calls ...ToolUseBlock) *Response {
+	blocks := make([]Block, len(calls))
+	for i, call := range calls {
+		blocks[i] = call
+	}
+	return &Response{Blocks: blocks, FinishReason: FinishReasonToolCall, Usage: Usage{InputTokens: 10, OutputTokens: 5, TotalTokens: 15}}
+}
+
+func TestRunnerExecutesToolsUntilAnswer(t *testing.T) {
+	weather, err := NewToolFunc("weather", "Current weather", func(ctx context.Context, args weatherArgs) (weatherResult, error) {
+		if args.City != "Paris" {
+			return weatherResult{}, errors.New("unknown city")
+		}
+		return weatherResult{TempC: 21}, nil
+	})
+	if err != nil {
+		t.Fatalf("NewToolFunc returned error: %v", err)
+	}
+	step := 0
+	provider := &testProvider{name: "test", chatFunc: func(_ context.Context, req *Request) (*Response, error) {
+		step++
+		switch step {
+		case 1:
+			if len(req.Tools) != 1 || req.Tools[0].Name != "weather" || !strings.Contains(string(req.Tools[0].Parameters), `"city"`) {
+				t.Fatalf("tools = %+v", req.Tools)
+			}
+			return toolCallResponse(
+				ToolUseBlock{ID: "call_1", Name: "weather", Arguments: json.RawMessage(`{"city":"Paris"}`)},
+				ToolUseBlock{ID: "call_2", Name: "weather", Arguments: json.RawMessage(`{"city":"Nowhere"}`)},
+				ToolUseBlock{ID: "call_3", Name: "missing", Arguments: json.RawMessage(`{}`)},
+			), nil
+		default:
+			return &Response{Blocks: []Block{TextBlock{Text: "21C in Paris"}}, Usage: Usage{InputTokens: 30, OutputTokens: 4, TotalTokens: 34}}, nil
+		}
+	}}
+	client, err := New(provider)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	runner, err := NewRunner(client, []*ToolFunc{weather})
+	if err != nil {
+		t.Fatalf("NewRunner returned error: %v", err)
+	}
+	result, err := runner.Run(context.Background(), Request{Model: "m", Messages: []Message{UserText("weather?")}})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if result.Steps != 2 || result.Response.Text() != "21C in Paris" || len(result.Messages) != 6 {
+		t.Fatalf("result = %+v", result)
+	}
+	if result.Usage.InputTokens != 40 || result.Usage.OutputTokens != 9 || result.Usage.TotalTokens != 49 {
+		t.Fatalf("usage = %+v", result.Usage)
+	}
+	ok := result.Messages[2].Blocks[0].(ToolResultBlock)
+	if ok.IsError || ok.Content[0].(TextBlock).Text != `{"temp_c":21}` {
+		t.Fatalf("ok result = %+v", ok)
+	}
+	for _, msg := range result.Messages[3:5] {
+		if res := msg.Blocks[0].(ToolResultBlock); !res.IsError {
+			t.Fatalf("expected error result, got %+v", res)
+		}
+	}
+}
+
+func TestRunnerStopsAtMaxSteps(t *testing.T) {
+	echo, err := NewToolFunc("echo", "", func(ctx context.Context, args struct{}) (string, error) {
+		return "again", nil
+	})
+	if err != nil {
+		t.Fatalf("NewToolFunc returned error: %v", err)
+	}
+	calls := 0
+	provider := &testProvider{name: "test", chatFunc: func(context.Context, *Request) (*Response, error) {
+		calls++
+		return toolCallResponse(ToolUseBlock{ID: "call_" + string(rune('a'+calls)), Name: "echo", Arguments: json.RawMessage(`{}`)}), nil
+	}}
+	client, _ := New(provider)
+	runner, err := NewRunner(client, []*ToolFunc{echo}, WithMaxSteps(2))
+	if err != nil {
+		t.Fatalf("NewRunner returned error: %v", err)
+	}
+	result, err := runner.Run(context.Background(), Request{Model: "m", Messages: []Message{UserText("loop")}})
+	if !errors.Is(err, ErrMaxSteps) || result.Steps != 2 || calls != 2 {
+		t.Fatalf("result = %+v, err = %v", result, err)
+	}
+	if got := result.Messages[len(result.Messages)-1].Role; got != RoleTool {
+		t.Fatalf("transcript should end with tool results, got %s", got)
+	}
+}
+
+func TestRunnerResumesPausedServerToolTurn(t *testing.T) {
+	search := Tool{Name: "web_search", Server: &ServerTool{Type: "web_search_20250305", Config: map[string]any{"max_uses": 2}}}
+	step := 0
+	provider := &testProvider{name: "test", chatFunc: func(_ context.Context, req *Request) (*Response, error) {
+		step++
+		if step == 1 {
+			return &Response{FinishReason: FinishReasonPause, Blocks: []Block{
+				ServerToolUseBlock{ID: "srvtoolu_1", Name: "web_search", Arguments: json.RawMessage(`{"query":"go"}`)},
+				ServerToolResultBlock{ToolUseID: "srvtoolu_1", Type: "web_search_tool_result", Content: json.RawMessage(`[]`)},
+			}}, nil
+		}
+		last := req.Messages[len(req.Messages)-1]
+		if last.Role != RoleAssistant || len(last.Blocks) != 2 || req.Tools[0].Server == nil {
+			t.Fatalf("resumed request = %+v", req)
+		}
+		return &Response{FinishReason: FinishReasonStop, Blocks: []Block{Text("done")}}, nil
+	}}
+	client, _ := New(provider)
+	runner, err := NewRunner(client, nil)
+	if err != nil {
+		t.Fatalf("NewRunner returned error: %v", err)
+	}
+	result, err := runner.Run(context.Background(), Request{Model: "m", Tools: []Tool{search}, Messages: []Message{UserText("search")}})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if result.Steps != 2 || result.Response.Text() != "done" || len(result.Messages) != 3 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	for _, tool := range []Tool{
+		{Name: "web_search", Server: &ServerTool{}},
+		{Name: "web_search", Parameters: Schema(`{"type":"object"}`), Server: &ServerTool{Type: "web_search_20250305"}},
+	} {
+		if _, err := client.Chat(context.Background(), Request{Model: "m", Tools: []Tool{tool}, Messages: []Message{UserText("hi")}}); !IsValidationError(err) {
+			t.Fatalf("Chat(%+v) error = %v, want validation error", tool, err)
+		}
+	}
+}
+
+func TestRunnerRunsParallelCallsConcurrently(t *testing.T) {
+	var active, peak int32
+	slow, err := NewToolFunc("slow", "", func(ctx context.Context, args struct{}) (string, error) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return "done", nil
+	})
+	if err != nil {
+		t.Fatalf("NewToolFunc returned error: %v", err)
+	}
+	step := 0
+	provider := &parallelToolProvider{&testProvider{name: "test", chatFunc: func(context.Context, *Request) (*Response, error) {
+		step++
+		if step == 1 {
+			return toolCallResponse(
+				ToolUseBlock{ID: "call_1", Name: "slow", Arguments: json.RawMessage(`{}`)},
+				ToolUseBlock{ID: "call_2", Name: "slow", Arguments: json.RawMessage(`{}`)},
+			), nil
+		}
+		return &Response{Blocks: []Block{TextBlock{Text: "ok"}}}, nil
+	}}}
+	client, _ := New(provider)
+	runner, _ := NewRunner(client, []*ToolFunc{slow})
+	result, err := runner.Run(context.Background(), Request{Model: "m", Messages: []Message{UserText("go")}})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if peak != 2 {
+		t.Fatalf("peak concurrency = %d, want 2", peak)
+	}
+	if result.Messages[2].Blocks[0].(ToolResultBlock).ToolUseID != "call_1" || result.Messages[3].Blocks[0].(ToolResultBlock).ToolUseID != "call_2" {
+		t.Fatalf("tool results out of order: %+v", result.Messages)
+	}
+}
+
+func TestRunnerRunStreamForwardsEvents(t *testing.T) {
+	ping, err := NewToolFunc("ping", "", func(ctx context.Context, args struct{}) (string, error) {
+		return "pong", nil
+	})
+	if err != nil {
+		t.Fatalf("NewToolFunc returned error: %v", err)
+	}
+	step := 0
+	provider := &testProvider{name: "test", streamFunc: func(_ context.Context, req *Request) (Stream, error) {
+		step++
+		if step == 1 {
+			return &testStream{events: []Event{
+				ToolUseStart{ID: "call_1", Name: "ping"},
+				ToolUseDelta{ID: "call_1", ArgumentsDelta: json.RawMessage(`{}`)},
+				ToolUseDone{ID: "call_1"},
+				UsageEvent{Usage: Usage{InputTokens: 3, OutputTokens: 2}},
+				DoneEvent{FinishReason: FinishReasonToolCall, Provider: "test", Model: req.Model},
+			}}, nil
+		}
+		return &testStream{events: []Event{
+			ContentDelta{Text: "pong!"},
+			UsageEvent{Usage: Usage{InputTokens: 7, OutputTokens: 1}},
+			DoneEvent{FinishReason: FinishReasonStop, Provider: "test", Model: req.Model},
+		}}, nil
+	}}
+	client, _ := New(provider)
+	runner, _ := NewRunner(client, []*ToolFunc{ping})
+	var text string
+	var events int
+	result, err := runner.RunStream(context.Background(), Request{Model: "m", Messages: []Message{UserText("ping")}}, func(event Event) error {
+		events++
+		if delta, ok := event.(ContentDelta); ok {
+			text += delta.Text
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunStream returned error: %v", err)
+	}
+	if text != "pong!" || events != 8 || result.Steps != 2 || result.Usage.InputTokens != 10 {
+		t.Fatalf("text = %q, events = %d, result = %+v", text, events, result)
+	}
+}
+
+func TestRunnerRejectsRequestToolClash(t *testing.T) {
+	echo, err := NewToolFunc("echo", "", func(ctx context.Context, args struct{}) (string, error) {
+		return "registered", nil
+	})
+	if err != nil {
+		t.Fatalf("NewToolFunc returned error: %v", err)
+	}
+	provider := &testProvider{name: "test"}
+	client, _ := New(provider)
+	runner, err := NewRunner(client, []*ToolFunc{echo})
+	if err != nil {
+		t.Fatalf("NewRunner returned error: %v", err)
+	}
+	_, err = runner.Run(context.Background(), Request{
+		Model:    "m",
+		Messages: []Message{UserText("hi")},
+		Tools:    []Tool{{Name: "echo"}},
+	})
+	if !IsValidationError(err) || !strings.Contains(err.Error(), `"echo"`) {
+		t.Fatalf("expected clash validation error, got %v", err)
+	}
+	if provider.lastReq != nil {
+		t.Fatal("provider should not be called")
+	}
+}
+
+func TestRunnerReturnsPendingRequestToolCalls(t *testing.T) {
+	echo, err := NewToolFunc("echo", "", func(ctx context.Context, args struct{}) (string, error) {
+		return "registered", nil
+	})
+	if err != nil {
+		t.Fatalf("NewToolFunc returned error: %v", err)
+	}
+	calls := 0
+	provider := &testProvider{name: "test", chatFunc: func(_ context.Context, req *Request) (*Response, error) {
+		calls++
+		if calls == 2 {
+			if last := req.Messages[len(req.Messages)-1]; last.Role != RoleTool || last.Blocks[0].(ToolResultBlock).ToolUseID != "call_2" {
+				t.Fatalf("resumed transcript ends with %+v", last)
+			}
+			return &Response{Blocks: []Block{TextBlock{Text: "done"}}}, nil
+		}
+		return toolCallResponse(
+			ToolUseBlock{ID: "call_1", Name: "echo", Arguments: json.RawMessage(`{}`)},
+			ToolUseBlock{ID: "call_2", Name: "approve", Arguments: json.RawMessage(`{}`)},
+		), nil
+	}}
+	client, _ := New(provider)
+	runner, err := NewRunner(client, []*ToolFunc{echo})
+	if err != nil {
+		t.Fatalf("NewRunner returned error: %v", err)
+	}
+	req := Request{Model: "m", Messages: []Message{UserText("hi")}, Tools: []Tool{{Name: "approve"}}}
+	result, err := runner.Run(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+	if calls != 1 || len(result.Pending) != 1 || result.Pending[0].ID != "call_2" || len(result.Messages) != 3 {
+		t.Fatalf("result = %+v", result)
+	}
+	if res := result.Messages[2].Blocks[0].(ToolResultBlock); res.ToolUseID != "call_1" || res.IsError {
+		t.Fatalf("registered call result = %+v", res)
+	}
+	req.Messages = append(result.Messages, ToolResultText("call_2", "approved"))
+	result, err = runner.Run(context.Background(), req)
+	if err != nil || result.Response.Text() != "done" || len(result.Pending) != 0 {
+		t.Fatalf("resumed result = %+v, err = %v", result, err)
+	}
+}
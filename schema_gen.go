@@ -0,0 +1,258 @@
+package litellm
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaFor generates a JSON schema for T. Structs become closed objects whose
+// properties follow the json tags; fields are required unless tagged
+// omitempty/omitzero, and `required:"true|false"` overrides that. The
+// `description` tag documents a field and `enum:"a,b,c"` restricts its values.
+// Recursive types are not supported.
+func SchemaFor[T any]() (Schema, error) {
+	return SchemaForType(reflect.TypeFor[T]())
+}
+
+// SchemaForType is SchemaFor for a reflect.Type.
+func SchemaForType(t reflect.Type) (Schema, error) {
+	if t == nil {
+		return nil, fmt.Errorf("schema type cannot be nil")
+	}
+	g := schemaGenerator{visiting: map[reflect.Type]bool{}}
+	node, err := g.generate(t)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(node)
+	if err != nil {
+		return nil, fmt.Errorf("marshal schema: %w", err)
+	}
+	return Schema(data), nil
+}
+
+// schemaObject is a JSON object that keeps insertion order so generated
+// property order matches struct field order.
+type schemaObject []schemaField
+
+type schemaField struct {
+	key   string
+	value any
+}
+
+func (o *schemaObject) set(key string, value any) {
+	*o = append(*o, schemaField{key: key, value: value})
+}
+
+func (o schemaObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(field.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	schemaType        = reflect.TypeFor[Schema]()
+)
+
+type schemaGenerator struct {
+	visiting map[reflect.Type]bool
+}
+
+func (g schemaGenerator) generate(t reflect.Type) (schemaObject, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var out schemaObject
+	switch {
+	case t == timeType:
+		out.set("type", "string")
+		out.set("format", "date-time")
+		return out, nil
+	case t == rawMessageType || t == schemaType:
+		return schemaObject{}, nil
+	case t.Kind() != reflect.Struct && t.Implements(textMarshalerType):
+		out.set("type", "string")
+		return out, nil
+	case t.Kind() != reflect.Struct && t.Implements(jsonMarshalerType):
+		return schemaObject{}, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		out.set("type", "boolean")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		out.set("type", "integer")
+	case reflect.Float32, reflect.Float64:
+		out.set("type", "number")
+	case reflect.String:
+		out.set("type", "string")
+	case reflect.Interface:
+		return schemaObject{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			out.set("type", "string")
+			out.set("contentEncoding", "base64")
+			return out, nil
+		}
+		items, err := g.generate(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		out.set("type", "array")
+		out.set("items", items)
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("schema: map key %s must be a string", t.Key())
+		}
+		values, err := g.generate(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		out.set("type", "object")
+		out.set("additionalProperties", values)
+	case reflect.Struct:
+		return g.generateStruct(t)
+	default:
+		return nil, fmt.Errorf("schema: unsupported type %s", t)
+	}
+	return out, nil
+}
+
+func (g schemaGenerator) generateStruct(t reflect.Type) (schemaObject, error) {
+	if g.visiting[t] {
+		return nil, fmt.Errorf("schema: recursive type %s is not supported", t)
+	}
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+
+	properties := schemaObject{}
+	required := []string{}
+	if err := g.collectFields(t, &properties, &required); err != nil {
+		return nil, err
+	}
+	var out schemaObject
+	out.set("type", "object")
+	out.set("properties", properties)
+	out.set("required", required)
+	out.set("additionalProperties", false)
+	return out, nil
+}
+
+func (g schemaGenerator) collectFields(t reflect.Type, properties *schemaObject, required *[]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := g.collectFields(embedded, properties, required); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		prop, err := g.generate(field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if desc := field.Tag.Get("description"); desc != "" {
+			prop.set("description", desc)
+		}
+		if enum := field.Tag.Get("enum"); enum != "" {
+			values, err := enumValues(field.Type, enum)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			prop = withEnum(prop, field.Type, values)
+		}
+		properties.set(name, prop)
+		optional := strings.Contains(","+opts+",", ",omitempty,") || strings.Contains(","+opts+",", ",omitzero,")
+		switch field.Tag.Get("required") {
+		case "true":
+			optional = false
+		case "false":
+			optional = true
+		}
+		if !optional {
+			*required = append(*required, name)
+		}
+	}
+	return nil
+}
+
+// withEnum sets enum on node, or for slices and arrays on their innermost
+// items schema, since the tag restricts the elements rather than the list.
+func withEnum(node schemaObject, t reflect.Type, values []any) schemaObject {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && t.Elem().Kind() != reflect.Uint8 {
+		for i := range node {
+			if items, ok := node[i].value.(schemaObject); ok && node[i].key == "items" {
+				node[i].value = withEnum(items, t.Elem(), values)
+			}
+		}
+		return node
+	}
+	node.set("enum", values)
+	return node
+}
+
+func enumValues(t reflect.Type, tag string) ([]any, error) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	var values []any
+	for _, raw := range strings.Split(tag, ",") {
+		raw = strings.TrimSpace(raw)
+		if t.Kind() == reflect.String {
+			values = append(values, raw)
+			continue
+		}
+		var value any
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, fmt.Errorf("enum value %q is not valid for %s", raw, t)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
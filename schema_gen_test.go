@@ -0,0 +1,88 @@
+package litellm
+
+import (
+	"testing"
+	"time"
+)
+
+type schemaAddress struct {
+	Street string `json:"street"`
+}
+
+type schemaBase struct {
+	ID string `json:"id"`
+}
+
+type schemaSample struct {
+	schemaBase
+	Name     string            `json:"name" description:"Full name"`
+	Age      int               `json:"age,omitempty"`
+	Score    float64           `json:"score,omitempty" required:"true"`
+	Role     string            `json:"role" enum:"admin,user" required:"false"`
+	Levels   []int             `json:"levels" enum:"1,2,3"`
+	Tags     []string          `json:"tags,omitempty" enum:"a,b"`
+	Address  *schemaAddress    `json:"address"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Born     time.Time         `json:"born"`
+	Data     []byte            `json:"data,omitempty"`
+	Extra    any               `json:"extra,omitempty"`
+	Skipped  string            `json:"-"`
+	internal string
+}
+
+type schemaNode struct {
+	Children []schemaNode `json:"children"`
+}
+
+func TestSchemaForStruct(t *testing.T) {
+	schema, err := SchemaFor[schemaSample]()
+	if err != nil {
+		t.Fatalf("SchemaFor returned error: %v", err)
+	}
+	want := `{"type":"object","properties":{` +
+		`"id":{"type":"string"},` +
+		`"name":{"type":"string","description":"Full name"},` +
+		`"age":{"type":"integer"},` +
+		`"score":{"type":"number"},` +
+		`"role":{"type":"string","enum":["admin","user"]},` +
+		`"levels":{"type":"array","items":{"type":"integer","enum":[1,2,3]}},` +
+		`"tags":{"type":"array","items":{"type":"string","enum":["a","b"]}},` +
+		`"address":{"type":"object","properties":{"street":{"type":"string"}},"required":["street"],"additionalProperties":false},` +
+		`"labels":{"type":"object","additionalProperties":{"type":"string"}},` +
+		`"born":{"type":"string","format":"date-time"},` +
+		`"data":{"type":"string","contentEncoding":"base64"},` +
+		`"extra":{}` +
+		`},"required":["id","name","score","levels","address","born"],"additionalProperties":false}`
+	if string(schema) != want {
+		t.Fatalf("schema =\n%s\nwant\n%s", schema, want)
+	}
+}
+
+func TestSchemaForSliceEnumAcceptsMatchingElements(t *testing.T) {
+	schema, err := SchemaFor[struct {
+		Tags [][]string `json:"tags" enum:"a,b"`
+	}]()
+	if err != nil {
+		t.Fatalf("SchemaFor returned error: %v", err)
+	}
+	violations, err := ValidateSchema(schema, []byte(`{"tags":[["a","b"],["b"]]}`))
+	if err != nil || len(violations) != 0 {
+		t.Fatalf("valid tags rejected: %v, %v", violations, err)
+	}
+	violations, err = ValidateSchema(schema, []byte(`{"tags":[["c"]]}`))
+	if err != nil || len(violations) == 0 {
+		t.Fatalf("expected enum violation, got %v, %v", violations, err)
+	}
+}
+
+func TestSchemaForRejectsUnsupportedTypes(t *testing.T) {
+	if _, err := SchemaFor[schemaNode](); err == nil {
+		t.Fatal("expected error for recursive type")
+	}
+	if _, err := SchemaFor[map[int]string](); err == nil {
+		t.Fatal("expected error for non-string map key")
+	}
+	if _, err := SchemaFor[chan int](); err == nil {
+		t.Fatal("expected error for channel")
+	}
+}
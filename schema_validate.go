@@ -0,0 +1,566 @@
+package litellm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SchemaViolation is one place where a JSON value does not satisfy a schema.
+// Path is a JSON pointer into the value ("" is the root).
+type SchemaViolation struct {
+	Path    string
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	path := v.Path
+	if path == "" {
+		path = "/"
+	}
+	return path + ": " + v.Message
+}
+
+// ValidateSchema checks value against schema and returns every violation
+// found. It implements the draft 2020-12 subset that tool and structured
+// output schemas use: type, enum, const, properties, required,
+// additionalProperties, items, prefixItems, array and object size limits,
+// string length and pattern, numeric bounds, multipleOf, allOf, anyOf, oneOf,
+// not, and local $ref into $defs or definitions. Other keywords are ignored.
+func ValidateSchema(schema Schema, value []byte) ([]SchemaViolation, error) {
+	root, err := decodeJSONNumbers(schema)
+	if err != nil {
+		return nil, fmt.Errorf("decode schema: %w", err)
+	}
+	instance, err := decodeJSONNumbers(value)
+	if err != nil {
+		return nil, fmt.Errorf("decode value: %w", err)
+	}
+	v := schemaValidator{root: root}
+	v.validate(root, instance, "", 0)
+	return v.violations, nil
+}
+
+func decodeJSONNumbers(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after JSON value")
+	}
+	return out, nil
+}
+
+// maxSchemaRefDepth bounds $ref expansion so self-referencing schemas cannot
+// recurse forever on adversarial input.
+const maxSchemaRefDepth = 64
+
+type schemaValidator struct {
+	root       any
+	violations []SchemaViolation
+}
+
+func (v *schemaValidator) fail(path, format string, args ...any) {
+	v.violations = append(v.violations, SchemaViolation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// valid reports whether instance satisfies schema without recording
+// violations, for the combinators.
+func (v *schemaValidator) valid(schema, instance any, depth int) bool {
+	sub := schemaValidator{root: v.root}
+	sub.validate(schema, instance, "", depth)
+	return len(sub.violations) == 0
+}
+
+func (v *schemaValidator) validate(schema, instance any, path string, depth int) {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			v.fail(path, "value is not allowed")
+		}
+		return
+	case map[string]any:
+		v.validateObjectSchema(s, instance, path, depth)
+	}
+}
+
+func (v *schemaValidator) validateObjectSchema(s map[string]any, instance any, path string, depth int) {
+	if ref, ok := s["$ref"].(string); ok {
+		if depth >= maxSchemaRefDepth {
+			v.fail(path, "schema $ref nesting is too deep")
+			return
+		}
+		if target, ok := v.resolveRef(ref); ok {
+			v.validate(target, instance, path, depth+1)
+		}
+	}
+	if t, ok := s["type"]; ok && !matchesSchemaType(t, instance) {
+		v.fail(path, "expected %s, got %s", describeSchemaType(t), jsonTypeName(instance))
+		return
+	}
+	if enum, ok := s["enum"].([]any); ok {
+		found := false
+		for _, allowed := range enum {
+			if jsonEqual(allowed, instance) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "value is not one of the allowed enum values")
+		}
+	}
+	if constant, ok := s["const"]; ok && !jsonEqual(constant, instance) {
+		v.fail(path, "value does not match const")
+	}
+
+	switch value := instance.(type) {
+	case map[string]any:
+		v.validateObject(s, value, path, depth)
+	case []any:
+		v.validateArray(s, value, path, depth)
+	case string:
+		v.validateString(s, value, path)
+	case json.Number:
+		v.validateNumber(s, value, path)
+	}
+
+	if all, ok := s["allOf"].([]any); ok {
+		for _, sub := range all {
+			v.validate(sub, instance, path, depth)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]any); ok {
+		matched := false
+		for _, sub := range anyOf {
+			if v.valid(sub, instance, depth) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.fail(path, "value does not match any schema in anyOf")
+		}
+	}
+	if oneOf, ok := s["oneOf"].([]any); ok {
+		matched := 0
+		for _, sub := range oneOf {
+			if v.valid(sub, instance, depth) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			v.fail(path, "value matches %d schemas in oneOf, want exactly 1", matched)
+		}
+	}
+	if not, ok := s["not"]; ok && v.valid(not, instance, depth) {
+		v.fail(path, "value matches a schema in not")
+	}
+}
+
+func (v *schemaValidator) validateObject(s map[string]any, value map[string]any, path string, depth int) {
+	if required, ok := s["required"].([]any); ok {
+		for _, name := range required {
+			if key, ok := name.(string); ok {
+				if _, present := value[key]; !present {
+					v.fail(path, "missing required property %q", key)
+				}
+			}
+		}
+	}
+	properties, _ := s["properties"].(map[string]any)
+	additional, hasAdditional := s["additionalProperties"]
+	for _, key := range sortedKeys(value) {
+		child := path + "/" + escapeJSONPointer(key)
+		if prop, ok := properties[key]; ok {
+			v.validate(prop, value[key], child, depth)
+			continue
+		}
+		if !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok && !allowed {
+			v.fail(child, "property is not allowed")
+			continue
+		}
+		v.validate(additional, value[key], child, depth)
+	}
+	if n, ok := schemaInt(s["minProperties"]); ok && len(value) < n {
+		v.fail(path, "object has %d properties, want at least %d", len(value), n)
+	}
+	if n, ok := schemaInt(s["maxProperties"]); ok && len(value) > n {
+		v.fail(path, "object has %d properties, want at most %d", len(value), n)
+	}
+}
+
+func (v *schemaValidator) validateArray(s map[string]any, value []any, path string, depth int) {
+	prefix, _ := s["prefixItems"].([]any)
+	for i, item := range value {
+		child := path + "/" + strconv.Itoa(i)
+		if i < len(prefix) {
+			v.validate(prefix[i], item, child, depth)
+		} else if items, ok := s["items"]; ok {
+			v.validate(items, item, child, depth)
+		}
+	}
+	if n, ok := schemaInt(s["minItems"]); ok && len(value) < n {
+		v.fail(path, "array has %d items, want at least %d", len(value), n)
+	}
+	if n, ok := schemaInt(s["maxItems"]); ok && len(value) > n {
+		v.fail(path, "array has %d items, want at most %d", len(value), n)
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+		for i := range value {
+			for j := i + 1; j < len(value); j++ {
+				if jsonEqual(value[i], value[j]) {
+					v.fail(path, "array items %d and %d are equal", i, j)
+					return
+				}
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) validateString(s map[string]any, value, path string) {
+	length := utf8.RuneCountInString(value)
+	if n, ok := schemaInt(s["minLength"]); ok && length < n {
+		v.fail(path, "string has length %d, want at least %d", length, n)
+	}
+	if n, ok := schemaInt(s["maxLength"]); ok && length > n {
+		v.fail(path, "string has length %d, want at most %d", length, n)
+	}
+	if pattern, ok := s["pattern"].(string); ok {
+		// Patterns RE2 cannot compile are skipped rather than reported.
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(value) {
+			v.fail(path, "string does not match pattern %q", pattern)
+		}
+	}
+}
+
+func (v *schemaValidator) validateNumber(s map[string]any, value json.Number, path string) {
+	n, err := value.Float64()
+	if err != nil {
+		return
+	}
+	if min, ok := schemaFloat(s["minimum"]); ok && n < min {
+		v.fail(path, "value %s is less than minimum %v", value, min)
+	}
+	if max, ok := schemaFloat(s["maximum"]); ok && n > max {
+		v.fail(path, "value %s is greater than maximum %v", value, max)
+	}
+	if min, ok := schemaFloat(s["exclusiveMinimum"]); ok && n <= min {
+		v.fail(path, "value %s must be greater than %v", value, min)
+	}
+	if max, ok := schemaFloat(s["exclusiveMaximum"]); ok && n >= max {
+		v.fail(path, "value %s must be less than %v", value, max)
+	}
+	if step, ok := schemaFloat(s["multipleOf"]); ok && step > 0 {
+		if q := n / step; math.Abs(q-math.Round(q)) > 1e-9 {
+			v.fail(path, "value %s is not a multiple of %v", value, step)
+		}
+	}
+}
+
+func (v *schemaValidator) resolveRef(ref string) (any, bool) {
+	if ref == "#" {
+		return v.root, true
+	}
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil, false
+	}
+	node := v.root
+	for _, token := range strings.Split(pointer, "/") {
+		obj, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		if node, ok = obj[token]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+func matchesSchemaType(t, instance any) bool {
+	switch t := t.(type) {
+	case string:
+		return matchesJSONType(t, instance)
+	case []any:
+		for _, name := range t {
+			if s, ok := name.(string); ok && matchesJSONType(s, instance) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func matchesJSONType(name string, instance any) bool {
+	switch name {
+	case "integer":
+		n, ok := instance.(json.Number)
+		if !ok {
+			return false
+		}
+		if _, err := n.Int64(); err == nil {
+			return true
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	case "number":
+		_, ok := instance.(json.Number)
+		return ok
+	default:
+		return jsonTypeName(instance) == name
+	}
+}
+
+func describeSchemaType(t any) string {
+	if list, ok := t.([]any); ok {
+		names := make([]string, 0, len(list))
+		for _, name := range list {
+			names = append(names, fmt.Sprint(name))
+		}
+		return strings.Join(names, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+func jsonTypeName(instance any) string {
+	switch instance.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", instance)
+	}
+}
+
+// jsonEqual compares decoded JSON values, treating numbers by value so 1 and
+// 1.0 are equal.
+func jsonEqual(a, b any) bool {
+	an, aNum := a.(json.Number)
+	bn, bNum := b.(json.Number)
+	if aNum || bNum {
+		if !aNum || !bNum {
+			return false
+		}
+		af, errA := an.Float64()
+		bf, errB := bn.Float64()
+		return errA == nil && errB == nil && af == bf
+	}
+	switch av := a.(type) {
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, ok := bv[key]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func schemaInt(v any) (int, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := n.Int64()
+	return int(i), err == nil
+}
+
+func schemaFloat(v any) (float64, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	f, err := n.Float64()
+	return f, err == nil
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// maxSchemaWarnings caps the violations reported per block so a badly
+// malformed payload does not flood Response.Warnings.
+const maxSchemaWarnings = 8
+
+// checkResponseSchemas validates tool arguments against the matching
+// Tool.Parameters and the answer text against ResponseFormat.JSONSchema.
+func checkResponseSchemas(req *Request, resp *Response, provider string) []Warning {
+	if req == nil || resp == nil {
+		return nil
+	}
+	var warnings []Warning
+	for _, call := range resp.ToolCalls() {
+		schema := toolParameters(req.Tools, call.Name)
+		if len(schema) == 0 || len(call.Arguments) == 0 || !json.Valid(call.Arguments) {
+			continue
+		}
+		subject := fmt.Sprintf("tool use %q (%s) arguments", call.ID, call.Name)
+		warnings = append(warnings, schemaWarnings("schema.tool_arguments_invalid", subject, schema, call.Arguments, provider)...)
+	}
+	format := req.ResponseFormat
+	if format != nil && format.Type == ResponseFormatJSONSchema && format.JSONSchema != nil && len(format.JSONSchema.Schema) > 0 && resp.Refusal == "" {
+		text := stripCodeFence(resp.Text())
+		if json.Valid([]byte(text)) {
+			warnings = append(warnings, schemaWarnings("schema.response_invalid", "response", format.JSONSchema.Schema, []byte(text), provider)...)
+		} else if strings.TrimSpace(text) != "" {
+			warnings = append(warnings, Warning{Code: "schema.response_invalid", Provider: provider, Message: "response is not valid JSON"})
+		}
+	}
+	return warnings
+}
+
+func toolParameters(tools []Tool, name string) Schema {
+	for _, tool := range tools {
+		if tool.Name == name {
+			return tool.Parameters
+		}
+	}
+	return nil
+}
+
+func schemaWarnings(code, subject string, schema Schema, value []byte, provider string) []Warning {
+	violations, err := ValidateSchema(schema, value)
+	if err != nil {
+		return []Warning{{Code: code, Provider: provider, Message: fmt.Sprintf("%s could not be validated: %v", subject, err)}}
+	}
+	var warnings []Warning
+	for i, violation := range violations {
+		if i == maxSchemaWarnings {
+			warnings = append(warnings, Warning{Code: code, Provider: provider, Message: fmt.Sprintf("%s: %d more violations", subject, len(violations)-i)})
+			break
+		}
+		warnings = append(warnings, Warning{Code: code, Provider: provider, Message: fmt.Sprintf("%s violate schema at %s", subject, violation)})
+	}
+	return warnings
+}
+
+// schemaViolationError turns schema warnings into the error returned when the
+// client enables strict validation.
+func schemaViolationError(provider string, warnings []Warning) error {
+	messages := make([]string, len(warnings))
+	for i, warning := range warnings {
+		messages[i] = warning.Message
+	}
+	err := NewValidationError(provider, strings.Join(messages, "; "))
+	err.Code = "schema_violation"
+	return err
+}
+
+// schemaCheckingStream validates the collected response when the stream
+// finishes and emits the violations as WarningEvents just before the
+// DoneEvent, or fails the stream under strict validation.
+type schemaCheckingStream struct {
+	inner     Stream
+	req       *Request
+	provider  string
+	strict    bool
+	collector *EventCollector
+	pending   []Event
+}
+
+func newSchemaCheckingStream(inner Stream, req *Request, provider string, strict bool) Stream {
+	if !hasResponseSchemas(req) {
+		return inner
+	}
+	return &schemaCheckingStream{inner: inner, req: req, provider: provider, strict: strict, collector: NewEventCollector()}
+}
+
+func hasResponseSchemas(req *Request) bool {
+	if req.ResponseFormat != nil && req.ResponseFormat.Type == ResponseFormatJSONSchema && req.ResponseFormat.JSONSchema != nil {
+		return true
+	}
+	for _, tool := range req.Tools {
+		if len(tool.Parameters) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *schemaCheckingStream) Next() (Event, error) {
+	if len(s.pending) > 0 {
+		event := s.pending[0]
+		s.pending = s.pending[1:]
+		return event, nil
+	}
+	event, err := s.inner.Next()
+	if err != nil {
+		return event, err
+	}
+	done, ok := event.(DoneEvent)
+	if !ok {
+		s.collector.Apply(event)
+		return event, nil
+	}
+	if _, err := s.collector.Apply(done); err != nil {
+		return done, nil
+	}
+	warnings := checkResponseSchemas(s.req, s.collector.Response(), s.provider)
+	if len(warnings) == 0 {
+		return done, nil
+	}
+	if s.strict {
+		return nil, schemaViolationError(s.provider, warnings)
+	}
+	for _, warning := range warnings[1:] {
+		s.pending = append(s.pending, WarningEvent{Warning: warning})
+	}
+	s.pending = append(s.pending, done)
+	return WarningEvent{Warning: warnings[0]}, nil
+}
+
+func (s *schemaCheckingStream) Close() error {
+	return s.inner.Close()
+}
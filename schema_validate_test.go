@@ -0,0 +1,116 @@
+package litellm
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidateSchemaReportsViolationsWithPointers(t *testing.T) {
+	schema := Schema(`{
+		"type": "object",
+		"properties": {
+			"city": {"type": "string", "minLength": 2},
+			"days": {"type": "integer", "minimum": 1, "maximum": 7},
+			"unit": {"enum": ["c", "f"]},
+			"tags": {"type": "array", "items": {"$ref": "#/$defs/tag"}, "uniqueItems": true},
+			"a/b": {"type": "boolean"}
+		},
+		"required": ["city", "days"],
+		"additionalProperties": false,
+		"$defs": {"tag": {"type": "string", "pattern": "^[a-z]+$"}}
+	}`)
+	violations, err := ValidateSchema(schema, []byte(`{"days": 9.5, "unit": "k", "tags": ["ok", "BAD"], "a/b": 1, "extra": true}`))
+	if err != nil {
+		t.Fatalf("ValidateSchema returned error: %v", err)
+	}
+	var got []string
+	for _, v := range violations {
+		got = append(got, v.String())
+	}
+	want := []string{
+		`/: missing required property "city"`,
+		`/a~1b: expected boolean, got number`,
+		`/days: expected integer, got number`,
+		`/extra: property is not allowed`,
+		`/tags/1: string does not match pattern "^[a-z]+$"`,
+		`/unit: value is not one of the allowed enum values`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("violations =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	violations, err = ValidateSchema(schema, []byte(`{"city": "Paris", "days": 3.0}`))
+	if err != nil || len(violations) != 0 {
+		t.Fatalf("valid value reported %v, %v", violations, err)
+	}
+}
+
+func TestValidateSchemaCombinators(t *testing.T) {
+	schema := Schema(`{"oneOf": [{"type": "string"}, {"type": "integer"}], "not": {"const": 0}}`)
+	cases := map[string]int{`"x"`: 0, `3`: 0, `0`: 1, `true`: 1, `null`: 1}
+	for value, want := range cases {
+		violations, err := ValidateSchema(schema, []byte(value))
+		if err != nil || len(violations) != want {
+			t.Fatalf("%s: violations = %v, err = %v", value, violations, err)
+		}
+	}
+	if _, err := ValidateSchema(Schema(`{`), []byte(`1`)); err == nil {
+		t.Fatal("expected error for invalid schema")
+	}
+}
+
+func schemaTestTool() Tool {
+	return Tool{Name: "weather", Parameters: Schema(`{"type":"object","properties":{"city":{"type":"string"}},"required":["city"]}`)}
+}
+
+func TestClientChatReportsToolArgumentViolations(t *testing.T) {
+	provider := &testProvider{name: "deepseek", chatFunc: func(context.Context, *Request) (*Response, error) {
+		return &Response{Blocks: []Block{ToolUseBlock{ID: "call_1", Name: "weather", Arguments: json.RawMessage(`{"city":42}`)}}}, nil
+	}}
+	req := Request{Model: "m", Messages: []Message{UserText("hi")}, Tools: []Tool{schemaTestTool()}}
+
+	client, _ := New(provider)
+	resp, err := client.Chat(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Chat returned error: %v", err)
+	}
+	if len(resp.Warnings) != 1 || resp.Warnings[0].Code != "schema.tool_arguments_invalid" || !strings.Contains(resp.Warnings[0].Message, "/city: expected string") {
+		t.Fatalf("warnings = %+v", resp.Warnings)
+	}
+
+	strict, _ := New(provider, WithStrictValidation(true))
+	if _, err := strict.Chat(context.Background(), req); !IsValidationError(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestClientStreamReportsStructuredOutputViolations(t *testing.T) {
+	provider := &testProvider{name: "minimax", streamFunc: func(_ context.Context, req *Request) (Stream, error) {
+		return &testStream{events: []Event{
+			ContentDelta{Text: `{"name":`},
+			ContentDelta{Text: `7}`},
+			DoneEvent{FinishReason: FinishReasonStop, Provider: "minimax", Model: req.Model},
+		}}, nil
+	}}
+	format, err := NewResponseFormatJSONSchema("person", "", Schema(`{"type":"object","properties":{"name":{"type":"string"}}}`), StrictEnabled)
+	if err != nil {
+		t.Fatalf("NewResponseFormatJSONSchema returned error: %v", err)
+	}
+	req := Request{Model: "m", Messages: []Message{UserText("hi")}, ResponseFormat: format}
+
+	client, _ := New(provider)
+	resp, err := client.StreamText(context.Background(), req, func(string) error { return nil })
+	if err != nil {
+		t.Fatalf("StreamText returned error: %v", err)
+	}
+	if len(resp.Warnings) != 1 || resp.Warnings[0].Code != "schema.response_invalid" {
+		t.Fatalf("warnings = %+v", resp.Warnings)
+	}
+
+	strict, _ := New(provider, WithStrictValidation(true))
+	if _, err := strict.StreamText(context.Background(), req, func(string) error { return nil }); !IsValidationError(err) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
@@ -59,6 +59,55 @@ type ToolUseDone struct {
 	ItemID      string
 }
 
+// AudioDelta carries a chunk of generated audio and/or its transcript. Chunks
+// with the same ID (or no ID) extend the current AudioOutputBlock.
+type AudioDelta struct {
+	ID         string
+	Data       []byte
+	Format     string
+	Transcript string
+	ExpiresAt  int64
+}
+
+// ImageDelta carries a generated image. Partial marks a progressive preview;
+// a later delta with the same ID replaces it in the collected ImageBlock.
+type ImageDelta struct {
+	ID      string
+	Data    []byte
+	MIME    string
+	URL     string
+	Partial bool
+}
+
+// AnnotationDelta attaches a citation to the text streamed under the same
+// OutputIndex and ContentIndex. Its Start and End are relative to that text.
+type AnnotationDelta struct {
+	Annotation   Annotation
+	OutputIndex  *int
+	ContentIndex *int
+}
+
+// GroundingEvent carries the search metadata behind a grounded response.
+type GroundingEvent struct {
+	Grounding Grounding
+}
+
+// ServerToolUseEvent carries a complete provider-hosted tool call; providers
+// emit it once the call's arguments are known.
+type ServerToolUseEvent struct {
+	Block ServerToolUseBlock
+}
+
+// ServerToolResultEvent carries the complete result of a provider-hosted tool.
+type ServerToolResultEvent struct {
+	Block ServerToolResultBlock
+}
+
+// SafetyEvent carries a content-safety assessment of the prompt or output.
+type SafetyEvent struct {
+	Safety SafetyAssessment
+}
+
 type UsageEvent struct {
 	Usage Usage
 }
@@ -83,17 +132,24 @@ type ProviderEvent struct {
 	Raw  json.RawMessage
 }
 
-func (ContentDelta) isEvent()   {}
-func (RefusalDelta) isEvent()   {}
-func (ReasoningDelta) isEvent() {}
-func (ToolUseStart) isEvent()   {}
-func (ToolUseDelta) isEvent()   {}
-func (ToolUseDone) isEvent()    {}
-func (UsageEvent) isEvent()     {}
-func (WarningEvent) isEvent()   {}
-func (DoneEvent) isEvent()      {}
-func (ErrorEvent) isEvent()     {}
-func (ProviderEvent) isEvent()  {}
+func (ContentDelta) isEvent()          {}
+func (RefusalDelta) isEvent()          {}
+func (ReasoningDelta) isEvent()        {}
+func (ToolUseStart) isEvent()          {}
+func (ToolUseDelta) isEvent()          {}
+func (ToolUseDone) isEvent()           {}
+func (AudioDelta) isEvent()            {}
+func (ImageDelta) isEvent()            {}
+func (AnnotationDelta) isEvent()       {}
+func (GroundingEvent) isEvent()        {}
+func (ServerToolUseEvent) isEvent()    {}
+func (ServerToolResultEvent) isEvent() {}
+func (SafetyEvent) isEvent()           {}
+func (UsageEvent) isEvent()            {}
+func (WarningEvent) isEvent()          {}
+func (DoneEvent) isEvent()             {}
+func (ErrorEvent) isEvent()            {}
+func (ProviderEvent) isEvent()         {}
 
 func cloneEvent(event Event) Event {
 	switch e := event.(type) {
@@ -112,6 +168,27 @@ func cloneEvent(event Event) Event {
 		return e
 	case ToolUseDone:
 		return e
+	case AudioDelta:
+		e.Data = cloneBytes(e.Data)
+		return e
+	case ImageDelta:
+		e.Data = cloneBytes(e.Data)
+		return e
+	case AnnotationDelta:
+		e.Annotation = cloneAnnotation(e.Annotation)
+		return e
+	case GroundingEvent:
+		e.Grounding = cloneGrounding(e.Grounding)
+		return e
+	case ServerToolUseEvent:
+		e.Block = cloneBlock(e.Block).(ServerToolUseBlock)
+		return e
+	case ServerToolResultEvent:
+		e.Block = cloneBlock(e.Block).(ServerToolResultBlock)
+		return e
+	case SafetyEvent:
+		e.Safety = cloneSafety(e.Safety)
+		return e
 	case UsageEvent:
 		return e
 	case WarningEvent:
@@ -133,6 +210,23 @@ type Stream interface {
 	Close() error
 }
 
+// ServedStream is a Stream that knows which provider and model serve it,
+// such as a Router stream after a fallback. Providers that wrap another
+// Provider's streams should implement it and forward to the inner stream,
+// so CallMeta reports the target that actually served the call. Empty
+// values mean unknown.
+type ServedStream interface {
+	Stream
+	ServedBy() (provider, model string)
+}
+
+func streamServedBy(stream Stream) (provider, model string) {
+	if served, ok := stream.(ServedStream); ok {
+		return served.ServedBy()
+	}
+	return "", ""
+}
+
 type providerErrorStream struct {
 	provider string
 	inner    Stream
@@ -280,32 +374,51 @@ type EventCollector struct {
 	blocks []Block
 	// blockText incrementally builds the final text-bearing block and is
 	// materialized before another block is appended or a Response is returned.
-	blockText   *strings.Builder
+	blockText *strings.Builder
+	// textStarts maps the output and content indexes streamed into the final
+	// TextBlock to the byte offset their text starts at, so annotations can be
+	// located after consecutive text parts are merged.
+	textStarts  map[string]int
 	toolIndexes map[string]int
-	usage       Usage
-	finish      FinishReason
-	finishRaw   string
-	refusal     strings.Builder
-	provider    string
-	model       string
-	warnings    []Warning
-	tools       *ToolUseAccumulator
+	// imageIndexes maps image IDs to blocks so a final image replaces its
+	// partial previews.
+	imageIndexes map[string]int
+	audioIndex   int
+	usage        Usage
+	finish       FinishReason
+	finishRaw    string
+	refusal      strings.Builder
+	provider     string
+	model        string
+	warnings     []Warning
+	grounding    *Grounding
+	safety       []SafetyAssessment
+	tools        *ToolUseAccumulator
 }
 
 // NewEventCollector returns an initialized stream event collector.
 func NewEventCollector() *EventCollector {
 	return &EventCollector{
-		toolIndexes: make(map[string]int),
-		tools:       NewToolUseAccumulator(),
+		toolIndexes:  make(map[string]int),
+		imageIndexes: make(map[string]int),
+		audioIndex:   -1,
+		tools:        NewToolUseAccumulator(),
 	}
 }
 
 func (c *EventCollector) Apply(event Event) (bool, error) {
 	switch e := event.(type) {
 	case ContentDelta:
-		c.appendContent(e.Text)
+		c.appendContent(e.Text, indexKey(e.OutputIndex, e.ContentIndex))
 	case RefusalDelta:
-		c.appendRefusal(e.Text)
+		c.appendRefusal(e.Text, indexKey(e.OutputIndex, e.ContentIndex))
+	case AnnotationDelta:
+		c.appendAnnotation(e)
+	case GroundingEvent:
+		grounding := cloneGrounding(e.Grounding)
+		c.grounding = &grounding
+	case SafetyEvent:
+		c.safety = append(c.safety, cloneSafety(e.Safety))
 	case ReasoningDelta:
 		c.appendReasoning(e)
 	case ToolUseStart:
@@ -329,6 +442,14 @@ func (c *EventCollector) Apply(event Event) (bool, error) {
 			c.appendWarning(*warning)
 		}
 		c.appendTool(key, tool)
+	case AudioDelta:
+		c.appendAudio(e)
+	case ImageDelta:
+		c.appendImage(e)
+	case ServerToolUseEvent:
+		c.appendBlock(e.Block)
+	case ServerToolResultEvent:
+		c.appendBlock(e.Block)
 	case UsageEvent:
 		c.usage = e.Usage
 		if e.Usage.Provider != "" {
@@ -414,19 +535,26 @@ func (c *EventCollector) Response() *Response {
 		Refusal:         c.refusal.String(),
 		Warnings:        append([]Warning(nil), c.warnings...),
 	}
+	if c.grounding != nil {
+		grounding := cloneGrounding(*c.grounding)
+		resp.Grounding = &grounding
+	}
+	for _, safety := range c.safety {
+		resp.Safety = append(resp.Safety, cloneSafety(safety))
+	}
 	resp.Usage.StampModel(resp.Provider, resp.Model)
 	return resp
 }
 
-func (c *EventCollector) appendRefusal(text string) {
+func (c *EventCollector) appendRefusal(text, key string) {
 	if text == "" {
 		return
 	}
 	c.refusal.WriteString(text)
-	c.appendContent(text)
+	c.appendContent(text, key)
 }
 
-func (c *EventCollector) appendContent(text string) {
+func (c *EventCollector) appendContent(text, key string) {
 	if text == "" {
 		return
 	}
@@ -438,15 +566,52 @@ func (c *EventCollector) appendContent(text string) {
 				block.Text = ""
 				c.blocks[index] = block
 			}
+			if _, ok := c.textStarts[key]; !ok {
+				c.textStarts[key] = c.blockText.Len()
+			}
 			c.blockText.WriteString(text)
 			return
 		}
 	}
 	c.flushBlockText()
 	c.blockText = newBlockTextBuilder(text, 0)
+	c.textStarts = map[string]int{key: 0}
 	c.blocks = append(c.blocks, TextBlock{})
 }
 
+// appendAnnotation attaches an annotation to the final TextBlock, shifting a
+// located span by the offset its indexes' text starts at in that block.
+func (c *EventCollector) appendAnnotation(delta AnnotationDelta) {
+	index := len(c.blocks) - 1
+	if index < 0 {
+		return
+	}
+	block, ok := c.blocks[index].(TextBlock)
+	if !ok {
+		return
+	}
+	annotation := cloneAnnotation(delta.Annotation)
+	if annotation.End > 0 {
+		start := c.textStarts[indexKey(delta.OutputIndex, delta.ContentIndex)]
+		annotation.Start += start
+		annotation.End += start
+	}
+	block.Annotations = append(block.Annotations, annotation)
+	c.blocks[index] = block
+}
+
+func indexKey(outputIndex, contentIndex *int) string {
+	key := ""
+	if outputIndex != nil {
+		key = fmt.Sprint(*outputIndex)
+	}
+	key += ":"
+	if contentIndex != nil {
+		key += fmt.Sprint(*contentIndex)
+	}
+	return key
+}
+
 func (c *EventCollector) appendReasoning(delta ReasoningDelta) {
 	if delta.Text == "" && delta.Signature == "" && len(delta.Redacted) == 0 && len(delta.Extra) == 0 {
 		return
@@ -546,6 +711,60 @@ func (c *EventCollector) appendTool(key string, tool *ToolUseBlock) {
 	c.blocks = append(c.blocks, cloneToolUseBlock(*tool))
 }
 
+func (c *EventCollector) appendAudio(delta AudioDelta) {
+	if delta.ID == "" && len(delta.Data) == 0 && delta.Transcript == "" {
+		return
+	}
+	if c.audioIndex >= 0 {
+		block := c.blocks[c.audioIndex].(AudioOutputBlock)
+		if delta.ID == "" || block.ID == "" || block.ID == delta.ID {
+			c.blocks[c.audioIndex] = mergeAudioDelta(block, delta)
+			return
+		}
+	}
+	c.flushBlockText()
+	c.audioIndex = len(c.blocks)
+	c.blocks = append(c.blocks, mergeAudioDelta(AudioOutputBlock{}, delta))
+}
+
+func mergeAudioDelta(block AudioOutputBlock, delta AudioDelta) AudioOutputBlock {
+	if delta.ID != "" {
+		block.ID = delta.ID
+	}
+	if delta.Format != "" {
+		block.Format = delta.Format
+	}
+	if delta.ExpiresAt != 0 {
+		block.ExpiresAt = delta.ExpiresAt
+	}
+	block.Data = append(block.Data, delta.Data...)
+	block.Transcript += delta.Transcript
+	return block
+}
+
+func (c *EventCollector) appendImage(delta ImageDelta) {
+	if len(delta.Data) == 0 && delta.URL == "" {
+		return
+	}
+	block := ImageBlock{Data: cloneBytes(delta.Data), MIME: delta.MIME, URL: delta.URL}
+	if delta.ID != "" {
+		if index, ok := c.imageIndexes[delta.ID]; ok {
+			c.blocks[index] = block
+			return
+		}
+	}
+	c.flushBlockText()
+	if delta.ID != "" {
+		c.imageIndexes[delta.ID] = len(c.blocks)
+	}
+	c.blocks = append(c.blocks, block)
+}
+
+func (c *EventCollector) appendBlock(block Block) {
+	c.flushBlockText()
+	c.blocks = append(c.blocks, cloneBlock(block))
+}
+
 func (c *EventCollector) cloneBlocks() []Block {
 	c.flushBlockText()
 	if len(c.blocks) == 0 {
@@ -555,11 +774,19 @@ func (c *EventCollector) cloneBlocks() []Block {
 	for i, block := range c.blocks {
 		switch b := block.(type) {
 		case TextBlock:
-			out[i] = b
+			out[i] = cloneBlock(b)
 		case ReasoningBlock:
 			out[i] = b
 		case ToolUseBlock:
 			out[i] = cloneToolUseBlock(b)
+		case AudioOutputBlock:
+			b.Data = cloneBytes(b.Data)
+			out[i] = b
+		case ImageBlock:
+			b.Data = cloneBytes(b.Data)
+			out[i] = b
+		case ServerToolUseBlock, ServerToolResultBlock:
+			out[i] = cloneBlock(b)
 		default:
 			out[i] = block
 		}
@@ -706,3 +933,85 @@ func toolUseKeys(id string, index, outputIndex *int, itemID string) []string {
 	}
 	return keys
 }
+
+// ReplayStream returns a Stream that emits resp as the events a provider
+// would have streamed for it, so collecting it yields resp again. Cached
+// responses are served through it.
+func ReplayStream(resp *Response) Stream {
+	return newReplayStream(resp)
+}
+
+type replayStream struct {
+	events []Event
+	index  int
+}
+
+func newReplayStream(resp *Response) *replayStream {
+	var events []Event
+	for i, block := range resp.Blocks {
+		switch b := block.(type) {
+		case TextBlock:
+			// Consecutive text blocks merge when collected; indexing each one
+			// keeps its annotations' offsets relative to its own text.
+			events = append(events, ContentDelta{Text: b.Text, ContentIndex: &i})
+			for _, annotation := range b.Annotations {
+				events = append(events, AnnotationDelta{Annotation: annotation, ContentIndex: &i})
+			}
+		case ReasoningBlock:
+			events = append(events, ReasoningDelta{
+				Text:      b.Text,
+				Summary:   b.Summary,
+				Signature: b.Signature,
+				Redacted:  b.Redacted,
+				Extra:     b.Extra,
+				ExtraFull: len(b.Extra) > 0,
+			})
+		case ToolUseBlock:
+			events = append(events,
+				ToolUseStart{ID: b.ID, Name: b.Name, Signature: b.Signature},
+				ToolUseDelta{ID: b.ID, ArgumentsDelta: b.Arguments},
+				ToolUseDone{ID: b.ID},
+			)
+		case AudioOutputBlock:
+			events = append(events, AudioDelta{ID: b.ID, Data: b.Data, Format: b.Format, Transcript: b.Transcript, ExpiresAt: b.ExpiresAt})
+		case ImageBlock:
+			events = append(events, ImageDelta{Data: b.Data, MIME: b.MIME, URL: b.URL})
+		case ServerToolUseBlock:
+			events = append(events, ServerToolUseEvent{Block: b})
+		case ServerToolResultBlock:
+			events = append(events, ServerToolResultEvent{Block: b})
+		}
+	}
+	if resp.Refusal != "" {
+		events = append(events, RefusalDelta{Text: resp.Refusal})
+	}
+	if resp.Grounding != nil {
+		events = append(events, GroundingEvent{Grounding: *resp.Grounding})
+	}
+	for _, safety := range resp.Safety {
+		events = append(events, SafetyEvent{Safety: safety})
+	}
+	if resp.Usage.HasTokens() {
+		events = append(events, UsageEvent{Usage: resp.Usage})
+	}
+	events = append(events, DoneEvent{
+		FinishReason:    resp.FinishReason,
+		FinishReasonRaw: resp.FinishReasonRaw,
+		Provider:        resp.Provider,
+		Model:           resp.Model,
+	})
+	return &replayStream{events: events}
+}
+
+func (s *replayStream) Next() (Event, error) {
+	if s.index >= len(s.events) {
+		return nil, io.EOF
+	}
+	event := s.events[s.index]
+	s.index++
+	return event, nil
+}
+
+func (s *replayStream) Close() error {
+	return nil
+}
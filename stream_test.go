@@ -81,6 +81,111 @@ func TestEventCollectorContinuesAfterResponseSnapshot(t *testing.T) {
 	}
 }
 
+func TestEventCollectorAssemblesAudioDeltas(t *testing.T) {
+	collector := NewEventCollector()
+	for _, event := range []Event{
+		AudioDelta{ID: "a1", Format: "wav", Transcript: "hel"},
+		ContentDelta{Text: "caption"},
+		AudioDelta{Data: []byte("RI"), Transcript: "lo"},
+		AudioDelta{ID: "a1", Data: []byte("FF"), ExpiresAt: 42},
+	} {
+		if _, err := collector.Apply(event); err != nil {
+			t.Fatalf("Apply(%T): %v", event, err)
+		}
+	}
+	snapshot := collector.Response()
+	if _, err := collector.Apply(AudioDelta{ID: "a2", Data: []byte("x")}); err != nil {
+		t.Fatalf("Apply second audio: %v", err)
+	}
+	resp := collector.Response()
+	if len(resp.Blocks) != 3 || resp.Text() != "caption" {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	first, ok := resp.Blocks[0].(AudioOutputBlock)
+	if !ok || first.ID != "a1" || string(first.Data) != "RIFF" || first.Format != "wav" || first.Transcript != "hello" || first.ExpiresAt != 42 {
+		t.Fatalf("first audio block = %#v", resp.Blocks[0])
+	}
+	second, ok := resp.Blocks[2].(AudioOutputBlock)
+	if !ok || second.ID != "a2" || string(second.Data) != "x" {
+		t.Fatalf("second audio block = %#v", resp.Blocks[2])
+	}
+	if len(snapshot.Blocks) != 2 {
+		t.Fatalf("snapshot blocks changed = %#v", snapshot.Blocks)
+	}
+}
+
+func TestEventCollectorReplacesPartialImages(t *testing.T) {
+	collector := NewEventCollector()
+	for _, event := range []Event{
+		ContentDelta{Text: "here"},
+		ImageDelta{ID: "ig_1", Data: []byte("p1"), MIME: "image/png", Partial: true},
+		ImageDelta{ID: "ig_1", Data: []byte("final"), MIME: "image/png"},
+		ImageDelta{Data: []byte("second"), MIME: "image/jpeg"},
+	} {
+		if _, err := collector.Apply(event); err != nil {
+			t.Fatalf("Apply(%T): %v", event, err)
+		}
+	}
+	resp := collector.Response()
+	if len(resp.Blocks) != 3 || resp.Text() != "here" {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	first, ok := resp.Blocks[1].(ImageBlock)
+	if !ok || string(first.Data) != "final" || first.MIME != "image/png" {
+		t.Fatalf("first image = %#v", resp.Blocks[1])
+	}
+	if second, ok := resp.Blocks[2].(ImageBlock); !ok || string(second.Data) != "second" {
+		t.Fatalf("second image = %#v", resp.Blocks[2])
+	}
+}
+
+func TestEventCollectorLocatesAnnotationsInMergedText(t *testing.T) {
+	collector := NewEventCollector()
+	for _, event := range []Event{
+		ContentDelta{Text: "Hello ", ContentIndex: IntPtr(0)},
+		ContentDelta{Text: "world", ContentIndex: IntPtr(1)},
+		AnnotationDelta{Annotation: Annotation{Type: AnnotationURLCitation, End: 5, URL: "https://example.com"}, ContentIndex: IntPtr(1)},
+		AnnotationDelta{Annotation: Annotation{Type: AnnotationURLCitation, URL: "https://example.org"}},
+		GroundingEvent{Grounding: Grounding{SearchQueries: []string{"hello world"}}},
+	} {
+		if _, err := collector.Apply(event); err != nil {
+			t.Fatalf("Apply(%T): %v", event, err)
+		}
+	}
+	resp := collector.Response()
+	block, ok := resp.Blocks[0].(TextBlock)
+	if len(resp.Blocks) != 1 || !ok || block.Text != "Hello world" || len(block.Annotations) != 2 {
+		t.Fatalf("blocks = %#v", resp.Blocks)
+	}
+	if located := block.Annotations[0]; block.Text[located.Start:located.End] != "world" {
+		t.Fatalf("located annotation = %+v", located)
+	}
+	if unlocated := block.Annotations[1]; unlocated.Start != 0 || unlocated.End != 0 || unlocated.URL != "https://example.org" {
+		t.Fatalf("unlocated annotation = %+v", unlocated)
+	}
+	if resp.Grounding == nil || len(resp.Grounding.SearchQueries) != 1 {
+		t.Fatalf("grounding = %+v", resp.Grounding)
+	}
+}
+
+func TestEventCollectorKeepsSafetyAssessments(t *testing.T) {
+	collector := NewEventCollector()
+	for _, event := range []Event{
+		SafetyEvent{Safety: SafetyAssessment{Target: SafetyTargetPrompt, Categories: []SafetyCategory{{Name: "hate", Severity: "safe"}}}},
+		ContentDelta{Text: "partial"},
+		SafetyEvent{Safety: SafetyAssessment{Target: SafetyTargetOutput, Blocked: true, Categories: []SafetyCategory{{Name: "violence", Severity: "high", Filtered: true}}}},
+		DoneEvent{FinishReason: FinishReasonSafety},
+	} {
+		if _, err := collector.Apply(event); err != nil {
+			t.Fatalf("Apply(%T): %v", event, err)
+		}
+	}
+	resp := collector.Response()
+	if len(resp.Safety) != 2 || resp.Safety[0].Target != SafetyTargetPrompt || !resp.Safety[1].Blocked {
+		t.Fatalf("safety = %+v", resp.Safety)
+	}
+}
+
 func BenchmarkEventCollectorContent(b *testing.B) {
 	for _, tc := range []struct {
 		name   string
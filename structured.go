@@ -0,0 +1,235 @@
+package litellm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// JSONDecodeError reports a structured response that could not be decoded
+// into the requested type. Raw is the text of the last attempt.
+type JSONDecodeError struct {
+	Raw      string
+	Attempts int
+	Err      error
+}
+
+func (e *JSONDecodeError) Error() string {
+	return fmt.Sprintf("decode structured response after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+func (e *JSONDecodeError) Unwrap() error {
+	return e.Err
+}
+
+type jsonConfig struct {
+	name        string
+	description string
+	retries     int
+}
+
+type JSONOption func(*jsonConfig) error
+
+// WithJSONSchemaName sets the schema name sent to the provider. The default is
+// derived from the Go type name.
+func WithJSONSchemaName(name, description string) JSONOption {
+	return func(c *jsonConfig) error {
+		if name == "" {
+			return fmt.Errorf("schema name cannot be empty")
+		}
+		c.name = name
+		c.description = description
+		return nil
+	}
+}
+
+// WithJSONRetries re-asks the model up to n times, quoting the decode error,
+// when its answer does not decode into the target type.
+func WithJSONRetries(n int) JSONOption {
+	return func(c *jsonConfig) error {
+		if n < 0 {
+			return fmt.Errorf("json retries cannot be negative")
+		}
+		c.retries = n
+		return nil
+	}
+}
+
+// ChatJSON asks for a response matching T's JSON schema (see SchemaFor) and
+// decodes it. Strict mode is requested when the model supports it and the
+// schema allows it. The returned Response is the last attempt; a decode
+// failure after all retries is a *JSONDecodeError.
+func ChatJSON[T any](ctx context.Context, client *Client, req Request, opts ...JSONOption) (T, *Response, error) {
+	return runJSON[T](ctx, client, req, opts, func(req Request) (*Response, error) {
+		return client.Chat(ctx, req)
+	})
+}
+
+// StreamJSON is ChatJSON with each attempt streamed; fn receives the raw JSON
+// text deltas as they arrive.
+func StreamJSON[T any](ctx context.Context, client *Client, req Request, fn func(string) error, opts ...JSONOption) (T, *Response, error) {
+	return runJSON[T](ctx, client, req, opts, func(req Request) (*Response, error) {
+		return client.StreamText(ctx, req, fn)
+	})
+}
+
+func runJSON[T any](ctx context.Context, client *Client, req Request, opts []JSONOption, call func(Request) (*Response, error)) (T, *Response, error) {
+	var zero T
+	if client == nil {
+		return zero, nil, NewError(ErrorTypeValidation, "client cannot be nil")
+	}
+	if req.ResponseFormat != nil {
+		return zero, nil, NewError(ErrorTypeValidation, "ChatJSON sets ResponseFormat; leave it nil")
+	}
+	cfg := jsonConfig{name: jsonSchemaName(reflect.TypeFor[T]())}
+	for _, opt := range opts {
+		if err := opt(&cfg); err != nil {
+			return zero, nil, fmt.Errorf("apply json option: %w", err)
+		}
+	}
+	schema, err := SchemaFor[T]()
+	if err != nil {
+		return zero, nil, NewError(ErrorTypeValidation, err.Error())
+	}
+	strict := StrictDefault
+	if client.Capabilities(req.Model).Structured.Strict == SupportYes && strictCompatible(schema) {
+		strict = StrictEnabled
+	}
+	req.ResponseFormat = &ResponseFormat{
+		Type: ResponseFormatJSONSchema,
+		JSONSchema: &JSONSchema{
+			Name:        cfg.name,
+			Description: cfg.description,
+			Schema:      schema,
+			Strict:      strict,
+		},
+	}
+	req.Messages = append([]Message(nil), req.Messages...)
+	for attempt := 1; ; attempt++ {
+		resp, err := call(req)
+		if err != nil {
+			return zero, resp, err
+		}
+		value, err := decodeJSON[T](resp)
+		if err == nil {
+			return value, resp, nil
+		}
+		if attempt > cfg.retries || ctx.Err() != nil {
+			return zero, resp, &JSONDecodeError{Raw: resp.Text(), Attempts: attempt, Err: err}
+		}
+		req.Messages = append(req.Messages,
+			Assistant(Text(resp.Text())),
+			UserText(fmt.Sprintf("Your previous reply did not match the required JSON schema: %v. Reply again with only the corrected JSON.", err)),
+		)
+	}
+}
+
+func decodeJSON[T any](resp *Response) (T, error) {
+	var value T
+	if resp.Refusal != "" {
+		return value, fmt.Errorf("model refused: %s", resp.Refusal)
+	}
+	text := stripCodeFence(resp.Text())
+	if strings.TrimSpace(text) == "" {
+		return value, fmt.Errorf("response has no text")
+	}
+	// Unknown keys are ignored like any json.Unmarshal: the schema is closed,
+	// and the client already reports keys a non-strict provider adds.
+	dec := json.NewDecoder(strings.NewReader(text))
+	if err := dec.Decode(&value); err != nil {
+		return value, err
+	}
+	if dec.More() {
+		return value, fmt.Errorf("unexpected data after JSON value")
+	}
+	return value, nil
+}
+
+var codeFence = regexp.MustCompile("(?s)^\\s*```[a-zA-Z]*\\s*\\n(.*?)\\n?```\\s*$")
+
+// stripCodeFence unwraps a markdown code block, which prompt-only providers
+// sometimes add around the JSON.
+func stripCodeFence(text string) string {
+	if m := codeFence.FindStringSubmatch(text); m != nil {
+		return m[1]
+	}
+	return text
+}
+
+var schemaNameInvalid = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+func jsonSchemaName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	name = schemaNameInvalid.ReplaceAllString(name, "_")
+	if name == "" {
+		return "response"
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// strictCompatible reports whether every object in schema is closed and
+// requires all of its properties, which strict structured output demands.
+func strictCompatible(schema Schema) bool {
+	var node any
+	dec := json.NewDecoder(bytes.NewReader(schema))
+	if err := dec.Decode(&node); err != nil {
+		return false
+	}
+	return strictNode(node)
+}
+
+func strictNode(node any) bool {
+	obj, ok := node.(map[string]any)
+	if !ok {
+		return true
+	}
+	if len(obj) == 0 {
+		return false
+	}
+	if props, ok := obj["properties"].(map[string]any); ok {
+		if obj["additionalProperties"] != false {
+			return false
+		}
+		required, _ := obj["required"].([]any)
+		if len(required) != len(props) {
+			return false
+		}
+		for _, prop := range props {
+			if !strictNode(prop) {
+				return false
+			}
+		}
+	} else if _, ok := obj["additionalProperties"].(map[string]any); ok {
+		return false
+	}
+	if items, ok := obj["items"]; ok && !strictNode(items) {
+		return false
+	}
+	for _, key := range []string{"anyOf", "oneOf", "allOf"} {
+		branches, _ := obj[key].([]any)
+		for _, branch := range branches {
+			if !strictNode(branch) {
+				return false
+			}
+		}
+	}
+	for _, key := range []string{"$defs", "definitions"} {
+		defs, _ := obj[key].(map[string]any)
+		for _, def := range defs {
+			if !strictNode(def) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package litellm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type structuredPerson struct {
+	Name string `json:"name" description:"Full name"`
+	Role string `json:"role" enum:"admin,user"`
+}
+
+type strictStructuredProvider struct {
+	*testProvider
+}
+
+func (p *strictStructuredProvider) Capabilities(model string) Capabilities {
+	return Capabilities{Structured: StructuredCapabilities{JSONSchema: SupportYes, Strict: SupportYes}}
+}
+
+func TestChatJSONDecodesIntoStruct(t *testing.T) {
+	provider := &strictStructuredProvider{&testProvider{name: "test", chatFunc: func(_ context.Context, req *Request) (*Response, error) {
+		return &Response{Blocks: []Block{TextBlock{Text: "```json\n{\"name\":\"Ada\",\"role\":\"admin\"}\n```"}}}, nil
+	}}}
+	client, err := New(provider)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	person, resp, err := ChatJSON[structuredPerson](context.Background(), client, Request{Model: "m", Messages: []Message{UserText("person")}})
+	if err != nil {
+		t.Fatalf("ChatJSON returned error: %v", err)
+	}
+	if person.Name != "Ada" || person.Role != "admin" || resp == nil {
+		t.Fatalf("person = %+v", person)
+	}
+	format := provider.lastReq.ResponseFormat
+	if format == nil || format.Type != ResponseFormatJSONSchema || format.JSONSchema.Name != "structuredPerson" || format.JSONSchema.Strict != StrictEnabled {
+		t.Fatalf("response format = %+v", format)
+	}
+	if !strings.Contains(string(format.JSONSchema.Schema), `"enum":["admin","user"]`) {
+		t.Fatalf("schema = %s", format.JSONSchema.Schema)
+	}
+}
+
+func TestChatJSONReasksOnDecodeFailure(t *testing.T) {
+	calls := 0
+	provider := &testProvider{name: "test", chatFunc: func(_ context.Context, req *Request) (*Response, error) {
+		calls++
+		if calls == 1 {
+			return &Response{Blocks: []Block{TextBlock{Text: `{"name":7,"role":"user"}`}}}, nil
+		}
+		last := req.Messages[len(req.Messages)-1]
+		if last.Role != RoleUser || !strings.Contains(last.Blocks[0].(TextBlock).Text, "name") {
+			t.Fatalf("re-ask message = %+v", last)
+		}
+		return &Response{Blocks: []Block{TextBlock{Text: `{"name":"Ada","role":"user"}`}}}, nil
+	}}
+	client, _ := New(provider)
+	person, _, err := ChatJSON[structuredPerson](context.Background(), client, Request{Model: "m", Messages: []Message{UserText("person")}}, WithJSONRetries(1))
+	if err != nil {
+		t.Fatalf("ChatJSON returned error: %v", err)
+	}
+	if calls != 2 || person.Role != "user" || provider.lastReq.ResponseFormat.JSONSchema.Strict != StrictDefault {
+		t.Fatalf("calls = %d, person = %+v", calls, person)
+	}
+}
+
+func TestChatJSONIgnoresUnknownKeys(t *testing.T) {
+	calls := 0
+	provider := &testProvider{name: "test", chatFunc: func(context.Context, *Request) (*Response, error) {
+		calls++
+		return &Response{Blocks: []Block{TextBlock{Text: `{"name":"Ada","role":"admin","note":"extra"}`}}}, nil
+	}}
+	client, _ := New(provider)
+	person, _, err := ChatJSON[structuredPerson](context.Background(), client, Request{Model: "m", Messages: []Message{UserText("person")}}, WithJSONRetries(1))
+	if err != nil || calls != 1 || person.Name != "Ada" {
+		t.Fatalf("person = %+v, calls = %d, err = %v", person, calls, err)
+	}
+}
+
+func TestChatJSONReturnsDecodeError(t *testing.T) {
+	provider := &testProvider{name: "test", chatFunc: func(context.Context, *Request) (*Response, error) {
+		return &Response{Blocks: []Block{TextBlock{Text: "not json"}}}, nil
+	}}
+	client, _ := New(provider)
+	_, _, err := ChatJSON[structuredPerson](context.Background(), client, Request{Model: "m", Messages: []Message{UserText("person")}})
+	var decodeErr *JSONDecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.Raw != "not json" || decodeErr.Attempts != 1 {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestStreamJSONForwardsDeltas(t *testing.T) {
+	provider := &testProvider{name: "test", streamFunc: func(_ context.Context, req *Request) (Stream, error) {
+		return &testStream{events: []Event{
+			ContentDelta{Text: `{"name":"Ada",`},
+			ContentDelta{Text: `"role":"admin"}`},
+			DoneEvent{FinishReason: FinishReasonStop, Provider: "test", Model: req.Model},
+		}}, nil
+	}}
+	client, _ := New(provider)
+	var deltas int
+	person, _, err := StreamJSON[structuredPerson](context.Background(), client, Request{Model: "m", Messages: []Message{UserText("person")}}, func(string) error {
+		deltas++
+		return nil
+	})
+	if err != nil || person.Name != "Ada" || deltas != 2 {
+		t.Fatalf("person = %+v, deltas = %d, err = %v", person, deltas, err)
+	}
+}
+
+func TestStrictCompatible(t *testing.T) {
+	type optional struct {
+		Name string `json:"name,omitempty"`
+	}
+	strict, _ := SchemaFor[structuredPerson]()
+	loose, _ := SchemaFor[optional]()
+	open, _ := SchemaFor[map[string]string]()
+	if !strictCompatible(strict) || strictCompatible(loose) || strictCompatible(open) {
+		t.Fatal("strictCompatible misclassified schemas")
+	}
+	for _, schema := range []string{
+		`{"anyOf":[{"type":"object","properties":{"a":{"type":"string"}},"required":["a"]}]}`,
+		`{"oneOf":[{"type":"string"},{"type":"object","additionalProperties":{"type":"string"}}]}`,
+		`{"$ref":"#/$defs/item","$defs":{"item":{"type":"object","properties":{"a":{"type":"string"}},"required":[],"additionalProperties":false}}}`,
+	} {
+		if strictCompatible(Schema(schema)) {
+			t.Fatalf("strictCompatible accepted nested open object in %s", schema)
+		}
+	}
+	closed := `{"anyOf":[{"type":"null"},{"$ref":"#/$defs/item"}],"$defs":{"item":{"type":"object","properties":{"a":{"type":"string"}},"required":["a"],"additionalProperties":false}}}`
+	if !strictCompatible(Schema(closed)) {
+		t.Fatalf("strictCompatible rejected %s", closed)
+	}
+}
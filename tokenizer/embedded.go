@@ -0,0 +1,53 @@
+package tokenizer
+
+import (
+	"embed"
+	"io/fs"
+	"sync"
+)
+
+//go:generate curl -sSfo vocab/o200k_base.tiktoken https://openaipublic.blob.core.windows.net/encodings/o200k_base.tiktoken
+//go:generate curl -sSfo vocab/cl100k_base.tiktoken https://openaipublic.blob.core.windows.net/encodings/cl100k_base.tiktoken
+
+// vocab holds the rank files shipped with the package, vocab/<name>.tiktoken
+// for each encoding.
+//
+//go:embed vocab
+var vocab embed.FS
+
+// vocabFS is read by loadBuiltin; tests swap it out.
+var vocabFS fs.FS = vocab
+
+// builtins parses each embedded vocabulary on first use, since a rank file
+// takes a noticeable moment to parse and most programs need only one.
+var builtins = map[string]*builtin{
+	O200kBase:  {},
+	Cl100kBase: {},
+}
+
+type builtin struct {
+	once sync.Once
+	enc  *Encoding
+}
+
+// loadBuiltin returns the embedded encoding for name, or nil when the package
+// was built without its rank file.
+func loadBuiltin(name string) *Encoding {
+	b, ok := builtins[name]
+	if !ok {
+		return nil
+	}
+	b.once.Do(func() {
+		f, err := vocabFS.Open("vocab/" + name + ".tiktoken")
+		if err != nil {
+			return
+		}
+		defer f.Close()
+		ranks, err := ParseRanks(f)
+		if err != nil {
+			return
+		}
+		b.enc, _ = NewEncoding(name, ranks)
+	})
+	return b.enc
+}
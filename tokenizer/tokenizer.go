@@ -0,0 +1,283 @@
+// Package tokenizer implements offline byte-pair encoding compatible with
+// OpenAI's tiktoken vocabularies (o200k_base and cl100k_base).
+//
+// The rank files for both encodings are embedded from the vocab directory
+// and parsed the first time an encoding is looked up. Register replaces an
+// encoding with one loaded elsewhere, such as a tiktoken rank file on disk:
+//
+//	enc, err := tokenizer.LoadFile(tokenizer.O200kBase, "o200k_base.tiktoken")
+//	if err != nil {
+//	    return err
+//	}
+//	tokenizer.Register(enc)
+//
+// litellm.EstimateTokens uses the encoding for the model and falls back to a
+// byte-length heuristic when none is available.
+package tokenizer
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	O200kBase  = "o200k_base"
+	Cl100kBase = "cl100k_base"
+)
+
+// The tiktoken split patterns end in `\s+(?!\S)|\s+`. RE2 has no lookahead,
+// so both collapse to `\s+` here and Encoding.split gives back the final
+// whitespace rune when the run is followed by a non-space, which is what the
+// lookahead achieves.
+var (
+	cl100kPattern = regexp.MustCompile(`(?i:'s|'t|'re|'ve|'m|'ll|'d)|[^\r\n\p{L}\p{N}]?\p{L}+|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n]*|\s*[\r\n]+|\s+`)
+	o200kPattern  = regexp.MustCompile(`[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]*[\p{Ll}\p{Lm}\p{Lo}\p{M}]+(?i:'s|'t|'re|'ve|'m|'ll|'d)?|[^\r\n\p{L}\p{N}]?[\p{Lu}\p{Lt}\p{Lm}\p{Lo}\p{M}]+[\p{Ll}\p{Lm}\p{Lo}\p{M}]*(?i:'s|'t|'re|'ve|'m|'ll|'d)?|\p{N}{1,3}| ?[^\s\p{L}\p{N}]+[\r\n/]*|\s*[\r\n]+|\s+`)
+)
+
+// Encoding is a byte-pair encoder for one vocabulary. It is safe for
+// concurrent use.
+type Encoding struct {
+	name    string
+	ranks   map[string]int
+	decoder map[int]string
+	pattern *regexp.Regexp
+}
+
+// NewEncoding builds an encoding from token ranks. name selects the
+// pre-tokenization pattern and must be O200kBase or Cl100kBase.
+func NewEncoding(name string, ranks map[string]int) (*Encoding, error) {
+	var pattern *regexp.Regexp
+	switch name {
+	case O200kBase:
+		pattern = o200kPattern
+	case Cl100kBase:
+		pattern = cl100kPattern
+	default:
+		return nil, fmt.Errorf("tokenizer: unsupported encoding %q", name)
+	}
+	if len(ranks) == 0 {
+		return nil, fmt.Errorf("tokenizer: %s ranks are empty", name)
+	}
+	decoder := make(map[int]string, len(ranks))
+	for token, rank := range ranks {
+		decoder[rank] = token
+	}
+	return &Encoding{name: name, ranks: ranks, decoder: decoder, pattern: pattern}, nil
+}
+
+// ParseRanks reads a tiktoken rank file.
+func ParseRanks(r io.Reader) (map[string]int, error) {
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		encoded, rawRank, ok := strings.Cut(text, " ")
+		if !ok {
+			return nil, fmt.Errorf("tokenizer: line %d: expected token and rank", line)
+		}
+		token, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("tokenizer: line %d: decode token: %w", line, err)
+		}
+		rank, err := strconv.Atoi(rawRank)
+		if err != nil {
+			return nil, fmt.Errorf("tokenizer: line %d: parse rank: %w", line, err)
+		}
+		ranks[string(token)] = rank
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("tokenizer: read ranks: %w", err)
+	}
+	return ranks, nil
+}
+
+// LoadFile parses the tiktoken rank file at path into an encoding.
+func LoadFile(name, path string) (*Encoding, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("tokenizer: open %s: %w", path, err)
+	}
+	defer f.Close()
+	ranks, err := ParseRanks(f)
+	if err != nil {
+		return nil, err
+	}
+	return NewEncoding(name, ranks)
+}
+
+func (e *Encoding) Name() string {
+	return e.name
+}
+
+// Encode returns the token ids for text. Special tokens are not recognised;
+// their text is encoded like any other.
+func (e *Encoding) Encode(text string) []int {
+	var tokens []int
+	for _, piece := range e.split(text) {
+		if rank, ok := e.ranks[piece]; ok {
+			tokens = append(tokens, rank)
+			continue
+		}
+		tokens = append(tokens, e.bytePairEncode(piece)...)
+	}
+	return tokens
+}
+
+// Count returns the number of tokens in text.
+func (e *Encoding) Count(text string) int {
+	n := 0
+	for _, piece := range e.split(text) {
+		if _, ok := e.ranks[piece]; ok {
+			n++
+			continue
+		}
+		n += len(e.bytePairEncode(piece))
+	}
+	return n
+}
+
+// Decode returns the text for tokens. Unknown ids are skipped.
+func (e *Encoding) Decode(tokens []int) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString(e.decoder[token])
+	}
+	return b.String()
+}
+
+func (e *Encoding) split(text string) []string {
+	var pieces []string
+	for len(text) > 0 {
+		loc := e.pattern.FindStringIndex(text)
+		if loc == nil || loc[0] != 0 || loc[1] == 0 {
+			// The patterns match any non-empty input; this only guards
+			// against a malformed custom vocabulary.
+			_, size := utf8.DecodeRuneInString(text)
+			loc = []int{0, size}
+		}
+		piece := text[:loc[1]]
+		if loc[1] < len(text) && isSpaceRun(piece) {
+			next, _ := utf8.DecodeRuneInString(text[loc[1]:])
+			if !unicode.IsSpace(next) && utf8.RuneCountInString(piece) > 1 {
+				_, size := utf8.DecodeLastRuneInString(piece)
+				piece = piece[:len(piece)-size]
+			}
+		}
+		pieces = append(pieces, piece)
+		text = text[len(piece):]
+	}
+	return pieces
+}
+
+// isSpaceRun reports whether piece came from the trailing `\s+` alternative:
+// all whitespace and not ending in a line break.
+func isSpaceRun(piece string) bool {
+	last, _ := utf8.DecodeLastRuneInString(piece)
+	if last == '\r' || last == '\n' {
+		return false
+	}
+	for _, r := range piece {
+		if !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// bytePairEncode merges the lowest-ranked adjacent pair until no pair is in
+// the vocabulary, as tiktoken does.
+func (e *Encoding) bytePairEncode(piece string) []int {
+	bounds := make([]int, len(piece)+1)
+	for i := range bounds {
+		bounds[i] = i
+	}
+	for len(bounds) > 2 {
+		best, bestRank := -1, math.MaxInt
+		for i := 0; i+2 < len(bounds); i++ {
+			if rank, ok := e.ranks[piece[bounds[i]:bounds[i+2]]]; ok && rank < bestRank {
+				best, bestRank = i, rank
+			}
+		}
+		if best < 0 {
+			break
+		}
+		bounds = append(bounds[:best+1], bounds[best+2:]...)
+	}
+	tokens := make([]int, 0, len(bounds)-1)
+	for i := 0; i+1 < len(bounds); i++ {
+		if rank, ok := e.ranks[piece[bounds[i]:bounds[i+1]]]; ok {
+			tokens = append(tokens, rank)
+		}
+	}
+	return tokens
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Encoding{}
+)
+
+// Register makes enc available to Lookup and ForModel under its name, in
+// place of any embedded encoding of that name.
+func Register(enc *Encoding) {
+	if enc == nil {
+		return
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[enc.name] = enc
+}
+
+// Lookup returns the encoding registered under name, or else the one
+// embedded in the package.
+func Lookup(name string) (*Encoding, bool) {
+	registryMu.RLock()
+	enc, ok := registry[name]
+	registryMu.RUnlock()
+	if ok {
+		return enc, true
+	}
+	enc = loadBuiltin(name)
+	return enc, enc != nil
+}
+
+// EncodingForModel returns the encoding name an OpenAI-family model uses.
+// Unknown models map to o200k_base, the encoding of current OpenAI models.
+func EncodingForModel(model string) string {
+	model = strings.ToLower(model)
+	if i := strings.LastIndexByte(model, '/'); i >= 0 {
+		model = model[i+1:]
+	}
+	for _, prefix := range []string{"gpt-4o", "gpt-4.1", "gpt-4.5", "gpt-5", "o1", "o3", "o4", "chatgpt-4o", "gpt-oss"} {
+		if strings.HasPrefix(model, prefix) {
+			return O200kBase
+		}
+	}
+	for _, prefix := range []string{"gpt-4", "gpt-3.5", "gpt-35", "text-embedding-3", "text-embedding-ada-002"} {
+		if strings.HasPrefix(model, prefix) {
+			return Cl100kBase
+		}
+	}
+	return O200kBase
+}
+
+// ForModel returns the encoding for model, if one is available.
+func ForModel(model string) (*Encoding, bool) {
+	return Lookup(EncodingForModel(model))
+}
@@ -0,0 +1,146 @@
+package tokenizer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// testRanks is every single byte plus a few merges, enough to exercise the
+// merge order without a real vocabulary.
+func testRanks(merges ...string) map[string]int {
+	ranks := make(map[string]int, 256+len(merges))
+	for i := 0; i < 256; i++ {
+		ranks[string([]byte{byte(i)})] = i
+	}
+	for i, merge := range merges {
+		ranks[merge] = 256 + i
+	}
+	return ranks
+}
+
+func TestSplitMatchesTiktokenPreTokenization(t *testing.T) {
+	enc, err := NewEncoding(Cl100kBase, testRanks())
+	if err != nil {
+		t.Fatalf("NewEncoding returned error: %v", err)
+	}
+	cases := map[string][]string{
+		"Hello world":       {"Hello", " world"},
+		"hello  world":      {"hello", " ", " world"},
+		"it's 12345 ok!!\n": {"it", "'s", " ", "123", "45", " ok", "!!\n"},
+		"a  \n\n  b   ":     {"a", "  \n\n", " ", " b", "   "},
+	}
+	for input, want := range cases {
+		if got := enc.split(input); !reflect.DeepEqual(got, want) {
+			t.Fatalf("split(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestO200kSplitKeepsCaseRuns(t *testing.T) {
+	enc, err := NewEncoding(O200kBase, testRanks())
+	if err != nil {
+		t.Fatalf("NewEncoding returned error: %v", err)
+	}
+	got := enc.split("HTTPServer isn't")
+	want := []string{"HTTPServer", " isn't"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("split = %q, want %q", got, want)
+	}
+}
+
+func TestEncodeMergesByRank(t *testing.T) {
+	enc, err := NewEncoding(Cl100kBase, testRanks("he", "ll", "llo", "hello", " w"))
+	if err != nil {
+		t.Fatalf("NewEncoding returned error: %v", err)
+	}
+	tokens := enc.Encode("hello world")
+	want := []int{256 + 3, 256 + 4, 'o', 'r', 'l', 'd'}
+	if !reflect.DeepEqual(tokens, want) {
+		t.Fatalf("Encode = %v, want %v", tokens, want)
+	}
+	if enc.Count("hello world") != len(want) || enc.Decode(tokens) != "hello world" {
+		t.Fatalf("Count/Decode mismatch for %v", tokens)
+	}
+}
+
+func TestParseRanksAndRegistry(t *testing.T) {
+	var b strings.Builder
+	for token, rank := range testRanks("ab") {
+		fmt.Fprintf(&b, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(token)), rank)
+	}
+	ranks, err := ParseRanks(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("ParseRanks returned error: %v", err)
+	}
+	enc, err := NewEncoding(O200kBase, ranks)
+	if err != nil {
+		t.Fatalf("NewEncoding returned error: %v", err)
+	}
+	Register(enc)
+	got, ok := ForModel("gpt-4o-mini")
+	if !ok || got.Count("ab") != 1 {
+		t.Fatalf("ForModel = %v, %v", got, ok)
+	}
+	if _, err := ParseRanks(strings.NewReader("!!! 1\n")); err == nil {
+		t.Fatal("expected error for invalid base64")
+	}
+	if EncodingForModel("openai/gpt-4-turbo") != Cl100kBase || EncodingForModel("gpt-5.4-mini") != O200kBase {
+		t.Fatal("EncodingForModel picked the wrong encoding")
+	}
+}
+
+func TestLookupParsesEmbeddedRanksOnce(t *testing.T) {
+	var b strings.Builder
+	for token, rank := range testRanks("ab") {
+		fmt.Fprintf(&b, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(token)), rank)
+	}
+	opens := 0
+	saved, savedBuiltin := vocabFS, builtins[Cl100kBase]
+	defer func() { vocabFS, builtins[Cl100kBase] = saved, savedBuiltin }()
+	vocabFS = countingFS{FS: fstest.MapFS{"vocab/cl100k_base.tiktoken": {Data: []byte(b.String())}}, opens: &opens}
+	builtins[Cl100kBase] = &builtin{}
+
+	for range 2 {
+		enc, ok := Lookup(Cl100kBase)
+		if !ok || enc.Count("ab") != 1 {
+			t.Fatalf("Lookup = %v, %v", enc, ok)
+		}
+	}
+	if opens != 1 {
+		t.Fatalf("opened rank file %d times, want 1", opens)
+	}
+	if _, ok := Lookup("p50k_base"); ok {
+		t.Fatal("Lookup found an encoding that is neither registered nor embedded")
+	}
+}
+
+func TestBuiltinEncodingsAreEmbedded(t *testing.T) {
+	want := map[string][]int{
+		Cl100kBase: {15339, 1917},
+		O200kBase:  {24912, 2375},
+	}
+	for name := range builtins {
+		enc := loadBuiltin(name)
+		if enc == nil {
+			t.Fatalf("vocab/%s.tiktoken is not embedded or does not parse", name)
+		}
+		if got := enc.Encode("hello world"); !reflect.DeepEqual(got, want[name]) {
+			t.Fatalf("%s Encode(hello world) = %v, want %v", name, got, want[name])
+		}
+	}
+}
+
+type countingFS struct {
+	fs.FS
+	opens *int
+}
+
+func (f countingFS) Open(name string) (fs.File, error) {
+	*f.opens++
+	return f.FS.Open(name)
+}
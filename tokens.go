@@ -0,0 +1,228 @@
+package litellm
+
+import (
+	"bytes"
+	"context"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+
+	"github.com/voocel/litellm/tokenizer"
+)
+
+// TokenCount is the input size of a request. InputTokens is the total; the
+// other fields break it down by what the tokens encode. Estimated is set when
+// the figures come from local estimation rather than the provider.
+type TokenCount struct {
+	InputTokens  int
+	TextTokens   int
+	ImageTokens  int
+	ToolTokens   int
+	SystemTokens int
+
+	Estimated bool
+	Provider  string
+	Model     string
+}
+
+// TokenCounter is implemented by providers with a token counting endpoint.
+// Providers may return only InputTokens; Client.CountTokens fills in the
+// breakdown.
+type TokenCounter interface {
+	CountTokens(ctx context.Context, req *Request) (*TokenCount, error)
+}
+
+// CountTokens reports how many input tokens req would use. It calls the
+// provider's counting endpoint when it has one and estimates locally
+// otherwise. A provider that returns only a total has the total split across
+// categories in proportion to the local estimate.
+func (c *Client) CountTokens(ctx context.Context, req Request) (*TokenCount, error) {
+	prepared, _, err := c.prepareRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	local := EstimateTokens(prepared)
+	local.Provider = c.provider.Name()
+	counter, ok := c.provider.(TokenCounter)
+	if !ok {
+		return &local, nil
+	}
+	count, err := counter.CountTokens(ctx, prepared)
+	if err != nil {
+		return nil, WrapError(err, c.provider.Name())
+	}
+	if count == nil {
+		return nil, NewProviderError(c.provider.Name(), ErrorTypeInternal, "provider returned nil token count without error")
+	}
+	out := *count
+	apportionTokens(&out, local)
+	if out.Provider == "" {
+		out.Provider = c.provider.Name()
+	}
+	if out.Model == "" {
+		out.Model = prepared.Model
+	}
+	return &out, nil
+}
+
+// Per-message and per-tool framing overhead, following OpenAI's chat format.
+const (
+	messageTokenOverhead = 3
+	replyTokenOverhead   = 3
+	toolTokenOverhead    = 8
+)
+
+// EstimateTokens counts req locally. Text is tokenized with the tokenizer
+// encoding for the model (see package tokenizer) or, when none is available,
+// estimated at four bytes per token. Images use OpenAI's tile formula when
+// their dimensions can be decoded. RateLimiter charges this estimate before
+// each call.
+func EstimateTokens(req *Request) TokenCount {
+	count := TokenCount{Estimated: true}
+	if req == nil {
+		return count
+	}
+	count.Model = req.Model
+	text := textCounter(req.Model)
+	for _, msg := range req.Messages {
+		tokens := messageTokenOverhead
+		images := 0
+		for _, block := range msg.Blocks {
+			t, img := estimateBlockTokens(block, text)
+			tokens += t
+			images += img
+		}
+		if msg.Role == RoleSystem {
+			count.SystemTokens += tokens
+		} else {
+			count.TextTokens += tokens
+		}
+		count.ImageTokens += images
+	}
+	if len(req.Messages) > 0 {
+		count.TextTokens += replyTokenOverhead
+	}
+	for _, tool := range req.Tools {
+		count.ToolTokens += toolTokenOverhead + text(tool.Name) + text(tool.Description) + text(string(tool.Parameters))
+	}
+	if format := req.ResponseFormat; format != nil && format.JSONSchema != nil {
+		count.SystemTokens += text(string(format.JSONSchema.Schema))
+	}
+	count.InputTokens = count.TextTokens + count.ImageTokens + count.ToolTokens + count.SystemTokens
+	return count
+}
+
+func textCounter(model string) func(string) int {
+	if enc, ok := tokenizer.ForModel(model); ok {
+		return enc.Count
+	}
+	return func(s string) int {
+		return (len(s) + 3) / 4
+	}
+}
+
+func estimateBlockTokens(block Block, text func(string) int) (tokens, images int) {
+	switch b := block.(type) {
+	case TextBlock:
+		return text(b.Text), 0
+	case GuardContentBlock:
+		return text(b.Text), 0
+	case ReasoningBlock:
+		return text(b.Text), 0
+	case ToolUseBlock:
+		return text(b.Name) + text(string(b.Arguments)), 0
+	case ToolResultBlock:
+		for _, inner := range b.Content {
+			t, img := estimateBlockTokens(inner, text)
+			tokens += t
+			images += img
+		}
+		return tokens, images
+	case ImageBlock:
+		return 0, estimateImageTokens(b)
+	case DocumentBlock:
+		if b.MIME == "text/plain" {
+			return text(string(b.Data)), 0
+		}
+		return defaultDocumentTokens, 0
+	case AudioBlock:
+		// Roughly 10 tokens per second at the 32 kbps typical of speech.
+		return len(b.Data) / 400, 0
+	}
+	return 0, 0
+}
+
+const (
+	defaultImageTokens    = 765
+	lowDetailImageTokens  = 85
+	defaultDocumentTokens = 1500
+)
+
+// estimateImageTokens applies OpenAI's formula: fit within 2048x2048, scale
+// the short side to 768, then 170 tokens per 512px tile plus 85.
+func estimateImageTokens(img ImageBlock) int {
+	if img.Detail == "low" {
+		return lowDetailImageTokens
+	}
+	if len(img.Data) == 0 {
+		return defaultImageTokens
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(img.Data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 {
+		return defaultImageTokens
+	}
+	w, h := float64(cfg.Width), float64(cfg.Height)
+	if scale := 2048 / math.Max(w, h); scale < 1 {
+		w, h = w*scale, h*scale
+	}
+	if scale := 768 / math.Min(w, h); scale < 1 {
+		w, h = w*scale, h*scale
+	}
+	tiles := math.Ceil(w/512) * math.Ceil(h/512)
+	return lowDetailImageTokens + 170*int(tiles)
+}
+
+// apportionTokens fills the categories a provider left at zero by splitting
+// what remains of its total in proportion to the local estimate.
+func apportionTokens(count *TokenCount, local TokenCount) {
+	fields := []struct {
+		dst *int
+		est int
+	}{
+		{&count.SystemTokens, local.SystemTokens},
+		{&count.ToolTokens, local.ToolTokens},
+		{&count.ImageTokens, local.ImageTokens},
+		{&count.TextTokens, local.TextTokens},
+	}
+	remaining := count.InputTokens
+	estimated := 0
+	for _, f := range fields {
+		remaining -= *f.dst
+		if *f.dst == 0 {
+			estimated += f.est
+		}
+	}
+	if remaining <= 0 {
+		return
+	}
+	if estimated == 0 {
+		count.TextTokens += remaining
+		return
+	}
+	left := remaining
+	var last *int
+	for _, f := range fields {
+		if *f.dst != 0 || f.est == 0 {
+			continue
+		}
+		share := remaining * f.est / estimated
+		*f.dst = share
+		left -= share
+		last = f.dst
+	}
+	if last != nil {
+		*last += left
+	}
+}
@@ -0,0 +1,95 @@
+package litellm
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+)
+
+type countingProvider struct {
+	*testProvider
+	count *TokenCount
+}
+
+func (p *countingProvider) CountTokens(ctx context.Context, req *Request) (*TokenCount, error) {
+	return p.count, nil
+}
+
+func tokenTestRequest() Request {
+	return Request{
+		Model: "claude-sonnet",
+		Messages: []Message{
+			System("You are terse."),
+			UserText("What is the capital of France?"),
+		},
+		Tools: []Tool{{Name: "lookup", Description: "Look up a fact.", Parameters: Schema(`{"type":"object"}`)}},
+	}
+}
+
+func TestEstimateTokensSeparatesCategories(t *testing.T) {
+	req := tokenTestRequest()
+	count := EstimateTokens(&req)
+	if !count.Estimated || count.SystemTokens == 0 || count.TextTokens == 0 || count.ToolTokens == 0 || count.ImageTokens != 0 {
+		t.Fatalf("count = %+v", count)
+	}
+	if count.InputTokens != count.SystemTokens+count.TextTokens+count.ToolTokens {
+		t.Fatalf("categories do not sum to total: %+v", count)
+	}
+}
+
+func TestEstimateImageTokensUsesDimensions(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1024, 1024))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	cases := []struct {
+		img  ImageBlock
+		want int
+	}{
+		{ImageBlock{Data: buf.Bytes(), MIME: "image/png"}, 765},
+		{ImageBlock{Data: buf.Bytes(), MIME: "image/png", Detail: "low"}, 85},
+		{ImageBlock{URL: "https://example.test/cat.png"}, defaultImageTokens},
+	}
+	for _, tc := range cases {
+		if got := estimateImageTokens(tc.img); got != tc.want {
+			t.Fatalf("estimateImageTokens(%+v) = %d, want %d", tc.img.Detail, got, tc.want)
+		}
+	}
+}
+
+func TestClientCountTokensFallsBackToEstimate(t *testing.T) {
+	client, _ := New(&testProvider{name: "openai"})
+	count, err := client.CountTokens(context.Background(), tokenTestRequest())
+	if err != nil {
+		t.Fatalf("CountTokens returned error: %v", err)
+	}
+	if !count.Estimated || count.Provider != "openai" || count.InputTokens == 0 {
+		t.Fatalf("count = %+v", count)
+	}
+}
+
+func TestClientCountTokensApportionsProviderTotal(t *testing.T) {
+	provider := &countingProvider{testProvider: &testProvider{name: "anthropic"}, count: &TokenCount{InputTokens: 1000}}
+	client, _ := New(provider)
+	count, err := client.CountTokens(context.Background(), tokenTestRequest())
+	if err != nil {
+		t.Fatalf("CountTokens returned error: %v", err)
+	}
+	if count.Estimated || count.InputTokens != 1000 || count.Model != "claude-sonnet" {
+		t.Fatalf("count = %+v", count)
+	}
+	if count.SystemTokens+count.TextTokens+count.ToolTokens+count.ImageTokens != 1000 || count.ToolTokens == 0 || count.SystemTokens == 0 {
+		t.Fatalf("breakdown = %+v", count)
+	}
+
+	provider.count = &TokenCount{InputTokens: 1000, ImageTokens: 600}
+	count, err = client.CountTokens(context.Background(), tokenTestRequest())
+	if err != nil {
+		t.Fatalf("CountTokens returned error: %v", err)
+	}
+	if count.ImageTokens != 600 || count.SystemTokens+count.TextTokens+count.ToolTokens != 400 {
+		t.Fatalf("breakdown with known images = %+v", count)
+	}
+}